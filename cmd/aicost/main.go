@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	daysFlag   = flag.Int("days", 7, "统计最近N个UTC自然日的AI费用")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *daysFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 必须大于 0")
+		os.Exit(1)
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-*daysFlag)
+	usage, err := storage.LoadUsage(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(usage) == 0 {
+		fmt.Println("统计区间内没有AI用量记录")
+		return
+	}
+	// 交易文件缺失时只输出费用，不做盈亏对比
+	trades, err := storage.LoadTrades(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	// 各日按提供商汇总；费用以记录时的价目表折算，价目表调整不影响历史数据
+	type key struct{ day, provider string }
+	spend := make(map[key]*ai.Spend)
+	for _, u := range usage {
+		k := key{day: dayOf(u.CreatedAt), provider: u.Provider}
+		s := spend[k]
+		if s == nil {
+			s = &ai.Spend{Provider: u.Provider}
+			spend[k] = s
+		}
+		s.Calls++
+		s.PromptTokens += u.PromptTokens
+		s.CompletionTokens += u.CompletionTokens
+		s.CostUSD += u.CostUSD
+	}
+	pnl := make(map[string]float64)
+	totalPnL := 0.0
+	for _, t := range trades {
+		pnl[dayOf(t.CreatedAt)] += t.PnL
+		totalPnL += t.PnL
+	}
+
+	keys := make([]key, 0, len(spend))
+	for k := range spend {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].day != keys[j].day {
+			return keys[i].day < keys[j].day
+		}
+		return keys[i].provider < keys[j].provider
+	})
+
+	fmt.Printf("%-10s %-10s %6s %12s %12s %12s\n", "日期", "提供商", "调用", "输入tok", "输出tok", "费用USD")
+	dayCost := make(map[string]float64)
+	var days []string
+	total := 0.0
+	for _, k := range keys {
+		s := spend[k]
+		fmt.Printf("%-10s %-10s %6d %12d %12d %12.4f\n", k.day, k.provider, s.Calls, s.PromptTokens, s.CompletionTokens, s.CostUSD)
+		if _, ok := dayCost[k.day]; !ok {
+			days = append(days, k.day)
+		}
+		dayCost[k.day] += s.CostUSD
+		total += s.CostUSD
+	}
+
+	fmt.Printf("\n%-10s %12s %14s %14s\n", "日期", "AI费用USD", "已实现盈亏", "扣除费用后")
+	for _, day := range days {
+		fmt.Printf("%-10s %12.4f %+14.4f %+14.4f\n", day, dayCost[day], pnl[day], pnl[day]-dayCost[day])
+	}
+	fmt.Printf("合计: AI费用 %.4f USD，已实现盈亏 %+.4f，扣除费用后 %+.4f\n", total, totalPnL, totalPnL-total)
+}
+
+func dayOf(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"autobot/internal/ai"
+	aifactory "autobot/internal/ai/factory"
+	"autobot/internal/config"
+)
+
+var configFlag = flag.String("config", "config.json", "配置文件路径")
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// 创建各交易者配置的提供商，factory 会把支持探活的提供商登记到 ai.DefaultHealth
+	seen := make(map[string]bool)
+	for _, profile := range cfg.TraderProfiles {
+		names := profile.Providers()
+		if profile.NewsProvider != "" {
+			names = append(names, profile.NewsProvider)
+		}
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			if _, err := aifactory.New(name, cfg); err != nil {
+				fmt.Printf("%-10s 创建失败: %v\n", name, err)
+			}
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	statuses := ai.DefaultHealth().Check(ctx, cfg.AIHealthTimeout)
+	if len(statuses) == 0 {
+		fmt.Println("没有支持探活的提供商")
+		return
+	}
+	failed := 0
+	for _, s := range statuses {
+		if s.Healthy {
+			fmt.Printf("%-10s 正常  延迟 %dms\n", s.Provider, s.LatencyMs)
+			continue
+		}
+		failed++
+		fmt.Printf("%-10s 异常  延迟 %dms  %s\n", s.Provider, s.LatencyMs, s.Error)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
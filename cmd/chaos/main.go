@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	aifactory "autobot/internal/ai/factory"
+	"autobot/internal/config"
+	"autobot/internal/eventlog"
+	"autobot/internal/storage"
+)
+
+var (
+	configFlag    = flag.String("config", "config.json", "配置文件路径")
+	minutesFlag   = flag.Int("minutes", 0, "开始演练：停用AI提供商N分钟")
+	modeFlag      = flag.String("mode", ai.ChaosModeReduceOnly, "演练期间的决策兜底：rules 按策略信号交易，reduceOnly 只持有或平仓")
+	providersFlag = flag.String("providers", "", "停用的提供商，逗号分隔，留空停用全部")
+	operatorFlag  = flag.String("operator", "", "操作者，写入演练记录")
+	noteFlag      = flag.String("note", "", "演练说明")
+	stopFlag      = flag.Bool("stop", false, "提前结束正在进行的演练")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sw := aifactory.ChaosSwitch(cfg)
+
+	switch {
+	case *stopFlag:
+		drill, ok, err := sw.Stop()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !ok {
+			fmt.Println("没有演练记录")
+			return
+		}
+		fmt.Println("演练已结束")
+		recordDrill(cfg, "chaos.stop", drill)
+		report(cfg, drill)
+	case *minutesFlag > 0:
+		now := time.Now().UTC()
+		drill := ai.ChaosDrill{
+			Mode:      *modeFlag,
+			StartedAt: now,
+			Until:     now.Add(time.Duration(*minutesFlag) * time.Minute),
+			Operator:  *operatorFlag,
+			Note:      *noteFlag,
+		}
+		for _, name := range strings.Split(*providersFlag, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				drill.Providers = append(drill.Providers, name)
+			}
+		}
+		if err := sw.Start(drill); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		recordDrill(cfg, "chaos.start", drill)
+		fmt.Printf("演练开始：%s 停用至 %s，兜底模式 %s\n", describe(drill), drill.Until.Local().Format("15:04:05"), drill.Mode)
+		fmt.Printf("运行中的交易程序下一次调用AI时生效；提前结束：go run ./cmd/chaos -config %s -stop\n", *configFlag)
+	case *minutesFlag < 0:
+		fmt.Fprintln(os.Stderr, "-minutes 必须大于 0")
+		os.Exit(1)
+	default:
+		drill, ok, err := sw.Current()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !ok {
+			fmt.Println("没有演练记录")
+			return
+		}
+		if drill.Active(time.Now()) {
+			fmt.Printf("演练进行中：%s 停用至 %s，剩余 %s\n", describe(drill), drill.Until.Local().Format("15:04:05"), time.Until(drill.Until).Round(time.Second))
+		} else {
+			fmt.Printf("最近一次演练已于 %s 结束\n", drill.Until.Local().Format("2006-01-02 15:04:05"))
+		}
+		report(cfg, drill)
+	}
+}
+
+// recordDrill 把演练的开始或提前结束作为人工干预写入事件日志（开启 storage.eventLog 时）。
+func recordDrill(cfg config.ParsedConfig, action string, drill ai.ChaosDrill) {
+	operator := *operatorFlag
+	if operator == "" {
+		operator = drill.Operator
+	}
+	note := fmt.Sprintf("providers=%s mode=%s until=%s", describe(drill), drill.Mode, drill.Until.Format(time.RFC3339))
+	if drill.Note != "" {
+		note += " " + drill.Note
+	}
+	err := storage.AppendEvent(cfg.Storage, eventlog.KindManual, "", eventlog.ManualAction{Operator: operator, Action: action, Note: note})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "写入事件日志失败: %v\n", err)
+	}
+}
+
+func describe(drill ai.ChaosDrill) string {
+	if len(drill.Providers) == 0 {
+		return "全部提供商"
+	}
+	return strings.Join(drill.Providers, ",")
+}
+
+// report 汇总演练时段内各交易者的决策：兜底决策数、各动作次数与失败次数。
+func report(cfg config.ParsedConfig, drill ai.ChaosDrill) {
+	records, err := storage.LoadDecisions(cfg.Storage, drill.StartedAt)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	type summary struct {
+		total, fallback, failed int
+		actions                 map[string]int
+	}
+	byTrader := map[string]*summary{}
+	end := drill.Until.UnixMilli()
+	for _, record := range records {
+		if record.CreatedAt > end {
+			continue
+		}
+		s := byTrader[record.Trader]
+		if s == nil {
+			s = &summary{actions: map[string]int{}}
+			byTrader[record.Trader] = s
+		}
+		s.total++
+		s.actions[record.Action]++
+		if !record.Success && record.ErrorMessage != "" {
+			s.failed++
+		}
+		for _, note := range record.RiskNotes {
+			if strings.HasPrefix(note, ai.ChaosDrillNote) {
+				s.fallback++
+				break
+			}
+		}
+	}
+	fmt.Printf("演练时段 %s ~ %s\n", drill.StartedAt.Local().Format("01-02 15:04:05"), drill.Until.Local().Format("01-02 15:04:05"))
+	if len(byTrader) == 0 {
+		fmt.Println("该时段没有决策记录")
+		return
+	}
+	traders := make([]string, 0, len(byTrader))
+	for name := range byTrader {
+		traders = append(traders, name)
+	}
+	sort.Strings(traders)
+	fmt.Printf("%-16s %6s %6s %6s  %s\n", "交易者", "决策", "兜底", "失败", "动作")
+	for _, name := range traders {
+		s := byTrader[name]
+		actions := make([]string, 0, len(s.actions))
+		for action, n := range s.actions {
+			actions = append(actions, fmt.Sprintf("%s=%d", action, n))
+		}
+		sort.Strings(actions)
+		fmt.Printf("%-16s %6d %6d %6d  %s\n", name, s.total, s.fallback, s.failed, strings.Join(actions, " "))
+	}
+}
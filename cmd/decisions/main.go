@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/counterfactual"
+	"autobot/internal/exchange/binance"
+	"autobot/internal/outcome"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+)
+
+const usage = `用法: decisions search <关键词...> [-trader 名称] [-symbol 交易对] [-action 动作] [-since 7d] [-limit 20]
+      decisions outcomes [-by provider|trader|strategy|prompt] [-since 7d] [-label]
+      decisions counterfactual [-trader 名称] [-since 7d]
+
+search 在已保存的决策理由、风险提示与思维链中全文检索，例如:
+  go run ./cmd/decisions search "liquidation risk" -trader main -since 7d
+
+outcomes 按决策结果标注汇总方向准确率，-label 先为尚未标注的决策补标注，例如:
+  go run ./cmd/decisions outcomes -by provider -since 30d -label
+
+counterfactual 用决策记录中的策略信号重算“按AI决策”与“只按策略信号”两条路径的模拟盈亏，例如:
+  go run ./cmd/decisions counterfactual -since 30d`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "search":
+		search(os.Args[2:])
+	case "outcomes":
+		outcomes(os.Args[2:])
+	case "counterfactual":
+		counterfactualReport(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func search(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", "config.json", "配置文件路径")
+	traderFlag := fs.String("trader", "", "只检索该交易者的决策")
+	symbolFlag := fs.String("symbol", "", "只检索该交易对的决策")
+	actionFlag := fs.String("action", "", "只检索该动作的决策，如 open_long、close")
+	sinceFlag := fs.String("since", "30d", "检索范围：7d、12h 等时长，或 2006-01-02 起的日期")
+	limitFlag := fs.Int("limit", 20, "最多显示的条数，0 为不限")
+
+	// 关键词与参数可以任意先后：逐段解析，非参数部分收集为关键词
+	var terms []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			os.Exit(2)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		terms = append(terms, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	query := strings.Join(terms, " ")
+	if strings.TrimSpace(query) == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	records, err := storage.LoadDecisions(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	filtered := records[:0]
+	for _, record := range records {
+		if *traderFlag != "" && record.Trader != *traderFlag {
+			continue
+		}
+		if *symbolFlag != "" && !strings.EqualFold(record.Symbol, *symbolFlag) {
+			continue
+		}
+		if *actionFlag != "" && !strings.EqualFold(record.Action, *actionFlag) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	matches := storage.SearchDecisions(filtered, query, 0)
+	if len(matches) == 0 {
+		fmt.Printf("%s 以来的 %d 条决策中没有找到 %q\n", since.Local().Format("2006-01-02 15:04"), len(filtered), query)
+		return
+	}
+	fmt.Printf("%s 以来的 %d 条决策中找到 %d 条匹配 %q\n\n", since.Local().Format("2006-01-02 15:04"), len(filtered), len(matches), query)
+	shown := matches
+	if *limitFlag > 0 && len(shown) > *limitFlag {
+		shown = shown[:*limitFlag]
+	}
+	for _, match := range shown {
+		r := match.Record
+		fmt.Printf("%s  %-12s %-10s %-10s 置信度 %.2f  得分 %d  #%s\n",
+			time.UnixMilli(r.CreatedAt).Local().Format("2006-01-02 15:04"), r.Trader, r.Symbol, r.Action, r.Confidence, match.Score, r.ID)
+		for _, snippet := range match.Snippets {
+			fmt.Printf("    %s\n", snippet)
+		}
+		fmt.Println()
+	}
+	if len(shown) < len(matches) {
+		fmt.Printf("另有 %d 条未显示，用 -limit 调整\n", len(matches)-len(shown))
+	}
+}
+
+func outcomes(args []string) {
+	fs := flag.NewFlagSet("outcomes", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", "config.json", "配置文件路径")
+	sinceFlag := fs.String("since", "30d", "汇总范围：7d、12h 等时长，或 2006-01-02 起的日期（按决策时间）")
+	byFlag := fs.String("by", "provider", "分组方式：provider、trader、strategy 或 prompt")
+	labelFlag := fs.Bool("label", false, "汇总前按 outcome 配置为尚未标注的决策补标注（使用币安公开K线）")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(2)
+	}
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	key, err := groupKey(*byFlag, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *labelFlag {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		labeler := outcome.Labeler{
+			Storage: cfg.Storage,
+			Source:  binance.New("", "", ""),
+			Config: outcome.Config{
+				Horizons:       cfg.OutcomeHorizons,
+				NeutralPercent: cfg.Outcome.NeutralPercent,
+				Interval:       cfg.Outcome.Interval,
+				Lookback:       cfg.OutcomeLookback,
+			},
+		}
+		n, err := labeler.LabelOnce(ctx, time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("新增标注 %d 条\n\n", n)
+	}
+
+	labels, err := storage.LoadDecisionLabels(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(labels) == 0 {
+		fmt.Printf("%s 以来没有决策标注，可加 -label 补标注或在交易进程中启用 outcome\n", since.Local().Format("2006-01-02 15:04"))
+		return
+	}
+	fmt.Printf("%s 以来的决策标注 %d 条 | 中性带 ±%.2f%%\n\n", since.Local().Format("2006-01-02 15:04"), len(labels), cfg.Outcome.NeutralPercent)
+	fmt.Printf("%-16s %6s %6s %6s %6s %8s %10s\n", *byFlag, "周期", "好", "中性", "坏", "准确率", "平均顺向")
+	for _, row := range outcome.Summarize(labels, key) {
+		fmt.Printf("%-16s %6s %6d %6d %6d %7.1f%% %+9.2f%%\n", row.Key, row.Horizon, row.Good, row.Neutral, row.Bad, row.Accuracy*100, row.AvgMove)
+	}
+	fmt.Println("\n准确率为 好/(好+坏)；平均顺向为开仓、持有方向上的平均涨跌幅（看空取反），不含观望。")
+}
+
+func counterfactualReport(args []string) {
+	fs := flag.NewFlagSet("counterfactual", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", "config.json", "配置文件路径")
+	traderFlag := fs.String("trader", "", "只对比该交易者")
+	sinceFlag := fs.String("since", "30d", "对比范围：7d、12h 等时长，或 2006-01-02 起的日期；两条路径均从该时刻空仓起算")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(2)
+	}
+	since, err := parseSince(*sinceFlag, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	records, err := storage.LoadDecisions(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *traderFlag != "" {
+		filtered := records[:0]
+		for _, record := range records {
+			if record.Trader == *traderFlag {
+				filtered = append(filtered, record)
+			}
+		}
+		records = filtered
+	}
+	comparisons := counterfactual.Replay(records, counterfactual.TraderFees(cfg))
+	if len(comparisons) == 0 {
+		fmt.Printf("%s 以来没有带策略信号的决策记录\n", since.Local().Format("2006-01-02 15:04"))
+		return
+	}
+	fmt.Printf("%s 以来 AI 与纯策略的反事实对比（单位仓位，扣吃单手续费）\n\n", since.Local().Format("2006-01-02 15:04"))
+	fmt.Printf("%-14s %-10s %9s %5s %6s %9s %5s %6s %9s %10s\n", "交易者", "交易对", "AI收益", "笔数", "胜率", "策略收益", "笔数", "胜率", "AI超额", "分歧/周期")
+	var edge float64
+	for _, c := range comparisons {
+		fmt.Printf("%-14s %-10s %+8.2f%% %5d %5.0f%% %+8.2f%% %5d %5.0f%% %+8.2f%% %5d/%d\n",
+			c.Trader, c.Symbol,
+			c.AI.TotalPercent(), c.AI.Trades, c.AI.WinRate()*100,
+			c.Strategy.TotalPercent(), c.Strategy.Trades, c.Strategy.WinRate()*100,
+			c.EdgePercent(), c.Disagreements, c.Cycles)
+		edge += c.EdgePercent()
+	}
+	fmt.Printf("\n合计 AI 超额 %+.2f%%。收益含未平仓部分（按最后一条决策的价格计），两条路径在同一周期以同一价格成交。\n", edge)
+}
+
+// groupKey 返回 -by 对应的分组函数；strategy 按配置中交易者的策略名分组，已不在配置中的交易者记为 "?交易者名"。
+func groupKey(by string, cfg config.ParsedConfig) (func(storage.DecisionLabel) string, error) {
+	switch by {
+	case "provider":
+		return func(l storage.DecisionLabel) string { return l.Provider }, nil
+	case "trader":
+		return func(l storage.DecisionLabel) string { return l.Trader }, nil
+	case "prompt":
+		return func(l storage.DecisionLabel) string { return l.PromptVersion }, nil
+	case "strategy":
+		strategies := make(map[string]string, len(cfg.TraderProfiles))
+		for _, profile := range cfg.TraderProfiles {
+			name := profile.Strategy
+			if name == "" {
+				name = strategy.DefaultName
+			}
+			strategies[profile.Name] = name
+		}
+		return func(l storage.DecisionLabel) string {
+			if name, ok := strategies[l.Trader]; ok {
+				return name
+			}
+			return "?" + l.Trader
+		}, nil
+	}
+	return nil, fmt.Errorf("无效的 -by %q，可选 provider/trader/strategy/prompt", by)
+}
+
+// parseSince 把 -since 解析为起始时间：支持 Go 时长（12h、90m）、按天/周的 7d、2w，以及日期 2006-01-02。
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if n := len(value); n > 1 && (value[n-1] == 'd' || value[n-1] == 'w') {
+		if count, err := strconv.Atoi(value[:n-1]); err == nil && count > 0 {
+			days := count
+			if value[n-1] == 'w' {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("无效的 -since %q，可用 7d、12h、2w 或 2006-01-02", value)
+}
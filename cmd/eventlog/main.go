@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/eventlog"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	fileFlag   = flag.String("file", "", "事件日志路径，留空使用存储目录下的 events.jsonl")
+	tailFlag   = flag.Int("tail", 0, "校验通过后列出最后N条事件")
+)
+
+func main() {
+	flag.Parse()
+	path := *fileFlag
+	if path == "" {
+		cfg, err := config.Load(*configFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		dir := cfg.Storage.Path
+		if dir == "" {
+			dir = "data"
+		}
+		path = filepath.Join(dir, "events.jsonl")
+	}
+
+	result, err := eventlog.VerifyFile(path)
+	var chainErr *eventlog.ChainError
+	switch {
+	case errors.As(err, &chainErr):
+		fmt.Printf("校验失败：%v\n", chainErr)
+		fmt.Printf("断裂前已通过 %d 条事件，最后有效序号 %d\n", result.Events, result.LastSeq)
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("校验通过：%s 共 %d 条事件\n", path, result.Events)
+	if result.Events == 0 {
+		return
+	}
+	fmt.Printf("最后序号 %d，最后哈希 %s\n", result.LastSeq, result.LastHash)
+	fmt.Println("（请在日志之外另行保存最后哈希，以便日后发现尾部被截断）")
+	kinds := make([]string, 0, len(result.Counts))
+	for kind := range result.Counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Printf("  %-10s %d\n", kind, result.Counts[kind])
+	}
+
+	if *tailFlag > 0 {
+		if err := printTail(path, *tailFlag); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func printTail(path string, n int) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var events []eventlog.Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4<<20)
+	for scanner.Scan() {
+		var event eventlog.Event
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			continue
+		}
+		events = append(events, event)
+		if len(events) > n {
+			events = events[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	fmt.Println()
+	for _, event := range events {
+		fmt.Printf("#%-6d %s %-8s %-12s %s\n", event.Seq, time.UnixMilli(event.Time).Format("2006-01-02 15:04:05"),
+			event.Kind, event.Trader, string(event.Data))
+	}
+	return nil
+}
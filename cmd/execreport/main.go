@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange"
+	"autobot/internal/exchange/factory"
+	"autobot/internal/execution"
+	"autobot/internal/market"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+)
+
+var (
+	configFlag   = flag.String("config", "config.json", "配置文件路径")
+	daysFlag     = flag.Int("days", 7, "统计最近N个UTC自然日的成交")
+	intervalFlag = flag.String("interval", "15m", "计算会话VWAP使用的K线周期")
+)
+
+// 15m K线下 1000 根约覆盖 10 天。
+const klineLimit = 1000
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *daysFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 必须大于 0")
+		os.Exit(1)
+	}
+	interval, err := config.NormalizeInterval(*intervalFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	now := time.Now().UTC()
+	since := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1-*daysFlag)
+	records, err := storage.LoadTrades(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fills := execution.FillsFromTrades(records)
+	if len(fills) == 0 {
+		fmt.Println("统计区间内没有成交记录")
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// 成交所属交易者决定使用哪个交易所的K线
+	clients := make(map[string]exchange.Exchange)
+	exchangeOf := make(map[string]string)
+	for _, profile := range cfg.TraderProfiles {
+		for _, fill := range fills {
+			if fill.Trader != profile.Name {
+				continue
+			}
+			if _, ok := clients[profile.Name]; !ok {
+				if _, err := config.CheckInterval(profile.Exchange, interval); err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", profile.Name, err)
+					os.Exit(1)
+				}
+				client, err := factory.New(profile.Exchange, profile.Account, cfg.Exchanges, profile.Settings)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", profile.Name, err)
+					os.Exit(1)
+				}
+				clients[profile.Name] = client
+			}
+			exchangeOf[fill.Symbol] = profile.Name
+		}
+	}
+
+	candles := make(map[string][]strategy.Candle)
+	vwap := func(symbol string, day time.Time) (float64, error) {
+		series, ok := candles[symbol]
+		if !ok {
+			client, found := clients[exchangeOf[symbol]]
+			if !found {
+				return 0, fmt.Errorf("%s 无对应交易者配置", symbol)
+			}
+			fetched, err := client.GetKlines(ctx, symbol, interval, klineLimit)
+			if err != nil {
+				return 0, err
+			}
+			series = fetched
+			candles[symbol] = series
+		}
+		price, ok := market.SessionVWAP(series, day)
+		if !ok {
+			return 0, fmt.Errorf("%s 在 %s 没有K线数据", symbol, day.Format("2006-01-02"))
+		}
+		return price, nil
+	}
+
+	execution.WriteReport(os.Stdout, execution.Evaluate(fills, vwap))
+}
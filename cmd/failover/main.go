@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/failover"
+)
+
+const usage = `用法: failover status [-config config.json]
+      failover promote -node 实例名 [-config config.json]
+
+status 显示租约文件中的当前主实例、任期与心跳时间；
+promote 请求当前主实例在下一次心跳时让出交易权，由指定的备用实例接管，例如:
+  go run ./cmd/failover promote -node vps-b`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	configFlag := fs.String("config", "config.json", "配置文件路径")
+	nodeFlag := fs.String("node", "", "接管交易的备用实例名（promote）")
+	_ = fs.Parse(os.Args[2:])
+
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	path := cfg.Failover.LeaseFile
+
+	switch os.Args[1] {
+	case "status":
+		lease, err := failover.ReadLease(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		printLease(path, lease, cfg.FailoverTimeout)
+	case "promote":
+		if *nodeFlag == "" {
+			fmt.Fprintln(os.Stderr, "promote 需要 -node")
+			os.Exit(2)
+		}
+		lease, err := failover.Promote(path, *nodeFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Printf("已请求切换到 %s，当前主实例 %s 将在下一次心跳（%s 内）让出\n", *nodeFlag, orDash(lease.Holder), cfg.FailoverHeartbeat)
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printLease(path string, lease failover.Lease, timeout time.Duration) {
+	fmt.Printf("租约文件: %s\n", path)
+	if lease.Holder == "" {
+		fmt.Println("尚无实例持有租约")
+		return
+	}
+	state := "存活"
+	switch {
+	case lease.Released:
+		state = "已让出"
+	case lease.Expired(time.Now(), timeout):
+		state = "心跳超时"
+	}
+	fmt.Printf("主实例:   %s（%s）\n", lease.Holder, state)
+	fmt.Printf("任期:     %d，自 %s 起\n", lease.Epoch, lease.AcquiredAt.Local().Format("2006-01-02 15:04:05"))
+	fmt.Printf("心跳:     %s（%s 前）\n", lease.Heartbeat.Local().Format("2006-01-02 15:04:05"), time.Since(lease.Heartbeat).Round(time.Second))
+	if lease.PromoteTo != "" {
+		fmt.Printf("切换请求: %s\n", lease.PromoteTo)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange/binance"
+	"autobot/internal/market"
+	"autobot/internal/storage"
+)
+
+const usage = `用法: funding download [-symbols BTCUSDT,ETHUSDT] [-days 90] [-period 1h]
+      funding report [-days 90] [-neutral 0.01] [-oi-flat 2]
+
+download 把币安合约的历史资金费率与持仓量增量下载到存储目录的 funding/、open_interest/（持仓量只保留最近30天）；
+report 把带盈亏的成交按开仓时的资金费率方向与持仓量变化分组，看机器人是否在系统性地逆资金费交易，例如:
+  go run ./cmd/funding download -days 180
+  go run ./cmd/funding report -days 180`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "download":
+		download(os.Args[2:])
+	case "report":
+		report(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func newFlagSet(name string) (*flag.FlagSet, *string, *int) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	return fs, fs.String("config", "config.json", "配置文件路径"), fs.Int("days", 90, "最近N天")
+}
+
+func download(args []string) {
+	fs, configFlag, daysFlag := newFlagSet("download")
+	symbolsFlag := fs.String("symbols", "", "逗号分隔的交易对，留空为配置中全部交易者的交易对")
+	periodFlag := fs.String("period", "1h", "持仓量采样周期：5m、15m、30m、1h、2h、4h、6h、12h 或 1d")
+	_ = fs.Parse(args)
+	cfg := load(*configFlag, *daysFlag)
+
+	symbols := traderSymbols(cfg)
+	if *symbolsFlag != "" {
+		symbols = nil
+		for _, symbol := range strings.Split(*symbolsFlag, ",") {
+			if symbol = strings.ToUpper(strings.TrimSpace(symbol)); symbol != "" {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	if len(symbols) == 0 {
+		fmt.Fprintln(os.Stderr, "没有要下载的交易对，请用 -symbols 指定")
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	src := binance.New("", "", "")
+	now := time.Now()
+	since := now.AddDate(0, 0, -*daysFlag)
+	failed := false
+	for _, symbol := range symbols {
+		result, err := market.IngestHistory(ctx, src, cfg.Storage, symbol, *periodFlag, since, now)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%-12s 失败: %v\n", symbol, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%-12s 资金费率 +%d  持仓量 +%d\n", symbol, result.Funding, result.OpenInterest)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func report(args []string) {
+	fs, configFlag, daysFlag := newFlagSet("report")
+	neutralFlag := fs.Float64("neutral", 0.01, "资金费率绝对值低于该百分比时视为中性（0.01 即 0.01%）")
+	oiFlatFlag := fs.Float64("oi-flat", 2, "开仓前24小时持仓量变化绝对值低于该百分比时视为持平")
+	_ = fs.Parse(args)
+	cfg := load(*configFlag, *daysFlag)
+	since := time.Now().AddDate(0, 0, -*daysFlag)
+
+	decisions, err := storage.LoadDecisions(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	trades, err := storage.LoadTrades(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	// 开仓时的资金费率可能在统计起点之前结算，多读一天
+	funding := make(map[string][]storage.FundingRecord)
+	openInterest := make(map[string][]storage.OpenInterestRecord)
+	for _, t := range trades {
+		symbol := strings.ToUpper(t.Symbol)
+		if _, ok := funding[symbol]; ok {
+			continue
+		}
+		if funding[symbol], err = storage.LoadFunding(cfg.Storage, symbol, since.AddDate(0, 0, -1)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if openInterest[symbol], err = storage.LoadOpenInterest(cfg.Storage, symbol, since.AddDate(0, 0, -1)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	contexts := storage.JoinFundingContext(decisions, trades, funding, openInterest)
+	if len(contexts) == 0 {
+		fmt.Printf("最近 %d 天没有可归因到开仓决策的平仓成交\n", *daysFlag)
+		return
+	}
+	neutral := *neutralFlag / 100
+	fmt.Printf("最近 %d 天平仓成交 %d 笔 | 中性费率 ±%.4f%% | 持仓量持平 ±%.1f%%\n", *daysFlag, len(contexts), *neutralFlag, *oiFlatFlag)
+	printBuckets("资金费率方向", storage.SummarizeFunding(contexts, func(c storage.TradeFundingContext) string {
+		return c.Alignment(neutral)
+	}))
+	printBuckets("开仓前24h持仓量", storage.SummarizeFunding(contexts, func(c storage.TradeFundingContext) string {
+		return c.OITrend(*oiFlatFlag)
+	}))
+	printBuckets("费率方向 × 持仓量", storage.SummarizeFunding(contexts, func(c storage.TradeFundingContext) string {
+		return c.Alignment(neutral) + "/" + c.OITrend(*oiFlatFlag)
+	}))
+
+	against, known := 0, 0
+	for _, c := range contexts {
+		switch c.Alignment(neutral) {
+		case storage.FundingAgainst:
+			against++
+			known++
+		case storage.FundingWith:
+			known++
+		}
+	}
+	if known > 0 {
+		fmt.Printf("\n非中性费率下逆资金费开仓占 %.0f%%（%d/%d）。against 为持仓需支付资金费（多单遇正费率、空单遇负费率）；unknown 表示未下载该时段的数据。\n",
+			float64(against)/float64(known)*100, against, known)
+	}
+}
+
+func printBuckets(title string, rows []storage.FundingBucketStats) {
+	fmt.Printf("\n%s\n%-22s %6s %7s %12s %10s %10s\n", title, "分组", "笔数", "胜率", "总盈亏", "平均盈亏", "平均费率")
+	for _, row := range rows {
+		fmt.Printf("%-22s %6d %6.1f%% %+12.2f %+10.2f %+9.4f%%\n", row.Bucket, row.Trades, row.WinRate*100, row.TotalPnL, row.AvgPnL, row.AvgFundingRate*100)
+	}
+}
+
+func load(path string, days int) config.ParsedConfig {
+	if days <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 必须大于 0")
+		os.Exit(2)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// traderSymbols 返回配置中合约交易者的交易对，去重排序。
+func traderSymbols(cfg config.ParsedConfig) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, profile := range cfg.TraderProfiles {
+		symbol := strings.ToUpper(profile.Symbol)
+		if profile.Settings.IsSpot() || symbol == "" || seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
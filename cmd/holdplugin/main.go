@@ -0,0 +1,66 @@
+// holdplugin 是 exec 提供商协议的最小参考实现：对任何决策请求都返回 hold，
+// 新闻情绪固定为 neutral。可作为接入自有模型的起点，或用于联调配置：
+//
+//	"plugins": {"hold": {"command": "go", "args": ["run", "./cmd/holdplugin"]}}
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"autobot/internal/ai"
+	"autobot/internal/ai/plugin"
+	"autobot/internal/news"
+)
+
+type request struct {
+	ID     int64           `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Result  any    `json:"result,omitempty"`
+	Error   any    `json:"error,omitempty"`
+}
+
+func main() {
+	reader := bufio.NewReader(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return
+		}
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid request:", err)
+			continue
+		}
+		resp := response{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case plugin.MethodGenerateDecision:
+			var decision ai.DecisionRequest
+			if err := json.Unmarshal(req.Params, &decision); err != nil {
+				resp.Error = map[string]any{"code": -32602, "message": err.Error()}
+				break
+			}
+			resp.Result = ai.DecisionResponse{
+				Action:     "hold",
+				Confidence: 0.5,
+				Reason:     fmt.Sprintf("holdplugin 不对 %s 给出交易建议", decision.Symbol),
+			}
+		case plugin.MethodAnalyzeNews:
+			resp.Result = news.SentimentSummary{Sentiment: "neutral"}
+		default:
+			resp.Error = map[string]any{"code": -32601, "message": "method not found: " + req.Method}
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	sinceFlag  = flag.Duration("since", 7*24*time.Hour, "只列出该时长内的记录")
+	symbolFlag = flag.String("symbol", "", "只列出该交易对的记录")
+	orderFlag  = flag.String("order", "", "只列出该订单号或客户端订单号的记录")
+	kindFlag   = flag.String("kind", "", "只列出该类型的记录：request 或 event")
+	rawFlag    = flag.Bool("raw", false, "输出完整的原始报文（JSON，每行一条）")
+	limitFlag  = flag.Int("limit", 50, "最多列出最近N条，0 为不限")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	entries, err := storage.LoadOrderJournal(cfg.Storage, time.Now().Add(-*sinceFlag))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	matched := entries[:0]
+	for _, entry := range entries {
+		if *symbolFlag != "" && !strings.EqualFold(entry.Symbol, *symbolFlag) {
+			continue
+		}
+		if *orderFlag != "" && entry.OrderID != *orderFlag && entry.ClientOrderID != *orderFlag {
+			continue
+		}
+		if *kindFlag != "" && entry.Kind != *kindFlag {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	if *limitFlag > 0 && len(matched) > *limitFlag {
+		matched = matched[len(matched)-*limitFlag:]
+	}
+	if len(matched) == 0 {
+		fmt.Fprintln(os.Stderr, "没有匹配的订单日志记录（需开启 storage.orderJournal）")
+		return
+	}
+
+	if *rawFlag {
+		enc := json.NewEncoder(os.Stdout)
+		for _, entry := range matched {
+			if err := enc.Encode(entry); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+	for _, entry := range matched {
+		at := time.UnixMilli(entry.CreatedAt).Local().Format("2006-01-02 15:04:05.000")
+		ids := fmt.Sprintf("order=%s client=%s", orDash(entry.OrderID), orDash(entry.ClientOrderID))
+		if entry.Kind == storage.OrderJournalEvent {
+			fmt.Printf("%s  %-8s event    %-12s %s\n    %s\n", at, entry.Exchange, orDash(entry.Symbol), ids, abbreviate(string(entry.Event)))
+			continue
+		}
+		fmt.Printf("%s  %-8s %-6s %3d %-12s %s %dms\n    %s\n", at, entry.Exchange, entry.Method, entry.Status, orDash(entry.Symbol), ids, entry.DurationMs, abbreviate(entry.ResponseBody))
+		if entry.Error != "" {
+			fmt.Printf("    错误: %s\n", entry.Error)
+		}
+	}
+	fmt.Printf("\n共 %d 条，用 -raw 输出完整报文\n", len(matched))
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// abbreviate 把报文截为一行摘要。
+func abbreviate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 160 {
+		return string(r[:160]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange/binance"
+	"autobot/internal/pool"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+)
+
+// maxKlines 为币安单次请求K线数量的上限。
+const maxKlines = 1500
+
+var (
+	configFlag    = flag.String("config", "config.json", "配置文件路径")
+	daysFlag      = flag.Int("days", 14, "分析最近N天的选币快照")
+	horizonsFlag  = flag.String("horizons", "1h,4h,24h", "入选后的观察周期，逗号分隔")
+	benchmarkFlag = flag.String("benchmark", "BTCUSDT", "计算超额收益的基准交易对")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *daysFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 必须大于 0")
+		os.Exit(1)
+	}
+	var horizons []time.Duration
+	for _, item := range strings.Split(*horizonsFlag, ",") {
+		horizon, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil || horizon < time.Hour {
+			fmt.Fprintf(os.Stderr, "无效的观察周期 %q（至少 1h）\n", item)
+			os.Exit(1)
+		}
+		horizons = append(horizons, horizon)
+	}
+
+	snapshots, err := storage.LoadPoolSnapshots(cfg.Storage, time.Now().AddDate(0, 0, -*daysFlag))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("统计区间内没有选币快照")
+		return
+	}
+
+	// 按小时K线计算事后表现，覆盖最早的快照到现在
+	oldest := time.UnixMilli(snapshots[0].CreatedAt)
+	for _, snap := range snapshots {
+		if at := time.UnixMilli(snap.CreatedAt); at.Before(oldest) {
+			oldest = at
+		}
+	}
+	limit := int(time.Since(oldest).Hours()) + 2
+	if limit > maxKlines {
+		limit = maxKlines
+		fmt.Fprintf(os.Stderr, "快照跨度超过 %d 根小时K线，更早的快照将被跳过\n", maxKlines)
+	}
+
+	symbols := map[string]bool{*benchmarkFlag: true}
+	for _, snap := range snapshots {
+		for _, coin := range snap.Coins {
+			symbols[coin.Symbol] = true
+		}
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	client := binance.New("", "", "")
+	candles := make(map[string][]strategy.Candle, len(symbols))
+	for symbol := range symbols {
+		data, err := client.GetKlines(ctx, symbol, "1h", limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", symbol, err)
+			continue
+		}
+		candles[symbol] = data
+	}
+
+	fmt.Printf("快照 %d 个 | 币种 %d 个 | 基准 %s\n\n", len(snapshots), len(symbols)-1, *benchmarkFlag)
+	fmt.Printf("%-10s %6s %6s %10s %10s %8s %8s\n", "来源", "周期", "样本", "平均涨跌", "平均超额", "跑赢率", "评分相关")
+	for _, s := range pool.AnalyzeSelections(snapshots, candles, *benchmarkFlag, horizons) {
+		fmt.Printf("%-10s %6s %6d %+9.2f%% %+9.2f%% %7.1f%% %+8.2f\n", s.Source, formatHorizon(s.Horizon), s.Samples, s.AvgReturn, s.AvgExcess, s.HitRate*100, s.ScoreCorr)
+	}
+	fmt.Println("\n平均超额持续为正、跑赢率高于50%且评分相关为正的来源才有预测价值。")
+}
+
+func formatHorizon(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh", d/time.Hour)
+}
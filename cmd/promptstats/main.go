@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	daysFlag   = flag.Int("days", 30, "统计最近N天的决策与成交")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *daysFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 必须大于 0")
+		os.Exit(1)
+	}
+
+	since := time.Now().AddDate(0, 0, -*daysFlag)
+	decisions, err := storage.LoadDecisions(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	trades, err := storage.LoadTrades(cfg.Storage, since)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	if len(decisions) == 0 {
+		fmt.Println("统计区间内没有决策记录")
+		return
+	}
+
+	fmt.Printf("%-28s %6s %6s %8s %12s %10s %8s\n", "提示词版本", "决策", "成交", "胜率", "总盈亏", "单笔均值", "夏普")
+	for _, s := range storage.ComparePromptVersions(decisions, trades) {
+		fmt.Printf("%-28s %6d %6d %7.1f%% %+12.4f %+10.4f %8.2f\n", s.Version, s.Decisions, s.Trades, s.WinRate*100, s.TotalPnL, s.AvgPnL, s.Sharpe)
+	}
+}
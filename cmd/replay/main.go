@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/ai/factory"
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+var (
+	configFlag   = flag.String("config", "config.json", "配置文件路径")
+	daysFlag     = flag.Int("days", 7, "重放最近N天的决策")
+	providerFlag = flag.String("provider", "deepseek", "重放使用的提供商：deepseek/qwen/claude/ollama")
+	modelFlag    = flag.String("model", "", "覆盖该提供商配置中的模型，留空使用配置")
+	traderFlag   = flag.String("trader", "", "只重放该交易者的决策")
+	symbolFlag   = flag.String("symbol", "", "只重放该交易对的决策")
+	limitFlag    = flag.Int("limit", 20, "最多重放的决策条数（从最近开始），控制费用")
+	changedFlag  = flag.Bool("changed", false, "只列出决策发生变化的记录")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *daysFlag <= 0 || *limitFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "-days 与 -limit 必须大于 0")
+		os.Exit(1)
+	}
+	if *modelFlag != "" {
+		switch strings.ToLower(*providerFlag) {
+		case "deepseek":
+			cfg.Deepseek.Model = *modelFlag
+		case "qwen":
+			cfg.Qwen.Model = *modelFlag
+		case "claude":
+			cfg.Claude.Model = *modelFlag
+		case "ollama":
+			cfg.Ollama.Model = *modelFlag
+		}
+	}
+	replayer, err := factory.NewReplayer(*providerFlag, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	decisions, err := storage.LoadDecisions(cfg.Storage, time.Now().AddDate(0, 0, -*daysFlag))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var selected []storage.DecisionRecord
+	for _, d := range decisions {
+		if strings.TrimSpace(d.InputPrompt) == "" {
+			continue
+		}
+		if *traderFlag != "" && d.Trader != *traderFlag {
+			continue
+		}
+		if *symbolFlag != "" && !strings.EqualFold(d.Symbol, *symbolFlag) {
+			continue
+		}
+		selected = append(selected, d)
+	}
+	if len(selected) == 0 {
+		fmt.Println("统计区间内没有带输入提示词的决策记录")
+		return
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].CreatedAt > selected[j].CreatedAt })
+	if len(selected) > *limitFlag {
+		selected = selected[:*limitFlag]
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var (
+		same, changed, failed int
+		confidenceDelta       float64
+		transitions           = make(map[string]int)
+	)
+	fmt.Printf("%-16s %-12s %-10s %-20s %-20s\n", "时间", "交易者", "交易对", "原决策", "重放决策")
+	for _, d := range selected {
+		if ctx.Err() != nil {
+			break
+		}
+		at := time.UnixMilli(d.CreatedAt).Format("2006-01-02 15:04")
+		original := fmt.Sprintf("%s(%.2f)", d.Action, d.Confidence)
+		replayed, err := replayer.ReplayDecision(ctx, d.InputPrompt)
+		if err != nil {
+			failed++
+			fmt.Printf("%-16s %-12s %-10s %-20s 失败: %v\n", at, d.Trader, d.Symbol, original, err)
+			continue
+		}
+		confidenceDelta += replayed.Confidence - d.Confidence
+		if replayed.Action == d.Action {
+			same++
+			if *changedFlag {
+				continue
+			}
+		} else {
+			changed++
+			transitions[d.Action+" → "+replayed.Action]++
+		}
+		marker := ""
+		if replayed.Action != d.Action {
+			marker = " *"
+		}
+		fmt.Printf("%-16s %-12s %-10s %-20s %-20s%s\n", at, d.Trader, d.Symbol, original,
+			fmt.Sprintf("%s(%.2f)", replayed.Action, replayed.Confidence), marker)
+		if marker != "" && replayed.Reason != "" {
+			fmt.Printf("    新理由: %s\n", strings.Join(strings.Fields(replayed.Reason), " "))
+		}
+	}
+
+	done := same + changed
+	fmt.Println()
+	fmt.Printf("重放 %d 条：一致 %d，改变 %d，失败 %d", done+failed, same, changed, failed)
+	if done > 0 {
+		fmt.Printf("；一致率 %.1f%%，平均信心变化 %+.3f", float64(same)/float64(done)*100, confidenceDelta/float64(done))
+	}
+	fmt.Println()
+	if len(transitions) > 0 {
+		keys := make([]string, 0, len(transitions))
+		for key := range transitions {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return transitions[keys[i]] > transitions[keys[j]] || (transitions[keys[i]] == transitions[keys[j]] && keys[i] < keys[j])
+		})
+		fmt.Println("动作变化：")
+		for _, key := range keys {
+			fmt.Printf("  %-28s %d\n", key, transitions[key])
+		}
+	}
+}
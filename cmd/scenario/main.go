@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"autobot/internal/scenario"
+)
+
+var (
+	dirFlag     = flag.String("dir", "scenarios", "场景目录，命令行参数指定文件时忽略")
+	verboseFlag = flag.Bool("v", false, "输出每个场景的全部订单事件")
+)
+
+func main() {
+	flag.Parse()
+
+	var scenarios []scenario.Scenario
+	if flag.NArg() > 0 {
+		for _, path := range flag.Args() {
+			sc, err := scenario.Load(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			scenarios = append(scenarios, sc)
+		}
+	} else {
+		loaded, err := scenario.LoadDir(*dirFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		scenarios = loaded
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	reports := make([]scenario.Report, 0, len(scenarios))
+	failed := false
+	for _, sc := range scenarios {
+		report := scenario.Run(ctx, sc)
+		failed = failed || !report.Passed()
+		reports = append(reports, report)
+	}
+	scenario.WriteReport(os.Stdout, reports, *verboseFlag)
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,41 @@
+// schemagen 导出决策请求/响应的 JSON Schema，由 internal/ai 的 go:generate 调用：
+//
+//	go generate ./internal/ai
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"autobot/internal/ai"
+)
+
+var outFlag = flag.String("out", "schema", "schema 输出目录")
+
+func main() {
+	flag.Parse()
+	if err := os.MkdirAll(*outFlag, 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	files := map[string]map[string]any{
+		fmt.Sprintf("decision_request.v%d.json", ai.SchemaVersion):  ai.DecisionRequestSchema(),
+		fmt.Sprintf("decision_response.v%d.json", ai.SchemaVersion): ai.DecisionResponseSchema(),
+	}
+	for name, schema := range files {
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		path := filepath.Join(*outFlag, name)
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("wrote", path)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"autobot/internal/ai"
+	aifactory "autobot/internal/ai/factory"
+	"autobot/internal/config"
+	"autobot/internal/exchange/factory"
+	"autobot/internal/metrics"
+	"autobot/internal/shadow"
+	"autobot/internal/strategy"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	dateFlag   = flag.String("date", "", "校验的UTC日期（YYYY-MM-DD），默认昨天")
+	equityFlag = flag.Float64("equity", 1000, "回测初始资金 USDT（只影响回测盈亏，不影响滑点与匹配）")
+	aiFlag     = flag.Bool("ai", false, "回测信号经配置的 decisionProvider 确认，与实盘决策流程一致（会产生API费用）")
+	daemonFlag = flag.Bool("daemon", false, "常驻运行，每天在 shadow.runAt（UTC）校验前一日")
+	listenFlag = flag.String("listen", "", "常驻模式下 /metrics 的监听地址（如 127.0.0.1:9101），留空不启动")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := strategy.Configure(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	runner := shadow.Runner{
+		Config: cfg,
+		Equity: *equityFlag,
+		Source: func(profile config.TraderProfileResolved) (shadow.KlineSource, error) {
+			return factory.New(profile.Exchange, profile.Account, cfg.Exchanges, profile.Settings)
+		},
+	}
+	if *aiFlag {
+		runner.Provider = func(profile config.TraderProfileResolved) (ai.Provider, error) {
+			return aifactory.NewChain(profile.Providers(), cfg)
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	if *daemonFlag {
+		if *listenFlag != "" {
+			go func() {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", metrics.Handler())
+				if err := http.ListenAndServe(*listenFlag, mux); err != nil {
+					fmt.Fprintf(os.Stderr, "metrics listen: %v\n", err)
+				}
+			}()
+		}
+		runner.Schedule(ctx, func(reports []shadow.Report) {
+			shadow.WriteReport(os.Stdout, reports)
+		})
+		return
+	}
+
+	day := time.Now().UTC().AddDate(0, 0, -1)
+	if *dateFlag != "" {
+		if day, err = time.Parse("2006-01-02", *dateFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -date %q: %v\n", *dateFlag, err)
+			os.Exit(1)
+		}
+	}
+	reports, err := runner.Run(ctx, day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	shadow.WriteReport(os.Stdout, reports)
+	for _, report := range reports {
+		if report.Diverged() {
+			os.Exit(2)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	aifactory "autobot/internal/ai/factory"
+	"autobot/internal/backtest"
+	"autobot/internal/config"
+	"autobot/internal/exchange/binance"
+	"autobot/internal/risk"
+	"autobot/internal/strategy"
+)
+
+var (
+	configsFlag = flag.String("configs", "", "参赛配置文件列表，逗号分隔")
+	limitFlag   = flag.Int("limit", 1000, "每个交易对拉取的K线数量（同一数据窗口）")
+	equityFlag  = flag.Float64("equity", 1000, "初始资金 USDT")
+	aiFlag      = flag.Bool("ai", false, "是否调用配置的 decisionProvider 确认信号（会产生API费用）")
+	parallel    = flag.Int("parallel", 4, "并发回测数量")
+	outputFlag  = flag.String("out", "", "报告输出文件，留空输出到标准输出")
+
+	latencyFlag = flag.Duration("latency", 0, "模拟下单延迟（如 500ms），开仓与信号平仓在延迟后按插值价成交")
+	partialFlag = flag.Float64("partial", 0, "开仓部分成交的概率（0~1）")
+	minFillFlag = flag.Float64("minfill", 0.5, "部分成交时的最小成交比例")
+	seedFlag    = flag.Int64("seed", 1, "部分成交随机数种子")
+	makerFlag   = flag.Bool("maker", false, "以限价挂单开仓：按挂单费率、不计开仓滑点，未触及挂单价则错过")
+	makerTPFlag = flag.Bool("makertp", false, "止盈按挂单费率成交")
+	fundingFlag = flag.Bool("funding", false, "拉取历史资金费率，持仓跨过结算时刻时计入收付")
+)
+
+func main() {
+	flag.Parse()
+	paths := splitList(*configsFlag)
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "请通过 -configs 指定至少一个配置文件")
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var entries []backtest.Entry
+	for _, path := range paths {
+		cfg, err := config.Load(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "load %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if err := strategy.Configure(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", base, err)
+			os.Exit(1)
+		}
+		for _, profile := range cfg.TraderProfiles {
+			strat, err := strategy.ForTrader(profile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s/%s: %v\n", base, profile.Name, err)
+				os.Exit(1)
+			}
+			entry := backtest.Config{
+				Name:          fmt.Sprintf("%s/%s", base, profile.Name),
+				Symbol:        profile.Symbol,
+				Interval:      profile.Interval,
+				Timeframes:    profile.Timeframes,
+				Strategy:      strat,
+				Settings:      profile.Settings,
+				Limits:        riskLimits(profile.Risk),
+				InitialEquity: *equityFlag,
+				Gate:          risk.NewGate(profile.Risk, profile.Fees),
+				Fees:          profile.Fees,
+				Realism: backtest.Realism{
+					Latency:                *latencyFlag,
+					PartialFillProbability: *partialFlag,
+					MinFillRatio:           *minFillFlag,
+					MakerEntries:           *makerFlag,
+					MakerTakeProfit:        *makerTPFlag,
+					Seed:                   *seedFlag,
+				},
+			}
+			if *aiFlag {
+				provider, err := aifactory.NewChain(profile.Providers(), cfg)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", entry.Name, err)
+					os.Exit(1)
+				}
+				entry.Name += "+" + strings.Join(profile.Providers(), ">")
+				entry.Provider = provider
+			}
+			entries = append(entries, backtest.Entry{Config: entry})
+		}
+	}
+
+	data, err := loadData(ctx, entries, *limitFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load klines: %v\n", err)
+		os.Exit(1)
+	}
+	if *fundingFlag {
+		if err := attachFunding(ctx, entries, data); err != nil {
+			fmt.Fprintf(os.Stderr, "load funding: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	started := time.Now()
+	results := backtest.RunTournament(ctx, data, entries, *parallel)
+
+	writer := os.Stdout
+	if *outputFlag != "" {
+		f, err := os.Create(*outputFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "create output: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		writer = f
+	}
+	fmt.Fprintf(writer, "锦标赛: %d 个参赛配置 | K线 %d 根 | 耗时 %s\n\n", len(entries), *limitFlag, time.Since(started).Round(time.Millisecond))
+	backtest.WriteReport(writer, results)
+}
+
+// loadData 每个 symbol/interval 只拉取一次，保证所有参赛者使用同一数据窗口。
+func loadData(ctx context.Context, entries []backtest.Entry, limit int) (map[backtest.DataKey][]strategy.Candle, error) {
+	client := binance.New("", "", "")
+	data := make(map[backtest.DataKey][]strategy.Candle)
+	for _, entry := range entries {
+		key := backtest.DataKey{Symbol: entry.Config.Symbol, Interval: entry.Config.Interval}
+		if _, ok := data[key]; ok {
+			continue
+		}
+		candles, err := client.GetKlines(ctx, key.Symbol, key.Interval, limit)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", key.Symbol, key.Interval, err)
+		}
+		data[key] = candles
+	}
+	return data, nil
+}
+
+// attachFunding 按交易对拉取K线区间内的资金费率历史，写入各参赛配置。
+func attachFunding(ctx context.Context, entries []backtest.Entry, data map[backtest.DataKey][]strategy.Candle) error {
+	client := binance.New("", "", "")
+	cache := make(map[string][]backtest.FundingRate)
+	for i := range entries {
+		cfg := &entries[i].Config
+		candles := data[backtest.DataKey{Symbol: cfg.Symbol, Interval: cfg.Interval}]
+		if len(candles) == 0 {
+			continue
+		}
+		key := cfg.Symbol + "|" + cfg.Interval
+		rates, ok := cache[key]
+		if !ok {
+			events, err := client.GetFundingHistory(ctx, cfg.Symbol, candles[0].OpenTime, candles[len(candles)-1].OpenTime)
+			if err != nil {
+				return fmt.Errorf("%s: %w", cfg.Symbol, err)
+			}
+			for _, event := range events {
+				rates = append(rates, backtest.FundingRate{Time: event.Time, Rate: event.Rate})
+			}
+			cache[key] = rates
+		}
+		cfg.Realism.Funding = rates
+	}
+	return nil
+}
+
+func riskLimits(risk config.RiskConfig) ai.RiskLimits {
+	return ai.RiskLimits{
+		MaxDailyLossPercent:    risk.MaxDailyLossPercent,
+		MaxPositionNotionalUSD: risk.MaxPositionNotionalUSD,
+		MaxConcurrentPositions: risk.MaxConcurrentPositions,
+		MaxLeverage:            risk.MaxLeverage,
+		BtcEthNotionalMultiple: risk.BtcEthNotionalMultiple,
+		AltNotionalMultiple:    risk.AltNotionalMultiple,
+		MinRiskRewardRatio:     risk.MinRiskRewardRatio,
+		MaxMajorPositions:      risk.MaxMajorPositions,
+		MaxAltPositions:        risk.MaxAltPositions,
+	}
+}
+
+func splitList(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"autobot/internal/config"
+	"autobot/internal/version"
+)
+
+var (
+	configFlag = flag.String("config", "", "配置文件路径；指定时列出其中已弃用的字段")
+	checkFlag  = flag.Bool("check", false, "查询最新发布版本并与当前版本比较")
+)
+
+func main() {
+	flag.Parse()
+	fmt.Println(version.Banner("version"))
+
+	url := config.DefaultUpdateURL
+	if *configFlag != "" {
+		cfg, err := config.Load(*configFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if cfg.Update.URL != "" {
+			url = cfg.Update.URL
+		}
+		if len(cfg.Deprecations) == 0 {
+			fmt.Println("配置未使用已弃用字段")
+		}
+		for _, d := range cfg.Deprecations {
+			fmt.Println("弃用:", d)
+		}
+	}
+	if !*checkFlag {
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	release, err := version.Latest(ctx, nil, url)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	current := version.Get().Version
+	if version.Newer(current, release.Tag) {
+		fmt.Printf("有新版本 %s（当前 %s）: %s\n", release.Tag, current, release.URL)
+		os.Exit(2)
+	}
+	fmt.Printf("已是最新（当前 %s，最新发布 %s）\n", current, release.Tag)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"autobot/internal/ai"
+	aifactory "autobot/internal/ai/factory"
+	"autobot/internal/config"
+	"autobot/internal/exchange/factory"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+	"autobot/internal/watch"
+)
+
+var (
+	configFlag = flag.String("config", "config.json", "配置文件路径")
+	onceFlag   = flag.Bool("once", false, "只分析一轮后退出")
+	streamFlag = flag.Bool("stream", false, "实时打印AI思维链（需在提供商配置中启用 stream）")
+)
+
+func main() {
+	flag.Parse()
+	cfg, err := config.Load(*configFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(cfg.Watchlist.Symbols) == 0 {
+		fmt.Fprintln(os.Stderr, "watchlist.symbols 为空")
+		os.Exit(1)
+	}
+
+	settings := cfg.Global.Defaults
+	// 观察列表只读取公开行情，不需要密钥
+	source, err := factory.New(cfg.Watchlist.Exchange, "", config.ExchangeConfig{}, settings)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := strategy.Configure(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	strat, err := strategy.New(cfg.Watchlist.Strategy, settings)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var store storage.Store
+	if cfg.Watchlist.Provider != "" || cfg.Storage.EventLog {
+		if store, err = storage.New(cfg.Storage); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		// 启动时记录配置哈希，事件日志中可看出两次启动之间配置是否被改过
+		if err := storage.RecordConfigChange(context.Background(), store, *configFlag, "cmd/watch 启动"); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	var provider ai.Provider
+	if cfg.Watchlist.Provider != "" {
+		if provider, err = aifactory.New(cfg.Watchlist.Provider, cfg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		// 观察列表的AI调用同样计费，用量写入存储目录供 aicost 汇总
+		now := time.Now().UTC()
+		usage, err := storage.LoadUsage(cfg.Storage, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		meter := ai.NewMeter(cfg.AIPricing, store)
+		meter.Seed(usage)
+		budget := ai.NewBudget(cfg.AIBudget)
+		budget.Seed(usage)
+		meter.SetBudget(budget)
+		ai.SetMeter(meter)
+	}
+
+	watcher := &watch.Watcher{
+		Source:   source,
+		Strategy: strat,
+		Settings: settings,
+		Provider: provider,
+		Symbols:  cfg.Watchlist.Symbols,
+		Interval: cfg.Watchlist.Interval,
+		Every:    cfg.WatchlistRefresh,
+		OnUpdate: func(reports []watch.Report) {
+			fmt.Printf("== 观察列表 %s ==\n", time.Now().Format("2006-01-02 15:04:05"))
+			for _, report := range reports {
+				fmt.Println(report)
+			}
+			for _, spend := range ai.DefaultMeter().Today() {
+				fmt.Printf("AI费用(今日) %s 调用%d 输入%d 输出%d $%.4f\n", spend.Provider, spend.Calls, spend.PromptTokens, spend.CompletionTokens, spend.CostUSD)
+			}
+		},
+	}
+
+	if *streamFlag {
+		watcher.OnThought = func(symbol, line string) {
+			fmt.Printf("  [%s] %s\n", symbol, line)
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if *onceFlag {
+		watcher.OnUpdate(watcher.Scan(ctx))
+		return
+	}
+	if err := watcher.Run(ctx); err != nil && ctx.Err() == nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,156 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// ErrBudgetExceeded 表示提供商或交易者的AI预算已用尽。
+var ErrBudgetExceeded = errors.New("AI预算已用尽")
+
+// Budget 统计各提供商、各交易者的调用次数（滚动一小时）与当日（UTC）token、费用，
+// 由 Meter 在每次记录用量时累计。
+type Budget struct {
+	cfg config.AIBudgetConfig
+
+	mu    sync.Mutex
+	day   string
+	daily map[string]*budgetUsage
+	calls map[string][]time.Time
+}
+
+type budgetUsage struct {
+	tokens int
+	cost   float64
+}
+
+// NewBudget 按配置创建预算。
+func NewBudget(cfg config.AIBudgetConfig) *Budget {
+	return &Budget{cfg: cfg, daily: map[string]*budgetUsage{}, calls: map[string][]time.Time{}}
+}
+
+// Seed 用已落盘的当日用量恢复计数。
+func (b *Budget) Seed(records []Usage) {
+	for _, u := range records {
+		b.Add(u)
+	}
+}
+
+// Add 计入一次调用。
+func (b *Budget) Add(u Usage) {
+	at := time.UnixMilli(u.CreatedAt)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	day := utcDay(u.CreatedAt)
+	if day > b.day {
+		b.day, b.daily = day, map[string]*budgetUsage{}
+	}
+	keys := []string{"provider:" + u.Provider}
+	if u.Trader != "" {
+		keys = append(keys, "trader:"+u.Trader)
+	}
+	for _, key := range keys {
+		b.calls[key] = append(pruneCalls(b.calls[key], time.Now()), at)
+		if day != b.day {
+			continue
+		}
+		usage := b.daily[key]
+		if usage == nil {
+			usage = &budgetUsage{}
+			b.daily[key] = usage
+		}
+		usage.tokens += u.TotalTokens()
+		usage.cost += u.CostUSD
+	}
+}
+
+// Check 在调用模型前检查预算，任一上限用尽时返回包装 ErrBudgetExceeded 的错误。
+func (b *Budget) Check(provider, trader string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if limits, ok := b.cfg.Providers[provider]; ok {
+		if err := b.checkLocked("provider:"+provider, provider, limits); err != nil {
+			return err
+		}
+	}
+	if limits, ok := b.cfg.Traders[trader]; ok && trader != "" {
+		if err := b.checkLocked("trader:"+trader, "交易者 "+trader, limits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Budget) checkLocked(key, label string, limits config.BudgetLimits) error {
+	now := time.Now()
+	b.calls[key] = pruneCalls(b.calls[key], now)
+	if limits.MaxCallsPerHour > 0 && len(b.calls[key]) >= limits.MaxCallsPerHour {
+		return fmt.Errorf("%w: %s 近1小时调用 %d 次，上限 %d", ErrBudgetExceeded, label, len(b.calls[key]), limits.MaxCallsPerHour)
+	}
+	usage := &budgetUsage{}
+	if b.day == utcDay(now.UnixMilli()) && b.daily[key] != nil {
+		usage = b.daily[key]
+	}
+	if limits.MaxTokensPerDay > 0 && usage.tokens >= limits.MaxTokensPerDay {
+		return fmt.Errorf("%w: %s 今日已用 %d tokens，上限 %d", ErrBudgetExceeded, label, usage.tokens, limits.MaxTokensPerDay)
+	}
+	if limits.MaxUSDPerDay > 0 && usage.cost >= limits.MaxUSDPerDay {
+		return fmt.Errorf("%w: %s 今日费用 $%.4f，上限 $%.2f", ErrBudgetExceeded, label, usage.cost, limits.MaxUSDPerDay)
+	}
+	return nil
+}
+
+// pruneCalls 丢弃一小时以前的调用时间。
+func pruneCalls(calls []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-time.Hour)
+	i := 0
+	for i < len(calls) && !calls[i].After(cutoff) {
+		i++
+	}
+	return calls[i:]
+}
+
+// Budgeted 在每次调用 provider 前按全局计费器上的预算检查 name 与交易者的额度：
+// 决策请求超额时返回 wait 决策，新闻分析超额时返回错误。
+func Budgeted(name string, provider Provider) Provider {
+	return &budgetedProvider{name: name, provider: provider}
+}
+
+type budgetedProvider struct {
+	name     string
+	provider Provider
+}
+
+func (p *budgetedProvider) check(trader string) error {
+	budget := DefaultMeter().Budget()
+	if budget == nil {
+		return nil
+	}
+	return budget.Check(p.name, trader)
+}
+
+func (p *budgetedProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if err := p.check(""); err != nil {
+		return news.SentimentSummary{}, err
+	}
+	return p.provider.AnalyzeNews(ctx, articles)
+}
+
+func (p *budgetedProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	if err := p.check(req.TraderName); err != nil {
+		loggerpkg.Get("ai.budget").Printf("budget.exceeded provider=%s trader=%s symbol=%s err=%v", p.name, req.TraderName, req.Symbol, err)
+		return DecisionResponse{
+			Action:    "wait",
+			Reason:    "AI预算已用尽，暂停调用模型",
+			RiskNotes: []string{err.Error()},
+		}, nil
+	}
+	return p.provider.GenerateDecision(ctx, req)
+}
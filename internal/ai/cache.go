@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// DecisionCache 在 TTL 内复用上下文相同的决策：同一周期内的重试、多个交易者评估同一交易对时
+// 只调用一次模型。只缓存成功的决策；相同请求并发到达时后来者等待首个请求的结果。
+type DecisionCache struct {
+	ttl    time.Duration
+	logger *loggerpkg.ModuleLogger
+
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	inflight map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	decision DecisionResponse
+	expires  time.Time
+}
+
+type cacheCall struct {
+	done     chan struct{}
+	decision DecisionResponse
+	err      error
+}
+
+// NewDecisionCache 创建决策缓存，多个提供商可共享同一实例。
+func NewDecisionCache(ttl time.Duration) *DecisionCache {
+	return &DecisionCache{
+		ttl:      ttl,
+		logger:   loggerpkg.Get("ai.cache"),
+		entries:  make(map[string]cacheEntry),
+		inflight: make(map[string]*cacheCall),
+	}
+}
+
+// Wrap 返回带缓存的提供商。name 区分不同的提供商组合，名称不同的请求互不命中；
+// AnalyzeNews 不经缓存（新闻模块自有缓存）。
+func (c *DecisionCache) Wrap(name string, provider Provider) Provider {
+	return &cachedProvider{cache: c, name: name, provider: provider}
+}
+
+type cachedProvider struct {
+	cache    *DecisionCache
+	name     string
+	provider Provider
+}
+
+func (p *cachedProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	return p.provider.AnalyzeNews(ctx, articles)
+}
+
+func (p *cachedProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	c := p.cache
+	hash, err := DecisionKey(req)
+	if err != nil {
+		// 无法计算键时不走缓存，避免不同请求落到同一个键上
+		c.logger.Printf("cache.skip provider=%s trader=%s symbol=%s err=%v", p.name, req.TraderName, req.Symbol, err)
+		return p.provider.GenerateDecision(ctx, req)
+	}
+	key := p.name + ":" + hash
+
+	c.mu.Lock()
+	now := time.Now()
+	if entry, ok := c.entries[key]; ok && now.Before(entry.expires) {
+		c.mu.Unlock()
+		c.logger.Printf("cache.hit provider=%s trader=%s symbol=%s action=%s", p.name, req.TraderName, req.Symbol, entry.decision.Action)
+		return cachedCopy(entry.decision), nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return DecisionResponse{}, ctx.Err()
+		}
+		if call.err != nil {
+			return DecisionResponse{}, call.err
+		}
+		c.logger.Printf("cache.shared provider=%s trader=%s symbol=%s action=%s", p.name, req.TraderName, req.Symbol, call.decision.Action)
+		return cachedCopy(call.decision), nil
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.decision, call.err = p.provider.GenerateDecision(ctx, req)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.sweepLocked(now)
+		c.entries[key] = cacheEntry{decision: call.decision, expires: time.Now().Add(c.ttl)}
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.decision, call.err
+}
+
+func (c *DecisionCache) sweepLocked(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cachedCopy 复制缓存的决策，复用的结果不产生新的 token 费用。
+func cachedCopy(d DecisionResponse) DecisionResponse {
+	d.RiskNotes = append([]string(nil), d.RiskNotes...)
+	d.Usage = Usage{}
+	return d
+}
+
+// decisionKey 为参与缓存键计算的请求内容：交易对、行情快照与持仓形态（不含随时间变化的
+// 浮盈、持仓时长），以及策略信号与风控边界。账户余额、时间等字段不参与，便于多个交易者共享。
+type decisionKey struct {
+	Symbol     string                        `json:"symbol"`
+	Price      float64                       `json:"price"`
+	Signal     string                        `json:"signal"`
+	Strength   float64                       `json:"strength,omitempty"`
+	RiskLimits RiskLimits                    `json:"riskLimits"`
+	MarketData map[string]MarketDataSnapshot `json:"marketData"`
+	Positions  []positionKey                 `json:"positions"`
+}
+
+type positionKey struct {
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"`
+	Quantity   float64 `json:"quantity"`
+	EntryPrice float64 `json:"entryPrice"`
+	Leverage   float64 `json:"leverage"`
+}
+
+// DecisionKey 返回请求上下文的哈希，上下文相同的请求得到相同的键。上下文无法编码（如含 NaN）时返回错误。
+func DecisionKey(req DecisionRequest) (string, error) {
+	key := decisionKey{
+		Symbol:     req.Symbol,
+		Price:      req.CurrentPrice,
+		Signal:     req.StrategySignal,
+		Strength:   req.StrategyStrength,
+		RiskLimits: req.RiskLimits,
+		MarketData: req.Context.MarketData,
+	}
+	for _, p := range req.Positions {
+		key.Positions = append(key.Positions, positionKey{Symbol: p.Symbol, Side: p.Side, Quantity: p.Quantity, EntryPrice: p.EntryPrice, Leverage: p.Leverage})
+	}
+	for _, p := range req.Context.Positions {
+		key.Positions = append(key.Positions, positionKey{Symbol: p.Symbol, Side: p.Side, Quantity: p.Quantity, EntryPrice: p.EntryPrice, Leverage: p.Leverage})
+	}
+	// map 按键排序编码，结果稳定
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("decision key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
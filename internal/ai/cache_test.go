@@ -0,0 +1,53 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"autobot/internal/news"
+)
+
+type countingProvider struct{ calls int }
+
+func (p *countingProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	return news.SentimentSummary{}, nil
+}
+
+func (p *countingProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	p.calls++
+	return DecisionResponse{Action: "hold"}, nil
+}
+
+func TestDecisionCacheReusesKey(t *testing.T) {
+	inner := &countingProvider{}
+	provider := NewDecisionCache(time.Minute).Wrap("test", inner)
+	req := DecisionRequest{Symbol: "BTCUSDT", CurrentPrice: 100}
+	for i := 0; i < 2; i++ {
+		if _, err := provider.GenerateDecision(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Fatalf("provider called %d times, want 1", inner.calls)
+	}
+}
+
+func TestDecisionCacheSkipsUnencodableRequest(t *testing.T) {
+	req := DecisionRequest{Symbol: "BTCUSDT", CurrentPrice: math.NaN()}
+	if _, err := DecisionKey(req); err == nil {
+		t.Fatal("NaN price produced a key")
+	}
+
+	inner := &countingProvider{}
+	provider := NewDecisionCache(time.Minute).Wrap("test", inner)
+	for i := 0; i < 2; i++ {
+		if _, err := provider.GenerateDecision(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Fatalf("provider called %d times, want 2 (no caching without a key)", inner.calls)
+	}
+}
@@ -0,0 +1,113 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// NamedProvider 为带名称的提供商，名称用于日志与标注实际给出决策的提供商。
+type NamedProvider struct {
+	Name     string
+	Provider Provider
+}
+
+// Chain 按顺序尝试多个提供商：前一个返回错误或决策未通过 ValidateDecision 时自动改用下一个，
+// 避免单个API故障让交易者长时间无法决策。探活（DefaultHealth）判定不健康的提供商排到最后，
+// 只在其余提供商都失败时才尝试。
+type Chain struct {
+	providers []NamedProvider
+	logger    *loggerpkg.ModuleLogger
+
+	mu   sync.Mutex
+	last string
+}
+
+var _ Provider = (*Chain)(nil)
+
+// NewChain 创建回退链，providers 按优先级排列。
+func NewChain(providers ...NamedProvider) *Chain {
+	return &Chain{providers: providers, logger: loggerpkg.Get("ai.chain")}
+}
+
+// Last 返回最近一次成功给出结果的提供商名称。
+func (c *Chain) Last() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.last
+}
+
+func (c *Chain) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	var errs []error
+	for _, p := range c.ordered() {
+		summary, err := p.Provider.AnalyzeNews(ctx, articles)
+		if err == nil {
+			c.answered(p.Name, len(errs))
+			return summary, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		c.logger.Printf("fallback.news provider=%s err=%v", p.Name, err)
+	}
+	return news.SentimentSummary{}, fmt.Errorf("all providers failed: %w", errors.Join(errs...))
+}
+
+func (c *Chain) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	var errs []error
+	for _, p := range c.ordered() {
+		decision, err := p.Provider.GenerateDecision(ctx, req)
+		if err == nil {
+			if err = ValidateDecision(decision, req.RiskLimits); err != nil {
+				err = fmt.Errorf("invalid decision: %w", err)
+			}
+		}
+		if err == nil {
+			c.answered(p.Name, len(errs))
+			return decision, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		c.logger.Printf("fallback.decision provider=%s symbol=%s err=%v", p.Name, req.Symbol, err)
+	}
+	return DecisionResponse{}, fmt.Errorf("all providers failed: %w", errors.Join(errs...))
+}
+
+// ordered 返回按健康状态调整后的尝试顺序：健康的提供商保持原有优先级在前。
+func (c *Chain) ordered() []NamedProvider {
+	health := DefaultHealth()
+	healthy := make([]NamedProvider, 0, len(c.providers))
+	var unhealthy []NamedProvider
+	for _, p := range c.providers {
+		if health.Healthy(p.Name) {
+			healthy = append(healthy, p)
+		} else {
+			unhealthy = append(unhealthy, p)
+		}
+	}
+	if len(unhealthy) > 0 && len(healthy) > 0 {
+		names := make([]string, len(unhealthy))
+		for i, p := range unhealthy {
+			names[i] = p.Name
+		}
+		c.logger.Printf("health.deprioritized providers=%s", strings.Join(names, ","))
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (c *Chain) answered(name string, failures int) {
+	c.mu.Lock()
+	c.last = name
+	c.mu.Unlock()
+	if failures > 0 {
+		c.logger.Printf("fallback.answered provider=%s after=%d", name, failures)
+	}
+}
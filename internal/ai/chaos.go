@@ -0,0 +1,225 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// ErrChaosDrill 表示提供商因AI不可用演练被人为停用。
+var ErrChaosDrill = errors.New("AI不可用演练中")
+
+// 演练期间的决策兜底方式。
+const (
+	// ChaosModeRules 按策略信号直接生成决策，检验规则策略能否独立运行。
+	ChaosModeRules = "rules"
+	// ChaosModeReduceOnly 只允许持有或平仓，不开新仓。
+	ChaosModeReduceOnly = "reduceOnly"
+)
+
+// ChaosDrillNote 为演练兜底决策 riskNotes 的前缀，便于在决策记录中筛出演练期间的行为。
+const ChaosDrillNote = "AI不可用演练"
+
+// ChaosDrill 为一次AI不可用演练：Until 之前 Providers 中的提供商（为空表示全部）调用直接失败，
+// 回退链全部失败时按 Mode 兜底生成决策。
+type ChaosDrill struct {
+	Providers []string  `json:"providers,omitempty"`
+	Mode      string    `json:"mode"`
+	StartedAt time.Time `json:"startedAt"`
+	Until     time.Time `json:"until"`
+	Operator  string    `json:"operator,omitempty"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// Active 报告演练在 now 时是否仍在进行。
+func (d ChaosDrill) Active(now time.Time) bool {
+	return now.Before(d.Until)
+}
+
+// Covers 报告演练是否停用 provider（不区分大小写）。
+func (d ChaosDrill) Covers(provider string) bool {
+	if len(d.Providers) == 0 {
+		return true
+	}
+	for _, name := range d.Providers {
+		if healthKey(name) == healthKey(provider) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChaosSwitch 以文件保存演练状态，运维命令（cmd/chaos）写入、交易进程每次调用前读取，
+// 跨进程生效且无需重启；文件未变化时不重复解析。
+type ChaosSwitch struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	drill   ChaosDrill
+	ok      bool
+}
+
+// NewChaosSwitch 创建读写 path 的演练开关。
+func NewChaosSwitch(path string) *ChaosSwitch {
+	return &ChaosSwitch{path: path}
+}
+
+// ChaosFile 返回存储目录下的演练状态文件路径。
+func ChaosFile(dir string) string {
+	return filepath.Join(dir, "chaos.json")
+}
+
+// Start 写入演练状态，覆盖正在进行的演练。
+func (s *ChaosSwitch) Start(drill ChaosDrill) error {
+	switch drill.Mode {
+	case ChaosModeRules, ChaosModeReduceOnly:
+	default:
+		return fmt.Errorf("未知演练模式 %q，可选 %s/%s", drill.Mode, ChaosModeRules, ChaosModeReduceOnly)
+	}
+	return s.write(drill)
+}
+
+func (s *ChaosSwitch) write(drill ChaosDrill) error {
+	data, err := json.MarshalIndent(drill, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Stop 把进行中的演练的结束时间改为现在，保留记录供事后汇总；返回结束后的演练，没有演练记录时 ok 为 false。
+func (s *ChaosSwitch) Stop() (ChaosDrill, bool, error) {
+	drill, ok, err := s.Current()
+	if err != nil || !ok {
+		return drill, ok, err
+	}
+	if now := time.Now().UTC(); drill.Until.After(now) {
+		drill.Until = now
+		if err := s.write(drill); err != nil {
+			return drill, true, err
+		}
+	}
+	return drill, true, nil
+}
+
+// Current 返回最近一次写入的演练（可能已结束），没有演练记录时 ok 为 false。
+func (s *ChaosSwitch) Current() (ChaosDrill, bool, error) {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.mu.Lock()
+		s.ok, s.modTime = false, time.Time{}
+		s.mu.Unlock()
+		return ChaosDrill{}, false, nil
+	}
+	if err != nil {
+		return ChaosDrill{}, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ok && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.drill, true, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return ChaosDrill{}, false, err
+	}
+	var drill ChaosDrill
+	if err := json.Unmarshal(data, &drill); err != nil {
+		return ChaosDrill{}, false, fmt.Errorf("parse %s: %w", s.path, err)
+	}
+	s.drill, s.ok, s.modTime, s.size = drill, true, info.ModTime(), info.Size()
+	return drill, true, nil
+}
+
+// Blocking 报告 provider 当前是否被演练停用。状态文件读取失败时记录日志并视为未演练。
+func (s *ChaosSwitch) Blocking(provider string) (ChaosDrill, bool) {
+	drill, ok, err := s.Current()
+	if err != nil {
+		loggerpkg.Get("ai.chaos").Printf("chaos.read_failed err=%v", err)
+		return ChaosDrill{}, false
+	}
+	if !ok || !drill.Active(time.Now()) || !drill.Covers(provider) {
+		return ChaosDrill{}, false
+	}
+	return drill, true
+}
+
+// WithChaos 在演练停用 name 时让 provider 的调用直接返回 ErrChaosDrill，走与真实故障相同的回退路径。
+func WithChaos(name string, provider Provider, sw *ChaosSwitch) Provider {
+	return &chaosProvider{name: name, provider: provider, sw: sw}
+}
+
+type chaosProvider struct {
+	name     string
+	provider Provider
+	sw       *ChaosSwitch
+}
+
+func (p *chaosProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if drill, ok := p.sw.Blocking(p.name); ok {
+		loggerpkg.Get("ai.chaos").Printf("chaos.blocked provider=%s kind=news until=%s", p.name, drill.Until.Format(time.RFC3339))
+		return news.SentimentSummary{}, ErrChaosDrill
+	}
+	return p.provider.AnalyzeNews(ctx, articles)
+}
+
+func (p *chaosProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	if drill, ok := p.sw.Blocking(p.name); ok {
+		loggerpkg.Get("ai.chaos").Printf("chaos.blocked provider=%s kind=decision trader=%s symbol=%s until=%s", p.name, req.TraderName, req.Symbol, drill.Until.Format(time.RFC3339))
+		return DecisionResponse{}, ErrChaosDrill
+	}
+	return p.provider.GenerateDecision(ctx, req)
+}
+
+// WithChaosFallback 在决策因演练失败（回退链上的提供商全部被停用）时按演练模式兜底生成决策，
+// riskNotes 以 ChaosDrillNote 开头；其他错误原样返回。
+func WithChaosFallback(provider Provider, sw *ChaosSwitch) Provider {
+	return &chaosFallbackProvider{Provider: provider, sw: sw}
+}
+
+type chaosFallbackProvider struct {
+	Provider
+	sw *ChaosSwitch
+}
+
+func (p *chaosFallbackProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	decision, err := p.Provider.GenerateDecision(ctx, req)
+	if err == nil || !errors.Is(err, ErrChaosDrill) {
+		return decision, err
+	}
+	drill, ok, readErr := p.sw.Current()
+	if readErr != nil || !ok {
+		return decision, err
+	}
+	decision = ChaosDecision(drill.Mode, req)
+	decision.RiskNotes = []string{fmt.Sprintf("%s（%s，至 %s）", ChaosDrillNote, drill.Mode, drill.Until.UTC().Format("15:04 UTC"))}
+	aiChaosFallbacksTotal.Inc(drill.Mode)
+	loggerpkg.Get("ai.chaos").Printf("chaos.fallback trader=%s symbol=%s mode=%s signal=%s action=%s",
+		req.TraderName, req.Symbol, drill.Mode, req.StrategySignal, decision.Action)
+	return decision, nil
+}
+
+// ChaosDecision 返回AI不可用时按 mode 得出的决策：rules 跟随策略信号，reduceOnly 不开新仓，
+// 两种模式都在出场或反向信号时平仓（见 SignalDecision）。
+func ChaosDecision(mode string, req DecisionRequest) DecisionResponse {
+	decision := SignalDecision(req, mode == ChaosModeRules)
+	decision.Reason = "AI不可用，" + decision.Reason
+	return decision
+}
@@ -0,0 +1,300 @@
+package claude
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+const (
+	messagesEndpoint = "/v1/messages"
+	apiVersion       = "2023-06-01"
+)
+
+// Client 封装 Anthropic Messages API。
+type Client struct {
+	httpClient *http.Client
+	apiKey     string
+	cfg        config.ClaudeConfig
+	logger     *loggerpkg.ModuleLogger
+}
+
+var _ ai.Provider = (*Client)(nil)
+
+// New 创建 Claude 客户端，未启用时返回 nil。
+func New(apiKey string, cfg config.ClaudeConfig) *Client {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.anthropic.com"
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.Model == "" {
+		cfg.Model = "claude-sonnet-4-5"
+	}
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = 2000
+	}
+	if apiKey == "" {
+		apiKey = cfg.APIKey
+	}
+	moduleLogger := loggerpkg.Get("ai.claude")
+	moduleLogger.Printf("initialized claude client model=%s base=%s maxTokens=%d", cfg.Model, cfg.BaseURL, cfg.MaxTokens)
+	return &Client{
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		apiKey:     apiKey,
+		cfg:        cfg,
+		logger:     moduleLogger,
+	}
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// tool 为 Messages API 的工具定义，input_schema 即参数的 JSON Schema。
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type toolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// requestBody 中系统提示词是顶层 system 字段，messages 只允许 user/assistant 角色。
+type requestBody struct {
+	Model       string      `json:"model"`
+	MaxTokens   int         `json:"max_tokens"`
+	System      string      `json:"system,omitempty"`
+	Messages    []message   `json:"messages"`
+	Temperature *float64    `json:"temperature,omitempty"`
+	Tools       []tool      `json:"tools,omitempty"`
+	ToolChoice  *toolChoice `json:"tool_choice,omitempty"`
+}
+
+type responseBody struct {
+	Content []struct {
+		Type  string          `json:"type"`
+		Text  string          `json:"text"`
+		Name  string          `json:"name"`
+		Input json.RawMessage `json:"input"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+	Usage      usage  `json:"usage"`
+	Error      *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// usage 为 Messages API 响应中的 token 用量。
+type usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if c == nil {
+		return news.SentimentSummary{}, errors.New("claude client is nil")
+	}
+	if len(articles) == 0 {
+		return news.SentimentSummary{Sentiment: "neutral"}, nil
+	}
+
+	payload, _ := json.Marshal(articles)
+	system := "你是一名资深的加密货币市场分析师。只输出JSON，不要输出其它文字。"
+	user := fmt.Sprintf("请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[], \"symbols\":{\"BTC\":number(-1~1)}}。symbols 为新闻直接涉及的币种情绪分，-1 利空、1 利好，未涉及的币种不要列出。\n```json\n%s\n```", string(payload))
+	c.logger.Printf("news.request count=%d", len(articles))
+
+	content, used, err := c.send(ctx, system, user, nil)
+	if err != nil {
+		c.logger.Printf("news.error: %v", err)
+		return news.SentimentSummary{}, err
+	}
+	c.recordUsage(ctx, used, "", ai.UsageKindNews)
+	summary := news.SentimentSummary{}
+	if err := json.Unmarshal([]byte(cleanJSON(content)), &summary); err != nil {
+		c.logger.Printf("news.parse.error: %v content=%s", err, content)
+		return news.SentimentSummary{}, fmt.Errorf("parse news sentiment: %w", err)
+	}
+	if summary.Sentiment == "" {
+		summary.Sentiment = "neutral"
+	}
+	return summary, nil
+}
+
+// 决策提示词模板；改动后 promptVersion 随之变化，决策记录据此区分提示词版本。
+const (
+	decisionSystemPrompt = "你是一名自动加密货币交易顾问，请严格遵守风控。只输出JSON，不要输出其它文字。"
+	decisionUserTemplate = "交易上下文如下:\n```json\n%s\n```\n请输出JSON {\"action\":string, \"confidence\":number(0-1), \"reason\":string, \"adjustments\":{\"sizeMultiplier\":number, \"targetLeverage\":number, \"stopLossPercent\":number, \"takeProfitPercent\":number, \"trailingStopPercent\":number}, \"riskNotes\":[string]}。"
+)
+
+var promptVersion = ai.PromptVersion("claude", decisionSystemPrompt, decisionUserTemplate)
+
+// renderDecision 渲染决策用户提示，上下文压缩据此估算长度。
+func renderDecision(req ai.DecisionRequest) string {
+	payload, _ := json.Marshal(req)
+	return fmt.Sprintf(decisionUserTemplate, string(payload))
+}
+
+func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("claude client is nil")
+	}
+
+	req, err := ai.PromptFit{Provider: "claude", Model: c.cfg.Model, Window: c.cfg.ContextWindow, Reserve: c.cfg.MaxTokens, System: decisionSystemPrompt}.Fit(req, renderDecision)
+	if err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	payload, _ := json.Marshal(req)
+	user := fmt.Sprintf(decisionUserTemplate, string(payload))
+	c.logger.Printf("decision.request symbol=%s signal=%s bytes=%d", req.Symbol, req.StrategySignal, len(payload))
+	return c.decide(ctx, user, req.TraderName)
+}
+
+// ReplayDecision 以当前决策模板的系统提示重发一条历史用户提示。
+func (c *Client) ReplayDecision(ctx context.Context, userPrompt string) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("claude client is nil")
+	}
+	return c.decide(ctx, userPrompt, ai.ReplayTrader)
+}
+
+func (c *Client) decide(ctx context.Context, user, trader string) (ai.DecisionResponse, error) {
+	// 强制调用决策工具，返回的 input 即为结构化决策，无需从文本中截取JSON
+	var decisionTool *tool
+	if !c.cfg.PlainOutput {
+		decisionTool = &tool{Name: ai.DecisionToolName, Description: ai.DecisionToolDescription, InputSchema: ai.DecisionParameters()}
+	}
+	content, used, err := c.send(ctx, decisionSystemPrompt, user, decisionTool)
+	if err != nil {
+		c.logger.Printf("decision.error: %v", err)
+		return ai.DecisionResponse{}, err
+	}
+	decision := ai.DecisionResponse{RawContent: content, Usage: c.recordUsage(ctx, used, trader, ai.UsageKindDecision), PromptVersion: promptVersion}
+	if err := json.Unmarshal([]byte(cleanJSON(content)), &decision); err != nil {
+		c.logger.Printf("decision.parse.error: %v content=%s", err, content)
+		return ai.DecisionResponse{}, fmt.Errorf("parse decision: %w", err)
+	}
+	if err := ai.ValidateDecisionJSON([]byte(cleanJSON(content))); err != nil {
+		c.logger.Printf("decision.schema.error: %v content=%s", err, content)
+		return ai.DecisionResponse{}, fmt.Errorf("decision schema: %w", err)
+	}
+	c.logger.Printf("decision.response action=%s confidence=%.2f", decision.Action, decision.Confidence)
+	return decision, nil
+}
+
+// recordUsage 按全局计费器记录一次调用的 token 用量。
+func (c *Client) recordUsage(ctx context.Context, used usage, trader, kind string) ai.Usage {
+	return ai.RecordUsage(ctx, ai.Usage{
+		Provider:         "claude",
+		Model:            c.cfg.Model,
+		Trader:           trader,
+		Kind:             kind,
+		PromptTokens:     used.InputTokens,
+		CompletionTokens: used.OutputTokens,
+	})
+}
+
+// send 发起一次请求。传入 forced 时强制模型调用该工具并返回工具输入的JSON，否则返回文本内容。
+func (c *Client) send(ctx context.Context, system, user string, forced *tool) (string, usage, error) {
+	if c.apiKey == "" {
+		return "", usage{}, errors.New("claude api key is empty")
+	}
+	body := requestBody{
+		Model:     c.cfg.Model,
+		MaxTokens: c.cfg.MaxTokens,
+		System:    system,
+		Messages:  []message{{Role: "user", Content: user}},
+	}
+	if c.cfg.Temperature > 0 {
+		temperature := c.cfg.Temperature
+		body.Temperature = &temperature
+	}
+	if forced != nil {
+		body.Tools = []tool{*forced}
+		body.ToolChoice = &toolChoice{Type: "tool", Name: forced.Name}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", usage{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+messagesEndpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", c.apiKey)
+	req.Header.Set("anthropic-version", apiVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", usage{}, fmt.Errorf("claude request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload responseBody
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", usage{}, fmt.Errorf("claude status %d: decode response: %w", resp.StatusCode, err)
+	}
+	if payload.Error != nil {
+		c.logger.Printf("http.error status=%d type=%s", resp.StatusCode, payload.Error.Type)
+		return "", usage{}, fmt.Errorf("claude status %d: %s: %s", resp.StatusCode, payload.Error.Type, payload.Error.Message)
+	}
+	if resp.StatusCode >= 400 {
+		return "", usage{}, fmt.Errorf("claude status %d", resp.StatusCode)
+	}
+
+	var text strings.Builder
+	var toolInput json.RawMessage
+	for _, block := range payload.Content {
+		switch {
+		case block.Type == "text":
+			text.WriteString(block.Text)
+		case block.Type == "tool_use" && forced != nil && block.Name == forced.Name:
+			toolInput = block.Input
+		}
+	}
+	c.logger.Printf("http.response inputTokens=%d outputTokens=%d stop=%s",
+		payload.Usage.InputTokens, payload.Usage.OutputTokens, payload.StopReason)
+	if payload.StopReason == "max_tokens" {
+		return "", usage{}, fmt.Errorf("claude 输出被 max_tokens=%d 截断", c.cfg.MaxTokens)
+	}
+	if forced != nil {
+		if len(toolInput) == 0 {
+			return "", usage{}, fmt.Errorf("claude 未调用工具 %s", forced.Name)
+		}
+		return string(toolInput), payload.Usage, nil
+	}
+	if strings.TrimSpace(text.String()) == "" {
+		return "", usage{}, errors.New("claude无返回结果")
+	}
+	return text.String(), payload.Usage, nil
+}
+
+// cleanJSON 去掉 Markdown 代码块包裹及JSON前后的说明文字。
+func cleanJSON(s string) string {
+	trimmed := strings.TrimSpace(s)
+	start := strings.Index(trimmed, "{")
+	end := strings.LastIndex(trimmed, "}")
+	if start != -1 && end > start {
+		return trimmed[start : end+1]
+	}
+	return trimmed
+}
@@ -0,0 +1,23 @@
+package claude
+
+import (
+	"context"
+	"errors"
+
+	"autobot/internal/ai"
+)
+
+const modelsEndpoint = "/v1/models"
+
+var _ ai.Pinger = (*Client)(nil)
+
+// Ping 请求模型列表接口校验密钥与连通性，不产生 token 费用。
+func (c *Client) Ping(ctx context.Context) error {
+	if c.apiKey == "" {
+		return errors.New("claude api key is empty")
+	}
+	return ai.ProbeHTTP(ctx, c.httpClient, c.cfg.BaseURL+modelsEndpoint, map[string]string{
+		"x-api-key":         c.apiKey,
+		"anthropic-version": apiVersion,
+	})
+}
@@ -0,0 +1,262 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// DefaultReserveTokens 为未配置输出上限时为模型回复预留的 token 数。
+const DefaultReserveTokens = 2000
+
+// ErrContextExceeded 表示压缩到底后提示词仍超出模型的上下文窗口。
+var ErrContextExceeded = errors.New("prompt exceeds model context window")
+
+// contextWindows 为常用模型的上下文窗口（token），按前缀匹配，取最长的前缀。
+var contextWindows = map[string]int{
+	"deepseek-chat":     65536,
+	"deepseek-reasoner": 65536,
+	"qwen-max":          32768,
+	"qwen-plus":         131072,
+	"qwen-turbo":        131072,
+	"qwen3":             131072,
+	"claude-":           200000,
+	"gpt-4o":            128000,
+	"llama3":            8192,
+	"llama3.1":          131072,
+}
+
+// ContextWindow 返回模型的上下文窗口，未知模型返回 0（不做压缩）。
+func ContextWindow(model string) int {
+	model = strings.ToLower(strings.TrimSpace(model))
+	best, window := -1, 0
+	for prefix, size := range contextWindows {
+		if strings.HasPrefix(model, prefix) && len(prefix) > best {
+			best, window = len(prefix), size
+		}
+	}
+	return window
+}
+
+// EstimateTokens 粗略估算文本的 token 数：中日韩字符每字计 1 个，其余每 4 字节计 1 个。
+// 各家分词器不同，估算偏保守，只用于判断是否需要压缩。
+func EstimateTokens(text string) int {
+	cjk, other := 0, 0
+	for _, r := range text {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+			continue
+		}
+		if r < 0x80 {
+			other++
+		} else {
+			other += 2
+		}
+	}
+	return cjk + (other+3)/4
+}
+
+// PromptFit 描述某个提供商一次决策请求可用的上下文：Window 为配置的上下文窗口，为 0 时按 Model
+// 取内置值，仍未知时不检查；Reserve 为预留给回复的 token，System 为系统提示（计入用量）。
+type PromptFit struct {
+	Provider string
+	Model    string
+	Window   int
+	Reserve  int
+	System   string
+}
+
+func (f PromptFit) window() int {
+	if f.Window > 0 {
+		return f.Window
+	}
+	return ContextWindow(f.Model)
+}
+
+// Budget 返回用户提示可用的 token 数。
+func (f PromptFit) Budget() int {
+	reserve := f.Reserve
+	if reserve <= 0 {
+		reserve = DefaultReserveTokens
+	}
+	return f.window() - reserve - EstimateTokens(f.System)
+}
+
+// Fit 在 render 渲染出的用户提示超出预算时逐步压缩请求，直到放得下：
+//  1. 从最早的一条开始逐条丢弃历史学习片段；
+//  2. 把市场数据快照截断到前 K 个交易对（当前交易对与持仓优先，其余按候选权重、成交额），K 每次减半；
+//  3. 从相似度最低的一条开始逐条丢弃相似历史情形；
+//  4. 候选币种按权重截半，最后去掉新闻要点与强平明细。
+//
+// 每次压缩都会记录日志；压缩到底仍超出时返回 ErrContextExceeded，由回退链改用其他提供商，
+// 避免提示词被服务端静默截断后拿到残缺的回复。传入的请求不会被修改。
+func (f PromptFit) Fit(req DecisionRequest, render func(DecisionRequest) string) (DecisionRequest, error) {
+	window := f.window()
+	if window <= 0 {
+		return req, nil
+	}
+	budget := f.Budget()
+	initial := EstimateTokens(render(req))
+	if initial <= budget {
+		return req, nil
+	}
+
+	tokens := initial
+	var steps []string
+	for _, step := range compactionSteps {
+		for tokens > budget {
+			next, note, ok := step(req)
+			if !ok {
+				break
+			}
+			req = next
+			steps = append(steps, note)
+			tokens = EstimateTokens(render(req))
+		}
+		if tokens <= budget {
+			break
+		}
+	}
+
+	logger := loggerpkg.Get("ai.context")
+	aiPromptCompactionsTotal.Inc(healthKey(f.Provider))
+	logger.Printf("prompt.compacted provider=%s symbol=%s window=%d budget=%d tokens=%d->%d steps=%s",
+		healthKey(f.Provider), req.Symbol, window, budget, initial, tokens, summarizeSteps(steps))
+	if tokens > budget {
+		return req, fmt.Errorf("%w: ~%d tokens after compaction, budget %d", ErrContextExceeded, tokens, budget)
+	}
+	return req, nil
+}
+
+// compactionSteps 按优先级排列，每个函数执行一次最小的压缩，无可压缩内容时返回 false。
+var compactionSteps = []func(DecisionRequest) (DecisionRequest, string, bool){
+	dropOldestLearning,
+	truncateMarketData,
+	dropLastMemory,
+	truncateCandidates,
+	dropNewsDetails,
+}
+
+// learningTime 匹配学习片段中的开仓时间，格式见 storage.LearningSnippets。
+var learningTime = regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}`)
+
+func dropOldestLearning(req DecisionRequest) (DecisionRequest, string, bool) {
+	if len(req.LearningSnippets) == 0 {
+		return req, "", false
+	}
+	// 无法识别时间的片段视为最旧，其次按开仓时间最早
+	oldest, oldestAt := -1, ""
+	for i, snippet := range req.LearningSnippets {
+		at := learningTime.FindString(snippet)
+		if oldest < 0 || at < oldestAt {
+			oldest, oldestAt = i, at
+		}
+	}
+	snippets := make([]string, 0, len(req.LearningSnippets)-1)
+	snippets = append(snippets, req.LearningSnippets[:oldest]...)
+	req.LearningSnippets = append(snippets, req.LearningSnippets[oldest+1:]...)
+	return req, "learning", true
+}
+
+func truncateMarketData(req DecisionRequest) (DecisionRequest, string, bool) {
+	data := req.Context.MarketData
+	keep := map[string]bool{req.Symbol: true}
+	for _, pos := range req.Context.Positions {
+		keep[pos.Symbol] = true
+	}
+	weight := make(map[string]float64, len(req.Context.CandidateCoins))
+	for _, coin := range req.Context.CandidateCoins {
+		weight[coin.Symbol] = coin.Weight
+	}
+	symbols := make([]string, 0, len(data))
+	for symbol := range data {
+		symbols = append(symbols, symbol)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		a, b := symbols[i], symbols[j]
+		if keep[a] != keep[b] {
+			return keep[a]
+		}
+		if weight[a] != weight[b] {
+			return weight[a] > weight[b]
+		}
+		if data[a].QuoteVolume24h != data[b].QuoteVolume24h {
+			return data[a].QuoteVolume24h > data[b].QuoteVolume24h
+		}
+		return a < b
+	})
+
+	required := 0
+	for _, symbol := range symbols {
+		if keep[symbol] {
+			required++
+		}
+	}
+	topK := len(symbols) / 2
+	if topK < required {
+		topK = required
+	}
+	if topK >= len(symbols) {
+		return req, "", false
+	}
+	trimmed := make(map[string]MarketDataSnapshot, topK)
+	for _, symbol := range symbols[:topK] {
+		trimmed[symbol] = data[symbol]
+	}
+	req.Context.MarketData = trimmed
+	return req, fmt.Sprintf("marketData:%d", topK), true
+}
+
+func dropLastMemory(req DecisionRequest) (DecisionRequest, string, bool) {
+	if len(req.Memories) == 0 {
+		return req, "", false
+	}
+	req.Memories = req.Memories[:len(req.Memories)-1]
+	return req, "memory", true
+}
+
+func truncateCandidates(req DecisionRequest) (DecisionRequest, string, bool) {
+	coins := req.Context.CandidateCoins
+	if len(coins) == 0 {
+		return req, "", false
+	}
+	sorted := append([]CandidateContext(nil), coins...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Weight > sorted[j].Weight })
+	req.Context.CandidateCoins = sorted[:len(sorted)/2]
+	return req, fmt.Sprintf("candidates:%d", len(req.Context.CandidateCoins)), true
+}
+
+func dropNewsDetails(req DecisionRequest) (DecisionRequest, string, bool) {
+	switch {
+	case len(req.NewsSentiment.Highlights) > 0:
+		req.NewsSentiment.Highlights = nil
+		return req, "newsHighlights", true
+	case len(req.Context.Liquidations) > 0:
+		req.Context.Liquidations = nil
+		return req, "liquidations", true
+	}
+	return req, "", false
+}
+
+// summarizeSteps 合并连续的同类步骤，如 learning×3,marketData:8,marketData:4。
+func summarizeSteps(steps []string) string {
+	var parts []string
+	for i := 0; i < len(steps); {
+		j := i
+		for j < len(steps) && steps[j] == steps[i] {
+			j++
+		}
+		if n := j - i; n > 1 {
+			parts = append(parts, fmt.Sprintf("%s×%d", steps[i], n))
+		} else {
+			parts = append(parts, steps[i])
+		}
+		i = j
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// CritiqueNote 为自我复核说明的前缀，写入最终决策的 riskNotes。
+const CritiqueNote = "自我复核"
+
+// CritiqueInstruction 为自我复核时对模型的要求，随 CritiqueRequest 发送；
+// 渲染自定义提示词的提供商（如 deepseek）把它追加到系统提示。
+const CritiqueInstruction = "你现在是风控审核者。待复核的决策草稿（critique.draft）是你在同一上下文中刚给出的决策，执行前请逐条复核：" +
+	"入场是否有多个相互独立的依据、是否在急涨急跌后追单、止损止盈与盈亏比是否合理、是否与持仓、新闻情绪或资金费率相矛盾。" +
+	"依据充分则原样确认；否则修改为 wait/hold 或调低 sizeMultiplier。不要放大仓位，也不要改为反向开仓。" +
+	"按与草稿相同的JSON格式输出最终决策，reason 中写明确认或修改的理由。"
+
+// CritiqueRequest 为自我复核时随决策请求发送的草稿与复核要求。
+type CritiqueRequest struct {
+	Instruction string           `json:"instruction"`
+	Draft       DecisionResponse `json:"draft"`
+}
+
+// 自我复核结果，用于 ai_self_critique_total 的 result 标签。
+const (
+	critiqueConfirmed = "confirmed"
+	critiqueRevised   = "revised"
+	critiqueFailed    = "failed"
+)
+
+// WithSelfCritique 让 provider 的开仓与加仓决策在执行前多走一轮：把草稿连同 CritiqueInstruction
+// 发回 provider，由模型确认或修改。复核只能维持或降低风险：改为反向开仓时按等待处理，
+// sizeMultiplier 不超过草稿。复核调用失败时沿用草稿，其余动作不复核。
+func WithSelfCritique(provider Provider) Provider {
+	return &critiqueProvider{Provider: provider, logger: loggerpkg.Get("ai.critique")}
+}
+
+type critiqueProvider struct {
+	Provider
+	logger *loggerpkg.ModuleLogger
+}
+
+func (p *critiqueProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	draft, err := p.Provider.GenerateDecision(ctx, req)
+	if err != nil || openDirection(draft.Action) == "" {
+		return draft, err
+	}
+	critiqueReq := req
+	critiqueReq.Critique = &CritiqueRequest{Instruction: CritiqueInstruction, Draft: draft}
+	final, err := p.Provider.GenerateDecision(ctx, critiqueReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return DecisionResponse{}, err
+		}
+		aiSelfCritiqueTotal.Inc(critiqueFailed)
+		p.logger.Printf("critique.failed trader=%s symbol=%s action=%s err=%v", req.TraderName, req.Symbol, draft.Action, err)
+		draft.RiskNotes = append(draft.RiskNotes, CritiqueNote+": 复核调用失败，沿用草稿")
+		return draft, nil
+	}
+	final = ReconcileCritique(req, draft, final)
+	final.Usage.Add(draft.Usage)
+	result := critiqueConfirmed
+	if final.Action != draft.Action || final.Adjustments.SizeMultiplier != draft.Adjustments.SizeMultiplier {
+		result = critiqueRevised
+	}
+	aiSelfCritiqueTotal.Inc(result)
+	p.logger.Printf("critique.%s trader=%s symbol=%s draft=%s(%.2f) final=%s(%.2f)",
+		result, req.TraderName, req.Symbol, draft.Action, draft.Confidence, final.Action, final.Confidence)
+	return final, nil
+}
+
+// ReconcileCritique 约束复核结果不高于草稿的风险：反向开仓改为等待（有持仓时持有），
+// 开仓或加仓的 sizeMultiplier 超过草稿时降为草稿值；并在 riskNotes 中记下草稿与最终动作。
+func ReconcileCritique(req DecisionRequest, draft, final DecisionResponse) DecisionResponse {
+	if dir := openDirection(final.Action); dir != "" && dir != openDirection(draft.Action) {
+		idle := "wait"
+		if positionSide(req) != "" {
+			idle = "hold"
+		}
+		final.RiskNotes = append(final.RiskNotes, fmt.Sprintf("%s: 复核改为反向 %s，按 %s 处理", CritiqueNote, final.Action, idle))
+		final.Action = idle
+	}
+	if openDirection(final.Action) != "" && draft.Adjustments.SizeMultiplier > 0 && final.Adjustments.SizeMultiplier > draft.Adjustments.SizeMultiplier {
+		final.Adjustments.SizeMultiplier = draft.Adjustments.SizeMultiplier
+	}
+	if final.Action == draft.Action {
+		final.RiskNotes = append(final.RiskNotes, fmt.Sprintf("%s: 确认 %s（草稿置信度 %.2f）", CritiqueNote, draft.Action, draft.Confidence))
+	} else {
+		final.RiskNotes = append(final.RiskNotes, fmt.Sprintf("%s: 草稿 %s（%.2f）改为 %s", CritiqueNote, draft.Action, draft.Confidence, final.Action))
+	}
+	return final
+}
@@ -103,8 +103,42 @@ func New(cfg config.DeepseekConfig) *Client {
 }
 
 type completionMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	ToolCalls []toolCall `json:"tool_calls,omitempty"`
+	// ToolCallID 为 role=tool 的消息对应的函数调用ID
+	ToolCallID string `json:"tool_call_id,omitempty"`
+
+	// usage 为产生该消息的调用用量，只在响应中填充，不随请求发送
+	usage completionUsage
+}
+
+// completionUsage 为 OpenAI 兼容格式的 token 用量。
+type completionUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// toolCall 为模型返回的函数调用，Arguments 是JSON字符串。
+type toolCall struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// toolDefinition 为 OpenAI 兼容格式的函数定义。
+type toolDefinition struct {
+	Type     string       `json:"type"`
+	Function toolFunction `json:"function"`
+}
+
+type toolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
 }
 
 type completionRequest struct {
@@ -113,12 +147,54 @@ type completionRequest struct {
 	Temperature float64             `json:"temperature"`
 	TopP        float64             `json:"top_p"`
 	MaxTokens   int                 `json:"max_tokens"`
+	Tools       []toolDefinition    `json:"tools,omitempty"`
+	ToolChoice  any                 `json:"tool_choice,omitempty"`
+	Stream      bool                `json:"stream,omitempty"`
+	// StreamOptions 要求流式响应在最后一个分片中附带用量
+	StreamOptions *streamOptions `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// decisionTool 为提交决策的函数定义，参数即决策 schema。
+func decisionTool() *toolDefinition {
+	return &toolDefinition{Type: "function", Function: toolFunction{
+		Name:        ai.DecisionToolName,
+		Description: ai.DecisionToolDescription,
+		Parameters:  ai.DecisionParameters(),
+	}}
+}
+
+// setTools 在请求中声明函数：只有一个时强制调用它，多个时要求调用其中之一。
+func (r *completionRequest) setTools(tools []toolDefinition) {
+	switch len(tools) {
+	case 0:
+	case 1:
+		r.Tools = tools
+		r.ToolChoice = &toolDefinition{Type: "function", Function: toolFunction{Name: tools[0].Function.Name}}
+	default:
+		r.Tools = tools
+		r.ToolChoice = "required"
+	}
+}
+
+// arguments 返回指定函数调用的参数，未调用时为空。
+func (m completionMessage) arguments(name string) string {
+	for _, call := range m.ToolCalls {
+		if call.Function.Name == name {
+			return call.Function.Arguments
+		}
+	}
+	return ""
 }
 
 type completionResponse struct {
 	Choices []struct {
 		Message completionMessage `json:"message"`
 	} `json:"choices"`
+	Usage completionUsage `json:"usage"`
 	Error *struct {
 		Message string `json:"message"`
 	} `json:"error"`
@@ -156,7 +232,7 @@ func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news
 
 	payload := map[string]any{
 		"task":         "crypto_news_sentiment",
-		"instructions": "请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[]}。",
+		"instructions": "请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[], \"symbols\":{\"BTC\":number(-1~1)}}。symbols 为新闻直接涉及的币种情绪分，-1 利空、1 利好，未涉及的币种不要列出。",
 		"articles":     articles,
 	}
 
@@ -186,15 +262,16 @@ func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news
 	}
 
 	// 使用新的重试机制
-	respContent, err := c.CallWithMessages(systemPrompt, userPrompt)
+	resp, err := c.callWithRetry(ctx, systemPrompt, userPrompt, nil)
 	if err != nil {
 		if c.logger != nil {
 			c.logger.Printf("news.error: %v", err)
 		}
 		return news.SentimentSummary{}, err
 	}
+	c.recordUsage(ctx, resp, "", ai.UsageKindNews)
 
-	content := cleanJSON(respContent)
+	content := cleanJSON(resp.Content)
 	summary := news.SentimentSummary{}
 	if err := json.Unmarshal([]byte(content), &summary); err != nil {
 		if c.logger != nil {
@@ -227,21 +304,79 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 	performance := req.Context.Performance
 	positions := req.Context.Positions
 	
-	promptCtx := newPromptContext(req, performance, positions)
 	accountEquity := req.AccountBalance
 	if req.Context.Account.TotalEquity > 0 {
 		accountEquity = req.Context.Account.TotalEquity
 	}
 	
+	limits := req.RiskLimits
+	if req.AmountsInPercent {
+		// 脱敏后的金额不是 USDT，系统提示改为按净值百分比描述
+		accountEquity = 0
+		limits.MaxPositionNotionalUSD = 0
+	}
+
 	// 使用集成了反思模块的系统提示
-	systemPrompt := buildSystemPrompt(accountEquity, req.Context.BTCETHLeverage, req.Context.AltcoinLeverage, req.RiskLimits, performance, positions)
-	userPrompt := buildUserPrompt(promptCtx)
+	systemPrompt := buildSystemPrompt(accountEquity, req.Context.BTCETHLeverage, req.Context.AltcoinLeverage, limits, performance, positions)
+	if req.AmountsInPercent {
+		systemPrompt += redactedAmountsPrompt(req.RiskLimits.MaxPositionNotionalUSD)
+	}
+	if req.Critique != nil {
+		systemPrompt += "\n\n# 自我复核\n" + req.Critique.Instruction
+	}
+	// 超出上下文窗口时先压缩学习片段、市场数据等，避免被服务端截断
+	fit := ai.PromptFit{Provider: "deepseek", Model: c.cfg.Model, Window: c.cfg.ContextWindow, Reserve: c.cfg.MaxTokens, System: systemPrompt}
+	req, err := fit.Fit(req, func(r ai.DecisionRequest) string {
+		return buildUserPrompt(newPromptContext(r, performance, positions))
+	})
+	if err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	userPrompt := buildUserPrompt(newPromptContext(req, performance, positions))
+	return c.decide(ctx, systemPrompt, userPrompt, req.TraderName, req.RiskLimits)
+}
+
+// ReplayDecision 重发一条历史用户提示。记录中只有用户提示，系统提示按默认参数生成
+// （杠杆等动态部分取默认值，不含当时的绩效反思）。
+func (c *Client) ReplayDecision(ctx context.Context, userPrompt string) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("deepseek client is nil")
+	}
+	if c.apiKeyValue() == "" {
+		return ai.DecisionResponse{}, errors.New("deepseek api key 未设置")
+	}
+	systemPrompt := buildSystemPrompt(0, 0, 0, ai.RiskLimits{}, ai.PerformanceStats{}, nil)
+	return c.decide(ctx, systemPrompt, userPrompt, ai.ReplayTrader, ai.RiskLimits{})
+}
+
+func (c *Client) decide(ctx context.Context, systemPrompt, userPrompt, trader string, limits ai.RiskLimits) (ai.DecisionResponse, error) {
+	var tool *toolDefinition
+	box, rounds := ai.ToolsFrom(ctx)
+	switch {
+	case c.cfg.PlainOutput:
+		// 纯文本输出不支持函数调用，数据工具一并停用
+		box = nil
+	case box != nil:
+		tool = decisionTool()
+		systemPrompt += toolsPrompt(box, rounds)
+	default:
+		tool = decisionTool()
+		systemPrompt += fmt.Sprintf("\n先在正文中写出思维链分析，再调用 %s 提交决策。\n", ai.DecisionToolName)
+	}
 	if c.logger != nil {
 		c.logger.Printf("decision.prompt system=%d chars user=long_prompt", len(systemPrompt))
+		c.logger.Debugf("decision.prompt.user %s", userPrompt)
 	}
 
-	// 使用新的重试机制
-	respContent, err := c.CallWithMessages(systemPrompt, userPrompt)
+	// 使用新的重试机制；携带数据工具时进行多轮调用
+	var resp completionMessage
+	var usage ai.Usage
+	var err error
+	if box != nil {
+		resp, usage, err = c.decideWithTools(ctx, systemPrompt, userPrompt, trader, box, rounds)
+	} else if resp, err = c.callWithRetry(ctx, systemPrompt, userPrompt, tool); err == nil {
+		usage = c.recordUsage(ctx, resp, trader, ai.UsageKindDecision)
+	}
 	if err != nil {
 		if c.logger != nil {
 			c.logger.Printf("decision.error: %v", err)
@@ -249,18 +384,35 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 		return ai.DecisionResponse{}, err
 	}
 
-	decision, err := parseFullDecisionResponse(respContent)
+	// 原生函数调用的参数即结构化决策；模型未调用时退回从正文解析
+	respContent := resp.Content
+	var decision ai.DecisionResponse
+	if args := resp.arguments(ai.DecisionToolName); args != "" {
+		decision, err = decodeDecision(args)
+		decision.CoTTrace = strings.TrimSpace(resp.Content)
+		respContent = strings.TrimSpace(resp.Content + "\n" + args)
+	} else {
+		decision, err = parseFullDecisionResponse(respContent)
+	}
 	if err != nil {
 		if c.logger != nil {
 			c.logger.Printf("decision.parse.error: %v content=%s", err, respContent)
 		}
-		return ai.DecisionResponse{}, err
+		// 输出不是合法JSON时把原文与 schema 发回模型要求重新输出，仍失败才放弃本周期
+		repaired, repairContent, repairUsage, repairErr := c.repairDecision(ctx, systemPrompt, userPrompt, respContent, err, trader)
+		usage.Add(repairUsage)
+		if repairErr != nil {
+			return ai.DecisionResponse{}, fmt.Errorf("%w（修复重试失败: %v）", err, repairErr)
+		}
+		decision, respContent = repaired, repairContent
 	}
 	decision.RawContent = respContent
+	decision.Usage = usage
+	decision.PromptVersion = promptVersion
 	if decision.CoTTrace == "" {
 		decision.CoTTrace = extractCoTTrace(respContent)
 	}
-	if err := validateDecisionResponse(decision, req.RiskLimits); err != nil {
+	if err := validateDecisionResponse(decision, limits); err != nil {
 		if c.logger != nil {
 			c.logger.Printf("decision.validate.error: %v", err)
 		}
@@ -276,13 +428,59 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 	return decision, nil
 }
 
-// CallWithMessages 带重试的AI调用
-func (c *Client) CallWithMessages(systemPrompt, userPrompt string) (string, error) {
+// CallWithMessages 带重试的AI调用，ctx 取消或超时后不再重试。
+func (c *Client) CallWithMessages(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	response, err := c.callWithRetry(ctx, systemPrompt, userPrompt, nil)
+	if err != nil {
+		return "", err
+	}
+	return response.Content, nil
+}
+
+// repairDecision 把无法解析的输出连同解析错误与决策 schema 作为后续消息发给模型，要求只重新输出
+// 合法的决策JSON，返回解析后的决策、修复回复原文及其用量。
+func (c *Client) repairDecision(ctx context.Context, systemPrompt, userPrompt, broken string, parseErr error, trader string) (ai.DecisionResponse, string, ai.Usage, error) {
+	schema, err := json.Marshal(ai.DecisionParameters())
+	if err != nil {
+		return ai.DecisionResponse{}, "", ai.Usage{}, err
+	}
+	messages := []completionMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+		{Role: "assistant", Content: broken},
+		{Role: "user", Content: fmt.Sprintf("上一条回复无法解析为决策JSON（%v）。请只输出一个符合以下 JSON Schema 的对象，不要输出思维链、代码块或其它文字：\n%s", parseErr, schema)},
+	}
+	if c.logger != nil {
+		c.logger.Printf("decision.repair.request trader=%s", trader)
+	}
+	resp, err := c.callMessagesWithRetry(ctx, messages, nil)
+	if err != nil {
+		return ai.DecisionResponse{}, "", ai.Usage{}, err
+	}
+	usage := c.recordUsage(ctx, resp, trader, ai.UsageKindDecision)
+	decision, err := parseFullDecisionResponse(resp.Content)
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Printf("decision.repair.error: %v content=%s", err, resp.Content)
+		}
+		return ai.DecisionResponse{}, "", usage, err
+	}
+	// 思维链保留在首次回复中，修复回复只有JSON
+	decision.CoTTrace = extractCoTTrace(broken)
+	if c.logger != nil {
+		c.logger.Printf("decision.repair.success action=%s", decision.Action)
+	}
+	return decision, strings.TrimSpace(broken + "\n" + resp.Content), usage, nil
+}
+
+// callWithRetry 在网络错误时重试；tool 非空时强制模型调用该函数。启用 Stream 且 ctx 携带
+// ai.WithStream 回调时改用流式请求，正文逐行回调。
+func (c *Client) callWithRetry(ctx context.Context, systemPrompt, userPrompt string, tool *toolDefinition) (completionMessage, error) {
 	if c == nil {
-		return "", errors.New("deepseek client is nil")
+		return completionMessage{}, errors.New("deepseek client is nil")
 	}
 	if c.apiKeyValue() == "" {
-		return "", errors.New("deepseek api key 未设置")
+		return completionMessage{}, errors.New("deepseek api key 未设置")
 	}
 
 	// 构建 messages 数组
@@ -297,34 +495,58 @@ func (c *Client) CallWithMessages(systemPrompt, userPrompt string) (string, erro
 		Role:    "user", 
 		Content: userPrompt,
 	})
+	var tools []toolDefinition
+	if tool != nil {
+		tools = []toolDefinition{*tool}
+	}
+	return c.callMessagesWithRetry(ctx, messages, tools)
+}
 
+// callMessagesWithRetry 发送完整的消息列表，网络错误时按退避重试；ctx 取消或超时时立即返回，
+// 不再重试也不等待退避。
+func (c *Client) callMessagesWithRetry(ctx context.Context, messages []completionMessage, tools []toolDefinition) (completionMessage, error) {
 	maxRetries := 3  // 最大重试次数
 	var lastErr error
 	
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		response, err := c.sendCompletion(context.Background(), messages)
+		var response completionMessage
+		var err error
+		if onLine := ai.StreamFrom(ctx); onLine != nil && c.cfg.Stream {
+			response, err = c.sendCompletionStream(ctx, messages, tools, onLine)
+		} else {
+			response, err = c.sendCompletion(ctx, messages, tools)
+		}
 		if err == nil {
-			return response.Content, nil  // 成功返回
+			return response, nil  // 成功返回
+		}
+		// 调用方取消（如程序关停）时请求中断产生的错误不是网络故障，不重试
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return completionMessage{}, fmt.Errorf("deepseek 调用已取消: %w", ctxErr)
 		}
 		
 		// 如果是网络错误才重试
 		if isNetworkError(err) {
 			lastErr = err
+			if attempt == maxRetries {
+				break
+			}
 			if c.logger != nil {
 				c.logger.Printf("retry.attempt attempt=%d/%d error=%v", attempt, maxRetries, err)
 			}
-			time.Sleep(time.Duration(attempt) * baseRetryDelay)  // 指数退避
+			if waitErr := mcp.Wait(ctx, time.Duration(attempt)*baseRetryDelay); waitErr != nil { // 指数退避
+				return completionMessage{}, fmt.Errorf("deepseek 重试等待中止: %w（上次错误: %v）", waitErr, lastErr)
+			}
 			continue
 		}
 		
-		return "", err  // 非网络错误直接返回
+		return completionMessage{}, err  // 非网络错误直接返回
 	}
 	
-	return "", fmt.Errorf("重试%d次后仍然失败: %w", maxRetries, lastErr)
+	return completionMessage{}, fmt.Errorf("重试%d次后仍然失败: %w", maxRetries, lastErr)
 }
 
 // sendCompletion 单次调用AI API
-func (c *Client) sendCompletion(ctx context.Context, messages []completionMessage) (completionMessage, error) {
+func (c *Client) sendCompletion(ctx context.Context, messages []completionMessage, tools []toolDefinition) (completionMessage, error) {
 	if len(messages) == 0 {
 		return completionMessage{}, errors.New("messages为空")
 	}
@@ -337,6 +559,7 @@ func (c *Client) sendCompletion(ctx context.Context, messages []completionMessag
 		TopP:        c.cfg.TopP,
 		MaxTokens:   c.cfg.MaxTokens,
 	}
+	requestBody.setTools(tools)
 
 	if c.logger != nil {
 		c.logger.Printf("http.request model=%s messages=%d", c.cfg.Model, len(messages))
@@ -375,12 +598,26 @@ func (c *Client) sendCompletion(ctx context.Context, messages []completionMessag
 	}
 
 	result := payload.Choices[0].Message
+	result.usage = payload.Usage
 	if c.logger != nil {
 		c.logger.Printf("http.response choices=%d", len(payload.Choices))
+		c.logger.Debugf("http.response.content tool_calls=%d content=%s", len(result.ToolCalls), result.Content)
 	}
 	return result, nil
 }
 
+// recordUsage 按全局计费器记录一次调用的 token 用量。
+func (c *Client) recordUsage(ctx context.Context, resp completionMessage, trader, kind string) ai.Usage {
+	return ai.RecordUsage(ctx, ai.Usage{
+		Provider:         "deepseek",
+		Model:            c.cfg.Model,
+		Trader:           trader,
+		Kind:             kind,
+		PromptTokens:     resp.usage.PromptTokens,
+		CompletionTokens: resp.usage.CompletionTokens,
+	})
+}
+
 func cleanJSON(s string) string {
 	trimmed := strings.TrimSpace(s)
 	if strings.HasPrefix(trimmed, "```") {
@@ -35,6 +35,9 @@ func formatNewsSummary(sentiment string, score float64) string {
 	return fmt.Sprintf("%s(%.2f)", sentiment, score)
 }
 
+// promptVersion 为决策提示词模板的版本标记，按固定输入渲染的系统提示计算，模板改动后随之变化。
+var promptVersion = ai.PromptVersion("deepseek", buildSystemPrompt(0, 0, 0, ai.RiskLimits{}, ai.PerformanceStats{}, nil))
+
 // buildSystemPrompt 定义交易系统的硬性约束与目标。
 func buildSystemPrompt(accountEquity float64, btcEthLeverage, altcoinLeverage int, limits ai.RiskLimits, performance ai.PerformanceStats, positions []ai.PositionContext) string {
 	if btcEthLeverage <= 0 {
@@ -67,6 +70,12 @@ func buildSystemPrompt(accountEquity float64, btcEthLeverage, altcoinLeverage in
 	if limits.MaxConcurrentPositions > 0 {
 		sb.WriteString(fmt.Sprintf("  * 最大同时持仓数：%d。\n", limits.MaxConcurrentPositions))
 	}
+	if limits.MaxMajorPositions > 0 {
+		sb.WriteString(fmt.Sprintf("  * BTC/ETH 最多同时持有 %d 个。\n", limits.MaxMajorPositions))
+	}
+	if limits.MaxAltPositions > 0 {
+		sb.WriteString(fmt.Sprintf("  * 山寨币最多同时持有 %d 个。\n", limits.MaxAltPositions))
+	}
 	if limits.MinRiskRewardRatio > 0 {
 		sb.WriteString(fmt.Sprintf("  * 止盈/止损需满足风险回报 ≥ %.1f。\n", limits.MinRiskRewardRatio))
 	}
@@ -81,19 +90,41 @@ func buildSystemPrompt(accountEquity float64, btcEthLeverage, altcoinLeverage in
 	return sb.String()
 }
 
+// redactedAmountsPrompt 说明脱敏后的金额口径（aiPrivacy.redactAmounts），maxNotionalPct 为单笔名义上限占净值的百分比。
+func redactedAmountsPrompt(maxNotionalPct float64) string {
+	var sb strings.Builder
+	sb.WriteString("\n# 🔒 金额口径\n\n")
+	sb.WriteString("- 账户净值记为 100，提示中的余额、盈亏、保证金均为占净值的百分比，持仓数量为名义价值占净值的百分比。\n")
+	sb.WriteString("- 仓位大小只通过 sizeMultiplier 相对调整，不要输出具体金额。\n")
+	if maxNotionalPct > 0 {
+		sb.WriteString(fmt.Sprintf("- 单笔名义价值不得超过净值的 %.2f%%。\n", maxNotionalPct))
+	}
+	return sb.String()
+}
+
 // buildUserPrompt 根据实时上下文构建用户提示。
 func buildUserPrompt(ctx promptContext) string {
 	now := time.Now().Format("2006-01-02 15:04:05")
 	request := ctx.Request
 	context := request.Context
 	newsSummary := formatNewsSummary(request.NewsSentiment.Sentiment, request.NewsSentiment.Score)
+	if request.NewsSentiment.LowConfidence && newsSummary != "无" {
+		newsSummary += " [本地词典估计，低置信，仅作参考]"
+	}
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("**时间**: %s | **运行**: %d分钟 | **周期**: #%d\n\n", now, context.RuntimeMinutes, context.CallCount))
 	sb.WriteString(fmt.Sprintf("**账户**: 净值%.2f | 可用%.2f | 未实现PnL %+.2f | 持仓%d个\n\n",
 		context.Account.TotalEquity, context.Account.Available, context.Account.UnrealizedPNL, len(context.Positions)))
+	if request.AmountsInPercent {
+		sb.WriteString("（账户金额与持仓数量均为占净值的百分比，净值=100）\n\n")
+	}
+	signal := request.StrategySignal
+	if request.StrategyStrength != 0 {
+		signal += fmt.Sprintf("（组合强度 %+.2f）", request.StrategyStrength)
+	}
 	sb.WriteString(fmt.Sprintf("**交易对**: %s (%s) | 当前价格 %.2f | 策略信号 %s\n\n",
-		request.Symbol, strings.ToUpper(request.Exchange), request.CurrentPrice, request.StrategySignal))
+		request.Symbol, strings.ToUpper(request.Exchange), request.CurrentPrice, signal))
 
 	if len(context.Positions) > 0 {
 		sb.WriteString("## 持仓明细\n")
@@ -134,16 +165,37 @@ func buildUserPrompt(ctx promptContext) string {
 
 	if len(request.LearningSnippets) > 0 {
 		sb.WriteString("## 历史学习片段\n")
+		sb.WriteString("以下是你近期盈利最多与亏损最多的交易及当时的开仓理由，延续有效的判断、避免重复亏损的错误：\n")
 		for _, snippet := range request.LearningSnippets {
 			sb.WriteString(fmt.Sprintf("- %s\n", snippet))
 		}
 		sb.WriteString("\n")
 	}
 
+	if len(request.Memories) > 0 {
+		sb.WriteString("## 相似历史情形\n")
+		sb.WriteString("以下是长期记忆中与当前行情最相似的情形及你当时的决策，仅供参考：\n")
+		for _, memory := range request.Memories {
+			sb.WriteString(fmt.Sprintf("- %s\n", memory))
+		}
+		sb.WriteString("\n")
+	}
+
 	if len(context.CandidateCoins) > 0 {
 		sb.WriteString("## 候选币种\n")
 		for _, coin := range context.CandidateCoins {
-			sb.WriteString(fmt.Sprintf("- %s 权重%.2f 理由:%s\n", coin.Symbol, coin.Weight, coin.Reason))
+			notes := ""
+			if coin.Sentiment != 0 {
+				notes = fmt.Sprintf(" 新闻情绪%+.2f", coin.Sentiment)
+			}
+			if coin.MaxSafeNotionalUSD > 0 {
+				if request.AmountsInPercent {
+					notes += fmt.Sprintf(" 安全单量≤净值%.2f%%", coin.MaxSafeNotionalUSD)
+				} else {
+					notes += fmt.Sprintf(" 安全单量≤%.0f USDT", coin.MaxSafeNotionalUSD)
+				}
+			}
+			sb.WriteString(fmt.Sprintf("- %s 权重%.2f%s 理由:%s\n", coin.Symbol, coin.Weight, notes, coin.Reason))
 		}
 		sb.WriteString("\n")
 	}
@@ -157,8 +209,60 @@ func buildUserPrompt(ctx promptContext) string {
 		sb.WriteString("## 市场数据快照\n")
 		for _, symbol := range symbols {
 			snapshot := context.MarketData[symbol]
-			sb.WriteString(fmt.Sprintf("- %s 现价%.4f 1h:%+.2f%% 4h:%+.2f%% EMA20=%.2f MACD=%.4f RSI7=%.2f RSI14=%.2f Funding=%.5f OI=%.2f\n",
-				symbol, snapshot.CurrentPrice, snapshot.PriceChange1h, snapshot.PriceChange4h, snapshot.EMA20, snapshot.MACD, snapshot.RSI7, snapshot.RSI14, snapshot.FundingRate, snapshot.OpenInterest))
+			sb.WriteString(fmt.Sprintf("- %s 现价%.4f 1h:%+.2f%% 4h:%+.2f%% 24h:%+.2f%% EMA20=%.2f MACD=%.4f RSI7=%.2f RSI14=%.2f Funding=%.5f OI=%.2f Vol24h=%.0f USDT",
+				symbol, snapshot.CurrentPrice, snapshot.PriceChange1h, snapshot.PriceChange4h, snapshot.PriceChange24h, snapshot.EMA20, snapshot.MACD, snapshot.RSI7, snapshot.RSI14, snapshot.FundingRate, snapshot.OpenInterest, snapshot.QuoteVolume24h))
+			if len(snapshot.Patterns) > 0 {
+				sb.WriteString(" 形态=" + strings.Join(snapshot.Patterns, ","))
+			}
+			if snapshot.NewsSentiment != 0 {
+				sb.WriteString(fmt.Sprintf(" 新闻情绪=%+.2f", snapshot.NewsSentiment))
+			}
+			sb.WriteString("\n")
+			if snapshot.Resistance != nil || snapshot.Support != nil {
+				sb.WriteString("  关键位:")
+				if lvl := snapshot.Resistance; lvl != nil {
+					sb.WriteString(fmt.Sprintf(" 上方阻力 %s=%.4f(%+.2f%%, 强度%d)", lvl.Name, lvl.Price, lvl.DistancePercent, lvl.Strength))
+				}
+				if lvl := snapshot.Support; lvl != nil {
+					sb.WriteString(fmt.Sprintf(" 下方支撑 %s=%.4f(%+.2f%%, 强度%d)", lvl.Name, lvl.Price, lvl.DistancePercent, lvl.Strength))
+				}
+				sb.WriteString("\n")
+			}
+			if len(snapshot.TakerFlow) > 0 {
+				sb.WriteString("  主动买卖失衡:")
+				for _, flow := range snapshot.TakerFlow {
+					sb.WriteString(fmt.Sprintf(" %s=%+.2f(买%.4g/卖%.4g)", flow.Window, flow.Imbalance, flow.BuyVolume, flow.SellVolume))
+				}
+				sb.WriteString("\n")
+			}
+			if snapshot.ADX > 0 {
+				sb.WriteString(fmt.Sprintf("  趋势强度: ADX14=%.1f +DI=%.1f -DI=%.1f\n", snapshot.ADX, snapshot.PlusDI, snapshot.MinusDI))
+			}
+			if snapshot.CCIPeriod > 0 {
+				sb.WriteString(fmt.Sprintf("  顺势指标: CCI%d=%.1f\n", snapshot.CCIPeriod, snapshot.CCI))
+			}
+			if snapshot.VWAP > 0 || snapshot.SessionVWAP > 0 {
+				sb.WriteString("  VWAP:")
+				if snapshot.VWAP > 0 {
+					sb.WriteString(fmt.Sprintf(" 滚动=%.4f(现价%+.2f%%)", snapshot.VWAP, (snapshot.CurrentPrice/snapshot.VWAP-1)*100))
+				}
+				if snapshot.SessionVWAP > 0 {
+					sb.WriteString(fmt.Sprintf(" 当日锚定=%.4f(现价%+.2f%%)", snapshot.SessionVWAP, (snapshot.CurrentPrice/snapshot.SessionVWAP-1)*100))
+				}
+				sb.WriteString("\n")
+			}
+			if vp := snapshot.VolumeProfile; vp != nil {
+				sb.WriteString(fmt.Sprintf("  成交量分布: POC=%.4f 价值区=[%.4f, %.4f]\n", vp.POC, vp.ValueAreaLow, vp.ValueAreaHigh))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(context.Liquidations) > 0 {
+		sb.WriteString("## 强平监控\n")
+		for _, liq := range context.Liquidations {
+			sb.WriteString(fmt.Sprintf("- %s 近%d分钟强平%d笔 | 多单爆仓%.0f USDT | 空单爆仓%.0f USDT | 最大单笔%.0f USDT\n",
+				liq.Symbol, liq.WindowMinutes, liq.Count, liq.LongLiquidatedUSD, liq.ShortLiquidatedUSD, liq.LargestUSD))
 		}
 		sb.WriteString("\n")
 	}
@@ -173,6 +277,13 @@ func buildUserPrompt(ctx promptContext) string {
 	sb.WriteString("## 系统约束\n")
 	sb.WriteString(fmt.Sprintf("```json\n%s\n```\n", string(limitsJSON)))
 
+	if critique := request.Critique; critique != nil {
+		draftJSON, _ := json.Marshal(critique.Draft)
+		sb.WriteString("\n## 待复核的决策草稿\n")
+		sb.WriteString(fmt.Sprintf("```json\n%s\n```\n", string(draftJSON)))
+		sb.WriteString("请按系统提示中的复核要求确认或修改该草稿，输出最终决策。\n")
+	}
+
 	return sb.String()
 }
 
@@ -264,7 +375,8 @@ func parseFullDecisionResponse(raw string) (ai.DecisionResponse, error) {
 		return ai.DecisionResponse{}, fmt.Errorf("模型未返回内容")
 	}
 
-	if resp, err := decodeDecision(content); err == nil {
+	resp, err := decodeDecision(content)
+	if err == nil {
 		resp.RawContent = content
 		resp.CoTTrace = extractCoTTrace(raw)
 		return resp, nil
@@ -274,14 +386,14 @@ func parseFullDecisionResponse(raw string) (ai.DecisionResponse, error) {
 	end := strings.LastIndex(content, "}")
 	if start >= 0 && end > start {
 		snippet := content[start : end+1]
-		if resp, err := decodeDecision(snippet); err == nil {
+		if resp, err = decodeDecision(snippet); err == nil {
 			resp.RawContent = content
 			resp.CoTTrace = extractCoTTrace(raw)
 			return resp, nil
 		}
 	}
 
-	return ai.DecisionResponse{}, fmt.Errorf("无法解析模型输出: %s", content)
+	return ai.DecisionResponse{}, fmt.Errorf("无法解析模型输出: %w: %s", err, content)
 }
 
 type rawDecision struct {
@@ -311,6 +423,22 @@ func decodeDecision(content string) (ai.DecisionResponse, error) {
 	}
 	resp.RiskNotes = notes
 
+	// riskNotes 兼容单个字符串，按归一化后的结构校验 schema
+	var value map[string]any
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	if _, ok := value["riskNotes"]; ok {
+		normalized := make([]any, len(notes))
+		for i, note := range notes {
+			normalized[i] = note
+		}
+		value["riskNotes"] = normalized
+	}
+	if err := ai.ValidateDecisionValue(value); err != nil {
+		return ai.DecisionResponse{}, fmt.Errorf("decision schema: %w", err)
+	}
+
 	return resp, nil
 }
 
@@ -364,40 +492,7 @@ func parseRiskNotes(raw json.RawMessage) ([]string, error) {
 
 // validateDecisionResponse 对模型返回的决策进行初步校验。
 func validateDecisionResponse(decision ai.DecisionResponse, limits ai.RiskLimits) error {
-	action := strings.ToLower(strings.TrimSpace(decision.Action))
-	validActions := map[string]struct{}{
-		"open_long":      {},
-		"open_short":     {},
-		"increase_long":  {},
-		"increase_short": {},
-		"close":          {},
-		"exit":           {},
-		"reduce":         {},
-		"hold":           {},
-		"wait":           {},
-	}
-	if _, ok := validActions[action]; !ok && action != "" {
-		return fmt.Errorf("未知 action: %s", decision.Action)
-	}
-
-	targetLev := decision.Adjustments.TargetLeverage
-	if targetLev < 0 {
-		return fmt.Errorf("targetLeverage 不得为负数")
-	}
-	if limits.MaxLeverage > 0 && targetLev > limits.MaxLeverage {
-		return fmt.Errorf("targetLeverage %.2f 超过上限 %.2f", targetLev, limits.MaxLeverage)
-	}
-
-	if decision.Adjustments.StopLossPercent > 0 && decision.Adjustments.TakeProfitPercent > 0 {
-		if limits.MinRiskRewardRatio > 0 {
-			rr := decision.Adjustments.TakeProfitPercent / decision.Adjustments.StopLossPercent
-			if rr+1e-9 < limits.MinRiskRewardRatio {
-				return fmt.Errorf("风险回报 %.2f 低于要求 %.2f", rr, limits.MinRiskRewardRatio)
-			}
-		}
-	}
-
-	return nil
+	return ai.ValidateDecision(decision, limits)
 }
 
 func trimJSONFences(s string) string {
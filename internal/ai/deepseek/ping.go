@@ -0,0 +1,25 @@
+package deepseek
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"autobot/internal/ai"
+)
+
+var _ ai.Pinger = (*Client)(nil)
+
+// Ping 请求模型列表接口校验密钥与连通性，不产生 token 费用。
+func (c *Client) Ping(ctx context.Context) error {
+	if c == nil {
+		return errors.New("deepseek client is nil")
+	}
+	apiKey := c.apiKeyValue()
+	if apiKey == "" {
+		return errors.New("deepseek api key 未设置")
+	}
+	return ai.ProbeHTTP(ctx, http.DefaultClient, strings.TrimRight(c.cfg.BaseURL, "/")+"/models",
+		map[string]string{"Authorization": "Bearer " + apiKey})
+}
@@ -0,0 +1,110 @@
+package deepseek
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autobot/internal/ai"
+)
+
+// streamChunk 为流式响应中的单个增量，reasoning_content 为 deepseek-reasoner 的推理过程。
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content          string `json:"content"`
+			ReasoningContent string `json:"reasoning_content"`
+			ToolCalls        []struct {
+				Index    int    `json:"index"`
+				ID       string `json:"id"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *completionUsage `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// sendCompletionStream 以 SSE 调用模型：正文与推理过程逐行回调 onLine，函数调用参数在流中
+// 分片到达，拼接完整后与正文一起组装为 completionMessage 返回，后续解析与非流式一致。
+func (c *Client) sendCompletionStream(ctx context.Context, messages []completionMessage, tools []toolDefinition, onLine func(string)) (completionMessage, error) {
+	apiKey := c.apiKeyValue()
+	if apiKey == "" {
+		return completionMessage{}, errors.New("deepseek api key 未设置")
+	}
+	requestBody := completionRequest{
+		Model:         c.cfg.Model,
+		Messages:      messages,
+		Temperature:   c.cfg.Temperature,
+		TopP:          c.cfg.TopP,
+		MaxTokens:     c.cfg.MaxTokens,
+		Stream:        true,
+		StreamOptions: &streamOptions{IncludeUsage: true},
+	}
+	requestBody.setTools(tools)
+	headers := map[string]string{"Authorization": "Bearer " + apiKey}
+	if c.logger != nil {
+		c.logger.Printf("http.stream model=%s messages=%d", c.cfg.Model, len(messages))
+	}
+
+	var content strings.Builder
+	var calls []toolCall
+	var usage completionUsage
+	lines := ai.NewLineSplitter(onLine)
+	reasoning := false
+	err := c.mcpClient.PostSSE(ctx, defaultCompletionPath, headers, requestBody, func(data []byte) error {
+		var chunk streamChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("decode stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return errors.New(chunk.Error.Message)
+		}
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.ReasoningContent != "" {
+				reasoning = true
+				lines.Write(choice.Delta.ReasoningContent)
+			}
+			if choice.Delta.Content != "" && reasoning {
+				// 推理结束，另起一行输出正文
+				reasoning = false
+				lines.Flush()
+			}
+			lines.Write(choice.Delta.Content)
+			content.WriteString(choice.Delta.Content)
+			for _, delta := range choice.Delta.ToolCalls {
+				for len(calls) <= delta.Index {
+					calls = append(calls, toolCall{Type: "function"})
+				}
+				calls[delta.Index].ID += delta.ID
+				calls[delta.Index].Function.Name += delta.Function.Name
+				calls[delta.Index].Function.Arguments += delta.Function.Arguments
+			}
+		}
+		return nil
+	})
+	lines.Flush()
+	if err != nil {
+		if c.logger != nil {
+			c.logger.Printf("http.error stream=%v", err)
+		}
+		return completionMessage{}, fmt.Errorf("deepseek stream: %w", err)
+	}
+	if content.Len() == 0 && len(calls) == 0 {
+		return completionMessage{}, errors.New("deepseek无返回结果")
+	}
+	if c.logger != nil {
+		c.logger.Printf("http.stream.done chars=%d toolCalls=%d", content.Len(), len(calls))
+	}
+	return completionMessage{Role: "assistant", Content: content.String(), ToolCalls: calls, usage: usage}, nil
+}
@@ -0,0 +1,87 @@
+package deepseek
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"autobot/internal/ai"
+)
+
+// maxToolResult 为单次工具结果发回模型的最大字节数。
+const maxToolResult = 16 << 10
+
+// toolsPrompt 为启用数据工具时追加到系统提示词的说明。
+func toolsPrompt(box ai.Toolbox, rounds int) string {
+	names := make([]string, 0, len(box.Specs()))
+	for _, spec := range box.Specs() {
+		names = append(names, spec.Name)
+	}
+	return fmt.Sprintf("\n先在正文中写出思维链分析。现有数据不足以判断时，可调用 %s 获取更多数据（最多 %d 轮），"+
+		"数据足够后调用 %s 提交决策。\n", strings.Join(names, "、"), rounds, ai.DecisionToolName)
+}
+
+// decideWithTools 进行多轮决策：模型可先调用 box 中的数据工具，结果以 role=tool 消息发回，
+// 第 rounds 轮之后只保留决策函数强制提交。返回最后一条回复，其正文为各轮思考与工具调用的记录。
+func (c *Client) decideWithTools(ctx context.Context, systemPrompt, userPrompt, trader string, box ai.Toolbox, rounds int) (completionMessage, ai.Usage, error) {
+	tools := []toolDefinition{*decisionTool()}
+	for _, spec := range box.Specs() {
+		tools = append(tools, toolDefinition{Type: "function", Function: toolFunction{
+			Name:        spec.Name,
+			Description: spec.Description,
+			Parameters:  spec.Parameters,
+		}})
+	}
+	messages := []completionMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userPrompt},
+	}
+	var usage ai.Usage
+	var transcript []string
+	for round := 0; ; round++ {
+		offered := tools
+		if round >= rounds {
+			offered = tools[:1]
+		}
+		resp, err := c.callMessagesWithRetry(ctx, messages, offered)
+		if err != nil {
+			return completionMessage{}, usage, err
+		}
+		usage.Add(c.recordUsage(ctx, resp, trader, ai.UsageKindDecision))
+		if text := strings.TrimSpace(resp.Content); text != "" {
+			transcript = append(transcript, text)
+		}
+		dataCalls := 0
+		for i := range resp.ToolCalls {
+			if resp.ToolCalls[i].Function.Name != ai.DecisionToolName {
+				dataCalls++
+			}
+			if resp.ToolCalls[i].ID == "" {
+				resp.ToolCalls[i].ID = fmt.Sprintf("call_%d_%d", round, i)
+			}
+		}
+		if resp.arguments(ai.DecisionToolName) != "" || dataCalls == 0 || round >= rounds {
+			resp.Content = strings.Join(transcript, "\n")
+			return resp, usage, nil
+		}
+
+		messages = append(messages, completionMessage{Role: "assistant", Content: resp.Content, ToolCalls: resp.ToolCalls})
+		for _, call := range resp.ToolCalls {
+			result, err := box.Call(ctx, call.Function.Name, json.RawMessage(call.Function.Arguments))
+			if err != nil {
+				data, _ := json.Marshal(map[string]string{"error": err.Error()})
+				result = string(data)
+			}
+			if len(result) > maxToolResult {
+				result = result[:maxToolResult] + "...(已截断)"
+			}
+			if c.logger != nil {
+				c.logger.Printf("decision.tool round=%d/%d trader=%s tool=%s args=%s bytes=%d err=%v",
+					round+1, rounds, trader, call.Function.Name, call.Function.Arguments, len(result), err)
+			}
+			transcript = append(transcript, fmt.Sprintf("[工具] %s %s", call.Function.Name, call.Function.Arguments))
+			messages = append(messages, completionMessage{Role: "tool", ToolCallID: call.ID, Content: result})
+		}
+	}
+}
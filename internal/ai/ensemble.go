@@ -0,0 +1,218 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// Ensemble 并行询问多个提供商并投票合并结果：取多数 action（平票时为 hold），置信度为多数方的
+// 平均值，调整参数取多数方非零值的平均，少数派意见写入 RiskNotes。有效回答少于 MinVotes 时返回错误。
+type Ensemble struct {
+	providers []NamedProvider
+	minVotes  int
+	logger    *loggerpkg.ModuleLogger
+}
+
+var _ Provider = (*Ensemble)(nil)
+
+// NewEnsemble 创建投票集成；minVotes 小于等于 0 时要求过半提供商给出有效回答。
+func NewEnsemble(minVotes int, providers ...NamedProvider) *Ensemble {
+	if minVotes <= 0 {
+		minVotes = len(providers)/2 + 1
+	}
+	return &Ensemble{providers: providers, minVotes: minVotes, logger: loggerpkg.Get("ai.ensemble")}
+}
+
+type vote struct {
+	name     string
+	decision DecisionResponse
+	summary  news.SentimentSummary
+	err      error
+}
+
+// collect 并行调用全部提供商，结果顺序与 providers 一致。
+func (e *Ensemble) collect(call func(p Provider) vote) []vote {
+	votes := make([]vote, len(e.providers))
+	var wg sync.WaitGroup
+	for i, p := range e.providers {
+		wg.Add(1)
+		go func(i int, p NamedProvider) {
+			defer wg.Done()
+			v := call(p.Provider)
+			v.name = p.Name
+			votes[i] = v
+		}(i, p)
+	}
+	wg.Wait()
+	return votes
+}
+
+func (e *Ensemble) valid(votes []vote) ([]vote, error) {
+	var ok []vote
+	var errs []error
+	for _, v := range votes {
+		if v.err != nil {
+			e.logger.Printf("ensemble.error provider=%s err=%v", v.name, v.err)
+			errs = append(errs, fmt.Errorf("%s: %w", v.name, v.err))
+			continue
+		}
+		ok = append(ok, v)
+	}
+	if len(ok) < e.minVotes {
+		return nil, fmt.Errorf("ensemble got %d valid answers, need %d: %w", len(ok), e.minVotes, errors.Join(errs...))
+	}
+	return ok, nil
+}
+
+func (e *Ensemble) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	votes, err := e.valid(e.collect(func(p Provider) vote {
+		decision, err := p.GenerateDecision(ctx, req)
+		if err == nil {
+			err = ValidateDecision(decision, req.RiskLimits)
+		}
+		return vote{decision: decision, err: err}
+	}))
+	if err != nil {
+		return DecisionResponse{}, err
+	}
+
+	tally := make(map[string][]vote)
+	for _, v := range votes {
+		action := strings.ToLower(strings.TrimSpace(v.decision.Action))
+		if action == "" {
+			action = "hold"
+		}
+		tally[action] = append(tally[action], v)
+	}
+	actions := make([]string, 0, len(tally))
+	for action := range tally {
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		if len(tally[actions[i]]) != len(tally[actions[j]]) {
+			return len(tally[actions[i]]) > len(tally[actions[j]])
+		}
+		return actions[i] < actions[j]
+	})
+	winner := actions[0]
+	if len(actions) > 1 && len(tally[actions[1]]) == len(tally[winner]) {
+		// 平票时不采取行动
+		winner = "hold"
+	}
+
+	majority := tally[winner]
+	result := DecisionResponse{Action: winner, Usage: Usage{Provider: "ensemble", Kind: UsageKindDecision, Trader: req.TraderName}}
+	// 各成员已各自计费，这里只汇总本轮全部成员的用量
+	// 提示词版本为全部成员版本的组合，任一成员模板改动都视为新版本
+	versions := make([]string, 0, len(votes))
+	for _, v := range votes {
+		result.Usage.Add(v.decision.Usage)
+		versions = append(versions, v.decision.PromptVersion)
+	}
+	sort.Strings(versions)
+	result.PromptVersion = "ensemble(" + strings.Join(versions, ",") + ")"
+	var reasons []string
+	var adjust [5]struct {
+		sum float64
+		n   int
+	}
+	for _, v := range majority {
+		result.Confidence += v.decision.Confidence
+		reasons = append(reasons, fmt.Sprintf("[%s] %s", v.name, v.decision.Reason))
+		result.RiskNotes = append(result.RiskNotes, v.decision.RiskNotes...)
+		for i, value := range adjustmentValues(v.decision.Adjustments) {
+			if value != 0 {
+				adjust[i].sum += value
+				adjust[i].n++
+			}
+		}
+	}
+	if len(majority) > 0 {
+		result.Confidence /= float64(len(majority))
+	}
+	var averaged [5]float64
+	for i, a := range adjust {
+		if a.n > 0 {
+			averaged[i] = a.sum / float64(a.n)
+		}
+	}
+	result.Adjustments = AdjustmentPlan{
+		SizeMultiplier:      averaged[0],
+		TargetLeverage:      averaged[1],
+		StopLossPercent:     averaged[2],
+		TakeProfitPercent:   averaged[3],
+		TrailingStopPercent: averaged[4],
+	}
+	for _, v := range votes {
+		if strings.ToLower(strings.TrimSpace(v.decision.Action)) == winner {
+			continue
+		}
+		result.RiskNotes = append(result.RiskNotes, fmt.Sprintf("分歧: %s 主张 %s(%.2f) %s", v.name, v.decision.Action, v.decision.Confidence, v.decision.Reason))
+	}
+	result.Reason = fmt.Sprintf("%d/%d 票 %s; %s", len(majority), len(votes), winner, strings.Join(reasons, " "))
+	e.logger.Printf("ensemble.decision symbol=%s action=%s votes=%d/%d confidence=%.2f", req.Symbol, winner, len(majority), len(votes), result.Confidence)
+	return result, nil
+}
+
+func adjustmentValues(plan AdjustmentPlan) [5]float64 {
+	return [5]float64{plan.SizeMultiplier, plan.TargetLeverage, plan.StopLossPercent, plan.TakeProfitPercent, plan.TrailingStopPercent}
+}
+
+func (e *Ensemble) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	votes, err := e.valid(e.collect(func(p Provider) vote {
+		summary, err := p.AnalyzeNews(ctx, articles)
+		return vote{summary: summary, err: err}
+	}))
+	if err != nil {
+		return news.SentimentSummary{}, err
+	}
+	counts := make(map[string]int)
+	result := news.SentimentSummary{}
+	for _, v := range votes {
+		counts[strings.ToLower(v.summary.Sentiment)]++
+		result.Score += v.summary.Score
+		result.Highlights = append(result.Highlights, v.summary.Highlights...)
+		result.RiskFactors = append(result.RiskFactors, v.summary.RiskFactors...)
+	}
+	result.Score /= float64(len(votes))
+	// 币种情绪分取给出该币种的成员的平均值
+	symbolVotes := make(map[string][]float64)
+	for _, v := range votes {
+		for symbol, score := range v.summary.Symbols {
+			base := news.BaseAsset(symbol)
+			symbolVotes[base] = append(symbolVotes[base], score)
+		}
+	}
+	for symbol, scores := range symbolVotes {
+		if result.Symbols == nil {
+			result.Symbols = make(map[string]float64, len(symbolVotes))
+		}
+		sum := 0.0
+		for _, score := range scores {
+			sum += score
+		}
+		result.Symbols[symbol] = sum / float64(len(scores))
+	}
+	// 情绪同样多数决，平票视为 neutral
+	result.Sentiment = "neutral"
+	best, tied := 0, false
+	for sentiment, n := range counts {
+		switch {
+		case n > best:
+			result.Sentiment, best, tied = sentiment, n, false
+		case n == best:
+			tied = true
+		}
+	}
+	if tied {
+		result.Sentiment = "neutral"
+	}
+	return result, nil
+}
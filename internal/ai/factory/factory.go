@@ -0,0 +1,279 @@
+package factory
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/ai/claude"
+	"autobot/internal/ai/deepseek"
+	"autobot/internal/ai/memory"
+	"autobot/internal/ai/mock"
+	"autobot/internal/ai/ollama"
+	"autobot/internal/ai/plugin"
+	"autobot/internal/ai/qwen"
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+// New 按 decisionProvider 名称创建AI提供商，环境变量中的密钥优先于配置文件；
+// 名称与 plugins 中的键匹配时创建子进程插件。返回的提供商在每次调用前检查 aiBudget，
+// 支持探活的提供商登记到 ai.DefaultHealth；AI不可用演练（cmd/chaos）停用该提供商时调用直接失败。
+// aiPrivacy.redactAmounts 时决策请求先脱敏账户金额（本地 mock 不发网络请求，不脱敏）。
+// 每次真实调用的延迟与成败记录到 ai.DefaultCallStats，供看板「AI 运行状态」面板使用。
+func New(name string, cfg config.ParsedConfig) (ai.Provider, error) {
+	provider, err := newProvider(name, cfg)
+	if err != nil {
+		return nil, err
+	}
+	ai.DefaultHealth().Register(name, provider)
+	if _, ok := provider.(*ai.Ensemble); ok {
+		// 投票成员已各自按预算检查、受演练控制
+		return provider, nil
+	}
+	// 预算按用量记录中的提供商名统计：内置提供商为小写名称，插件为配置中的键名
+	name = strings.TrimSpace(name)
+	if _, ok := cfg.Plugins[name]; !ok {
+		name = strings.ToLower(name)
+	}
+	if name == "" {
+		name = "deepseek"
+	}
+	provider = ai.Observed(name, provider, nil)
+	if cfg.AIPrivacy.RedactAmounts && name != "mock" {
+		provider = ai.WithRedaction(provider)
+	}
+	return ai.WithChaos(name, ai.Budgeted(name, provider), ChaosSwitch(cfg)), nil
+}
+
+func newProvider(name string, cfg config.ParsedConfig) (ai.Provider, error) {
+	if pluginCfg, ok := cfg.Plugins[strings.TrimSpace(name)]; ok {
+		return plugin.New(strings.TrimSpace(name), pluginCfg), nil
+	}
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "deepseek", "":
+		client := deepseek.New(cfg.Deepseek)
+		if client == nil {
+			return nil, fmt.Errorf("deepseek 未启用")
+		}
+		key := os.Getenv("DEEPSEEK_API_KEY")
+		if key == "" {
+			key = cfg.Deepseek.APIKey
+		}
+		client.SetDeepSeekAPIKey(key)
+		return client, nil
+	case "qwen":
+		client := qwen.New(os.Getenv("QWEN_API_KEY"), cfg.Qwen)
+		if client == nil {
+			return nil, fmt.Errorf("qwen 未启用")
+		}
+		return client, nil
+	case "claude":
+		client := claude.New(os.Getenv("ANTHROPIC_API_KEY"), cfg.Claude)
+		if client == nil {
+			return nil, fmt.Errorf("claude 未启用")
+		}
+		return client, nil
+	case "ollama":
+		client := ollama.New(cfg.Ollama)
+		if client == nil {
+			return nil, fmt.Errorf("ollama 未启用")
+		}
+		return client, nil
+	case "mock":
+		return mock.New(cfg.Mock), nil
+	case "ensemble":
+		if len(cfg.Ensemble.Providers) < 2 {
+			return nil, fmt.Errorf("ensemble 需在 ensemble.providers 中配置至少2个提供商")
+		}
+		members := make([]ai.NamedProvider, 0, len(cfg.Ensemble.Providers))
+		for _, member := range cfg.Ensemble.Providers {
+			if strings.EqualFold(strings.TrimSpace(member), "ensemble") {
+				return nil, fmt.Errorf("ensemble 不能包含自身")
+			}
+			provider, err := New(member, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("ensemble member %s: %w", member, err)
+			}
+			members = append(members, ai.NamedProvider{Name: member, Provider: provider})
+		}
+		return ai.NewEnsemble(cfg.Ensemble.MinVotes, members...), nil
+	default:
+		return nil, fmt.Errorf("未知 decisionProvider %q", name)
+	}
+}
+
+// NewReplayer 创建用于离线重跑历史提示词的提供商，不经过预算、缓存与记忆。
+// 多模型投票与插件没有固定的用户提示格式，不支持重放。
+func NewReplayer(name string, cfg config.ParsedConfig) (ai.PromptReplayer, error) {
+	provider, err := newProvider(name, cfg)
+	if err != nil {
+		return nil, err
+	}
+	replayer, ok := provider.(ai.PromptReplayer)
+	if !ok {
+		return nil, fmt.Errorf("提供商 %q 不支持重放历史提示词", name)
+	}
+	return replayer, nil
+}
+
+// NewChain 按名称顺序创建提供商；多于一个时返回 ai.Chain，出错或决策校验失败时依次回退。
+// aiMemory.enabled 时决策带上长期记忆（见 ensureMemoryStore）；aiCache.ttl 大于 0 时
+// 外层再包一层共享的决策缓存，命中缓存的决策不重复写入记忆。
+// 新闻分析先查全局情绪缓存（ai.SetNewsCache），所有提供商都失败或超过 news.analyzeTimeout 时
+// 改用本地词典评分（低置信）；news.sentiment=lexicon 时始终使用词典。
+// 演练停用了链上全部提供商时，决策按演练模式兜底生成。最后按 guardrails 执行确定性护栏，
+// 兜底与缓存命中的决策同样受约束。
+func NewChain(names []string, cfg config.ParsedConfig) (ai.Provider, error) {
+	return newChain(names, cfg, false)
+}
+
+func newChain(names []string, cfg config.ParsedConfig, critique bool) (ai.Provider, error) {
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	providers := make([]ai.NamedProvider, 0, len(names))
+	for _, name := range names {
+		provider, err := New(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			name = "deepseek"
+		}
+		providers = append(providers, ai.NamedProvider{Name: name, Provider: provider})
+	}
+	var provider ai.Provider = providers[0].Provider
+	if len(providers) > 1 {
+		provider = ai.NewChain(providers...)
+	}
+	cacheKey := strings.Join(names, ",")
+	if critique {
+		provider = ai.WithSelfCritique(provider)
+		cacheKey += "+critique"
+	}
+	if cfg.AIMemory.Enabled {
+		if err := ensureMemoryStore(cfg); err != nil {
+			return nil, err
+		}
+		provider = memory.Wrap(provider, cfg.AIMemory.TopK, cfg.AIMemory.MinScore)
+	}
+	if cfg.AICacheTTL > 0 {
+		provider = sharedCache(cfg.AICacheTTL).Wrap(cacheKey, provider)
+	}
+	provider = ai.WithChaosFallback(provider, ChaosSwitch(cfg))
+	provider = ai.WithGuardrails(provider, cfg.Guardrails)
+	return ai.WithNewsFallback(ai.CachedNews(provider), newsFallback(cfg)), nil
+}
+
+// ForTrader 按交易者配置创建提供商：决策走 Providers() 组成的回退链；设置了 newsProvider 时
+// 新闻分析改由该提供商（newsModel 覆盖其模型）完成，同样带情绪缓存与词典兜底。
+// selfCritique 时开仓与加仓决策先经 ai.WithSelfCritique 复核，再进入缓存、演练兜底与护栏。
+func ForTrader(profile config.TraderProfile, cfg config.ParsedConfig) (ai.Provider, error) {
+	decisions, err := newChain(profile.Providers(), cfg, profile.SelfCritique)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(profile.NewsProvider) == "" {
+		return decisions, nil
+	}
+	newsCfg, err := withModel(profile.NewsProvider, profile.NewsModel, cfg)
+	if err != nil {
+		return nil, err
+	}
+	newsProvider, err := New(profile.NewsProvider, newsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("newsProvider %s: %w", profile.NewsProvider, err)
+	}
+	return ai.Route{
+		News:      ai.WithNewsFallback(ai.CachedNews(newsProvider), newsFallback(cfg)),
+		Decisions: decisions,
+	}, nil
+}
+
+// withModel 返回把 name 对应内置提供商的模型替换为 model 的配置副本，model 为空时原样返回。
+func withModel(name, model string, cfg config.ParsedConfig) (config.ParsedConfig, error) {
+	if model == "" {
+		return cfg, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "deepseek", "":
+		cfg.Deepseek.Model = model
+	case "qwen":
+		cfg.Qwen.Model = model
+	case "claude":
+		cfg.Claude.Model = model
+	case "ollama":
+		cfg.Ollama.Model = model
+	default:
+		return cfg, fmt.Errorf("提供商 %q 不支持单独指定模型", name)
+	}
+	return cfg, nil
+}
+
+func newsFallback(cfg config.ParsedConfig) ai.NewsFallbackOptions {
+	return ai.NewsFallbackOptions{
+		Lexicon:     cfg.News.Lexicon,
+		Timeout:     cfg.NewsAnalyzeTimeout,
+		LexiconOnly: cfg.News.Sentiment == config.NewsSentimentLexicon,
+	}
+}
+
+var (
+	cacheMu sync.Mutex
+	caches  = map[time.Duration]*ai.DecisionCache{}
+	chaos   = map[string]*ai.ChaosSwitch{}
+)
+
+// ChaosSwitch 返回存储目录下演练状态文件对应的共享开关。
+func ChaosSwitch(cfg config.ParsedConfig) *ai.ChaosSwitch {
+	path := ai.ChaosFile(cfg.Storage.Path)
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	sw, ok := chaos[path]
+	if !ok {
+		sw = ai.NewChaosSwitch(path)
+		chaos[path] = sw
+	}
+	return sw
+}
+
+// sharedCache 返回进程内共享的决策缓存，使用同一提供商组合的交易者可以复用彼此的决策。
+func sharedCache(ttl time.Duration) *ai.DecisionCache {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cache, ok := caches[ttl]
+	if !ok {
+		cache = ai.NewDecisionCache(ttl)
+		caches[ttl] = cache
+	}
+	return cache
+}
+
+var memoryMu sync.Mutex
+
+// ensureMemoryStore 在尚未设置全局记忆库时按 aiMemory 创建向量化器，用存储目录中已落盘的记忆
+// （storage.LoadMemories）恢复后调用 memory.SetStore，新记忆追加到同一文件。进程内只创建一次，
+// 调用方已通过 memory.SetStore 设置时沿用。
+func ensureMemoryStore(cfg config.ParsedConfig) error {
+	memoryMu.Lock()
+	defer memoryMu.Unlock()
+	if memory.Default() != nil {
+		return nil
+	}
+	embedder, err := memory.NewEmbedder(cfg.AIMemory)
+	if err != nil {
+		return err
+	}
+	entries, err := storage.LoadMemories(cfg.Storage, time.Time{})
+	if err != nil {
+		return fmt.Errorf("load ai memory: %w", err)
+	}
+	store := memory.NewStore(embedder, storage.NewMemoryFile(cfg.Storage), cfg.AIMemory.MaxEntries)
+	store.Seed(entries)
+	memory.SetStore(store)
+	return nil
+}
@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+)
+
+// 护栏规则名，用于 Vetoes 说明与 ai_guardrail_interventions_total 的 rule 标签。
+const (
+	GuardrailFunding = "funding"
+	GuardrailSize    = "size"
+	GuardrailMargin  = "margin"
+)
+
+// GuardrailNote 为护栏说明的前缀，同时写入 riskNotes，看板决策日志中可以直接看到。
+const GuardrailNote = "护栏"
+
+// WithGuardrails 在 provider 给出决策之后按 cfg 执行确定性规则（见 ApplyGuardrails），
+// 规则全部为 0 时原样返回 provider。
+func WithGuardrails(provider Provider, cfg config.GuardrailsConfig) Provider {
+	if cfg == (config.GuardrailsConfig{}) {
+		return provider
+	}
+	return &guardrailProvider{Provider: provider, cfg: cfg, logger: loggerpkg.Get("ai.guardrails")}
+}
+
+type guardrailProvider struct {
+	Provider
+	cfg    config.GuardrailsConfig
+	logger *loggerpkg.ModuleLogger
+}
+
+func (p *guardrailProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	decision, err := p.Provider.GenerateDecision(ctx, req)
+	if err != nil {
+		return decision, err
+	}
+	guarded, rules := ApplyGuardrails(p.cfg, req, decision)
+	for _, rule := range rules {
+		aiGuardrailsTotal.Inc(rule)
+	}
+	if len(rules) > 0 {
+		p.logger.Printf("guardrail.applied trader=%s symbol=%s rules=%s action=%s->%s notes=%q",
+			req.TraderName, req.Symbol, strings.Join(rules, ","), decision.Action, guarded.Action, guarded.Vetoes)
+	}
+	return guarded, nil
+}
+
+// ApplyGuardrails 按规则检查决策，返回处理后的决策与触发的规则名。依次执行：
+//   - margin：保证金占用达到 ReduceMarginUsage 时，有持仓且未在平仓则改为 reduce，无持仓的开仓改为 wait；
+//   - funding：资金费率高于 MaxFundingForLong 时否决开多/加多，低于 MinFundingForShort 时否决开空/加空；
+//   - size：开仓或加仓的 sizeMultiplier 超过 MaxSizeMultiplier 时缩减到上限。
+//
+// 每条说明追加到 Vetoes，并以 GuardrailNote 开头写入 riskNotes；模型的 reason 保持原样。
+func ApplyGuardrails(cfg config.GuardrailsConfig, req DecisionRequest, decision DecisionResponse) (DecisionResponse, []string) {
+	var rules []string
+	note := func(rule, text string) {
+		rules = append(rules, rule)
+		decision.Vetoes = append(decision.Vetoes, text)
+		decision.RiskNotes = append(decision.RiskNotes, GuardrailNote+": "+text)
+	}
+	side := positionSide(req)
+	idle := "wait"
+	if side != "" {
+		idle = "hold"
+	}
+
+	if limit := cfg.ReduceMarginUsage; limit > 0 {
+		usage := req.Context.Account.MarginUsage
+		if usage <= 0 {
+			usage = req.Context.MarginUsage
+		}
+		if usage >= limit {
+			switch {
+			case side != "" && !isExitAction(decision.Action):
+				note(GuardrailMargin, fmt.Sprintf("保证金占用 %.1f%% ≥ %.1f%%，%s 改为 reduce", usage, limit, decision.Action))
+				decision.Action = "reduce"
+			case side == "" && openDirection(decision.Action) != "":
+				note(GuardrailMargin, fmt.Sprintf("保证金占用 %.1f%% ≥ %.1f%%，否决 %s", usage, limit, decision.Action))
+				decision.Action = idle
+			}
+		}
+	}
+
+	if snapshot, ok := req.Context.MarketData[req.Symbol]; ok {
+		funding := snapshot.FundingRate
+		switch openDirection(decision.Action) {
+		case "long":
+			if cfg.MaxFundingForLong > 0 && funding > cfg.MaxFundingForLong {
+				note(GuardrailFunding, fmt.Sprintf("资金费率 %.4f%% 高于 %.4f%%，否决 %s", funding*100, cfg.MaxFundingForLong*100, decision.Action))
+				decision.Action = idle
+			}
+		case "short":
+			if cfg.MinFundingForShort < 0 && funding < cfg.MinFundingForShort {
+				note(GuardrailFunding, fmt.Sprintf("资金费率 %.4f%% 低于 %.4f%%，否决 %s", funding*100, cfg.MinFundingForShort*100, decision.Action))
+				decision.Action = idle
+			}
+		}
+	}
+
+	if limit := cfg.MaxSizeMultiplier; limit > 0 && openDirection(decision.Action) != "" && decision.Adjustments.SizeMultiplier > limit {
+		note(GuardrailSize, fmt.Sprintf("sizeMultiplier %.4g 超过上限，缩减为 %.4g", decision.Adjustments.SizeMultiplier, limit))
+		decision.Adjustments.SizeMultiplier = limit
+	}
+	return decision, rules
+}
+
+// openDirection 返回开仓或加仓动作的方向（long/short），其他动作为空。
+func openDirection(action string) string {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "open_long", "increase_long":
+		return "long"
+	case "open_short", "increase_short":
+		return "short"
+	}
+	return ""
+}
+
+func isExitAction(action string) bool {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "close", "exit", "reduce":
+		return true
+	}
+	return false
+}
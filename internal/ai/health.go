@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// Pinger 由支持轻量探活的提供商实现：校验密钥与连通性，不产生 token 费用。
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// HealthStatus 为某提供商最近一次探活的结果。
+type HealthStatus struct {
+	Provider  string    `json:"provider"`
+	Healthy   bool      `json:"healthy"`
+	LatencyMs int64     `json:"latencyMs"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+	// Failures 为连续探活失败次数。
+	Failures int `json:"failures"`
+}
+
+// HealthMonitor 定期探活已注册的提供商，回退链据此把不健康的提供商排到最后。
+type HealthMonitor struct {
+	mu      sync.RWMutex
+	pingers map[string]Pinger
+	status  map[string]HealthStatus
+}
+
+// NewHealthMonitor 创建空的探活器。
+func NewHealthMonitor() *HealthMonitor {
+	return &HealthMonitor{pingers: map[string]Pinger{}, status: map[string]HealthStatus{}}
+}
+
+// Register 登记提供商，不支持探活的提供商不登记并视为健康。名称不区分大小写。
+func (m *HealthMonitor) Register(name string, provider Provider) bool {
+	pinger, ok := provider.(Pinger)
+	if !ok {
+		return false
+	}
+	m.mu.Lock()
+	m.pingers[healthKey(name)] = pinger
+	m.mu.Unlock()
+	return true
+}
+
+// Healthy 报告提供商是否可用；未登记或尚未探活的提供商视为健康。
+func (m *HealthMonitor) Healthy(name string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, ok := m.status[healthKey(name)]
+	return !ok || status.Healthy
+}
+
+// Statuses 返回各提供商最近一次探活结果，按名称排序。
+func (m *HealthMonitor) Statuses() []HealthStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]HealthStatus, 0, len(m.status))
+	for _, status := range m.status {
+		out = append(out, status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
+
+// Check 并发探活所有已登记的提供商，每个探活最长 timeout，返回更新后的全部状态。
+func (m *HealthMonitor) Check(ctx context.Context, timeout time.Duration) []HealthStatus {
+	m.mu.RLock()
+	pingers := make(map[string]Pinger, len(m.pingers))
+	for name, pinger := range m.pingers {
+		pingers[name] = pinger
+	}
+	m.mu.RUnlock()
+
+	logger := loggerpkg.Get("ai.health")
+	var wg sync.WaitGroup
+	for name, pinger := range pingers {
+		wg.Add(1)
+		go func(name string, pinger Pinger) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			start := time.Now()
+			err := pinger.Ping(pingCtx)
+			status := HealthStatus{Provider: name, Healthy: err == nil, LatencyMs: time.Since(start).Milliseconds(), CheckedAt: start}
+			observeHealth(status)
+
+			m.mu.Lock()
+			previous, seen := m.status[name]
+			if err != nil {
+				status.Error = err.Error()
+				status.Failures = previous.Failures + 1
+			}
+			m.status[name] = status
+			m.mu.Unlock()
+
+			switch {
+			case err != nil:
+				logger.Printf("health.down provider=%s latency_ms=%d failures=%d err=%v", name, status.LatencyMs, status.Failures, err)
+			case seen && !previous.Healthy:
+				logger.Printf("health.recovered provider=%s latency_ms=%d", name, status.LatencyMs)
+			}
+		}(name, pinger)
+	}
+	wg.Wait()
+	return m.Statuses()
+}
+
+// Run 立即探活一次，之后每隔 interval 探活，直到 ctx 结束；每轮结果交给 onUpdate（可为空）。
+func (m *HealthMonitor) Run(ctx context.Context, interval, timeout time.Duration, onUpdate func([]HealthStatus)) {
+	for {
+		statuses := m.Check(ctx, timeout)
+		if onUpdate != nil {
+			onUpdate(statuses)
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func healthKey(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "deepseek"
+	}
+	return name
+}
+
+var defaultHealth atomic.Pointer[HealthMonitor]
+
+func init() {
+	defaultHealth.Store(NewHealthMonitor())
+}
+
+// DefaultHealth 返回全局探活器，factory 创建提供商时自动登记，回退链据此排序。
+func DefaultHealth() *HealthMonitor {
+	return defaultHealth.Load()
+}
+
+// ProbeHTTP 以 GET 请求 url 探活：密钥被拒（401/403）或其他非 2xx 状态返回错误。
+func ProbeHTTP(ctx context.Context, client *http.Client, url string, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("API key rejected (status %d)", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
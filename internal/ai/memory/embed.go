@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+)
+
+// hashDimensions 为本地特征哈希向量的维度。
+const hashDimensions = 256
+
+// Embedder 把文本转换为向量。Name 标识向量空间，不同 Name 的向量不能互相比较。
+type Embedder interface {
+	Name() string
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+// NewEmbedder 按 aiMemory 配置创建向量化器。
+func NewEmbedder(cfg config.AIMemoryConfig) (Embedder, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	switch cfg.Embedder {
+	case config.EmbedderHash, "":
+		return HashEmbedder{}, nil
+	case config.EmbedderOllama:
+		return &ollamaEmbedder{client: client, baseURL: baseURL, model: cfg.Model}, nil
+	case config.EmbedderOpenAI:
+		key := os.Getenv("EMBEDDING_API_KEY")
+		if key == "" {
+			return nil, errors.New("aiMemory.embedder=openai 需要设置 EMBEDDING_API_KEY")
+		}
+		return &openAIEmbedder{client: client, baseURL: baseURL, model: cfg.Model, apiKey: key}, nil
+	default:
+		return nil, fmt.Errorf("未知 aiMemory.embedder %q", cfg.Embedder)
+	}
+}
+
+// HashEmbedder 把按空白切分的词哈希到固定维度（带符号）并归一化，无需模型与网络。
+// 情形描述由离散的分档词组成，词重合越多相似度越高。
+type HashEmbedder struct{}
+
+func (HashEmbedder) Name() string {
+	return fmt.Sprintf("hash-%d", hashDimensions)
+}
+
+func (HashEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	vec := make([]float32, hashDimensions)
+	for _, token := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New64a()
+		h.Write([]byte(token))
+		sum := h.Sum64()
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		vec[sum%hashDimensions] += sign
+	}
+	return normalize(vec), nil
+}
+
+type ollamaEmbedder struct {
+	client  *http.Client
+	baseURL string
+	model   string
+}
+
+func (e *ollamaEmbedder) Name() string {
+	return "ollama/" + e.model
+}
+
+func (e *ollamaEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	var payload struct {
+		Embeddings [][]float32 `json:"embeddings"`
+		Error      string      `json:"error"`
+	}
+	status, err := postJSON(ctx, e.client, e.baseURL+"/api/embed", "", map[string]any{"model": e.model, "input": text}, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("ollama embed: %w", err)
+	}
+	if status >= 400 || payload.Error != "" {
+		return nil, fmt.Errorf("ollama embed status %d: %s", status, payload.Error)
+	}
+	if len(payload.Embeddings) == 0 || len(payload.Embeddings[0]) == 0 {
+		return nil, errors.New("ollama embed 返回为空")
+	}
+	return normalize(payload.Embeddings[0]), nil
+}
+
+type openAIEmbedder struct {
+	client  *http.Client
+	baseURL string
+	model   string
+	apiKey  string
+}
+
+func (e *openAIEmbedder) Name() string {
+	return "openai/" + e.model
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	var payload struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	status, err := postJSON(ctx, e.client, e.baseURL+"/embeddings", e.apiKey, map[string]any{"model": e.model, "input": text}, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: %w", err)
+	}
+	if status >= 400 || payload.Error != nil {
+		msg := ""
+		if payload.Error != nil {
+			msg = payload.Error.Message
+		}
+		return nil, fmt.Errorf("embeddings status %d: %s", status, msg)
+	}
+	if len(payload.Data) == 0 || len(payload.Data[0].Embedding) == 0 {
+		return nil, errors.New("embeddings 返回为空")
+	}
+	return normalize(payload.Data[0].Embedding), nil
+}
+
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, out any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("status %d: decode response: %w", resp.StatusCode, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// normalize 把向量缩放为单位长度，之后余弦相似度即点积。
+func normalize(vec []float32) []float32 {
+	var norm float64
+	for _, v := range vec {
+		norm += float64(v) * float64(v)
+	}
+	if norm == 0 {
+		return vec
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range vec {
+		vec[i] *= scale
+	}
+	return vec
+}
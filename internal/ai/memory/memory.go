@@ -0,0 +1,141 @@
+// Package memory 为AI决策提供长期记忆：把每次决策时的行情情形向量化并连同决策一起保存，
+// 之后遇到相似的情形时检索出来放入提示词，让模型参考自己在类似行情下的判断。
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// Entry 为一条记忆：决策时的情形描述、当时的决策摘要及情形向量。
+type Entry struct {
+	Trader    string    `json:"trader"`
+	Symbol    string    `json:"symbol"`
+	Situation string    `json:"situation"`
+	Decision  string    `json:"decision"`
+	Embedder  string    `json:"embedder"`
+	Vector    []float32 `json:"vector"`
+	CreatedAt int64     `json:"createdAt"`
+}
+
+// Match 为一条检索结果，Score 为余弦相似度。
+type Match struct {
+	Entry
+	Score float64
+}
+
+// Recorder 持久化记忆；storage.Store 满足该接口。
+type Recorder interface {
+	RecordMemory(ctx context.Context, entry Entry) error
+}
+
+// Store 在内存中保存最近 maxEntries 条记忆并按向量相似度检索，recorder 不为空时新记忆同时落盘。
+type Store struct {
+	embedder   Embedder
+	recorder   Recorder
+	maxEntries int
+
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+// NewStore 创建记忆库，recorder 可为空（不落盘），maxEntries 为 0 时不限条数。
+func NewStore(embedder Embedder, recorder Recorder, maxEntries int) *Store {
+	return &Store{embedder: embedder, recorder: recorder, maxEntries: maxEntries}
+}
+
+// Seed 恢复已落盘的记忆，其他向量化器生成的条目无法比较，被忽略。
+func (s *Store) Seed(entries []Entry) {
+	name := s.embedder.Name()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range entries {
+		if entry.Embedder == name && len(entry.Vector) > 0 {
+			s.entries = append(s.entries, entry)
+		}
+	}
+	s.trimLocked()
+}
+
+// Len 返回当前记忆条数。
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// Embed 用记忆库的向量化器转换文本。
+func (s *Store) Embed(ctx context.Context, text string) ([]float32, error) {
+	return s.embedder.Embed(ctx, text)
+}
+
+// Search 返回与 vector 相似度不低于 minScore 的前 k 条记忆，按相似度从高到低排列。
+func (s *Store) Search(vector []float32, k int, minScore float64) []Match {
+	if k <= 0 || len(vector) == 0 {
+		return nil
+	}
+	s.mu.RLock()
+	var matches []Match
+	for _, entry := range s.entries {
+		if len(entry.Vector) != len(vector) {
+			continue
+		}
+		if score := dot(entry.Vector, vector); score >= minScore {
+			matches = append(matches, Match{Entry: entry, Score: score})
+		}
+	}
+	s.mu.RUnlock()
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > k {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+// Add 保存一条已带向量的记忆并落盘。
+func (s *Store) Add(ctx context.Context, entry Entry) {
+	entry.Embedder = s.embedder.Name()
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+	s.mu.Lock()
+	s.entries = append(s.entries, entry)
+	s.trimLocked()
+	s.mu.Unlock()
+	if s.recorder != nil {
+		if err := s.recorder.RecordMemory(ctx, entry); err != nil {
+			loggerpkg.Get("ai.memory").Printf("memory.record.error: %v", err)
+		}
+	}
+}
+
+func (s *Store) trimLocked() {
+	if s.maxEntries > 0 && len(s.entries) > s.maxEntries {
+		s.entries = append([]Entry(nil), s.entries[len(s.entries)-s.maxEntries:]...)
+	}
+}
+
+func dot(a, b []float32) float64 {
+	var sum float64
+	for i := range a {
+		sum += float64(a[i]) * float64(b[i])
+	}
+	return sum
+}
+
+var defaultStore atomic.Pointer[Store]
+
+// SetStore 设置 Wrap 使用的全局记忆库，启动时按存储恢复后设置一次；nil 关闭记忆。
+func SetStore(s *Store) {
+	defaultStore.Store(s)
+}
+
+// Default 返回 SetStore 设置的全局记忆库，未设置时为 nil。
+func Default() *Store {
+	return defaultStore.Load()
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStoreSearchAndSeed(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(HashEmbedder{}, nil, 2)
+	for _, situation := range []string{"signal long rsi high", "signal short rsi low", "signal long rsi mid"} {
+		vector, err := store.Embed(ctx, situation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.Add(ctx, Entry{Situation: situation, Vector: vector})
+	}
+	if store.Len() != 2 {
+		t.Fatalf("len = %d, want maxEntries 2", store.Len())
+	}
+
+	query, _ := store.Embed(ctx, "signal long rsi mid")
+	matches := store.Search(query, 1, 0)
+	if len(matches) != 1 || matches[0].Situation != "signal long rsi mid" {
+		t.Fatalf("matches = %+v", matches)
+	}
+
+	seeded := NewStore(HashEmbedder{}, nil, 0)
+	seeded.Seed([]Entry{
+		{Situation: "a", Embedder: HashEmbedder{}.Name(), Vector: query},
+		{Situation: "b", Embedder: "other", Vector: query},
+	})
+	if seeded.Len() != 1 {
+		t.Fatalf("seeded %d entries, want only the matching embedder", seeded.Len())
+	}
+}
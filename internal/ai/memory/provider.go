@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// decisionReasonLimit 为记忆中决策理由保留的最大字数。
+const decisionReasonLimit = 120
+
+// Wrap 让 provider 的决策带上长期记忆：调用前检索与当前情形最相似的 topK 条历史记忆写入
+// DecisionRequest.Memories，成功后把本次情形与决策存入记忆。全局记忆库未设置（SetStore）或
+// 向量化失败时直接调用 provider。
+func Wrap(provider ai.Provider, topK int, minScore float64) ai.Provider {
+	return &memoryProvider{provider: provider, topK: topK, minScore: minScore}
+}
+
+type memoryProvider struct {
+	provider ai.Provider
+	topK     int
+	minScore float64
+}
+
+func (p *memoryProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	return p.provider.AnalyzeNews(ctx, articles)
+}
+
+func (p *memoryProvider) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	store := defaultStore.Load()
+	if store == nil {
+		return p.provider.GenerateDecision(ctx, req)
+	}
+	logger := loggerpkg.Get("ai.memory")
+	situation := Situation(req)
+	vector, err := store.Embed(ctx, situation)
+	if err != nil {
+		logger.Printf("memory.embed.error trader=%s symbol=%s: %v", req.TraderName, req.Symbol, err)
+		return p.provider.GenerateDecision(ctx, req)
+	}
+	if matches := store.Search(vector, p.topK, p.minScore); len(matches) > 0 {
+		memories := make([]string, 0, len(req.Memories)+len(matches))
+		memories = append(memories, req.Memories...)
+		for _, m := range matches {
+			memories = append(memories, formatMatch(m))
+		}
+		req.Memories = memories
+		logger.Printf("memory.recall trader=%s symbol=%s matches=%d best=%.3f", req.TraderName, req.Symbol, len(matches), matches[0].Score)
+	}
+
+	decision, err := p.provider.GenerateDecision(ctx, req)
+	if err != nil {
+		return decision, err
+	}
+	store.Add(ctx, Entry{
+		Trader:    req.TraderName,
+		Symbol:    req.Symbol,
+		Situation: situation,
+		Decision:  summarizeDecision(decision),
+		Vector:    vector,
+	})
+	return decision, nil
+}
+
+// Situation 把请求中当前交易对的行情、信号、新闻情绪与持仓描述为分档词，
+// 数值按区间离散化，使相近的行情得到相近的描述与向量。
+func Situation(req ai.DecisionRequest) string {
+	parts := []string{req.Symbol, "信号:" + orDefault(strings.ToLower(req.StrategySignal), "无")}
+	if md, ok := req.Context.MarketData[req.Symbol]; ok {
+		parts = append(parts,
+			"1h:"+changeBucket(md.PriceChange1h),
+			"4h:"+changeBucket(md.PriceChange4h),
+			"24h:"+changeBucket(md.PriceChange24h),
+			"RSI14:"+rsiBucket(md.RSI14),
+			"RSI7:"+rsiBucket(md.RSI7),
+		)
+		if md.MACD != 0 || md.MACDSignal != 0 {
+			parts = append(parts, "MACD:"+pick(md.MACD >= md.MACDSignal, "多头", "空头"))
+		}
+		if md.EMA20 > 0 && md.CurrentPrice > 0 {
+			parts = append(parts, "EMA20:"+pick(md.CurrentPrice >= md.EMA20, "上方", "下方"))
+		}
+		switch {
+		case md.FundingRate > 0.0005:
+			parts = append(parts, "资金费率:高正")
+		case md.FundingRate > 0:
+			parts = append(parts, "资金费率:正")
+		case md.FundingRate < 0:
+			parts = append(parts, "资金费率:负")
+		}
+		for _, pattern := range md.Patterns {
+			parts = append(parts, "形态:"+pattern)
+		}
+	}
+	if sentiment := strings.ToLower(req.NewsSentiment.Sentiment); sentiment != "" {
+		parts = append(parts, "新闻:"+sentiment)
+	}
+	parts = append(parts, "持仓:"+positionSide(req))
+	return strings.Join(parts, " ")
+}
+
+func positionSide(req ai.DecisionRequest) string {
+	for _, pos := range req.Context.Positions {
+		if pos.Symbol == req.Symbol && pos.Quantity != 0 {
+			return strings.ToLower(pos.Side)
+		}
+	}
+	for _, pos := range req.Positions {
+		if pos.Symbol == req.Symbol && pos.Quantity != 0 {
+			return strings.ToLower(pos.Side)
+		}
+	}
+	return "无"
+}
+
+func changeBucket(pct float64) string {
+	switch {
+	case pct <= -3:
+		return "大跌"
+	case pct <= -1:
+		return "下跌"
+	case pct < -0.2:
+		return "小跌"
+	case pct <= 0.2:
+		return "横盘"
+	case pct < 1:
+		return "小涨"
+	case pct < 3:
+		return "上涨"
+	default:
+		return "大涨"
+	}
+}
+
+func rsiBucket(rsi float64) string {
+	switch {
+	case rsi <= 0:
+		return "无"
+	case rsi < 30:
+		return "超卖"
+	case rsi < 45:
+		return "偏弱"
+	case rsi <= 55:
+		return "中性"
+	case rsi <= 70:
+		return "偏强"
+	default:
+		return "超买"
+	}
+}
+
+func summarizeDecision(d ai.DecisionResponse) string {
+	reason := []rune(strings.Join(strings.Fields(d.Reason), " "))
+	if len(reason) > decisionReasonLimit {
+		reason = append(reason[:decisionReasonLimit], '…')
+	}
+	return fmt.Sprintf("%s 信心%.2f：%s", d.Action, d.Confidence, string(reason))
+}
+
+func formatMatch(m Match) string {
+	return fmt.Sprintf("相似度%.2f %s 情形[%s] 决策[%s]",
+		m.Score, time.UnixMilli(m.CreatedAt).Format("2006-01-02 15:04"), m.Situation, m.Decision)
+}
+
+func pick(cond bool, yes, no string) string {
+	if cond {
+		return yes
+	}
+	return no
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
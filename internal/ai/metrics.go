@@ -0,0 +1,44 @@
+package ai
+
+import "autobot/internal/metrics"
+
+// 按 provider/model/trader/kind 标签导出的模型调用指标，由 Meter.Record 更新。
+var (
+	aiRequestsTotal         = metrics.NewCounter("ai_requests_total", "Model calls that returned a response.", "provider", "model", "trader", "kind")
+	aiPromptTokensTotal     = metrics.NewCounter("ai_prompt_tokens_total", "Prompt tokens sent to the model.", "provider", "model", "trader", "kind")
+	aiCompletionTokensTotal = metrics.NewCounter("ai_completion_tokens_total", "Completion tokens returned by the model.", "provider", "model", "trader", "kind")
+	aiCostUSDTotal          = metrics.NewCounter("ai_cost_usd_total", "Model spend priced by the configured table.", "provider", "model", "trader", "kind")
+
+	// 探活结果，由 HealthMonitor.Check 更新。
+	aiProviderUp     = metrics.NewGauge("ai_provider_up", "1 when the last health probe succeeded.", "provider")
+	aiPingLatencySec = metrics.NewGauge("ai_ping_latency_seconds", "Latency of the last health probe.", "provider")
+
+	// 提示词超出上下文窗口被压缩的次数，由 PromptFit.Fit 更新。
+	aiPromptCompactionsTotal = metrics.NewCounter("ai_prompt_compactions_total", "Decision prompts compacted to fit the model context window.", "provider")
+
+	// AI不可用演练期间兜底生成的决策数，由 WithChaosFallback 更新。
+	aiChaosFallbacksTotal = metrics.NewCounter("ai_chaos_fallbacks_total", "Decisions produced by the fallback path during an AI outage drill.", "mode")
+
+	// 确定性护栏改写决策的次数，由 WithGuardrails 更新。
+	aiGuardrailsTotal = metrics.NewCounter("ai_guardrail_interventions_total", "Decisions vetoed, rewritten or downscaled by a guardrail rule.", "rule")
+
+	// 自我复核的结果（confirmed/revised/failed），由 WithSelfCritique 更新。
+	aiSelfCritiqueTotal = metrics.NewCounter("ai_self_critique_total", "Draft decisions sent back for self-critique, by outcome.", "result")
+)
+
+func observeUsage(u Usage) {
+	labels := []string{u.Provider, u.Model, u.Trader, u.Kind}
+	aiRequestsTotal.Inc(labels...)
+	aiPromptTokensTotal.Add(float64(u.PromptTokens), labels...)
+	aiCompletionTokensTotal.Add(float64(u.CompletionTokens), labels...)
+	aiCostUSDTotal.Add(u.CostUSD, labels...)
+}
+
+func observeHealth(status HealthStatus) {
+	up := 0.0
+	if status.Healthy {
+		up = 1
+	}
+	aiProviderUp.Set(up, status.Provider)
+	aiPingLatencySec.Set(float64(status.LatencyMs)/1000, status.Provider)
+}
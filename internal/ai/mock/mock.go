@@ -0,0 +1,91 @@
+// Package mock 实现确定性的AI提供商：按规则或脚本给出决策，不需要密钥也不发网络请求，
+// 集成测试与试运行可以在没有模型的环境中走完整的决策流程。
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// Source 为 mock 新闻情绪的来源标记。
+const Source = "mock"
+
+// Provider 为 decisionProvider=mock 的提供商。
+type Provider struct {
+	cfg    config.MockAIConfig
+	logger *loggerpkg.ModuleLogger
+
+	mu   sync.Mutex
+	next int
+}
+
+var _ ai.Provider = (*Provider)(nil)
+
+// New 创建 mock 提供商。
+func New(cfg config.MockAIConfig) *Provider {
+	if cfg.Mode == "" {
+		cfg.Mode = config.MockModeFollow
+	}
+	logger := loggerpkg.Get("ai.mock")
+	logger.Printf("initialized mock provider mode=%s responses=%d", cfg.Mode, len(cfg.Responses))
+	return &Provider{cfg: cfg, logger: logger}
+}
+
+// AnalyzeNews 返回配置的固定情绪，未配置时按本地词典评分。
+func (p *Provider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if p.cfg.Sentiment == "" {
+		summary := news.ScoreLexicon(articles, nil)
+		summary.Source = Source
+		return summary, nil
+	}
+	return news.SentimentSummary{Sentiment: p.cfg.Sentiment, Score: p.cfg.Score, Source: Source}, nil
+}
+
+// GenerateDecision 按模式给出决策：follow 与 hold 由 ai.SignalDecision 根据策略信号与持仓得出，
+// script 依次取 Responses。
+func (p *Provider) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	var decision ai.DecisionResponse
+	switch p.cfg.Mode {
+	case config.MockModeScript:
+		decision = p.scripted()
+	default:
+		decision = ai.SignalDecision(req, p.cfg.Mode == config.MockModeFollow)
+		decision.Reason = "mock: " + decision.Reason
+	}
+	p.logger.Printf("decision trader=%s symbol=%s signal=%s action=%s", req.TraderName, req.Symbol, req.StrategySignal, decision.Action)
+	return decision, nil
+}
+
+// scripted 返回下一条脚本决策，用完后重复最后一条。
+func (p *Provider) scripted() ai.DecisionResponse {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	i := p.next
+	if i >= len(p.cfg.Responses) {
+		i = len(p.cfg.Responses) - 1
+	} else {
+		p.next++
+	}
+	if i < 0 {
+		return ai.DecisionResponse{Action: "wait", Reason: "mock: 没有脚本决策"}
+	}
+	r := p.cfg.Responses[i]
+	return ai.DecisionResponse{
+		Action:     r.Action,
+		Confidence: r.Confidence,
+		Reason:     r.Reason,
+		Adjustments: ai.AdjustmentPlan{
+			SizeMultiplier:    r.SizeMultiplier,
+			StopLossPercent:   r.StopLossPercent,
+			TakeProfitPercent: r.TakeProfitPercent,
+		},
+	}
+}
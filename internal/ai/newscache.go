@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// SentimentRecord 为按新闻集合哈希缓存的情绪分析结果。
+type SentimentRecord struct {
+	Hash      string                `json:"hash"`
+	Summary   news.SentimentSummary `json:"summary"`
+	CreatedAt int64                 `json:"createdAt"`
+}
+
+// SentimentRecorder 持久化情绪分析结果；storage.Store 满足该接口。
+type SentimentRecorder interface {
+	RecordSentiment(ctx context.Context, record SentimentRecord) error
+}
+
+// NewsCache 在 TTL 内复用同一组新闻的情绪分析结果，避免重启或多个交易者对相同新闻重复调用模型。
+// 本地词典等低置信结果不缓存，AI恢复后即可重新分析。
+type NewsCache struct {
+	ttl      time.Duration
+	recorder SentimentRecorder
+
+	mu      sync.Mutex
+	entries map[string]SentimentRecord
+}
+
+// NewNewsCache 创建情绪缓存，recorder 可为空（不落盘）。
+func NewNewsCache(ttl time.Duration, recorder SentimentRecorder) *NewsCache {
+	return &NewsCache{ttl: ttl, recorder: recorder, entries: make(map[string]SentimentRecord)}
+}
+
+// Seed 恢复已落盘的分析结果。
+func (c *NewsCache) Seed(records []SentimentRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rec := range records {
+		if rec.CreatedAt > c.entries[rec.Hash].CreatedAt {
+			c.entries[rec.Hash] = rec
+		}
+	}
+}
+
+// Lookup 返回 TTL 内该组新闻的分析结果。
+func (c *NewsCache) Lookup(hash string) (news.SentimentSummary, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rec, ok := c.entries[hash]
+	if !ok {
+		return news.SentimentSummary{}, false
+	}
+	if time.Since(time.UnixMilli(rec.CreatedAt)) >= c.ttl {
+		delete(c.entries, hash)
+		return news.SentimentSummary{}, false
+	}
+	return rec.Summary, true
+}
+
+// Store 缓存一次分析结果并落盘。
+func (c *NewsCache) Store(ctx context.Context, hash string, summary news.SentimentSummary) {
+	if summary.LowConfidence {
+		return
+	}
+	rec := SentimentRecord{Hash: hash, Summary: summary, CreatedAt: time.Now().UnixMilli()}
+	c.mu.Lock()
+	c.entries[hash] = rec
+	c.mu.Unlock()
+	if c.recorder != nil {
+		if err := c.recorder.RecordSentiment(ctx, rec); err != nil {
+			loggerpkg.Get("ai.news").Printf("sentiment.record.error: %v", err)
+		}
+	}
+}
+
+var defaultNewsCache atomic.Pointer[NewsCache]
+
+// SetNewsCache 设置 CachedNews 使用的全局情绪缓存，启动时按存储恢复后设置一次；nil 关闭缓存。
+func SetNewsCache(c *NewsCache) {
+	defaultNewsCache.Store(c)
+}
+
+// CachedNews 让 provider 的新闻分析先查全局情绪缓存（未设置时直接调用）；决策请求不受影响。
+func CachedNews(provider Provider) Provider {
+	return &newsCachedProvider{provider: provider}
+}
+
+type newsCachedProvider struct {
+	provider Provider
+}
+
+func (p *newsCachedProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	cache := defaultNewsCache.Load()
+	if cache == nil || len(articles) == 0 {
+		return p.provider.AnalyzeNews(ctx, articles)
+	}
+	hash := news.SetHash(articles)
+	if summary, ok := cache.Lookup(hash); ok {
+		loggerpkg.Get("ai.news").Printf("sentiment.cache.hit articles=%d sentiment=%s", len(articles), summary.Sentiment)
+		return summary, nil
+	}
+	summary, err := p.provider.AnalyzeNews(ctx, articles)
+	if err != nil {
+		return summary, err
+	}
+	cache.Store(ctx, hash, summary)
+	return summary, nil
+}
+
+func (p *newsCachedProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	return p.provider.GenerateDecision(ctx, req)
+}
@@ -0,0 +1,81 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+// NewsFallbackOptions 控制新闻情绪的本地词典兜底。
+type NewsFallbackOptions struct {
+	// Lexicon 补充内置词典。
+	Lexicon map[string]float64
+	// Timeout 大于 0 时，模型分析超过该时长即改用词典评分，不阻塞交易周期。
+	Timeout time.Duration
+	// LexiconOnly 为 true 时不调用模型，始终使用词典评分（news.sentiment=lexicon）。
+	LexiconOnly bool
+}
+
+// WithNewsFallback 在 provider 的新闻分析失败（接口故障、预算用尽等）或超时时改用本地词典评分，
+// 结果标记为低置信，新闻面板与提示词不会因此缺少情绪信息，情绪分析也不会拖住交易周期。
+func WithNewsFallback(provider Provider, opts NewsFallbackOptions) Provider {
+	return &newsFallbackProvider{provider: provider, opts: opts}
+}
+
+type newsFallbackProvider struct {
+	provider Provider
+	opts     NewsFallbackOptions
+}
+
+func (p *newsFallbackProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if p.opts.LexiconOnly {
+		return news.ScoreLexicon(articles, p.opts.Lexicon), nil
+	}
+	summary, err := p.analyze(ctx, articles)
+	if err == nil {
+		return summary, nil
+	}
+	summary = news.ScoreLexicon(articles, p.opts.Lexicon)
+	loggerpkg.Get("ai.news").Printf("news.fallback source=%s articles=%d sentiment=%s score=%.2f err=%v",
+		news.LexiconSource, len(articles), summary.Sentiment, summary.Score, err)
+	return summary, nil
+}
+
+// errNewsTimeout 表示模型分析新闻超过 Timeout。
+var errNewsTimeout = errors.New("news analysis timed out")
+
+// analyze 调用模型分析新闻，最多等待 Timeout；超时后不再等待未返回的调用，
+// 即使提供商没有遵守 ctx 取消也不会阻塞。
+func (p *newsFallbackProvider) analyze(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if p.opts.Timeout <= 0 {
+		return p.provider.AnalyzeNews(ctx, articles)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	type result struct {
+		summary news.SentimentSummary
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		summary, err := p.provider.AnalyzeNews(ctx, articles)
+		done <- result{summary, err}
+	}()
+	select {
+	case r := <-done:
+		return r.summary, r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return news.SentimentSummary{}, errNewsTimeout
+		}
+		return news.SentimentSummary{}, ctx.Err()
+	}
+}
+
+func (p *newsFallbackProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	return p.provider.GenerateDecision(ctx, req)
+}
@@ -0,0 +1,242 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+)
+
+const chatEndpoint = "/api/chat"
+
+// Client 封装本地 Ollama 服务的 /api/chat 接口，账户与行情数据不离开本机。
+type Client struct {
+	httpClient *http.Client
+	cfg        config.OllamaConfig
+	logger     *loggerpkg.ModuleLogger
+}
+
+var _ ai.Provider = (*Client)(nil)
+
+// New 创建 Ollama 客户端，未启用时返回 nil。
+func New(cfg config.OllamaConfig) *Client {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Host == "" {
+		cfg.Host = "http://localhost:11434"
+	}
+	cfg.Host = strings.TrimRight(cfg.Host, "/")
+	if cfg.Model == "" {
+		cfg.Model = "llama3.1"
+	}
+	if cfg.TimeoutSeconds <= 0 {
+		// 本地模型首次加载与推理都较慢
+		cfg.TimeoutSeconds = 180
+	}
+	moduleLogger := loggerpkg.Get("ai.ollama")
+	moduleLogger.Printf("initialized ollama client model=%s host=%s keepAlive=%s", cfg.Model, cfg.Host, cfg.KeepAlive)
+	return &Client{
+		httpClient: &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		cfg:        cfg,
+		logger:     moduleLogger,
+	}
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type options struct {
+	Temperature float64 `json:"temperature,omitempty"`
+	TopP        float64 `json:"top_p,omitempty"`
+	NumCtx      int     `json:"num_ctx,omitempty"`
+}
+
+type requestBody struct {
+	Model     string    `json:"model"`
+	Messages  []message `json:"messages"`
+	Stream    bool      `json:"stream"`
+	Format    any       `json:"format"`
+	KeepAlive string    `json:"keep_alive,omitempty"`
+	Options   options   `json:"options"`
+}
+
+type responseBody struct {
+	Message         message `json:"message"`
+	Done            bool    `json:"done"`
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+	TotalDuration   int64   `json:"total_duration"`
+	Error           string  `json:"error"`
+}
+
+func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if c == nil {
+		return news.SentimentSummary{}, errors.New("ollama client is nil")
+	}
+	if len(articles) == 0 {
+		return news.SentimentSummary{Sentiment: "neutral"}, nil
+	}
+
+	payload, _ := json.Marshal(articles)
+	msgs := []message{
+		{Role: "system", Content: "你是一名资深的加密货币市场分析师，只输出JSON。"},
+		{Role: "user", Content: fmt.Sprintf("请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[], \"symbols\":{\"BTC\":number(-1~1)}}。symbols 为新闻直接涉及的币种情绪分，-1 利空、1 利好，未涉及的币种不要列出。\n```json\n%s\n```", string(payload))},
+	}
+	c.logger.Printf("news.request count=%d", len(articles))
+
+	content, used, err := c.send(ctx, msgs, "json")
+	if err != nil {
+		c.logger.Printf("news.error: %v", err)
+		return news.SentimentSummary{}, err
+	}
+	used.Kind = ai.UsageKindNews
+	ai.RecordUsage(ctx, used)
+	summary := news.SentimentSummary{}
+	if err := json.Unmarshal([]byte(cleanJSON(content)), &summary); err != nil {
+		c.logger.Printf("news.parse.error: %v content=%s", err, content)
+		return news.SentimentSummary{}, fmt.Errorf("parse news sentiment: %w", err)
+	}
+	if summary.Sentiment == "" {
+		summary.Sentiment = "neutral"
+	}
+	return summary, nil
+}
+
+// 决策提示词模板；改动后 promptVersion 随之变化，决策记录据此区分提示词版本。
+const (
+	decisionSystemPrompt = "你是一名自动加密货币交易顾问，请严格遵守风控并只输出JSON"
+	decisionUserTemplate = "交易上下文如下:\n```json\n%s\n```\n请输出JSON {\"action\":string, \"confidence\":number(0-1), \"reason\":string, \"adjustments\":{\"sizeMultiplier\":number, \"targetLeverage\":number, \"stopLossPercent\":number, \"takeProfitPercent\":number, \"trailingStopPercent\":number}, \"riskNotes\":[string]}。"
+)
+
+var promptVersion = ai.PromptVersion("ollama", decisionSystemPrompt, decisionUserTemplate)
+
+// renderDecision 渲染决策用户提示，上下文压缩据此估算长度。
+func renderDecision(req ai.DecisionRequest) string {
+	payload, _ := json.Marshal(req)
+	return fmt.Sprintf(decisionUserTemplate, string(payload))
+}
+
+func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("ollama client is nil")
+	}
+
+	req, err := ai.PromptFit{Provider: "ollama", Model: c.cfg.Model, Window: c.cfg.NumCtx, System: decisionSystemPrompt}.Fit(req, renderDecision)
+	if err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	payload, _ := json.Marshal(req)
+	c.logger.Printf("decision.request symbol=%s signal=%s bytes=%d", req.Symbol, req.StrategySignal, len(payload))
+	return c.decide(ctx, fmt.Sprintf(decisionUserTemplate, string(payload)), req.TraderName)
+}
+
+// ReplayDecision 以当前决策模板的系统提示重发一条历史用户提示。
+func (c *Client) ReplayDecision(ctx context.Context, userPrompt string) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("ollama client is nil")
+	}
+	return c.decide(ctx, userPrompt, ai.ReplayTrader)
+}
+
+func (c *Client) decide(ctx context.Context, user, trader string) (ai.DecisionResponse, error) {
+	msgs := []message{
+		{Role: "system", Content: decisionSystemPrompt},
+		{Role: "user", Content: user},
+	}
+
+	// format 传入决策 schema 时由 Ollama 约束解码，输出必然符合结构
+	var format any = ai.DecisionParameters()
+	if c.cfg.PlainOutput {
+		format = "json"
+	}
+	content, used, err := c.send(ctx, msgs, format)
+	if err != nil {
+		c.logger.Printf("decision.error: %v", err)
+		return ai.DecisionResponse{}, err
+	}
+	used.Trader, used.Kind = trader, ai.UsageKindDecision
+	decision := ai.DecisionResponse{RawContent: content, Usage: ai.RecordUsage(ctx, used), PromptVersion: promptVersion}
+	if err := json.Unmarshal([]byte(cleanJSON(content)), &decision); err != nil {
+		c.logger.Printf("decision.parse.error: %v content=%s", err, content)
+		return ai.DecisionResponse{}, fmt.Errorf("parse decision: %w", err)
+	}
+	if err := ai.ValidateDecisionJSON([]byte(cleanJSON(content))); err != nil {
+		c.logger.Printf("decision.schema.error: %v content=%s", err, content)
+		return ai.DecisionResponse{}, fmt.Errorf("decision schema: %w", err)
+	}
+	c.logger.Printf("decision.response action=%s confidence=%.2f", decision.Action, decision.Confidence)
+	return decision, nil
+}
+
+// send 发起一次非流式对话请求，返回正文及本次调用的 token 用量（未计费）。
+func (c *Client) send(ctx context.Context, messages []message, format any) (string, ai.Usage, error) {
+	body := requestBody{
+		Model:     c.cfg.Model,
+		Messages:  messages,
+		Format:    format,
+		KeepAlive: c.cfg.KeepAlive,
+		Options: options{
+			Temperature: c.cfg.Temperature,
+			TopP:        c.cfg.TopP,
+			NumCtx:      c.cfg.NumCtx,
+		},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", ai.Usage{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Host+chatEndpoint, bytes.NewReader(data))
+	if err != nil {
+		return "", ai.Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", ai.Usage{}, fmt.Errorf("ollama request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload responseBody
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", ai.Usage{}, fmt.Errorf("ollama status %d: decode response: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode >= 400 || payload.Error != "" {
+		c.logger.Printf("http.error status=%d error=%s", resp.StatusCode, payload.Error)
+		// 最常见的原因是模型尚未 ollama pull
+		return "", ai.Usage{}, fmt.Errorf("ollama status %d: %s", resp.StatusCode, payload.Error)
+	}
+	c.logger.Printf("http.response model=%s promptTokens=%d outputTokens=%d elapsed=%s",
+		c.cfg.Model, payload.PromptEvalCount, payload.EvalCount, time.Since(start).Round(time.Millisecond))
+	if strings.TrimSpace(payload.Message.Content) == "" {
+		return "", ai.Usage{}, errors.New("ollama返回内容为空")
+	}
+	used := ai.Usage{Provider: "ollama", Model: c.cfg.Model, PromptTokens: payload.PromptEvalCount, CompletionTokens: payload.EvalCount}
+	return payload.Message.Content, used, nil
+}
+
+// cleanJSON 去掉推理模型的 <think> 段落与 Markdown 代码块包裹。
+func cleanJSON(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if idx := strings.LastIndex(trimmed, "</think>"); idx != -1 {
+		trimmed = strings.TrimSpace(trimmed[idx+len("</think>"):])
+	}
+	trimmed = strings.TrimPrefix(trimmed, "```json")
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	trimmed = strings.TrimSuffix(trimmed, "```")
+	return strings.TrimSpace(trimmed)
+}
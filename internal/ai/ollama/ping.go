@@ -0,0 +1,46 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"autobot/internal/ai"
+)
+
+const tagsEndpoint = "/api/tags"
+
+var _ ai.Pinger = (*Client)(nil)
+
+// Ping 查询本地模型列表，确认服务可达且已拉取配置的模型。
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Host+tagsEndpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ollama tags status %d", resp.StatusCode)
+	}
+	var payload struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return fmt.Errorf("decode ollama tags: %w", err)
+	}
+	for _, model := range payload.Models {
+		// 未带标签的模型名默认对应 :latest
+		if model.Name == c.cfg.Model || strings.TrimSuffix(model.Name, ":latest") == c.cfg.Model {
+			return nil
+		}
+	}
+	return fmt.Errorf("ollama model %s not pulled", c.cfg.Model)
+}
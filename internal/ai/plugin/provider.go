@@ -0,0 +1,154 @@
+// Package plugin 以子进程方式接入仓库外的AI提供商（exec provider）。
+//
+// 协议为标准输入/输出上逐行传输的 JSON-RPC 2.0，每行一个对象：
+//
+//	→ {"jsonrpc":"2.0","id":1,"method":"generateDecision","params":<ai.DecisionRequest>}
+//	← {"jsonrpc":"2.0","id":1,"result":<ai.DecisionResponse>}
+//	→ {"jsonrpc":"2.0","id":2,"method":"analyzeNews","params":{"articles":[<news.Article>...]}}
+//	← {"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"..."}}
+//
+// 插件进程在首次调用时启动并常驻，退出或超时后下一次调用自动重启；插件的 stderr 写入日志。
+// 请求与响应的字段定义见 schema/ 目录下的 JSON Schema。
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+	"autobot/internal/subproc"
+)
+
+// 协议方法名。
+const (
+	MethodGenerateDecision = "generateDecision"
+	MethodAnalyzeNews      = "analyzeNews"
+)
+
+// Provider 通过子进程实现 ai.Provider。同一时刻只有一个请求在途，调用方无需额外加锁。
+type Provider struct {
+	name    string
+	process *subproc.Process
+	logger  *loggerpkg.ModuleLogger
+
+	mu     sync.Mutex
+	nextID int64
+}
+
+var _ ai.Provider = (*Provider)(nil)
+
+// New 创建插件提供商，进程延迟到首次调用时启动。
+func New(name string, cfg config.PluginConfig) *Provider {
+	logger := loggerpkg.Get("ai.plugin." + name)
+	return &Provider{
+		name: name,
+		process: &subproc.Process{
+			Command: cfg.Command,
+			Args:    cfg.Args,
+			Env:     cfg.Env,
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+			Logger:  logger,
+		},
+		logger: logger,
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type newsParams struct {
+	Articles []news.Article `json:"articles"`
+}
+
+// GenerateDecision 调用插件的 generateDecision 方法，返回结果按决策 schema 校验。
+func (p *Provider) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	raw, err := p.call(ctx, MethodGenerateDecision, req)
+	if err != nil {
+		return ai.DecisionResponse{}, err
+	}
+	if err := ai.ValidateDecisionJSON(raw); err != nil {
+		p.logger.Printf("decision.schema.error: %v content=%s", err, raw)
+		return ai.DecisionResponse{}, fmt.Errorf("plugin %s decision schema: %w", p.name, err)
+	}
+	decision := ai.DecisionResponse{RawContent: string(raw)}
+	if err := json.Unmarshal(raw, &decision); err != nil {
+		return ai.DecisionResponse{}, fmt.Errorf("plugin %s parse decision: %w", p.name, err)
+	}
+	decision.Usage = p.recordUsage(ctx, req.TraderName, ai.UsageKindDecision)
+	// 插件的提示词在进程外维护，按插件名区分版本
+	decision.PromptVersion = "plugin/" + p.name
+	p.logger.Printf("decision.response action=%s confidence=%.2f", decision.Action, decision.Confidence)
+	return decision, nil
+}
+
+// AnalyzeNews 调用插件的 analyzeNews 方法。
+func (p *Provider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	if len(articles) == 0 {
+		return news.SentimentSummary{Sentiment: "neutral"}, nil
+	}
+	raw, err := p.call(ctx, MethodAnalyzeNews, newsParams{Articles: articles})
+	if err != nil {
+		return news.SentimentSummary{}, err
+	}
+	var summary news.SentimentSummary
+	if err := json.Unmarshal(raw, &summary); err != nil {
+		return news.SentimentSummary{}, fmt.Errorf("plugin %s parse news: %w", p.name, err)
+	}
+	p.recordUsage(ctx, "", ai.UsageKindNews)
+	return summary, nil
+}
+
+// recordUsage 记录一次插件调用。协议不含 token 数，只计入调用次数（aiBudget.maxCallsPerHour）。
+func (p *Provider) recordUsage(ctx context.Context, trader, kind string) ai.Usage {
+	return ai.RecordUsage(ctx, ai.Usage{Provider: p.name, Trader: trader, Kind: kind})
+}
+
+// Close 结束插件进程。
+func (p *Provider) Close() error {
+	return p.process.Close()
+}
+
+func (p *Provider) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	line, err := p.process.Call(ctx, rpcRequest{JSONRPC: "2.0", ID: p.nextID, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s %s: %w", p.name, method, err)
+	}
+	var resp rpcResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		p.process.Reset()
+		return nil, fmt.Errorf("plugin %s invalid response: %w", p.name, err)
+	}
+	if resp.ID != p.nextID {
+		p.process.Reset()
+		return nil, fmt.Errorf("plugin %s response id %d, want %d", p.name, resp.ID, p.nextID)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("plugin %s: %s (code %d)", p.name, resp.Error.Message, resp.Error.Code)
+	}
+	return resp.Result, nil
+}
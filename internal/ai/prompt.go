@@ -0,0 +1,15 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// PromptVersion 返回提示词模板的版本标记 name@hash，hash 为模板文本 sha256 的前8位。
+// 各提供商用固定输入渲染模板后计算，模板改动时标记随之变化；决策记录据此按提示词版本
+// 统计胜率与夏普，做提示词 A/B 对比。
+func PromptVersion(name string, templates ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(templates, "\x00")))
+	return name + "@" + hex.EncodeToString(sum[:4])
+}
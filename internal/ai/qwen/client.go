@@ -56,11 +56,29 @@ type message struct {
 	Content string `json:"content"`
 }
 
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
 type requestBody struct {
-	Model       string    `json:"model"`
-	Messages    []message `json:"messages"`
-	Temperature float64   `json:"temperature"`
-	TopP        float64   `json:"top_p"`
+	Model          string          `json:"model"`
+	Messages       []message       `json:"messages"`
+	Temperature    float64         `json:"temperature"`
+	TopP           float64         `json:"top_p"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+}
+
+// streamOptions 要求流式响应在最后一个分片中附带用量。
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// usage 为兼容模式响应中的 token 用量。
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
 }
 
 type responseBody struct {
@@ -68,6 +86,7 @@ type responseBody struct {
 		Message message `json:"message"`
 	} `json:"choices"`
 	OutputText string `json:"output_text"`
+	Usage      usage  `json:"usage"`
 	Error      *struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
@@ -84,7 +103,7 @@ func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news
 
 	payload := map[string]any{
 		"task":         "crypto_news_sentiment",
-		"instructions": "请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[]}。",
+		"instructions": "请分析以下加密货币新闻，输出JSON {\"sentiment\":string, \"score\":number(0-1), \"highlights\":[], \"riskFactors\":[], \"symbols\":{\"BTC\":number(-1~1)}}。symbols 为新闻直接涉及的币种情绪分，-1 利空、1 利好，未涉及的币种不要列出。",
 		"articles":     articles,
 	}
 	body, _ := json.Marshal(payload)
@@ -120,6 +139,7 @@ func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news
 		}
 		return news.SentimentSummary{}, err
 	}
+	c.recordUsage(ctx, resp, "", ai.UsageKindNews)
 
 	content := cleanJSON(resp.Content)
 	summary := news.SentimentSummary{}
@@ -149,19 +169,49 @@ func (c *Client) AnalyzeNews(ctx context.Context, articles []news.Article) (news
 	return summary, nil
 }
 
+// 决策提示词模板；改动后 promptVersion 随之变化，决策记录据此区分提示词版本。
+const (
+	decisionSystemPrompt = "你是一名自动加密货币交易顾问，请严格遵守风控并输出JSON"
+	decisionUserTemplate = "交易上下文如下:\n```json\n%s\n```\n请输出JSON {\"action\":string, \"confidence\":number(0-1), \"reason\":string, \"adjustments\":{\"sizeMultiplier\":number, \"targetLeverage\":number, \"stopLossPercent\":number, \"takeProfitPercent\":number, \"trailingStopPercent\":number}, \"riskNotes\":[string]}。"
+)
+
+var promptVersion = ai.PromptVersion("qwen", decisionSystemPrompt, decisionUserTemplate)
+
+// renderDecision 渲染决策用户提示，上下文压缩据此估算长度。
+func renderDecision(req ai.DecisionRequest) string {
+	payload, _ := json.Marshal(req)
+	return fmt.Sprintf(decisionUserTemplate, string(payload))
+}
+
 func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
 	if c == nil {
 		return ai.DecisionResponse{}, errors.New("qwen client is nil")
 	}
 
-	payload, _ := json.Marshal(req)
-	msgs := []message{
-		{Role: "system", Content: "你是一名自动加密货币交易顾问，请严格遵守风控并输出JSON"},
-		{Role: "user", Content: fmt.Sprintf("交易上下文如下:\n```json\n%s\n```\n请输出JSON {\"action\":string, \"confidence\":number(0-1), \"reason\":string, \"adjustments\":{\"sizeMultiplier\":number, \"targetLeverage\":number, \"stopLossPercent\":number, \"takeProfitPercent\":number, \"trailingStopPercent\":number}, \"riskNotes\":[string]}。", string(payload))},
+	req, err := ai.PromptFit{Provider: "qwen", Model: c.cfg.Model, Window: c.cfg.ContextWindow, System: decisionSystemPrompt}.Fit(req, renderDecision)
+	if err != nil {
+		return ai.DecisionResponse{}, err
 	}
+	payload, _ := json.Marshal(req)
 	if c.logger != nil {
 		c.logger.Printf("decision.request payload=%s", string(payload))
 	}
+	return c.decide(ctx, fmt.Sprintf(decisionUserTemplate, string(payload)), req.TraderName)
+}
+
+// ReplayDecision 以当前决策模板的系统提示重发一条历史用户提示。
+func (c *Client) ReplayDecision(ctx context.Context, userPrompt string) (ai.DecisionResponse, error) {
+	if c == nil {
+		return ai.DecisionResponse{}, errors.New("qwen client is nil")
+	}
+	return c.decide(ctx, userPrompt, ai.ReplayTrader)
+}
+
+func (c *Client) decide(ctx context.Context, user, trader string) (ai.DecisionResponse, error) {
+	msgs := []message{
+		{Role: "system", Content: decisionSystemPrompt},
+		{Role: "user", Content: user},
+	}
 
 	resp, err := c.send(ctx, msgs)
 	if err != nil {
@@ -170,13 +220,17 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 		}
 		return ai.DecisionResponse{}, err
 	}
+	used := c.recordUsage(ctx, resp, trader, ai.UsageKindDecision)
 
 	content := cleanJSON(resp.Content)
-	decision := ai.DecisionResponse{}
+	decision := ai.DecisionResponse{Usage: used, PromptVersion: promptVersion}
 	if err := json.Unmarshal([]byte(content), &decision); err != nil {
 		if resp.Content == "" && resp.Output != "" {
 			cleaned := cleanJSON(resp.Output)
 			if err := json.Unmarshal([]byte(cleaned), &decision); err == nil {
+				if err := ai.ValidateDecisionJSON([]byte(cleaned)); err != nil {
+					return ai.DecisionResponse{}, fmt.Errorf("decision schema: %w", err)
+				}
 				if c.logger != nil {
 					c.logger.Printf("decision.response payload=%s", cleaned)
 				}
@@ -188,6 +242,12 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 		}
 		return ai.DecisionResponse{}, fmt.Errorf("parse decision: %w", err)
 	}
+	if err := ai.ValidateDecisionJSON([]byte(content)); err != nil {
+		if c.logger != nil {
+			c.logger.Printf("decision.schema.error: %v content=%s", err, content)
+		}
+		return ai.DecisionResponse{}, fmt.Errorf("decision schema: %w", err)
+	}
 	if c.logger != nil {
 		if data, err := json.Marshal(decision); err == nil {
 			c.logger.Printf("decision.response payload=%s", string(data))
@@ -200,6 +260,19 @@ func (c *Client) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (
 type completion struct {
 	Content string
 	Output  string
+	Usage   usage
+}
+
+// recordUsage 按全局计费器记录一次调用的 token 用量。
+func (c *Client) recordUsage(ctx context.Context, resp completion, trader, kind string) ai.Usage {
+	return ai.RecordUsage(ctx, ai.Usage{
+		Provider:         "qwen",
+		Model:            c.cfg.Model,
+		Trader:           trader,
+		Kind:             kind,
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+	})
 }
 
 func (c *Client) send(ctx context.Context, messages []message) (completion, error) {
@@ -209,6 +282,15 @@ func (c *Client) send(ctx context.Context, messages []message) (completion, erro
 		Temperature: c.cfg.Temperature,
 		TopP:        c.cfg.TopP,
 	}
+	if !c.cfg.PlainOutput {
+		// JSON 模式下模型只输出一个JSON对象，不再夹带 Markdown 代码块；提示词中需含 "JSON" 字样
+		body.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+	if onLine := ai.StreamFrom(ctx); onLine != nil && c.cfg.Stream {
+		body.Stream = true
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
+		return c.sendStream(ctx, body, onLine)
+	}
 
 	data, err := json.Marshal(body)
 	if err != nil {
@@ -257,7 +339,7 @@ func (c *Client) send(ctx context.Context, messages []message) (completion, erro
 		return completion{}, errors.New("qwen无返回结果")
 	}
 
-	result := completion{Content: payload.Choices[0].Message.Content, Output: payload.OutputText}
+	result := completion{Content: payload.Choices[0].Message.Content, Output: payload.OutputText, Usage: payload.Usage}
 	if c.logger != nil {
 		c.logger.Printf("http.response choices=%d", len(payload.Choices))
 	}
@@ -0,0 +1,23 @@
+package qwen
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"autobot/internal/ai"
+)
+
+// modelsEndpoint 为 DashScope OpenAI 兼容模式的模型列表接口。
+const modelsEndpoint = "/compatible-mode/v1/models"
+
+var _ ai.Pinger = (*Client)(nil)
+
+// Ping 请求模型列表接口校验密钥与连通性，不产生 token 费用。
+func (c *Client) Ping(ctx context.Context) error {
+	if c.apiKey == "" {
+		return errors.New("qwen api key is empty")
+	}
+	return ai.ProbeHTTP(ctx, c.httpClient, strings.TrimRight(c.cfg.BaseURL, "/")+modelsEndpoint,
+		map[string]string{"Authorization": "Bearer " + c.apiKey})
+}
@@ -0,0 +1,101 @@
+package qwen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"autobot/internal/ai"
+	"autobot/internal/mcp"
+)
+
+// streamChunk 为兼容模式流式响应的单个增量，reasoning_content 为 qwen3 思考模式的推理过程。
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content          string `json:"content"`
+			ReasoningContent string `json:"reasoning_content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *usage `json:"usage"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// sendStream 以 SSE 发送请求，推理过程与正文逐行回调 onLine，返回拼接后的完整正文。
+func (c *Client) sendStream(ctx context.Context, body requestBody, onLine func(string)) (completion, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return completion{}, err
+	}
+	if c.logger != nil {
+		c.logger.Printf("http.stream model=%s messages=%d", c.cfg.Model, len(body.Messages))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+defaultEndpoint, bytes.NewReader(data))
+	if err != nil {
+		return completion{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return completion{}, fmt.Errorf("qwen request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		if c.logger != nil {
+			c.logger.Printf("http.error status=%d", resp.StatusCode)
+		}
+		return completion{}, fmt.Errorf("qwen status %d", resp.StatusCode)
+	}
+
+	var content strings.Builder
+	var used usage
+	lines := ai.NewLineSplitter(onLine)
+	reasoning := false
+	err = mcp.ReadSSE(resp.Body, func(data []byte) error {
+		var chunk streamChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return fmt.Errorf("decode stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return errors.New(chunk.Error.Message)
+		}
+		if chunk.Usage != nil {
+			used = *chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.ReasoningContent != "" {
+				reasoning = true
+				lines.Write(choice.Delta.ReasoningContent)
+			}
+			if choice.Delta.Content != "" && reasoning {
+				// 推理结束，另起一行输出正文
+				reasoning = false
+				lines.Flush()
+			}
+			lines.Write(choice.Delta.Content)
+			content.WriteString(choice.Delta.Content)
+		}
+		return nil
+	})
+	lines.Flush()
+	if err != nil {
+		return completion{}, fmt.Errorf("qwen stream: %w", err)
+	}
+	if content.Len() == 0 {
+		return completion{}, errors.New("qwen无返回结果")
+	}
+	if c.logger != nil {
+		c.logger.Printf("http.stream.done chars=%d", content.Len())
+	}
+	return completion{Content: content.String(), Usage: used}, nil
+}
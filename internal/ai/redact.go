@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"math"
+)
+
+// RedactAmounts 返回不含账户绝对金额的请求副本：以账户净值为 100，余额、可用资金、未实现/已实现盈亏、
+// 保证金与单笔名义上限换算为占净值的百分比，持仓数量换算为名义价值占净值的百分比，并设置
+// AmountsInPercent；候选币种的安全下单额同样换算。价格、杠杆与比率类字段不变。净值未知时金额全部置 0，持仓数量保留方向记为 ±1。
+func RedactAmounts(req DecisionRequest) DecisionRequest {
+	equity := req.Context.Account.TotalEquity
+	if equity <= 0 {
+		equity = req.AccountBalance
+	}
+	pct := func(v float64) float64 {
+		if equity <= 0 {
+			return 0
+		}
+		return math.Round(v/equity*10000) / 100
+	}
+	size := func(quantity, price float64) float64 {
+		if equity <= 0 || price <= 0 {
+			if quantity == 0 {
+				return 0
+			}
+			return math.Copysign(1, quantity)
+		}
+		return pct(quantity * price)
+	}
+
+	req.AmountsInPercent = true
+	req.AccountBalance = pct(req.AccountBalance)
+	req.AvailableBalance = pct(req.AvailableBalance)
+	req.UnrealizedPNL = pct(req.UnrealizedPNL)
+	req.RiskLimits.MaxPositionNotionalUSD = pct(req.RiskLimits.MaxPositionNotionalUSD)
+	if len(req.Positions) > 0 {
+		positions := make([]PositionSnapshot, len(req.Positions))
+		for i, pos := range req.Positions {
+			pos.Quantity = size(pos.Quantity, pos.EntryPrice)
+			pos.UnrealizedPNL = pct(pos.UnrealizedPNL)
+			positions[i] = pos
+		}
+		req.Positions = positions
+	}
+
+	account := &req.Context.Account
+	account.TotalEquity = pct(account.TotalEquity)
+	account.Available = pct(account.Available)
+	account.UnrealizedPNL = pct(account.UnrealizedPNL)
+	account.DailyRealized = pct(account.DailyRealized)
+	req.Context.InitialEquity = pct(req.Context.InitialEquity)
+	if len(req.Context.CandidateCoins) > 0 {
+		coins := make([]CandidateContext, len(req.Context.CandidateCoins))
+		for i, coin := range req.Context.CandidateCoins {
+			coin.MaxSafeNotionalUSD = pct(coin.MaxSafeNotionalUSD)
+			coins[i] = coin
+		}
+		req.Context.CandidateCoins = coins
+	}
+	if len(req.Context.Positions) > 0 {
+		positions := make([]PositionContext, len(req.Context.Positions))
+		for i, pos := range req.Context.Positions {
+			price := pos.MarkPrice
+			if price <= 0 {
+				price = pos.EntryPrice
+			}
+			pos.Quantity = size(pos.Quantity, price)
+			pos.UnrealizedPNL = pct(pos.UnrealizedPNL)
+			pos.MarginUsed = pct(pos.MarginUsed)
+			positions[i] = pos
+		}
+		req.Context.Positions = positions
+	}
+	return req
+}
+
+// WithRedaction 在把决策请求交给 provider 前调用 RedactAmounts，账户净值、余额与持仓规模不会
+// 出现在发往第三方模型的提示词中。新闻分析不含账户信息，原样转发。
+func WithRedaction(provider Provider) Provider {
+	return &redactingProvider{Provider: provider}
+}
+
+type redactingProvider struct {
+	Provider
+}
+
+func (p *redactingProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	return p.Provider.GenerateDecision(ctx, RedactAmounts(req))
+}
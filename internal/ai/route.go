@@ -0,0 +1,23 @@
+package ai
+
+import (
+	"context"
+
+	"autobot/internal/news"
+)
+
+// Route 把新闻分析与决策分派给不同的提供商，例如新闻用便宜的小模型、决策用强模型。
+type Route struct {
+	News      Provider
+	Decisions Provider
+}
+
+var _ Provider = Route{}
+
+func (r Route) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	return r.News.AnalyzeNews(ctx, articles)
+}
+
+func (r Route) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	return r.Decisions.GenerateDecision(ctx, req)
+}
@@ -0,0 +1,271 @@
+package ai
+
+//go:generate go run ../../cmd/schemagen -out ../../schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SchemaVersion 为决策请求/响应契约的版本号。字段含义变化或新增必填字段时递增，
+// 导出的 schema 文件名与 $id 均带此版本。
+const SchemaVersion = 1
+
+// DecisionActions 为 DecisionResponse.Action 的合法取值。
+var DecisionActions = []string{
+	"open_long", "open_short", "increase_long", "increase_short",
+	"close", "exit", "reduce", "hold", "wait",
+}
+
+// decisionRequired 为模型输出中必须出现的字段；adjustments 与 riskNotes 可省略。
+var decisionRequired = []string{"action", "confidence", "reason"}
+
+// DecisionRequestSchema 返回 DecisionRequest 的 JSON Schema。
+func DecisionRequestSchema() map[string]any {
+	schema := schemaFor(reflect.TypeOf(DecisionRequest{}), map[reflect.Type]bool{})
+	return withHeader(schema, "decision-request", "AI决策请求上下文")
+}
+
+// DecisionResponseSchema 返回 DecisionResponse 的 JSON Schema，即提示词要求模型输出的格式。
+func DecisionResponseSchema() map[string]any {
+	schema := schemaFor(reflect.TypeOf(DecisionResponse{}), map[reflect.Type]bool{})
+	props := schema["properties"].(map[string]any)
+	props["action"] = map[string]any{"type": "string", "enum": stringsToAny(DecisionActions)}
+	props["confidence"] = map[string]any{"type": "number", "minimum": 0, "maximum": 1}
+	// 模型输出中的空值与缺省的调整项均按零值处理（即不调整）
+	adjustments := nullable(props["adjustments"].(map[string]any))
+	delete(adjustments, "required")
+	props["adjustments"] = adjustments
+	props["riskNotes"] = nullable(props["riskNotes"].(map[string]any))
+	schema["required"] = stringsToAny(decisionRequired)
+	return withHeader(schema, "decision-response", "AI决策响应")
+}
+
+// DecisionToolName 为原生结构化输出（函数调用/工具调用）中提交决策的工具名。
+const DecisionToolName = "submit_decision"
+
+// DecisionToolDescription 为提交决策工具的说明。
+const DecisionToolDescription = "提交本轮交易决策，字段含义与系统提示词中的决策必备字段一致。"
+
+// DecisionParameters 返回不带 $schema/$id 等元数据的响应 schema，
+// 直接用作函数调用的 parameters 或 response_format 中的 schema。
+func DecisionParameters() map[string]any {
+	schema := DecisionResponseSchema()
+	for _, key := range []string{"$schema", "$id", "title", "version"} {
+		delete(schema, key)
+	}
+	return schema
+}
+
+func withHeader(schema map[string]any, name, title string) map[string]any {
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = fmt.Sprintf("autobot/%s/v%d", name, SchemaVersion)
+	schema["title"] = title
+	schema["version"] = SchemaVersion
+	return schema
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaFor 按 json 标签反射生成 schema：未标 omitempty 的字段视为必填，
+// Go 序列化为 null 的切片、map 与指针允许 null。
+func schemaFor(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(schemaFor(t.Elem(), seen))
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return nullable(map[string]any{"type": "array", "items": schemaFor(t.Elem(), seen)})
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), seen)})
+	case reflect.Struct:
+		if t == timeType {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+		if seen[t] {
+			return map[string]any{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+
+		props := map[string]any{}
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			props[name] = schemaFor(field.Type, seen)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			schema["required"] = stringsToAny(required)
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+func nullable(schema map[string]any) map[string]any {
+	out := make(map[string]any, len(schema))
+	for k, v := range schema {
+		out[k] = v
+	}
+	if typ, ok := schema["type"].(string); ok {
+		out["type"] = []any{typ, "null"}
+	}
+	return out
+}
+
+func stringsToAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+var decisionSchema = DecisionResponseSchema()
+
+// ValidateDecisionJSON 按 DecisionResponseSchema 校验模型输出的原始JSON，
+// 所有提供商在解析决策前调用，错误信息给出违规字段路径。
+func ValidateDecisionJSON(content []byte) error {
+	var value any
+	if err := json.Unmarshal(content, &value); err != nil {
+		return fmt.Errorf("decision 不是合法JSON: %w", err)
+	}
+	return ValidateDecisionValue(value)
+}
+
+// ValidateDecisionValue 校验已解码的通用JSON值（map/slice/float64 等）。
+func ValidateDecisionValue(value any) error {
+	var errs []error
+	validateSchema(decisionSchema, value, "$", &errs)
+	return errors.Join(errs...)
+}
+
+// validateSchema 实现 schema 生成器用到的子集：type、enum、required、properties、
+// items、additionalProperties、minimum、maximum。
+func validateSchema(schema map[string]any, value any, path string, errs *[]error) {
+	if !matchesType(schema["type"], value) {
+		*errs = append(*errs, fmt.Errorf("%s: 类型应为 %v", path, schema["type"]))
+		return
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		// 字符串枚举不区分大小写，与 ValidateDecision 对 action 的处理一致
+		found := false
+		for _, allowed := range enum {
+			if allowed == value || (isString(value) && strings.EqualFold(fmt.Sprint(allowed), value.(string))) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*errs = append(*errs, fmt.Errorf("%s: 取值 %v 不在允许范围内", path, value))
+		}
+	}
+	switch v := value.(type) {
+	case float64:
+		if min, ok := schema["minimum"].(int); ok && v < float64(min) {
+			*errs = append(*errs, fmt.Errorf("%s: %v 小于 %d", path, v, min))
+		}
+		if max, ok := schema["maximum"].(int); ok && v > float64(max) {
+			*errs = append(*errs, fmt.Errorf("%s: %v 大于 %d", path, v, max))
+		}
+	case map[string]any:
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				if _, present := v[name.(string)]; !present {
+					*errs = append(*errs, fmt.Errorf("%s: 缺少字段 %s", path, name))
+				}
+			}
+		}
+		props, _ := schema["properties"].(map[string]any)
+		extra, _ := schema["additionalProperties"].(map[string]any)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if sub, ok := props[key].(map[string]any); ok {
+				validateSchema(sub, v[key], path+"."+key, errs)
+			} else if extra != nil {
+				validateSchema(extra, v[key], path+"."+key, errs)
+			}
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+	}
+}
+
+func isString(value any) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+func matchesType(typ any, value any) bool {
+	switch t := typ.(type) {
+	case nil:
+		return true
+	case []any:
+		for _, option := range t {
+			if matchesType(option, value) {
+				return true
+			}
+		}
+		return false
+	case string:
+		switch t {
+		case "null":
+			return value == nil
+		case "boolean":
+			_, ok := value.(bool)
+			return ok
+		case "number":
+			_, ok := value.(float64)
+			return ok
+		case "integer":
+			f, ok := value.(float64)
+			return ok && f == float64(int64(f))
+		case "string":
+			_, ok := value.(string)
+			return ok
+		case "array":
+			_, ok := value.([]any)
+			return ok
+		case "object":
+			_, ok := value.(map[string]any)
+			return ok
+		}
+	}
+	return false
+}
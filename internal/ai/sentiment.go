@@ -0,0 +1,20 @@
+package ai
+
+// ApplySymbolSentiment 把 req.NewsSentiment 中的币种情绪分写入对应的行情快照与候选币种，
+// 模型只给出整体情绪时不做改动。
+func ApplySymbolSentiment(req *DecisionRequest) {
+	if len(req.NewsSentiment.Symbols) == 0 {
+		return
+	}
+	for symbol, snapshot := range req.Context.MarketData {
+		if score, ok := req.NewsSentiment.SymbolScore(symbol); ok {
+			snapshot.NewsSentiment = score
+			req.Context.MarketData[symbol] = snapshot
+		}
+	}
+	for i := range req.Context.CandidateCoins {
+		if score, ok := req.NewsSentiment.SymbolScore(req.Context.CandidateCoins[i].Symbol); ok {
+			req.Context.CandidateCoins[i].Sentiment = score
+		}
+	}
+}
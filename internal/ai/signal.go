@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SignalDecision 把策略信号直接转换为决策：已有该交易对持仓时，出场信号或反向信号平仓、其余持有；
+// 空仓时 allowOpen 为 true 则按多空信号开仓（使用配置的止损止盈），否则等待。置信度固定为 0.5。
+func SignalDecision(req DecisionRequest, allowOpen bool) DecisionResponse {
+	side := positionSide(req)
+	signal := strings.ToLower(strings.TrimSpace(req.StrategySignal))
+	idle := "wait"
+	if side != "" {
+		idle = "hold"
+	}
+	decision := DecisionResponse{Action: idle, Confidence: 0.5}
+	switch {
+	case side != "" && (signal == "exit" || (signal == "long" || signal == "short") && signal != side):
+		decision.Action = "close"
+		decision.Reason = fmt.Sprintf("策略信号 %s 与 %s 持仓相反或要求出场，平仓", signal, side)
+	case allowOpen && side == "" && (signal == "long" || signal == "short"):
+		decision.Action = "open_" + signal
+		decision.Reason = fmt.Sprintf("按策略信号 %s 开仓，使用配置的止损止盈", signal)
+	default:
+		decision.Reason = fmt.Sprintf("策略信号 %s 不产生新操作", orNone(signal))
+	}
+	return decision
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "无"
+	}
+	return s
+}
+
+// positionSide 返回请求交易对当前持仓的方向（小写），空仓时为空。
+func positionSide(req DecisionRequest) string {
+	for _, pos := range req.Positions {
+		if strings.EqualFold(pos.Symbol, req.Symbol) && pos.Quantity != 0 {
+			return strings.ToLower(pos.Side)
+		}
+	}
+	return ""
+}
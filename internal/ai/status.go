@@ -0,0 +1,235 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"autobot/internal/news"
+)
+
+// DefaultStatsWindow 为滚动错误率的统计窗口。
+const DefaultStatsWindow = time.Hour
+
+// maxCallsPerProvider 为每个提供商在窗口内保留的调用记录上限。
+const maxCallsPerProvider = 500
+
+// 提供商的断路状态：预算用尽或演练停用时调用被直接拦截（open），探活失败时在回退链中排到最后
+// （degraded），其余为正常（closed）。
+const (
+	BreakerClosed   = "closed"
+	BreakerDegraded = "degraded"
+	BreakerOpen     = "open"
+)
+
+type callOutcome struct {
+	at      time.Time
+	latency time.Duration
+	err     string
+}
+
+// CallStats 记录各提供商真实调用（不含探活、预算与演练拦截）的延迟与成败，窗口外的记录自动丢弃。
+type CallStats struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	calls map[string][]callOutcome
+}
+
+// NewCallStats 创建统计窗口为 window 的调用统计，window 不大于 0 时取 DefaultStatsWindow。
+func NewCallStats(window time.Duration) *CallStats {
+	if window <= 0 {
+		window = DefaultStatsWindow
+	}
+	return &CallStats{window: window, calls: map[string][]callOutcome{}}
+}
+
+// Record 记录一次调用。调用方取消导致的失败不算提供商错误，不记录。
+func (s *CallStats) Record(provider string, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	outcome := callOutcome{at: time.Now(), latency: latency}
+	if err != nil {
+		outcome.err = err.Error()
+	}
+	key := healthKey(provider)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := append(s.pruneLocked(s.calls[key], outcome.at), outcome)
+	if len(calls) > maxCallsPerProvider {
+		calls = calls[len(calls)-maxCallsPerProvider:]
+	}
+	s.calls[key] = calls
+}
+
+func (s *CallStats) pruneLocked(calls []callOutcome, now time.Time) []callOutcome {
+	cutoff := now.Add(-s.window)
+	i := 0
+	for i < len(calls) && calls[i].at.Before(cutoff) {
+		i++
+	}
+	return calls[i:]
+}
+
+// ProviderCalls 为某提供商在统计窗口内的调用汇总。
+type ProviderCalls struct {
+	Provider      string
+	Calls         int
+	Errors        int
+	LastLatencyMs int64
+	LastError     string
+	LastCallAt    time.Time
+}
+
+// ErrorRate 返回窗口内失败调用的占比，没有调用时为 0。
+func (c ProviderCalls) ErrorRate() float64 {
+	if c.Calls == 0 {
+		return 0
+	}
+	return float64(c.Errors) / float64(c.Calls)
+}
+
+// Snapshot 返回各提供商窗口内的调用汇总，按名称排序。窗口内没有调用的提供商保留最近一次调用。
+func (s *CallStats) Snapshot() []ProviderCalls {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ProviderCalls, 0, len(s.calls))
+	for name, calls := range s.calls {
+		if len(calls) == 0 {
+			continue
+		}
+		last := calls[len(calls)-1]
+		summary := ProviderCalls{Provider: name, LastLatencyMs: last.latency.Milliseconds(), LastCallAt: last.at}
+		for _, call := range s.pruneLocked(calls, now) {
+			summary.Calls++
+			if call.err != "" {
+				summary.Errors++
+				summary.LastError = call.err
+			}
+		}
+		out = append(out, summary)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
+
+var defaultCallStats = NewCallStats(DefaultStatsWindow)
+
+// DefaultCallStats 返回全局调用统计，factory 创建的提供商自动记录到这里。
+func DefaultCallStats() *CallStats {
+	return defaultCallStats
+}
+
+// Observed 把 provider 每次调用的延迟与成败记录到 stats（为空时使用 DefaultCallStats）。
+func Observed(name string, provider Provider, stats *CallStats) Provider {
+	if stats == nil {
+		stats = defaultCallStats
+	}
+	return &observedProvider{name: name, provider: provider, stats: stats}
+}
+
+type observedProvider struct {
+	name     string
+	provider Provider
+	stats    *CallStats
+}
+
+func (p *observedProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	start := time.Now()
+	summary, err := p.provider.AnalyzeNews(ctx, articles)
+	p.stats.Record(p.name, time.Since(start), err)
+	return summary, err
+}
+
+func (p *observedProvider) GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error) {
+	start := time.Now()
+	decision, err := p.provider.GenerateDecision(ctx, req)
+	p.stats.Record(p.name, time.Since(start), err)
+	return decision, err
+}
+
+// ProviderStatus 为看板「AI 运行状态」面板的一行：最近一次真实调用的延迟、滚动错误率、
+// 当日（UTC）用量与断路状态。
+type ProviderStatus struct {
+	Provider      string    `json:"provider"`
+	LastLatencyMs int64     `json:"lastLatencyMs"`
+	LastCallAt    time.Time `json:"lastCallAt,omitempty"`
+	Calls         int       `json:"calls"`
+	ErrorRate     float64   `json:"errorRate"`
+	LastError     string    `json:"lastError,omitempty"`
+	Tokens        int       `json:"tokens"`
+	CostUSD       float64   `json:"costUsd"`
+	Breaker       string    `json:"breaker"`
+	BreakerReason string    `json:"breakerReason,omitempty"`
+}
+
+// ProviderStatuses 汇总全局调用统计、计费器、探活结果与演练开关（可为空），返回出现在任一来源中的
+// 提供商状态，按名称排序。
+func ProviderStatuses(chaos *ChaosSwitch) []ProviderStatus {
+	byName := map[string]*ProviderStatus{}
+	get := func(name string) *ProviderStatus {
+		key := healthKey(name)
+		status := byName[key]
+		if status == nil {
+			status = &ProviderStatus{Provider: key, Breaker: BreakerClosed}
+			byName[key] = status
+		}
+		return status
+	}
+	for _, calls := range DefaultCallStats().Snapshot() {
+		status := get(calls.Provider)
+		status.LastLatencyMs, status.LastCallAt = calls.LastLatencyMs, calls.LastCallAt
+		status.Calls, status.ErrorRate, status.LastError = calls.Calls, calls.ErrorRate(), calls.LastError
+	}
+	meter := DefaultMeter()
+	for _, spend := range meter.Today() {
+		status := get(spend.Provider)
+		status.Tokens += spend.PromptTokens + spend.CompletionTokens
+		status.CostUSD += spend.CostUSD
+	}
+	for _, health := range DefaultHealth().Statuses() {
+		status := get(health.Provider)
+		if !health.Healthy {
+			status.Breaker, status.BreakerReason = BreakerDegraded, "探活失败: "+health.Error
+		}
+	}
+
+	budget := meter.Budget()
+	out := make([]ProviderStatus, 0, len(byName))
+	for _, status := range byName {
+		if chaos != nil {
+			if drill, ok := chaos.Blocking(status.Provider); ok {
+				status.Breaker, status.BreakerReason = BreakerOpen, ChaosDrillNote+"至 "+drill.Until.Local().Format("15:04")
+			}
+		}
+		if budget != nil && status.Breaker != BreakerOpen {
+			if err := budget.Check(status.Provider, ""); err != nil {
+				status.Breaker, status.BreakerReason = BreakerOpen, err.Error()
+			}
+		}
+		out = append(out, *status)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
+
+// WatchStatus 每隔 interval 调用一次 ProviderStatuses 并交给 onUpdate，直到 ctx 结束。
+func WatchStatus(ctx context.Context, interval time.Duration, chaos *ChaosSwitch, onUpdate func([]ProviderStatus)) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		onUpdate(ProviderStatuses(chaos))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package ai
+
+import (
+	"context"
+	"strings"
+)
+
+type streamKey struct{}
+
+// WithStream 返回携带流式输出回调的 context。支持流式的提供商在生成过程中把模型正文
+// （含思维链）按整行回调 fn，调用方可据此实时刷新看板，而不必等待完整响应。
+func WithStream(ctx context.Context, fn func(line string)) context.Context {
+	if fn == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, streamKey{}, fn)
+}
+
+// StreamFrom 取出 WithStream 设置的回调，未设置时返回 nil。
+func StreamFrom(ctx context.Context) func(line string) {
+	if ctx == nil {
+		return nil
+	}
+	fn, _ := ctx.Value(streamKey{}).(func(line string))
+	return fn
+}
+
+// LineSplitter 把流式增量文本拼接成整行后回调，空行跳过。
+type LineSplitter struct {
+	emit    func(line string)
+	pending strings.Builder
+}
+
+// NewLineSplitter 创建按行回调 emit 的拼接器。
+func NewLineSplitter(emit func(line string)) *LineSplitter {
+	return &LineSplitter{emit: emit}
+}
+
+// Write 追加一段增量文本，遇到换行即回调已完成的行。
+func (s *LineSplitter) Write(delta string) {
+	for {
+		idx := strings.IndexByte(delta, '\n')
+		if idx < 0 {
+			s.pending.WriteString(delta)
+			return
+		}
+		s.pending.WriteString(delta[:idx])
+		s.flushLine()
+		delta = delta[idx+1:]
+	}
+}
+
+// Flush 回调尚未以换行结束的最后一行。
+func (s *LineSplitter) Flush() {
+	s.flushLine()
+}
+
+func (s *LineSplitter) flushLine() {
+	line := strings.TrimRight(s.pending.String(), "\r ")
+	s.pending.Reset()
+	if strings.TrimSpace(line) != "" {
+		s.emit(line)
+	}
+}
@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// 决策过程中模型可调用的数据工具名称。
+const (
+	ToolGetKlines         = "get_klines"
+	ToolGetDepth          = "get_depth"
+	ToolGetFundingHistory = "get_funding_history"
+)
+
+// DefaultToolRounds 为未配置时每次决策最多的工具调用轮数。
+const DefaultToolRounds = 3
+
+// ToolSpec 声明一个可供模型调用的工具，Parameters 为参数的 JSON Schema。
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// Toolbox 为决策时模型可调用的工具集合。Call 的返回值作为工具结果原样发回模型，
+// 出错时提供商把错误信息发回模型，由模型决定改用其他数据或直接决策。
+type Toolbox interface {
+	Specs() []ToolSpec
+	Call(ctx context.Context, name string, args json.RawMessage) (string, error)
+}
+
+type toolsKey struct{}
+
+type toolsValue struct {
+	box    Toolbox
+	rounds int
+}
+
+// WithTools 返回携带工具集的 context。支持函数调用的提供商据此进行多轮决策：模型可先调用工具
+// 获取更多数据，最多 rounds 轮（不大于 0 时取 DefaultToolRounds），之后必须提交决策。
+func WithTools(ctx context.Context, box Toolbox, rounds int) context.Context {
+	if box == nil {
+		return ctx
+	}
+	if rounds <= 0 {
+		rounds = DefaultToolRounds
+	}
+	return context.WithValue(ctx, toolsKey{}, toolsValue{box: box, rounds: rounds})
+}
+
+// ToolsFrom 取出 WithTools 设置的工具集与轮数上限，未设置时返回 nil。
+func ToolsFrom(ctx context.Context) (Toolbox, int) {
+	if ctx == nil {
+		return nil, 0
+	}
+	v, _ := ctx.Value(toolsKey{}).(toolsValue)
+	return v.box, v.rounds
+}
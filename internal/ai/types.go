@@ -21,6 +21,19 @@ type DecisionRequest struct {
 	NewsSentiment    news.SentimentSummary `json:"newsSentiment"`
 	RiskLimits       RiskLimits            `json:"riskLimits"`
 	Context          DecisionContext       `json:"context"`
+
+	// Memories 为长期记忆中与当前行情相似的历史情形及当时的决策，未启用记忆时为空。
+	Memories []string `json:"memories,omitempty"`
+
+	// StrategyStrength 为组合策略（metaStrategies）的信号强度，-1~1，正为看多、负为看空，绝对值为子策略加权一致程度；
+	// 单一策略不提供时为0。
+	StrategyStrength float64 `json:"strategyStrength,omitempty"`
+
+	// AmountsInPercent 为 true 时账户金额与持仓规模均为占账户净值的百分比（净值为 100），见 RedactAmounts。
+	AmountsInPercent bool `json:"amountsInPercentOfEquity,omitempty"`
+
+	// Critique 不为空时本次调用为自我复核：模型需审查同一上下文下给出的决策草稿，确认或修改后输出最终决策，见 WithSelfCritique。
+	Critique *CritiqueRequest `json:"critique,omitempty"`
 }
 
 // PositionSnapshot 为AI压缩后的持仓信息。
@@ -42,6 +55,8 @@ type RiskLimits struct {
 	BtcEthNotionalMultiple float64 `json:"btcEthNotionalMultiple"`
 	AltNotionalMultiple    float64 `json:"altNotionalMultiple"`
 	MinRiskRewardRatio     float64 `json:"minRiskRewardRatio"`
+	MaxMajorPositions      int     `json:"maxMajorPositions,omitempty"`
+	MaxAltPositions        int     `json:"maxAltPositions,omitempty"`
 }
 
 // DecisionContext 提供更丰富的状态背景。
@@ -54,6 +69,7 @@ type DecisionContext struct {
 	CandidateCoins  []CandidateContext            `json:"candidateCoins"`
 	MarketData      map[string]MarketDataSnapshot `json:"marketData"`
 	OITopData       map[string]OITopSnapshot      `json:"oiTopData"`
+	Liquidations    []LiquidationContext          `json:"liquidations"`
 	Performance     PerformanceStats              `json:"performance"`
 	BTCETHLeverage  int                           `json:"btcEthLeverage"`
 	AltcoinLeverage int                           `json:"altcoinLeverage"`
@@ -89,6 +105,11 @@ type CandidateContext struct {
 	Symbol string  `json:"symbol"`
 	Weight float64 `json:"weight"`
 	Reason string  `json:"reason"`
+
+	// Sentiment 为该币种的新闻情绪分（-1 利空 ~ 1 利好），无相关新闻时省略。
+	Sentiment float64 `json:"sentiment,omitempty"`
+	// MaxSafeNotionalUSD 为按当前盘口估算的单笔安全下单额（USDT），超过时执行层需要缩单；未估算时省略。
+	MaxSafeNotionalUSD float64 `json:"maxSafeNotionalUsd,omitempty"`
 }
 
 type MarketDataSnapshot struct {
@@ -105,6 +126,75 @@ type MarketDataSnapshot struct {
 	OpenInterest  float64 `json:"openInterest"`
 	Volume24h     float64 `json:"volume24h"`
 	DataInterval  string  `json:"dataInterval"`
+
+	QuoteVolume24h float64 `json:"quoteVolume24h"`
+	PriceChange24h float64 `json:"priceChange24h"`
+	High24h        float64 `json:"high24h"`
+	Low24h         float64 `json:"low24h"`
+
+	// Patterns 为最近几根K线识别出的形态名称。
+	Patterns []string `json:"patterns,omitempty"`
+
+	// Levels 为枢轴点与摆动高低点，Resistance/Support 为现价上下最近的关键位。
+	Levels     []PriceLevel `json:"levels,omitempty"`
+	Resistance *PriceLevel  `json:"resistance,omitempty"`
+	Support    *PriceLevel  `json:"support,omitempty"`
+
+	// VWAP 为最近20根K线的滚动成交量加权均价，SessionVWAP 为自当日 UTC 0 点锚定的 VWAP；
+	// K线不足或覆盖不到当日开盘时省略。
+	VWAP        float64 `json:"vwap,omitempty"`
+	SessionVWAP float64 `json:"sessionVwap,omitempty"`
+
+	// ADX 为14周期平均趋向指数（趋势强度，低于20左右通常为震荡），PlusDI/MinusDI 为多空趋向线；K线不足时省略。
+	ADX     float64 `json:"adx,omitempty"`
+	PlusDI  float64 `json:"plusDi,omitempty"`
+	MinusDI float64 `json:"minusDi,omitempty"`
+
+	// CCI 为 CCIPeriod 周期的顺势指标（典型价偏离均值的程度，高于+100/低于-100 为强势偏离）；K线不足时两者均省略。
+	CCI       float64 `json:"cci,omitempty"`
+	CCIPeriod int     `json:"cciPeriod,omitempty"`
+
+	// VolumeProfile 为回看窗口内的成交量分布。
+	VolumeProfile *VolumeProfile `json:"volumeProfile,omitempty"`
+
+	// TakerFlow 为近期各窗口主动买卖量，Imbalance>0 表示主动买盘占优。
+	TakerFlow []TakerFlow `json:"takerFlow,omitempty"`
+
+	// NewsSentiment 为该币种的新闻情绪分（-1 利空 ~ 1 利好），无相关新闻时省略。
+	NewsSentiment float64 `json:"newsSentiment,omitempty"`
+}
+
+// TakerFlow 为单个窗口的主动买卖统计。
+type TakerFlow struct {
+	Window     string  `json:"window"`
+	BuyVolume  float64 `json:"buyVolume"`
+	SellVolume float64 `json:"sellVolume"`
+	Imbalance  float64 `json:"imbalance"`
+}
+
+// VolumeProfile 为成交量分布摘要：POC 为成交最密集价位，价值区覆盖70%成交量。
+type VolumeProfile struct {
+	POC           float64 `json:"poc"`
+	ValueAreaHigh float64 `json:"valueAreaHigh"`
+	ValueAreaLow  float64 `json:"valueAreaLow"`
+}
+
+// PriceLevel 为支撑/阻力价位，DistancePercent 为相对现价的距离（正数在上方）。
+type PriceLevel struct {
+	Name            string  `json:"name"`
+	Price           float64 `json:"price"`
+	Strength        int     `json:"strength"`
+	DistancePercent float64 `json:"distancePercent"`
+}
+
+// LiquidationContext 汇总窗口期内单个币种的强平情况。
+type LiquidationContext struct {
+	Symbol             string  `json:"symbol"`
+	WindowMinutes      int     `json:"windowMinutes"`
+	Count              int     `json:"count"`
+	LongLiquidatedUSD  float64 `json:"longLiquidatedUsd"`
+	ShortLiquidatedUSD float64 `json:"shortLiquidatedUsd"`
+	LargestUSD         float64 `json:"largestUsd"`
 }
 
 type OITopSnapshot struct {
@@ -130,6 +220,13 @@ type DecisionResponse struct {
 	RiskNotes   []string       `json:"riskNotes"`
 	RawContent  string         `json:"-"`
 	CoTTrace    string         `json:"-"`
+	Usage       Usage          `json:"-"`
+
+	// PromptVersion 为生成该决策的提示词模板版本（见 PromptVersion），写入决策记录用于 A/B 对比。
+	PromptVersion string `json:"-"`
+
+	// Vetoes 为确定性护栏（见 WithGuardrails）对模型决策的否决、改写与缩减说明，写入决策记录。
+	Vetoes []string `json:"-"`
 }
 
 // AdjustmentPlan 用于AI微调仓位与风控参数。
@@ -146,3 +243,12 @@ type Provider interface {
 	AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error)
 	GenerateDecision(ctx context.Context, req DecisionRequest) (DecisionResponse, error)
 }
+
+// ReplayTrader 为离线重跑历史决策时记入用量的交易者名。
+const ReplayTrader = "replay"
+
+// PromptReplayer 由可以直接发送已渲染用户提示的提供商实现：系统提示使用提供商当前的决策模板，
+// 用户提示为决策记录中保存的 InputPrompt，供 cmd/replay 离线重跑历史决策、评估模型或提示词升级。
+type PromptReplayer interface {
+	ReplayDecision(ctx context.Context, userPrompt string) (DecisionResponse, error)
+}
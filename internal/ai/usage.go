@@ -0,0 +1,184 @@
+package ai
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+)
+
+// 用量类别。
+const (
+	UsageKindDecision = "decision"
+	UsageKindNews     = "news"
+)
+
+// Usage 为一次模型调用的 token 用量及按价目表折算的费用。
+type Usage struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Trader           string  `json:"trader,omitempty"`
+	Kind             string  `json:"kind"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	CostUSD          float64 `json:"costUsd"`
+	CreatedAt        int64   `json:"createdAt"`
+}
+
+// TotalTokens 返回输入与输出 token 之和。
+func (u Usage) TotalTokens() int {
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add 累加另一次调用的用量，用于多模型投票等组合提供商。
+func (u *Usage) Add(other Usage) {
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.CostUSD += other.CostUSD
+}
+
+// UsageRecorder 持久化用量记录；storage.Store 满足该接口。
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, usage Usage) error
+}
+
+// Spend 为某提供商在统计区间内的累计用量。
+type Spend struct {
+	Provider         string  `json:"provider"`
+	Calls            int     `json:"calls"`
+	PromptTokens     int     `json:"promptTokens"`
+	CompletionTokens int     `json:"completionTokens"`
+	CostUSD          float64 `json:"costUsd"`
+}
+
+// Meter 按价目表为每次调用计费，累计当日（UTC）各提供商的花费并写入 recorder。
+type Meter struct {
+	pricing  config.AIPricing
+	recorder UsageRecorder
+
+	mu     sync.Mutex
+	day    string
+	spend  map[string]*Spend
+	budget *Budget
+}
+
+// NewMeter 创建计费器，recorder 可为空（只统计不落盘）。
+func NewMeter(pricing config.AIPricing, recorder UsageRecorder) *Meter {
+	return &Meter{pricing: pricing, recorder: recorder, spend: map[string]*Spend{}}
+}
+
+// Seed 用已落盘的记录恢复当日累计，进程重启后看板数字不归零。
+func (m *Meter) Seed(records []Usage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, u := range records {
+		m.addLocked(u)
+	}
+}
+
+// SetBudget 设置预算，之后记录的每次调用都计入预算。
+func (m *Meter) SetBudget(b *Budget) {
+	m.mu.Lock()
+	m.budget = b
+	m.mu.Unlock()
+}
+
+// Budget 返回当前预算，未设置时为 nil。
+func (m *Meter) Budget() *Budget {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.budget
+}
+
+// Record 为 u 计费并累计、落盘，返回带 CostUSD 与 CreatedAt 的记录。
+func (m *Meter) Record(ctx context.Context, u Usage) Usage {
+	if u.CreatedAt == 0 {
+		u.CreatedAt = time.Now().UnixMilli()
+	}
+	u.CostUSD = m.pricing.Price(u.Provider, u.Model).Cost(u.PromptTokens, u.CompletionTokens)
+
+	m.mu.Lock()
+	m.addLocked(u)
+	budget := m.budget
+	m.mu.Unlock()
+	if budget != nil {
+		budget.Add(u)
+	}
+	observeUsage(u)
+
+	// 全局计费器在包初始化时创建，日志器延迟到首次记录时获取，以使用程序配置的日志目录
+	logger := loggerpkg.Get("ai.usage")
+	logger.Printf("usage provider=%s model=%s trader=%s kind=%s prompt=%d completion=%d cost=%.6f",
+		u.Provider, u.Model, u.Trader, u.Kind, u.PromptTokens, u.CompletionTokens, u.CostUSD)
+	if m.recorder != nil {
+		if err := m.recorder.RecordUsage(ctx, u); err != nil {
+			logger.Printf("usage.record.error: %v", err)
+		}
+	}
+	return u
+}
+
+// Today 返回当日（UTC）各提供商的累计花费，按提供商名排序。
+func (m *Meter) Today() []Spend {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.day != utcDay(time.Now().UnixMilli()) {
+		return nil
+	}
+	out := make([]Spend, 0, len(m.spend))
+	for _, s := range m.spend {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out
+}
+
+func (m *Meter) addLocked(u Usage) {
+	day := utcDay(u.CreatedAt)
+	if day < m.day {
+		return
+	}
+	if day != m.day {
+		m.day, m.spend = day, map[string]*Spend{}
+	}
+	s := m.spend[u.Provider]
+	if s == nil {
+		s = &Spend{Provider: u.Provider}
+		m.spend[u.Provider] = s
+	}
+	s.Calls++
+	s.PromptTokens += u.PromptTokens
+	s.CompletionTokens += u.CompletionTokens
+	s.CostUSD += u.CostUSD
+}
+
+func utcDay(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format("2006-01-02")
+}
+
+var defaultMeter atomic.Pointer[Meter]
+
+func init() {
+	defaultMeter.Store(NewMeter(nil, nil))
+}
+
+// SetMeter 替换各提供商使用的全局计费器，启动时按配置价目表与存储设置一次。
+func SetMeter(m *Meter) {
+	if m != nil {
+		defaultMeter.Store(m)
+	}
+}
+
+// DefaultMeter 返回全局计费器。
+func DefaultMeter() *Meter {
+	return defaultMeter.Load()
+}
+
+// RecordUsage 用全局计费器记录一次调用，供各提供商在拿到响应后调用。
+func RecordUsage(ctx context.Context, u Usage) Usage {
+	return defaultMeter.Load().Record(ctx, u)
+}
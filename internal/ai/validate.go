@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateDecision 对模型返回的决策进行初步校验：action 取值、目标杠杆上限与最低风险回报比。
+func ValidateDecision(decision DecisionResponse, limits RiskLimits) error {
+	action := strings.ToLower(strings.TrimSpace(decision.Action))
+	known := false
+	for _, valid := range DecisionActions {
+		if action == valid {
+			known = true
+			break
+		}
+	}
+	if !known && action != "" {
+		return fmt.Errorf("未知 action: %s", decision.Action)
+	}
+
+	targetLev := decision.Adjustments.TargetLeverage
+	if targetLev < 0 {
+		return fmt.Errorf("targetLeverage 不得为负数")
+	}
+	if limits.MaxLeverage > 0 && targetLev > limits.MaxLeverage {
+		return fmt.Errorf("targetLeverage %.2f 超过上限 %.2f", targetLev, limits.MaxLeverage)
+	}
+
+	if decision.Adjustments.StopLossPercent > 0 && decision.Adjustments.TakeProfitPercent > 0 {
+		if limits.MinRiskRewardRatio > 0 {
+			rr := decision.Adjustments.TakeProfitPercent / decision.Adjustments.StopLossPercent
+			if rr+1e-9 < limits.MinRiskRewardRatio {
+				return fmt.Errorf("风险回报 %.2f 低于要求 %.2f", rr, limits.MinRiskRewardRatio)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,534 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	"autobot/internal/market"
+	"autobot/internal/news"
+	"autobot/internal/risk"
+	"autobot/internal/strategy"
+)
+
+// Config 描述一次回测的参数。
+type Config struct {
+	Name          string
+	Symbol        string
+	Interval      string
+	Strategy      strategy.Strategy
+	Settings      config.TradeSettings
+	Limits        ai.RiskLimits
+	InitialEquity float64
+	// Provider 可选；设置后策略信号需经AI确认才会开仓。
+	Provider ai.Provider
+	// News 可选；返回 [from, to] 区间内可见的新闻，经 Provider 分析后写入决策请求。
+	News func(from, to time.Time) []news.Article
+	// Gate 可选；开仓前按关键位等规则复核，被拒绝的信号不入场。
+	Gate *risk.Gate
+	// Fees 为交易所费率，默认开平仓均按吃单费率从盈亏中扣除。
+	Fees config.FeeSchedule
+	// Realism 为可选的延迟、部分成交、资金费与挂单费率仿真。
+	Realism Realism
+	// Timeframes 为交易者额外的K线周期；回测只有 Interval 的K线，各周期由其聚合得到，
+	// 策略经 strategy.EvaluateTimeframes 评估，与实盘多周期一致。
+	Timeframes []string
+}
+
+// Trade 为回测中的一笔完整交易。
+type Trade struct {
+	Side       string
+	EntryTime  time.Time
+	ExitTime   time.Time
+	EntryPrice float64
+	ExitPrice  float64
+	Quantity   float64
+	PnL        float64
+	Fees       float64
+	Funding    float64
+	ExitReason string
+}
+
+// Result 汇总回测绩效。
+type Result struct {
+	Name               string
+	Symbol             string
+	Trades             []Trade
+	InitialEquity      float64
+	FinalEquity        float64
+	TotalFees          float64
+	TotalFunding       float64
+	PartialFills       int
+	MissedEntries      int
+	ReturnPercent      float64
+	MaxDrawdownPercent float64
+	WinRate            float64
+	ProfitFactor       float64
+	Sharpe             float64
+	AICalls            int
+	AIErrors           int
+	Err                error
+}
+
+type openPosition struct {
+	side       string
+	entryTime  time.Time
+	entryPrice float64
+	quantity   float64
+	stopPrice  float64
+	trailed    bool
+	takePrice  float64
+	filledAt   time.Time
+	funding    float64
+	makerEntry bool
+}
+
+// Run 在给定K线上逐根回放策略，返回绩效结果。
+func Run(ctx context.Context, cfg Config, candles []strategy.Candle) (Result, error) {
+	if cfg.Strategy == nil {
+		return Result{}, errors.New("backtest strategy is nil")
+	}
+	if cfg.InitialEquity <= 0 {
+		cfg.InitialEquity = 1000
+	}
+	lookback := cfg.Settings.LookbackCandles
+	if lookback <= 0 {
+		lookback = 120
+	}
+	if len(candles) <= lookback {
+		return Result{}, fmt.Errorf("need more than %d candles, got %d", lookback, len(candles))
+	}
+
+	if err := cfg.Realism.validate(); err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Name: cfg.Name, Symbol: cfg.Symbol, InitialEquity: cfg.InitialEquity}
+	equity := cfg.InitialEquity
+	peak := equity
+	var pos *openPosition
+	ramp := risk.NewRamp(cfg.Settings)
+	flat := risk.NewFlat(cfg.Settings)
+	sizing := risk.NewConfidenceSizing(cfg.Settings)
+	sim := newSimulator(cfg.Realism)
+	var pendingEntry, pendingExit *pendingOrder
+
+	closePosition := func(at time.Time, price float64, reason string) {
+		pnl := (price - pos.entryPrice) * pos.quantity
+		if pos.side == "short" {
+			pnl = -pnl
+		}
+		entryRate, exitRate, legs := cfg.Fees.Taker(), cfg.Fees.Taker(), 2
+		if pos.makerEntry {
+			entryRate, legs = cfg.Fees.Maker(), legs-1
+		}
+		if reason == "take_profit" && cfg.Realism.MakerTakeProfit {
+			exitRate, legs = cfg.Fees.Maker(), legs-1
+		}
+		pnl -= slippageCost(cfg.Settings, price, pos.quantity, legs)
+		fees := (pos.entryPrice*entryRate + price*exitRate) * pos.quantity / 100
+		pnl -= fees
+		pnl += pos.funding
+		result.TotalFees += fees
+		result.TotalFunding += pos.funding
+		equity += pnl
+		ramp.Record(at, pnl)
+		result.Trades = append(result.Trades, Trade{
+			Side:       pos.side,
+			EntryTime:  pos.entryTime,
+			ExitTime:   at,
+			EntryPrice: pos.entryPrice,
+			ExitPrice:  price,
+			Quantity:   pos.quantity,
+			PnL:        pnl,
+			Fees:       fees,
+			Funding:    pos.funding,
+			ExitReason: reason,
+		})
+		pos = nil
+	}
+
+	// fill 按仿真参数完成开仓：延迟成交时平移到实际成交价，并按概率部分成交。
+	fill := func(i int, order pendingOrder, delayed bool) {
+		price := priceAt(candles, i, order.fillAt)
+		if cfg.Realism.MakerEntries {
+			limit := order.window[len(order.window)-1].Close
+			bar := candles[i]
+			if delayed && !(order.signal == strategy.SignalLong && bar.Low <= limit) && !(order.signal == strategy.SignalShort && bar.High >= limit) {
+				result.MissedEntries++
+				return
+			}
+			price = limit
+		}
+		pos = openAt(cfg.Settings, cfg.Strategy, order.signal, order.window, equity, order.sizeMultiplier)
+		if delayed {
+			pos.shift(price, order.fillAt)
+		} else {
+			pos.filledAt = order.fillAt
+		}
+		pos.makerEntry = cfg.Realism.MakerEntries
+		if ratio := sim.fillRatio(); ratio < 1 {
+			pos.quantity *= ratio
+			result.PartialFills++
+		}
+	}
+
+	frameSpan := timeframeSpan(cfg.Interval, cfg.Timeframes, lookback)
+	for i := lookback; i < len(candles); i++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		bar := candles[i]
+		end := barEnd(candles, i)
+
+		sim.settleFunding(pos, bar)
+		// 延迟订单在成交时刻所在的K线成交，先平仓后开仓
+		if pendingExit != nil && end.After(pendingExit.fillAt) {
+			if pos != nil {
+				closePosition(pendingExit.fillAt, priceAt(candles, i, pendingExit.fillAt), pendingExit.exitReason)
+			}
+			pendingExit = nil
+		}
+		if pendingEntry != nil && end.After(pendingEntry.fillAt) {
+			fill(i, *pendingEntry, true)
+			pendingEntry = nil
+		}
+
+		if pos != nil {
+			if price, reason, hit := checkExit(pos, bar); hit {
+				closePosition(bar.OpenTime, price, reason)
+			}
+		}
+		// 日终平仓：平仓时刻落在本K线内（或K线开盘已处于应空仓时段）时按该时刻的插值价平仓
+		if pos != nil && flat != nil {
+			if at := flat.NextFlat(bar.OpenTime); at.Before(end) {
+				closePosition(at, priceAt(candles, i, at), "end_of_day")
+			} else if flat.MustBeFlat(bar.OpenTime) {
+				closePosition(bar.OpenTime, bar.Open, "end_of_day")
+			}
+		}
+
+		window := candles[i+1-lookback : i+1]
+		if pos != nil {
+			trail(pos, cfg.Strategy, window)
+		}
+		var signal strategy.Signal
+		var err error
+		if len(cfg.Timeframes) == 0 {
+			signal, err = cfg.Strategy.Evaluate(market.TransformCandles(window, cfg.Settings))
+		} else {
+			// 高周期按同样的根数回看，从更长的一段信号周期K线聚合
+			frames := market.ResampleTimeframes(candles[max(0, i+1-frameSpan):i+1], cfg.Interval, cfg.Timeframes)
+			frames[cfg.Interval] = window
+			signal, err = strategy.EvaluateTimeframes(cfg.Strategy, cfg.Interval, market.TransformTimeframes(frames, cfg.Settings))
+		}
+		if err != nil {
+			continue
+		}
+
+		if pos != nil && pendingExit == nil && isOpposite(pos.side, signal) {
+			if cfg.Realism.Latency > 0 {
+				pendingExit = &pendingOrder{fillAt: end.Add(cfg.Realism.Latency), exitReason: "signal_reverse"}
+			} else {
+				closePosition(bar.OpenTime, bar.Close, "signal_reverse")
+			}
+		}
+		if (pos == nil || pendingExit != nil) && pendingEntry == nil && (signal == strategy.SignalLong || signal == strategy.SignalShort) && !flat.Blocked(end) {
+			entry := risk.Entry{Symbol: cfg.Symbol, Side: "long", Price: bar.Close, SlippagePercent: cfg.Settings.SlippagePercent, Time: end}
+			if signal == strategy.SignalShort {
+				entry.Side = "short"
+			}
+			if cfg.Gate != nil {
+				entry.Snapshot = market.BuildSnapshot(cfg.Symbol, cfg.Interval, window)
+				if target := openAt(cfg.Settings, cfg.Strategy, signal, window, equity, 1).takePrice; target > 0 && !math.IsInf(target, 1) {
+					entry.TargetPrice = target
+				}
+				if err := cfg.Gate.CheckEntry(entry); err != nil {
+					continue
+				}
+			}
+			sizeMultiplier := 1.0
+			if cfg.Provider != nil {
+				decision, err := cfg.decide(ctx, signal, window, equity)
+				result.AICalls++
+				if err != nil {
+					result.AIErrors++
+					continue
+				}
+				if !confirms(decision.Action, signal) {
+					continue
+				}
+				if decision, _, err = sizing.Apply(cfg.Symbol, decision); err != nil {
+					continue
+				}
+				adjustments, _, err := cfg.Gate.GuardAdjustments(cfg.Symbol, decision.Adjustments)
+				if err != nil {
+					continue
+				}
+				if adjustments.SizeMultiplier > 0 {
+					sizeMultiplier = adjustments.SizeMultiplier
+				}
+				// 拿到置信度后按预期收益复核
+				entry.Confidence = decision.Confidence
+				if err := cfg.Gate.CheckEntry(entry); err != nil {
+					continue
+				}
+			}
+			order := pendingOrder{signal: signal, window: window, sizeMultiplier: sizeMultiplier * ramp.Multiplier()}
+			if cfg.Realism.Latency > 0 {
+				order.fillAt = end.Add(cfg.Realism.Latency)
+				pendingEntry = &order
+			} else {
+				order.fillAt = end
+				fill(i, order, false)
+			}
+		}
+
+		mark := equity
+		if pos != nil {
+			mark += unrealized(pos, bar.Close) + pos.funding
+		}
+		if mark > peak {
+			peak = mark
+		}
+		if peak > 0 {
+			if dd := (peak - mark) / peak * 100; dd > result.MaxDrawdownPercent {
+				result.MaxDrawdownPercent = dd
+			}
+		}
+	}
+
+	if pos != nil {
+		last := candles[len(candles)-1]
+		closePosition(last.OpenTime, last.Close, "end_of_data")
+	}
+
+	result.FinalEquity = equity
+	summarize(&result)
+	return result, nil
+}
+
+func (cfg Config) decide(ctx context.Context, signal strategy.Signal, window []strategy.Candle, equity float64) (ai.DecisionResponse, error) {
+	snapshot := market.BuildSnapshot(cfg.Symbol, cfg.Interval, window)
+	req := ai.DecisionRequest{
+		TraderName:       cfg.Name,
+		Exchange:         "backtest",
+		Symbol:           cfg.Symbol,
+		CurrentPrice:     snapshot.CurrentPrice,
+		StrategySignal:   signal.String(),
+		AccountBalance:   equity,
+		AvailableBalance: equity,
+		RiskLimits:       cfg.Limits,
+		Context: ai.DecisionContext{
+			CurrentTime: window[len(window)-1].OpenTime.Format("2006-01-02 15:04:05"),
+			Account:     ai.AccountContext{TotalEquity: equity, Available: equity},
+			MarketData:  map[string]ai.MarketDataSnapshot{cfg.Symbol: snapshot},
+		},
+	}
+	if cfg.News != nil {
+		if articles := cfg.News(window[0].OpenTime, window[len(window)-1].OpenTime); len(articles) > 0 {
+			summary, err := cfg.Provider.AnalyzeNews(ctx, articles)
+			if err != nil {
+				return ai.DecisionResponse{}, fmt.Errorf("analyze news: %w", err)
+			}
+			req.NewsSentiment = summary
+			ai.ApplySymbolSentiment(&req)
+		}
+	}
+	return cfg.Provider.GenerateDecision(ctx, req)
+}
+
+func openAt(settings config.TradeSettings, strat strategy.Strategy, signal strategy.Signal, window []strategy.Candle, equity, sizeMultiplier float64) *openPosition {
+	bar := window[len(window)-1]
+	price := bar.Close
+	long := signal == strategy.SignalLong
+	stopPct := settings.StopLossPercent / 100
+	takePct := settings.TakeProfitPercent / 100
+	stopPct, takePct = strategy.ATRStopPercents(window, settings.ATRPeriod, settings.StopLossATRMultiple, settings.TakeProfitATRMultiple, stopPct, takePct)
+	if settings.ProfileStopBufferPercent > 0 {
+		if profile, ok := strategy.VolumeProfile(window); ok {
+			if stop, ok := strategy.ProfileStop(profile, long, price, settings.ProfileStopBufferPercent); ok {
+				stopPct = math.Abs(price-stop) / price
+			}
+		}
+	}
+	if sizer, ok := strategy.Unwrap(strat).(strategy.StopSizer); ok {
+		if distance, ok := sizer.StopDistance(window); ok {
+			stopPct = distance / price
+		}
+	}
+	qty := settings.OrderQuantity
+	if stopPct > 0 && settings.RiskPerTradePercent > 0 {
+		riskBudget := equity * settings.RiskPerTradePercent / 100
+		qty = riskBudget / (price * stopPct)
+	}
+	qty *= sizeMultiplier
+	leverage := float64(settings.Leverage)
+	if leverage <= 0 {
+		leverage = 1
+	}
+	if maxQty := equity * leverage / price; qty > maxQty {
+		qty = maxQty
+	}
+
+	pos := &openPosition{entryTime: bar.OpenTime, entryPrice: price, quantity: qty}
+	if long {
+		pos.side = "long"
+		pos.stopPrice = price * (1 - stopPct)
+		pos.takePrice = price * (1 + takePct)
+	} else {
+		pos.side = "short"
+		pos.stopPrice = price * (1 + stopPct)
+		pos.takePrice = price * (1 - takePct)
+	}
+	if _, ok := strategy.Unwrap(strat).(strategy.TrailingStopper); ok {
+		// 移动止损管理离场的策略不设固定止盈
+		pos.takePrice = math.Inf(1)
+		if !long {
+			pos.takePrice = 0
+		}
+	}
+	return pos
+}
+
+// trail 按策略的移动止损收紧止损价，只向有利方向移动。
+func trail(pos *openPosition, strat strategy.Strategy, window []strategy.Candle) {
+	trailer, ok := strategy.Unwrap(strat).(strategy.TrailingStopper)
+	if !ok {
+		return
+	}
+	stop, ok := trailer.TrailingStop(window, pos.side == "long")
+	if !ok {
+		return
+	}
+	if (pos.side == "long" && stop > pos.stopPrice) || (pos.side == "short" && stop < pos.stopPrice) {
+		pos.stopPrice = stop
+		pos.trailed = true
+	}
+}
+
+// checkExit 判断当根K线是否触发止损/止盈；同时触发时保守地按止损处理。
+func checkExit(pos *openPosition, bar strategy.Candle) (float64, string, bool) {
+	stopReason := "stop_loss"
+	if pos.trailed {
+		stopReason = "trailing_stop"
+	}
+	if pos.side == "long" {
+		if bar.Low <= pos.stopPrice {
+			return pos.stopPrice, stopReason, true
+		}
+		if bar.High >= pos.takePrice {
+			return pos.takePrice, "take_profit", true
+		}
+		return 0, "", false
+	}
+	if bar.High >= pos.stopPrice {
+		return pos.stopPrice, stopReason, true
+	}
+	if bar.Low <= pos.takePrice {
+		return pos.takePrice, "take_profit", true
+	}
+	return 0, "", false
+}
+
+func unrealized(pos *openPosition, price float64) float64 {
+	pnl := (price - pos.entryPrice) * pos.quantity
+	if pos.side == "short" {
+		return -pnl
+	}
+	return pnl
+}
+
+// slippageCost 按吃单成交的腿数计滑点，默认进出场各计一次。
+func slippageCost(settings config.TradeSettings, price, qty float64, legs int) float64 {
+	return float64(legs) * price * qty * settings.SlippagePercent / 100
+}
+
+func isOpposite(side string, signal strategy.Signal) bool {
+	return (side == "long" && (signal == strategy.SignalShort || signal == strategy.SignalExit)) ||
+		(side == "short" && (signal == strategy.SignalLong || signal == strategy.SignalExit))
+}
+
+func confirms(action string, signal strategy.Signal) bool {
+	action = strings.ToLower(strings.TrimSpace(action))
+	switch signal {
+	case strategy.SignalLong:
+		return action == "open_long"
+	case strategy.SignalShort:
+		return action == "open_short"
+	default:
+		return false
+	}
+}
+
+func summarize(result *Result) {
+	if result.InitialEquity > 0 {
+		result.ReturnPercent = (result.FinalEquity/result.InitialEquity - 1) * 100
+	}
+	if len(result.Trades) == 0 {
+		return
+	}
+	wins := 0
+	grossProfit, grossLoss := 0.0, 0.0
+	returns := make([]float64, 0, len(result.Trades))
+	for _, trade := range result.Trades {
+		if trade.PnL > 0 {
+			wins++
+			grossProfit += trade.PnL
+		} else {
+			grossLoss -= trade.PnL
+		}
+		notional := trade.EntryPrice * trade.Quantity
+		if notional > 0 {
+			returns = append(returns, trade.PnL/notional)
+		}
+	}
+	result.WinRate = float64(wins) / float64(len(result.Trades))
+	switch {
+	case grossLoss > 0:
+		result.ProfitFactor = grossProfit / grossLoss
+	case grossProfit > 0:
+		result.ProfitFactor = math.Inf(1)
+	}
+	result.Sharpe = sharpe(returns)
+}
+
+func sharpe(returns []float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	if std == 0 {
+		return 0
+	}
+	return mean / std * math.Sqrt(float64(len(returns)))
+}
+
+// timeframeSpan 返回聚合出 lookback 根最长额外周期K线所需的信号周期K线根数。
+func timeframeSpan(interval string, timeframes []string, lookback int) int {
+	span := lookback
+	base, ok := market.IntervalDuration(interval)
+	if !ok {
+		return span
+	}
+	for _, tf := range timeframes {
+		if length, ok := market.IntervalDuration(tf); ok && length > base {
+			span = max(span, lookback*int(length/base))
+		}
+	}
+	return span
+}
@@ -0,0 +1,144 @@
+package backtest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"autobot/internal/strategy"
+)
+
+// Realism 为可选的成交仿真参数。零值保持原有行为：信号K线收盘价立即全部成交、开平仓均按吃单费率。
+type Realism struct {
+	// Latency 为信号K线收盘到订单成交的延迟；大于 0 时开仓与信号平仓在延迟后所在K线内
+	// 按开盘到收盘线性插值的价格成交，止损止盈视为交易所挂单不受影响。
+	Latency time.Duration
+	// PartialFillProbability 为每次开仓只部分成交的概率（0~1），
+	// 部分成交比例在 MinFillRatio~1 之间均匀分布。
+	PartialFillProbability float64
+	// MinFillRatio 为部分成交的最小比例，为 0 时取 0.5。
+	MinFillRatio float64
+	// Funding 为资金费率历史，持仓跨过结算时刻时按当时名义价值收付：费率为正多头付、空头收。
+	Funding []FundingRate
+	// MakerEntries 为 true 时以信号价挂限价单开仓：按挂单费率、不计开仓滑点；
+	// 有延迟时成交K线未触及挂单价则错过该次入场。
+	MakerEntries bool
+	// MakerTakeProfit 为 true 时止盈按挂单费率成交且不计滑点（止盈为预挂限价单）。
+	MakerTakeProfit bool
+	// Seed 为部分成交的随机数种子，相同种子结果可复现；为 0 时取 1。
+	Seed int64
+}
+
+// FundingRate 为一次资金费结算，Rate 为小数（0.0001 即 0.01%）。
+type FundingRate struct {
+	Time time.Time
+	Rate float64
+}
+
+func (r Realism) validate() error {
+	if r.Latency < 0 {
+		return fmt.Errorf("backtest latency must not be negative, got %s", r.Latency)
+	}
+	if r.PartialFillProbability < 0 || r.PartialFillProbability > 1 {
+		return fmt.Errorf("partial fill probability must be within 0..1, got %v", r.PartialFillProbability)
+	}
+	if r.MinFillRatio < 0 || r.MinFillRatio > 1 {
+		return fmt.Errorf("min fill ratio must be within 0..1, got %v", r.MinFillRatio)
+	}
+	return nil
+}
+
+// simulator 保存一次回测中的成交仿真状态。
+type simulator struct {
+	Realism
+	rng     *rand.Rand
+	funding []FundingRate
+	next    int
+}
+
+func newSimulator(r Realism) *simulator {
+	seed := r.Seed
+	if seed == 0 {
+		seed = 1
+	}
+	if r.MinFillRatio == 0 {
+		r.MinFillRatio = 0.5
+	}
+	funding := append([]FundingRate(nil), r.Funding...)
+	sort.Slice(funding, func(i, j int) bool { return funding[i].Time.Before(funding[j].Time) })
+	return &simulator{Realism: r, rng: rand.New(rand.NewSource(seed)), funding: funding}
+}
+
+// fillRatio 返回本次开仓的成交比例。
+func (s *simulator) fillRatio() float64 {
+	if s.PartialFillProbability <= 0 || s.rng.Float64() >= s.PartialFillProbability {
+		return 1
+	}
+	return s.MinFillRatio + (1-s.MinFillRatio)*s.rng.Float64()
+}
+
+// settleFunding 对结算时间不晚于 bar 开盘的资金费按开盘价收付到持仓上，
+// 只计入在结算时刻之前已成交的持仓。
+func (s *simulator) settleFunding(pos *openPosition, bar strategy.Candle) {
+	for s.next < len(s.funding) && !s.funding[s.next].Time.After(bar.OpenTime) {
+		event := s.funding[s.next]
+		s.next++
+		if pos == nil || !event.Time.After(pos.filledAt) {
+			continue
+		}
+		payment := pos.quantity * bar.Open * event.Rate
+		if pos.side == "long" {
+			payment = -payment
+		}
+		pos.funding += payment
+	}
+}
+
+// pendingOrder 为等待延迟成交的开仓或信号平仓。
+type pendingOrder struct {
+	fillAt time.Time
+	// 开仓
+	signal         strategy.Signal
+	window         []strategy.Candle
+	sizeMultiplier float64
+	// 平仓
+	exitReason string
+}
+
+// barEnd 返回第 i 根K线的收盘时刻（下一根的开盘时间）。
+func barEnd(candles []strategy.Candle, i int) time.Time {
+	if i+1 < len(candles) {
+		return candles[i+1].OpenTime
+	}
+	if i > 0 {
+		return candles[i].OpenTime.Add(candles[i].OpenTime.Sub(candles[i-1].OpenTime))
+	}
+	return candles[i].OpenTime
+}
+
+// priceAt 按开盘到收盘线性插值估算 at 时刻在第 i 根K线内的成交价。
+func priceAt(candles []strategy.Candle, i int, at time.Time) float64 {
+	bar := candles[i]
+	span := barEnd(candles, i).Sub(bar.OpenTime)
+	if span <= 0 || !at.After(bar.OpenTime) {
+		return bar.Open
+	}
+	frac := float64(at.Sub(bar.OpenTime)) / float64(span)
+	if frac > 1 {
+		frac = 1
+	}
+	return bar.Open + (bar.Close-bar.Open)*frac
+}
+
+// shift 把按信号价计算的持仓平移到实际成交价，止损止盈保持相同的百分比距离。
+func (pos *openPosition) shift(price float64, at time.Time) {
+	if pos.entryPrice > 0 && price > 0 {
+		ratio := price / pos.entryPrice
+		pos.stopPrice *= ratio
+		pos.takePrice *= ratio
+	}
+	pos.entryPrice = price
+	pos.entryTime = at
+	pos.filledAt = at
+}
@@ -0,0 +1,95 @@
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"autobot/internal/strategy"
+)
+
+// Entry 为锦标赛中的一个参赛配置。
+type Entry struct {
+	Config Config
+}
+
+// DataKey 标识同一数据窗口下的K线序列。
+type DataKey struct {
+	Symbol   string
+	Interval string
+}
+
+// RunTournament 在同一组K线数据上并发回测所有参赛配置，并按夏普、收益排序。
+func RunTournament(ctx context.Context, data map[DataKey][]strategy.Candle, entries []Entry, concurrency int) []Result {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	results := make([]Result, len(entries))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for idx, entry := range entries {
+		wg.Add(1)
+		go func(idx int, cfg Config) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			candles, ok := data[DataKey{Symbol: cfg.Symbol, Interval: cfg.Interval}]
+			if !ok {
+				results[idx] = Result{Name: cfg.Name, Symbol: cfg.Symbol, Err: fmt.Errorf("no data for %s %s", cfg.Symbol, cfg.Interval)}
+				return
+			}
+			result, err := Run(ctx, cfg, candles)
+			if err != nil {
+				result.Name = cfg.Name
+				result.Symbol = cfg.Symbol
+				result.Err = err
+			}
+			results[idx] = result
+		}(idx, entry.Config)
+	}
+	wg.Wait()
+
+	Rank(results)
+	return results
+}
+
+// Rank 按夏普比率、收益率降序排列，失败项置底。
+func Rank(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if (a.Err == nil) != (b.Err == nil) {
+			return a.Err == nil
+		}
+		if math.Abs(a.Sharpe-b.Sharpe) > 1e-9 {
+			return a.Sharpe > b.Sharpe
+		}
+		return a.ReturnPercent > b.ReturnPercent
+	})
+}
+
+// WriteReport 输出排名对比表。
+func WriteReport(w io.Writer, results []Result) {
+	fmt.Fprintf(w, "%-4s %-32s %-10s %7s %9s %8s %8s %7s %7s %8s %10s %10s\n", "排名", "配置", "交易对", "交易数", "收益%", "回撤%", "胜率%", "PF", "夏普", "AI调用", "手续费", "资金费")
+	fmt.Fprintln(w, strings.Repeat("-", 132))
+	for idx, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(w, "%-4d %-32s %-10s 失败: %v\n", idx+1, r.Name, r.Symbol, r.Err)
+			continue
+		}
+		pf := fmt.Sprintf("%.2f", r.ProfitFactor)
+		if math.IsInf(r.ProfitFactor, 1) {
+			pf = "∞"
+		}
+		fmt.Fprintf(w, "%-4d %-32s %-10s %7d %+9.2f %8.2f %8.2f %7s %7.2f %8d %10.2f %+10.2f\n",
+			idx+1, r.Name, r.Symbol, len(r.Trades), r.ReturnPercent, r.MaxDrawdownPercent, r.WinRate*100, pf, r.Sharpe, r.AICalls, r.TotalFees, r.TotalFunding)
+		if r.PartialFills > 0 || r.MissedEntries > 0 {
+			fmt.Fprintf(w, "     部分成交 %d 次，错过入场 %d 次\n", r.PartialFills, r.MissedEntries)
+		}
+	}
+}
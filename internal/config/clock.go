@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClockOffset 把 HH:MM 形式的时刻转换为距 0 点的时长。
+func ClockOffset(clock string) (time.Duration, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("时刻需为 HH:MM 格式，当前为 %q", clock)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// validateFlat 校验日终平仓设置：恢复开仓时刻不能落在禁止开仓到平仓之间（含两端），否则平仓前会重新开仓，
+// 恢复时刻与停止开仓时刻相同时禁止开仓时段为空，与平仓时刻相同时平仓后立即恢复开仓。
+func validateFlat(settings TradeSettings) error {
+	if settings.FlatBy == "" {
+		return nil
+	}
+	at, err := ClockOffset(settings.FlatBy)
+	if err != nil {
+		return fmt.Errorf("flatBy: %w", err)
+	}
+	resume, err := ClockOffset(settings.FlatResumeAt)
+	if err != nil {
+		return fmt.Errorf("flatResumeAt: %w", err)
+	}
+	if settings.FlatNoEntryMinutes < 0 || settings.FlatNoEntryMinutes >= 24*60 {
+		return errors.New("flatNoEntryMinutes 需在 0~1439 之间")
+	}
+	day := 24 * time.Hour
+	stop := (at - time.Duration(settings.FlatNoEntryMinutes)*time.Minute + day) % day
+	if since := (resume - stop + day) % day; since <= (at-stop+day)%day {
+		return fmt.Errorf("flatResumeAt %s 需晚于平仓 %s 且早于停止开仓（平仓前 %d 分钟）", settings.FlatResumeAt, settings.FlatBy, settings.FlatNoEntryMinutes)
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -20,6 +22,52 @@ type Config struct {
 	Logging   LoggingConfig   `json:"logging"`
 	Exchanges ExchangeConfig  `json:"exchanges"`
 	CoinPool  CoinPoolConfig  `json:"coinPool"`
+
+	Liquidations LiquidationConfig `json:"liquidations"`
+	OrderFlow    OrderFlowConfig   `json:"orderFlow"`
+	Simulator    SimulatorConfig   `json:"simulator"`
+	Ollama       OllamaConfig      `json:"ollama"`
+	Claude       ClaudeConfig      `json:"claude"`
+	Mock         MockAIConfig      `json:"mock"`
+	Watchlist    WatchlistConfig   `json:"watchlist"`
+	Ensemble     EnsembleConfig    `json:"ensemble"`
+
+	// Plugins 为进程外AI提供商，键名即 decisionProvider 中使用的名称。
+	Plugins map[string]PluginConfig `json:"plugins"`
+	// ExecStrategies 为进程外策略，键名即交易者 strategy 中使用的名称。
+	ExecStrategies map[string]ExecStrategyConfig `json:"execStrategies"`
+	// MetaStrategies 为按权重组合多个子策略的组合策略，键名即交易者 strategy 中使用的名称。
+	MetaStrategies map[string]MetaStrategyConfig `json:"metaStrategies"`
+	// AIPricing 为各模型的 token 单价，用于折算AI费用；未列出的模型使用内置价目表。
+	AIPricing AIPricing `json:"aiPricing"`
+	// AICache 为决策结果的短期缓存。
+	AICache AICacheConfig `json:"aiCache"`
+	// AIBudget 为按提供商、按交易者的AI调用预算。
+	AIBudget AIBudgetConfig `json:"aiBudget"`
+	// AILearning 为从历史成交中选取学习片段的方式。
+	AILearning AILearningConfig `json:"aiLearning"`
+	// AIMemory 为按情形相似度检索历史决策的长期记忆。
+	AIMemory AIMemoryConfig `json:"aiMemory"`
+	// AIHealth 为AI提供商的探活周期。
+	AIHealth AIHealthConfig `json:"aiHealth"`
+	// AITools 为决策时模型可调用的数据工具。
+	AITools AIToolsConfig `json:"aiTools"`
+	// AIPrivacy 控制发往AI提供商的提示词中是否包含账户绝对金额。
+	AIPrivacy AIPrivacyConfig `json:"aiPrivacy"`
+	// Guardrails 为模型给出决策之后的确定性规则。
+	Guardrails GuardrailsConfig `json:"guardrails"`
+	// Shadow 为每日影子回测校验。
+	Shadow ShadowConfig `json:"shadow"`
+	// Outcome 为按事后行情标注历史决策的后台任务。
+	Outcome OutcomeConfig `json:"outcome"`
+	// Failover 为主备热切换。
+	Failover FailoverConfig `json:"failover"`
+	// Update 为新版本检查。
+	Update UpdateConfig `json:"update"`
+	// Web 为看板推送接口的监听配置。
+	Web WebConfig `json:"web"`
+	// Dashboard 为终端看板的显示设置。
+	Dashboard DashboardConfig `json:"dashboard"`
 }
 
 // GlobalConfig 定义全局默认值。
@@ -28,8 +76,18 @@ type GlobalConfig struct {
 	ScanIntervalMinutes int           `json:"scanIntervalMinutes"`
 	DryRun              bool          `json:"dryRun"`
 	Defaults            TradeSettings `json:"defaults"`
+
+	// ExistingPositions 为启动时账户已有持仓的处理方式：ask 交互询问、adopt 接管并挂保护单、ignore 忽略。
+	ExistingPositions string `json:"existingPositions"`
 }
 
+// 启动时已有持仓的处理方式。
+const (
+	ExistingPositionsAsk    = "ask"
+	ExistingPositionsAdopt  = "adopt"
+	ExistingPositionsIgnore = "ignore"
+)
+
 // TraderProfile 定义单个自动交易者。
 type TraderProfile struct {
 	Name             string        `json:"name"`
@@ -38,6 +96,43 @@ type TraderProfile struct {
 	Interval         string        `json:"interval"`
 	DecisionProvider string        `json:"decisionProvider"`
 	Settings         TradeSettings `json:"settings"`
+
+	// Timeframes 为除 interval 外额外拉取的K线周期，如 ["1h", "4h"]；各周期K线以 周期→K线 一并传给策略，
+	// interval 仍为信号周期。配合 settings.trendEmaPeriod 作高周期趋势过滤。
+	Timeframes []string `json:"timeframes"`
+
+	// Account 引用 exchanges.accounts 中的命名账户，留空使用该交易所的默认密钥。
+	Account string `json:"account"`
+	// Strategy 为策略注册表中的策略名，留空使用 ema_rsi_macd 组合策略。
+	Strategy string `json:"strategy"`
+	// StrategyParams 覆盖所选策略读取的参数（键为 settings 中的字段名，如 {"superTrendPeriod": 14}），
+	// 只作用于策略信号；可用的键见 strategy.Params。
+	StrategyParams map[string]float64 `json:"strategyParams"`
+	// FallbackProviders 为 decisionProvider 出错或决策校验失败时依次尝试的备用提供商，例如 ["qwen", "ollama"]。
+	FallbackProviders []string `json:"fallbackProviders"`
+	// NewsProvider 为新闻情绪分析使用的提供商，留空时与决策共用 decisionProvider 及其备用链；
+	// 设置后新闻分析只走该提供商（失败或超时改用本地词典），决策仍走 decisionProvider。
+	NewsProvider string `json:"newsProvider"`
+	// NewsModel 覆盖 newsProvider 使用的模型，例如决策用 qwen-max、新闻用 qwen-turbo；留空沿用该提供商的 model。
+	NewsModel string `json:"newsModel"`
+	// SelfCritique 为 true 时开仓与加仓决策在执行前发回模型复核一次，由模型确认或修改（只能维持或降低风险），
+	// 每次开仓多一次AI调用。
+	SelfCritique bool `json:"selfCritique"`
+}
+
+// Providers 返回按优先级排列的决策提供商名称，主提供商在前且去重。
+func (p TraderProfile) Providers() []string {
+	names := []string{p.DecisionProvider}
+	seen := map[string]bool{strings.ToLower(strings.TrimSpace(p.DecisionProvider)): true}
+	for _, name := range p.FallbackProviders {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
 }
 
 // TradeSettings 包含交易参数。
@@ -62,6 +157,104 @@ type TradeSettings struct {
 	MACDSlowPeriod      int      `json:"macdSlowPeriod"`
 	MACDSignalPeriod    int      `json:"macdSignalPeriod"`
 	CandidateSymbols    []string `json:"candidateSymbols"`
+
+	// VolumeConfirmMultiple 大于0时，ema_rsi_macd 的交叉信号需放量确认：信号K线成交量不低于前 VolumeConfirmPeriod
+	// 根（默认20）均量的该倍数，且同期 OBV 与信号同向，避免“涨但量萎缩”时入场。
+	VolumeConfirmMultiple float64 `json:"volumeConfirmMultiple"`
+	VolumeConfirmPeriod   int     `json:"volumeConfirmPeriod"`
+
+	// PatternConfirmationBars 大于0时，开仓信号需在最近N根K线内出现同向K线形态确认。
+	PatternConfirmationBars int `json:"patternConfirmationBars"`
+	// ProfileStopBufferPercent 大于0时，止损放在成交量价值区边界外该百分比处（多单在价值区下沿下方），取代固定百分比止损。
+	ProfileStopBufferPercent float64 `json:"profileStopBufferPercent"`
+
+	// Donchian 通道突破策略参数：入场通道周期、离场（移动止损）通道周期、ATR 周期及初始止损的 ATR 倍数。
+	DonchianPeriod     int     `json:"donchianPeriod"`
+	DonchianExitPeriod int     `json:"donchianExitPeriod"`
+	ATRPeriod          int     `json:"atrPeriod"`
+	ATRStopMultiple    float64 `json:"atrStopMultiple"`
+
+	// SuperTrend 策略参数：ATR 周期（默认10）与带宽的 ATR 倍数（默认3）。
+	SuperTrendPeriod     int     `json:"superTrendPeriod"`
+	SuperTrendMultiplier float64 `json:"superTrendMultiplier"`
+
+	// StopLossATRMultiple / TakeProfitATRMultiple 大于0时止损/止盈距离为该倍数 × ATR(atrPeriod，默认14)，
+	// 取代 stopLossPercent / takeProfitPercent，使止损随币种波动率伸缩；K线不足时仍按百分比。
+	StopLossATRMultiple   float64 `json:"stopLossAtrMultiple"`
+	TakeProfitATRMultiple float64 `json:"takeProfitAtrMultiple"`
+
+	// ADXMinStrength 大于0时，ADX(adxPeriod，默认14) 低于该值（震荡行情）时不开仓，常用 20~25。
+	ADXPeriod      int     `json:"adxPeriod"`
+	ADXMinStrength float64 `json:"adxMinStrength"`
+
+	// CCIPeriod 为行情快照与策略中 CCI（顺势指标）的周期，0 为默认20。
+	CCIPeriod int `json:"cciPeriod"`
+
+	// IntentHoldSeconds 为下单意图在执行前保持 pending 的秒数，期间操作者可在看板或 /api/intents 撤销；0 为立即执行。
+	IntentHoldSeconds int `json:"intentHoldSeconds"`
+
+	// TrendEMAPeriod 大于0时，开仓方向需与交易者 timeframes 中每个周期的趋势一致：做多要求各周期
+	// 收盘价在 EMA(trendEmaPeriod) 之上，做空要求在其下；高周期K线不足时不开仓。
+	TrendEMAPeriod int `json:"trendEmaPeriod"`
+
+	// TakerFlowMinImbalance 大于0时，开仓信号需近5分钟主动买卖失衡同向且不低于该值（0~1）。
+	TakerFlowMinImbalance float64 `json:"takerFlowMinImbalance"`
+
+	// CandleType 为策略使用的K线类型：standard（默认）、heikin_ashi 或 renko；止损止盈仍按原始K线成交。
+	CandleType string `json:"candleType"`
+	// RenkoBrickPercent 为 renko 砖块大小占价格的百分比。
+	RenkoBrickPercent float64 `json:"renkoBrickPercent"`
+
+	// KlineSource 为策略信号使用的K线价格来源：last（默认，成交价K线）或 mark（标记价格K线，仅合约）；
+	// StopKlineSource 为止损管理与风控复核（ATR 止损、移动止损、关键位复核）使用的来源，默认同 klineSource。
+	// 交易所按标记价格触发止损与强平，插针时两者差异明显；交易所不提供标记价格K线时退回成交价K线。
+	KlineSource     string `json:"klineSource"`
+	StopKlineSource string `json:"stopKlineSource"`
+
+	// RampStartPercent 大于0时新部署的交易者以该比例的仓位起步，每个盈利日按比例提升，
+	// 累计 RampProfitableDays 个盈利日后恢复满仓；爬坡期间出现亏损日则重新计数。
+	RampStartPercent   float64 `json:"rampStartPercent"`
+	RampProfitableDays int     `json:"rampProfitableDays"`
+
+	// FlatBy 设置后（HH:MM，UTC）每天该时刻平掉全部持仓，提前 FlatNoEntryMinutes 分钟（默认30）停止开新仓，
+	// 直到 FlatResumeAt（HH:MM，UTC，默认 00:00）恢复，用于不愿隔夜持仓的交易者。
+	FlatBy             string `json:"flatBy"`
+	FlatNoEntryMinutes int    `json:"flatNoEntryMinutes"`
+	FlatResumeAt       string `json:"flatResumeAt"`
+
+	// ConfidenceSizing 非空时开仓仓位倍数按AI置信度分档决定，不再使用模型给出的 sizeMultiplier：
+	// 取 min 不高于置信度的最高一档，低于最低一档时不开仓；分档倍数仍受 adjustmentGuard 上限约束。
+	ConfidenceSizing []ConfidenceBracket `json:"confidenceSizing"`
+}
+
+// ConfidenceBracket 为一档置信度分档：AI置信度（0~1）不低于 Min 时仓位倍数为 Multiplier。
+type ConfidenceBracket struct {
+	Min        float64 `json:"min"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// 合约类型取值。
+const (
+	ContractTypePerpetual = "PERPETUAL"
+	ContractTypeSpot      = "SPOT"
+)
+
+// K线类型取值。
+const (
+	CandleTypeStandard   = "standard"
+	CandleTypeHeikinAshi = "heikin_ashi"
+	CandleTypeRenko      = "renko"
+)
+
+// K线价格来源取值。
+const (
+	KlineSourceLast = "last"
+	KlineSourceMark = "mark"
+)
+
+// IsSpot 判断是否为现货模式（无杠杆、仅做多）。
+func (s TradeSettings) IsSpot() bool {
+	return strings.EqualFold(strings.TrimSpace(s.ContractType), ContractTypeSpot)
 }
 
 // DeepseekConfig 描述 DeepSeek AI 服务参数。
@@ -73,6 +266,14 @@ type DeepseekConfig struct {
 	Temperature float64 `json:"temperature"`
 	TopP        float64 `json:"topP"`
 	MaxTokens   int     `json:"maxTokens"`
+
+	// PlainOutput 为 true 时不使用原生结构化输出（函数调用/response_format），
+	// 退回提示词约束加文本解析，用于不支持这些参数的代理或旧模型。
+	PlainOutput bool `json:"plainOutput"`
+	// Stream 为 true 时以 SSE 流式接收决策，思维链逐行推送到看板。
+	Stream bool `json:"stream"`
+	// ContextWindow 为模型上下文窗口（token），0 时按模型名取内置值；决策提示词超出时自动压缩。
+	ContextWindow int `json:"contextWindow"`
 }
 
 // QwenConfig 描述通义千问配置。
@@ -82,6 +283,274 @@ type QwenConfig struct {
 	Model       string  `json:"model"`
 	Temperature float64 `json:"temperature"`
 	TopP        float64 `json:"topP"`
+
+	// PlainOutput 为 true 时不发送 response_format=json_object。
+	PlainOutput bool `json:"plainOutput"`
+	// Stream 为 true 时以 SSE 流式接收输出（含 qwen3 的 reasoning_content），逐行推送到看板。
+	Stream bool `json:"stream"`
+	// ContextWindow 含义同 deepseek.contextWindow。
+	ContextWindow int `json:"contextWindow"`
+}
+
+// PluginConfig 描述一个 exec 提供商：以 Command/Args 启动子进程，经 stdin/stdout 上的
+// JSON-RPC 交互；Env 追加到子进程环境变量，TimeoutSeconds 为单次调用超时（默认60秒）。
+type PluginConfig struct {
+	Command        string            `json:"command"`
+	Args           []string          `json:"args"`
+	Env            map[string]string `json:"env"`
+	TimeoutSeconds int               `json:"timeoutSeconds"`
+}
+
+// ExecStrategyConfig 描述一个 exec 策略：每次评估把最近 Window 根K线（默认200）连同 Params
+// 以一行JSON写入子进程 stdin，并读取一行信号，适合直接复用 Python 等语言的研究代码。
+type ExecStrategyConfig struct {
+	Command        string            `json:"command"`
+	Args           []string          `json:"args"`
+	Env            map[string]string `json:"env"`
+	TimeoutSeconds int               `json:"timeoutSeconds"`
+	Window         int               `json:"window"`
+	Params         map[string]any    `json:"params"`
+}
+
+// MetaStrategyConfig 描述一个组合策略：各子策略的信号按 Weight 加权投票，某一信号（多、空或出场）的权重占
+// 全部子策略权重的比例不低于 MinAgreement（0~1，默认0.5）且多于其他信号时输出该信号，否则观望。
+type MetaStrategyConfig struct {
+	Strategies   []MetaStrategyChild `json:"strategies"`
+	MinAgreement float64             `json:"minAgreement"`
+}
+
+// MetaStrategyChild 为组合策略中的一个子策略，Name 为内置或 exec 策略名，Weight 默认1。
+type MetaStrategyChild struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// EnsembleConfig 为 decisionProvider=ensemble 时参与投票的提供商。MinVotes 为最少有效回答数，
+// 0 表示过半。
+type EnsembleConfig struct {
+	Providers []string `json:"providers"`
+	MinVotes  int      `json:"minVotes"`
+}
+
+// AICacheConfig 控制决策缓存：TTL 内交易对、行情快照与持仓完全相同的请求直接复用上次决策，
+// 避免重试或多个交易者同一交易对时重复付费。TTL 为 "0" 时关闭。
+type AICacheConfig struct {
+	TTL string `json:"ttl"`
+}
+
+// AIBudgetConfig 为AI调用预算，键分别为提供商名与交易者名。每次调用模型前检查，
+// 任一上限用尽时决策直接返回 wait 并在 riskNotes 中注明。
+type AIBudgetConfig struct {
+	Providers map[string]BudgetLimits `json:"providers"`
+	Traders   map[string]BudgetLimits `json:"traders"`
+}
+
+// AIPrivacyConfig 控制提示词脱敏：RedactAmounts 为 true 时账户净值、余额、盈亏、保证金与持仓数量
+// 换算为占净值的百分比后再发给提供商（本地 mock 提供商除外），第三方模型看不到账户的绝对规模。
+type AIPrivacyConfig struct {
+	RedactAmounts bool `json:"redactAmounts"`
+}
+
+// GuardrailsConfig 为决策生成之后的确定性护栏，不论模型输出什么都会执行：资金费率过高时否决开多、
+// 过低时否决开空，限制 sizeMultiplier，保证金占用（百分比）达到 ReduceMarginUsage 时有持仓改为减仓、
+// 无持仓否决开仓。为 0 的规则不生效。
+type GuardrailsConfig struct {
+	MaxFundingForLong  float64 `json:"maxFundingForLong"`
+	MinFundingForShort float64 `json:"minFundingForShort"`
+	MaxSizeMultiplier  float64 `json:"maxSizeMultiplier"`
+	ReduceMarginUsage  float64 `json:"reduceMarginUsage"`
+}
+
+// AILearningConfig 控制决策请求中的学习片段：从 Lookback 内已平仓的成交中选出盈利最多与亏损最多的
+// 各 Trades 笔，连同当时的开仓理由一起放入提示词。Lookback 为 "0" 时关闭。
+type AILearningConfig struct {
+	Trades   int    `json:"trades"`
+	Lookback string `json:"lookback"`
+}
+
+// AIMemoryConfig 控制长期记忆：每次决策时把行情情形向量化，检索相似度不低于 MinScore 的 TopK 条
+// 历史情形及当时的决策放入提示词，并把本次情形与决策存入记忆。Embedder 为 hash（本地特征哈希，
+// 无需模型）、ollama（/api/embed）或 openai（OpenAI 兼容的 /embeddings，密钥取 EMBEDDING_API_KEY）。
+type AIMemoryConfig struct {
+	Enabled    bool    `json:"enabled"`
+	Embedder   string  `json:"embedder"`
+	BaseURL    string  `json:"baseUrl"`
+	Model      string  `json:"model"`
+	TopK       int     `json:"topK"`
+	MinScore   float64 `json:"minScore"`
+	MaxEntries int     `json:"maxEntries"`
+}
+
+// AIToolsConfig 控制决策时的数据工具：Enabled 为 true 时模型可先调用 get_klines、get_depth、
+// get_funding_history 获取更多数据再提交决策，最多 MaxRounds 轮。目前只有 deepseek 支持（需未开启 plainOutput）。
+type AIToolsConfig struct {
+	Enabled   bool `json:"enabled"`
+	MaxRounds int  `json:"maxRounds"`
+}
+
+// AIHealthConfig 控制AI提供商探活：启动时及每隔 Interval 请求各提供商的模型列表等轻量接口，
+// 校验密钥并测量延迟，失败的提供商在回退链中排到最后。Interval 为 "0" 时只在启动时探活一次。
+type AIHealthConfig struct {
+	Interval string `json:"interval"`
+	Timeout  string `json:"timeout"`
+}
+
+// ShadowConfig 控制影子校验：每天 RunAt（UTC，HH:MM）用实盘配置回测前一个UTC自然日的行情，
+// 并与实盘成交逐笔对比。入场滑点均值超过 MaxSlippageBps，或对不上的入场占比超过 MaxMismatchRatio 时告警，
+// 用于发现回测与实盘之间的漂移。实盘入场与回测入场方向相同、时间相差不超过 MatchWindow 视为同一次入场。
+type ShadowConfig struct {
+	RunAt            string  `json:"runAt"`
+	WarmupBars       int     `json:"warmupBars"`
+	MatchWindow      string  `json:"matchWindow"`
+	MaxSlippageBps   float64 `json:"maxSlippageBps"`
+	MaxMismatchRatio float64 `json:"maxMismatchRatio"`
+}
+
+// OutcomeConfig 控制决策结果标注：启用后每隔 Every 为 Lookback 内已走完各观察周期（Horizons）的决策，
+// 按决策之后的 Interval K线计算涨跌幅并标注好/中性/坏，结果追加到存储目录的 decision_labels.jsonl。
+// 涨跌幅在 ±NeutralPercent（%）之内视为中性；观望决策在该范围内为好。
+type OutcomeConfig struct {
+	Enabled        bool     `json:"enabled"`
+	Horizons       []string `json:"horizons"`
+	NeutralPercent float64  `json:"neutralPercent"`
+	Interval       string   `json:"interval"`
+	Every          string   `json:"every"`
+	Lookback       string   `json:"lookback"`
+}
+
+// FailoverConfig 控制主备热切换：Role 为 primary 或 standby 时启用，两个实例使用同一份配置并共享
+// storage.path（网络盘或同步目录）。主实例每隔 Heartbeat 在租约文件中写入心跳，备用实例超过 Timeout
+// 未见心跳时接管交易；NodeID 为实例名，默认为主机名。LeaseFile 为空时使用存储目录下的 failover.json。
+type FailoverConfig struct {
+	Role      string `json:"role"`
+	NodeID    string `json:"nodeId"`
+	Heartbeat string `json:"heartbeat"`
+	Timeout   string `json:"timeout"`
+	LeaseFile string `json:"leaseFile"`
+}
+
+// DefaultUpdateURL 为默认查询的最新发布接口。
+const DefaultUpdateURL = "https://api.github.com/repos/kasading12134/Ox-and-Horse-Toolbox/releases/latest"
+
+// UpdateConfig 控制新版本检查：Check 为 true 时启动时及每隔 Interval 查询 URL（GitHub 最新发布接口），
+// 发现比当前版本新的发布时在日志中提示，不会自动下载或替换程序。
+type UpdateConfig struct {
+	Check    bool   `json:"check"`
+	URL      string `json:"url"`
+	Interval string `json:"interval"`
+}
+
+// 长期记忆的向量化方式。
+const (
+	EmbedderHash   = "hash"
+	EmbedderOllama = "ollama"
+	EmbedderOpenAI = "openai"
+)
+
+// WebConfig 为看板的 HTTP 接口。Listen 为监听地址（如 127.0.0.1:8080），留空时不启动；
+// /ws 以 WebSocket 推送持仓、决策、净值与新闻的变化，供自定义前端与手机客户端订阅。
+// Token 不为空时所有接口都需携带该令牌，并启用 /api/summary 精简概览。
+type WebConfig struct {
+	Listen string `json:"listen"`
+	Token  string `json:"token"`
+}
+
+// DashboardConfig 为终端看板的显示设置。
+type DashboardConfig struct {
+	// PriceDecimals 按交易对（大写）覆盖价格显示的小数位；未列出的交易对按交易所最小价格变动单位推算，
+	// 取不到时按价格大小自动选择。
+	PriceDecimals map[string]int `json:"priceDecimals"`
+	// ManualOrders 开启终端手动下单表单（键盘输入 m 回车），默认关闭。手动单由 execution.ManualDesk 执行，与AI交易走同一风控闸门与下单流程。
+	ManualOrders bool `json:"manualOrders"`
+	// ManualMaxNotional 为单笔手动下单的名义价值上限（USDT），0 表示不限。
+	ManualMaxNotional float64 `json:"manualMaxNotional"`
+}
+
+// BudgetLimits 为单项预算上限，0 表示不限。小时为滚动窗口，日为UTC自然日。
+type BudgetLimits struct {
+	MaxCallsPerHour int     `json:"maxCallsPerHour"`
+	MaxTokensPerDay int     `json:"maxTokensPerDay"`
+	MaxUSDPerDay    float64 `json:"maxUsdPerDay"`
+}
+
+// WatchlistConfig 为仅观察不交易的交易对：照常计算策略信号、生成AI点评并在看板展示，但从不下单，
+// 适合新市场正式启用前先观察一段时间。Provider 留空时不调用AI。
+type WatchlistConfig struct {
+	Symbols         []string `json:"symbols"`
+	Exchange        string   `json:"exchange"`
+	Interval        string   `json:"interval"`
+	RefreshInterval string   `json:"refreshInterval"`
+	Provider        string   `json:"provider"`
+	Strategy        string   `json:"strategy"`
+}
+
+// IsWatchOnly 报告交易对是否位于观察列表中；下单路径须据此拒绝交易。
+func (c Config) IsWatchOnly(symbol string) bool {
+	for _, watched := range c.Watchlist.Symbols {
+		if strings.EqualFold(watched, symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaudeConfig 描述 Anthropic Claude（Messages API）配置，APIKey 可由环境变量 ANTHROPIC_API_KEY 覆盖。
+type ClaudeConfig struct {
+	Enabled     bool    `json:"enabled"`
+	BaseURL     string  `json:"baseUrl"`
+	APIKey      string  `json:"apiKey"`
+	Model       string  `json:"model"`
+	MaxTokens   int     `json:"maxTokens"`
+	Temperature float64 `json:"temperature"`
+
+	// PlainOutput 为 true 时不强制调用决策工具，改为解析文本中的JSON。
+	PlainOutput bool `json:"plainOutput"`
+	// ContextWindow 含义同 deepseek.contextWindow。
+	ContextWindow int `json:"contextWindow"`
+}
+
+// OllamaConfig 描述本地 Ollama 服务。Host 默认 http://localhost:11434；KeepAlive 为模型在内存中
+// 保留的时长（如 "30m"，"-1" 常驻），留空使用 Ollama 默认值；NumCtx 为上下文窗口，0 使用模型默认值。
+type OllamaConfig struct {
+	Enabled        bool    `json:"enabled"`
+	Host           string  `json:"host"`
+	Model          string  `json:"model"`
+	KeepAlive      string  `json:"keepAlive"`
+	Temperature    float64 `json:"temperature"`
+	TopP           float64 `json:"topP"`
+	NumCtx         int     `json:"numCtx"`
+	TimeoutSeconds int     `json:"timeoutSeconds"`
+
+	// PlainOutput 为 true 时决策请求只用 format=json，不传入决策 schema（Ollama 0.5 之前的版本）。
+	PlainOutput bool `json:"plainOutput"`
+}
+
+// decisionProvider=mock 的决策方式。
+const (
+	MockModeFollow = "follow"
+	MockModeHold   = "hold"
+	MockModeScript = "script"
+)
+
+// MockAIConfig 描述 decisionProvider=mock 的确定性提供商：不需要密钥，也不发网络请求，用于集成测试与试运行。
+// Mode 为 follow（跟随策略信号开平仓，默认）、hold（不开新仓，只在出场或反向信号时平仓）或 script
+// （依次返回 Responses，用完后重复最后一条）。Sentiment 非空时新闻分析固定返回该情绪与 Score，
+// 否则按本地词典评分。
+type MockAIConfig struct {
+	Mode      string         `json:"mode"`
+	Responses []MockResponse `json:"responses"`
+	Sentiment string         `json:"sentiment"`
+	Score     float64        `json:"score"`
+}
+
+// MockResponse 为 script 模式下的一条决策，调整项为 0 时使用交易者配置。
+type MockResponse struct {
+	Action            string  `json:"action"`
+	Confidence        float64 `json:"confidence"`
+	Reason            string  `json:"reason"`
+	SizeMultiplier    float64 `json:"sizeMultiplier"`
+	StopLossPercent   float64 `json:"stopLossPercent"`
+	TakeProfitPercent float64 `json:"takeProfitPercent"`
 }
 
 // NewsConfig 控制新闻源抓取。
@@ -95,6 +564,64 @@ type NewsConfig struct {
 	CacheTTL string `json:"cacheTtl"`
 	// BlockbeatsDisabled 允许在保持其他新闻源启用的情况下单独关闭律动新闻。
 	BlockbeatsDisabled bool `json:"blockbeatsDisabled"`
+
+	// Lexicon 补充或覆盖本地情绪词典的关键词权重（-1 利空 ~ 1 利好），AI不可用时用于兜底评分。
+	Lexicon map[string]float64 `json:"lexicon"`
+
+	// Filter 在抓取之后、缓存之前按语言、域名与来源过滤新闻。
+	Filter NewsFilterConfig `json:"filter"`
+	// DedupTTL 为已见新闻哈希与情绪分析结果的保留时长（默认 24h），落盘后重启不会重复提醒与分析；"0" 关闭。
+	DedupTTL string `json:"dedupTtl"`
+
+	// Sentiment 为新闻情绪的评分方式：ai（默认）由决策提供商分析，失败时改用本地词典；
+	// lexicon 始终使用本地词典，不调用模型。
+	Sentiment string `json:"sentiment"`
+	// AnalyzeTimeout 为模型分析新闻的最长等待时间（默认 30s），超时改用本地词典，不阻塞交易周期；"0" 不限。
+	AnalyzeTimeout string `json:"analyzeTimeout"`
+
+	// Webhook 为 provider 为 webhook 时的字段映射，用 JSONPath 从任意 JSON 新闻接口中取出新闻，无需写代码接入。
+	Webhook NewsWebhookConfig `json:"webhook"`
+}
+
+// NewsProviderWebhook 为按 news.webhook 字段映射解析响应的自定义新闻源。
+const NewsProviderWebhook = "webhook"
+
+// NewsWebhookConfig 描述自定义新闻接口（apiUrl）的响应结构。路径为 JSONPath 子集：以 $ 开头，
+// 支持 .key、['key']、[n] 与 [*]。
+type NewsWebhookConfig struct {
+	// Items 指向新闻数组，如 $.data.list；留空表示响应本身就是数组。
+	Items string `json:"items"`
+	// Title、Summary、URL、Time、Source 为相对单条新闻的路径，如 $.headline、$.meta.author；Title 必填，其余留空不取。
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+	URL     string `json:"url"`
+	Time    string `json:"time"`
+	Source  string `json:"source"`
+	// TimeLayout 为时间字段的 Go 时间格式（如 2006-01-02 15:04:05），留空时自动识别常见日期格式与秒/毫秒时间戳。
+	TimeLayout string `json:"timeLayout"`
+	// SourceName 为没有配置 Source 或取不到来源时的来源名称，留空为 webhook。
+	SourceName string `json:"sourceName"`
+	// Headers 为附加的请求头；apiKey 非空且未设置 Authorization 时以 Bearer 方式发送。
+	Headers map[string]string `json:"headers"`
+}
+
+// 新闻情绪的评分方式。
+const (
+	NewsSentimentAI      = "ai"
+	NewsSentimentLexicon = "lexicon"
+)
+
+// NewsFilterConfig 为新闻过滤条件，留空的条件不生效。
+type NewsFilterConfig struct {
+	// Languages 只保留这些语言的新闻（zh 或 en，按标题与摘要的字符判断）。
+	Languages []string `json:"languages"`
+	// Domains 只保留链接域名（含子域名）在列表中的新闻；ExcludeDomains 排除这些域名。
+	Domains        []string `json:"domains"`
+	ExcludeDomains []string `json:"excludeDomains"`
+	// ExcludeSources 按来源名称（不区分大小写）排除新闻。
+	ExcludeSources []string `json:"excludeSources"`
+	// SourceMaxItems 限制每个来源保留的条数，键为来源名称（不区分大小写）。
+	SourceMaxItems map[string]int `json:"sourceMaxItems"`
 }
 
 // CoinPoolConfig 控制多源币种池。
@@ -106,6 +633,78 @@ type CoinPoolConfig struct {
 	OITopAPIKey     string `json:"oi_top_api_key"`
 	CacheTTL        string `json:"cache_ttl"`
 	MaxCombined     int    `json:"max_combined"`
+
+	// SentimentWeight 为币种新闻情绪对候选评分的加权系数，缺省 0.5，设为 0 关闭。
+	SentimentWeight *float64 `json:"sentiment_weight"`
+
+	// LiquidityWeight 大于0时候选币种按综合评分 (1-w)×币种池排名 + w×盘口流动性排名 重新排序，
+	// 并标注单笔安全下单额，0（默认）关闭。
+	LiquidityWeight float64 `json:"liquidity_weight"`
+	// LiquiditySlippagePercent 为计入可成交深度的价格范围（距中间价百分比），缺省 0.2。
+	LiquiditySlippagePercent float64 `json:"liquidity_slippage_percent"`
+	// LiquidityBookShare 为单笔最多吃掉该范围内深度的比例，缺省 0.25。
+	LiquidityBookShare float64 `json:"liquidity_book_share"`
+
+	// Onboarding 为新币种准入检查，首次进入币种池的币种通过检查前留在试用期、不交给交易者。
+	Onboarding CoinOnboardingConfig `json:"onboarding"`
+}
+
+// CoinOnboardingConfig 为新币种准入检查的门槛，为 0 的项不检查。
+type CoinOnboardingConfig struct {
+	Enabled bool `json:"enabled"`
+	// Interval 与 MinCandles：该周期至少要有的历史K线根数，缺省 1h / 100。
+	Interval   string `json:"interval"`
+	MinCandles int    `json:"min_candles"`
+	// MinQuoteVolume 为24小时成交额（USDT）下限，缺省 10000000。
+	MinQuoteVolume float64 `json:"min_quote_volume"`
+	// MaxTakerFeePercent 为吃单费率上限（百分比），交易所不提供按币种费率时按 exchanges.fees 判断。
+	MaxTakerFeePercent float64 `json:"max_taker_fee_percent"`
+	// RequiredLeverage 为币种最大杠杆的下限，留空按 global.defaults.leverage。
+	RequiredLeverage float64 `json:"required_leverage"`
+	// RecheckInterval 为试用期币种的重检间隔，缺省 30m。
+	RecheckInterval string `json:"recheck_interval"`
+}
+
+// SentimentWeighting 返回生效的情绪加权系数。
+func (c CoinPoolConfig) SentimentWeighting() float64 {
+	if c.SentimentWeight == nil {
+		return 0.5
+	}
+	return *c.SentimentWeight
+}
+
+// CloseAuditTolerance 返回生效的平仓核对数量偏差百分比。
+func (r RiskConfig) CloseAuditTolerance() float64 {
+	if r.CloseAuditTolerancePercent == nil {
+		return 1
+	}
+	return *r.CloseAuditTolerancePercent
+}
+
+// SimulatorConfig 为 dryRun 模式下模拟撮合的参数：以最新K线价格成交并计入滑点与手续费。
+// FeePercent 留空时按所属交易所的吃单费率（exchanges.fees）计费。
+type SimulatorConfig struct {
+	InitialBalance  float64 `json:"initialBalance"`
+	SlippagePercent float64 `json:"slippagePercent"`
+	FeePercent      float64 `json:"feePercent"`
+}
+
+// OrderFlowConfig 控制基于 bookTicker 的盘口订单流择时：开仓前最多等待 MaxEntryDelay，
+// 直到窗口内订单流失衡与微观价格漂移同向且失衡不低于 MinImbalance，超时仍照常开仓。
+type OrderFlowConfig struct {
+	Enabled       bool    `json:"enabled"`
+	StreamURL     string  `json:"streamUrl"`
+	Window        string  `json:"window"`
+	MaxEntryDelay string  `json:"maxEntryDelay"`
+	MinImbalance  float64 `json:"minImbalance"`
+}
+
+// LiquidationConfig 控制强平订单流监控。
+type LiquidationConfig struct {
+	Enabled        bool    `json:"enabled"`
+	StreamURL      string  `json:"streamUrl"`
+	MinNotionalUSD float64 `json:"minNotionalUsd"`
+	Window         string  `json:"window"`
 }
 
 // RiskConfig 定义附加风控。
@@ -118,12 +717,69 @@ type RiskConfig struct {
 	BtcEthNotionalMultiple float64 `json:"btcEthNotionalMultiple"`
 	AltNotionalMultiple    float64 `json:"altNotionalMultiple"`
 	MinRiskRewardRatio     float64 `json:"minRiskRewardRatio"`
+	// MaxMajorPositions / MaxAltPositions 分别限制账户同时持有的 BTC/ETH 与其他币种的持仓数，
+	// 由风控闸门在开新仓前检查（对已有持仓加仓不计为新仓），0 为不单独限制。
+	MaxMajorPositions int `json:"maxMajorPositions"`
+	MaxAltPositions   int `json:"maxAltPositions"`
+	// CloseAuditTolerancePercent 平仓前交易所持仓数量与本地记录允许的偏差百分比，缺省 1，设为 0 要求完全一致。
+	CloseAuditTolerancePercent *float64 `json:"closeAuditTolerancePercent"`
+
+	// ResistanceBufferPercent 开多时若现价上方该百分比内存在强阻力（开空对应强支撑）则拒绝入场，0 为关闭。
+	ResistanceBufferPercent float64 `json:"resistanceBufferPercent"`
+	// LevelMinStrength 参与上述判断的关键位最低强度。
+	LevelMinStrength int `json:"levelMinStrength"`
+	// MinEdgeCostMultiple 要求预期收益（止盈距离×置信度）至少为预期成本（往返手续费+滑点+资金费）
+	// 的该倍数才允许开仓，0 为关闭。
+	MinEdgeCostMultiple float64 `json:"minEdgeCostMultiple"`
+	// EdgeFundingPeriods 估算资金费成本时假设持仓经历的结算次数。
+	EdgeFundingPeriods int `json:"edgeFundingPeriods"`
+	// AdjustmentGuard 为AI调整参数的异常值防护。
+	AdjustmentGuard AdjustmentGuardConfig `json:"adjustmentGuard"`
+}
+
+// Bounds 为闭区间 [Min, Max]，Max 为 0 表示不设上限。
+type Bounds struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
 }
 
+// AdjustmentGuardConfig 限定AI调整参数的合理范围。Mode 为 clamp（默认，越界值夹紧到边界）
+// 或 reject（任一字段越界即拒绝整个决策）。AI未给出（为0）的字段不检查。
+type AdjustmentGuardConfig struct {
+	Mode                string `json:"mode"`
+	SizeMultiplier      Bounds `json:"sizeMultiplier"`
+	TargetLeverage      Bounds `json:"targetLeverage"`
+	StopLossPercent     Bounds `json:"stopLossPercent"`
+	TakeProfitPercent   Bounds `json:"takeProfitPercent"`
+	TrailingStopPercent Bounds `json:"trailingStopPercent"`
+}
+
+// 异常值防护模式。
+const (
+	GuardModeClamp  = "clamp"
+	GuardModeReject = "reject"
+)
+
 // StorageConfig 控制持久化。
 type StorageConfig struct {
 	Type string `json:"type"`
 	Path string `json:"path"`
+
+	// ExchangeAudit 为 true 时将每个签名请求（密钥脱敏）及原始响应写入 exchange_audit.jsonl。
+	ExchangeAudit bool `json:"exchangeAudit"`
+	// OrderJournal 为 true 时将下单、撤单请求及原始响应与用户数据流事件写入 order_journal.jsonl（密钥脱敏），
+	// 与 trades.jsonl 分开保存，用于与交易所核对成交争议。
+	OrderJournal bool `json:"orderJournal"`
+	// OrderJournalMaxMB 为单个订单日志文件的大小上限（MB，默认50），超过后轮转；OrderJournalKeep 为保留的历史文件数（默认10）。
+	OrderJournalMaxMB int `json:"orderJournalMaxMb"`
+	OrderJournalKeep  int `json:"orderJournalKeep"`
+
+	// EventLog 为 true 时把订单、决策、配置变更与人工干预写入哈希链式的 events.jsonl。
+	EventLog bool `json:"eventLog"`
+
+	// PerTrader 为 true 时各交易者的决策与成交写入 traders/<交易者>/ 下各自的文件，
+	// 按交易者查询时只读取对应文件；未开启前写入根目录的记录仍会被读取。
+	PerTrader bool `json:"perTrader"`
 }
 
 // ParsedConfig 为运行时提供解析后的配置。
@@ -133,7 +789,28 @@ type ParsedConfig struct {
 	NewsCacheTTL       time.Duration
 	RiskCheckDuration  time.Duration
 	CoinPoolTTL        time.Duration
+	OnboardingRecheck  time.Duration
+	LiquidationWindow  time.Duration
+	OrderFlowWindow    time.Duration
+	OrderFlowMaxDelay  time.Duration
+	WatchlistRefresh   time.Duration
+	AICacheTTL         time.Duration
+	NewsDedupTTL       time.Duration
+	NewsAnalyzeTimeout time.Duration
+	AILearningLookback time.Duration
+	AIHealthInterval   time.Duration
+	AIHealthTimeout    time.Duration
+	ShadowMatchWindow  time.Duration
+	OutcomeHorizons    []time.Duration
+	OutcomeEvery       time.Duration
+	OutcomeLookback    time.Duration
+	FailoverHeartbeat  time.Duration
+	FailoverTimeout    time.Duration
+	UpdateInterval     time.Duration
 	TraderProfiles     []TraderProfileResolved
+
+	// Deprecations 为配置文件中使用的已弃用字段，Load 已按新名称读取并输出到标准错误。
+	Deprecations []Deprecation
 }
 
 // TraderProfileResolved 合并全局默认值后的配置。
@@ -141,6 +818,10 @@ type TraderProfileResolved struct {
 	TraderProfile
 	Settings TradeSettings
 	DryRun   bool
+	// Risk 为全局风控叠加所属账户覆盖项后的结果。
+	Risk RiskConfig
+	// Fees 为所属交易所（现货/合约）的费率。
+	Fees FeeSchedule
 }
 
 // Load 读取配置文件并应用默认值。
@@ -154,6 +835,14 @@ func Load(path string) (ParsedConfig, error) {
 		return ParsedConfig{}, fmt.Errorf("read config: %w", err)
 	}
 
+	data, deprecations, err := migrateDeprecated(data)
+	if err != nil {
+		return ParsedConfig{}, err
+	}
+	for _, d := range deprecations {
+		fmt.Fprintf(os.Stderr, "config: %s\n", d)
+	}
+
 	cfg := Config{}
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return ParsedConfig{}, fmt.Errorf("parse config json: %w", err)
@@ -206,6 +895,97 @@ func Load(path string) (ParsedConfig, error) {
 		return ParsedConfig{}, fmt.Errorf("invalid coin pool cache ttl %q: %w", poolTTL, err)
 	}
 
+	onboardingRecheck, err := time.ParseDuration(cfg.CoinPool.Onboarding.RecheckInterval)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid coin onboarding recheck interval %q: %w", cfg.CoinPool.Onboarding.RecheckInterval, err)
+	}
+
+	liquidationWindow, err := time.ParseDuration(cfg.Liquidations.Window)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid liquidation window %q: %w", cfg.Liquidations.Window, err)
+	}
+
+	orderFlowWindow, err := time.ParseDuration(cfg.OrderFlow.Window)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid order flow window %q: %w", cfg.OrderFlow.Window, err)
+	}
+	orderFlowMaxDelay, err := time.ParseDuration(cfg.OrderFlow.MaxEntryDelay)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid order flow max entry delay %q: %w", cfg.OrderFlow.MaxEntryDelay, err)
+	}
+
+	watchlistRefresh, err := time.ParseDuration(cfg.Watchlist.RefreshInterval)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid watchlist refresh interval %q: %w", cfg.Watchlist.RefreshInterval, err)
+	}
+
+	aiCacheTTL, err := time.ParseDuration(cfg.AICache.TTL)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid ai cache ttl %q: %w", cfg.AICache.TTL, err)
+	}
+
+	newsDedupTTL, err := time.ParseDuration(cfg.News.DedupTTL)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid news dedup ttl %q: %w", cfg.News.DedupTTL, err)
+	}
+	newsAnalyzeTimeout, err := time.ParseDuration(cfg.News.AnalyzeTimeout)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid news analyze timeout %q: %w", cfg.News.AnalyzeTimeout, err)
+	}
+
+	aiLearningLookback, err := time.ParseDuration(cfg.AILearning.Lookback)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid ai learning lookback %q: %w", cfg.AILearning.Lookback, err)
+	}
+
+	aiHealthInterval, err := time.ParseDuration(cfg.AIHealth.Interval)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid ai health interval %q: %w", cfg.AIHealth.Interval, err)
+	}
+	aiHealthTimeout, err := time.ParseDuration(cfg.AIHealth.Timeout)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid ai health timeout %q: %w", cfg.AIHealth.Timeout, err)
+	}
+	if aiHealthTimeout <= 0 {
+		return ParsedConfig{}, errors.New("aiHealth.timeout 必须为正数")
+	}
+
+	shadowMatchWindow, err := time.ParseDuration(cfg.Shadow.MatchWindow)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid shadow match window %q: %w", cfg.Shadow.MatchWindow, err)
+	}
+
+	outcomeHorizons := make([]time.Duration, 0, len(cfg.Outcome.Horizons))
+	for _, raw := range cfg.Outcome.Horizons {
+		horizon, err := time.ParseDuration(raw)
+		if err != nil || horizon <= 0 {
+			return ParsedConfig{}, fmt.Errorf("invalid outcome horizon %q", raw)
+		}
+		outcomeHorizons = append(outcomeHorizons, horizon)
+	}
+	outcomeEvery, err := time.ParseDuration(cfg.Outcome.Every)
+	if err != nil || outcomeEvery <= 0 {
+		return ParsedConfig{}, fmt.Errorf("invalid outcome every %q", cfg.Outcome.Every)
+	}
+	outcomeLookback, err := time.ParseDuration(cfg.Outcome.Lookback)
+	if err != nil || outcomeLookback <= 0 {
+		return ParsedConfig{}, fmt.Errorf("invalid outcome lookback %q", cfg.Outcome.Lookback)
+	}
+
+	failoverHeartbeat, err := time.ParseDuration(cfg.Failover.Heartbeat)
+	if err != nil || failoverHeartbeat <= 0 {
+		return ParsedConfig{}, fmt.Errorf("invalid failover heartbeat %q", cfg.Failover.Heartbeat)
+	}
+	failoverTimeout, err := time.ParseDuration(cfg.Failover.Timeout)
+	if err != nil || failoverTimeout < 2*failoverHeartbeat {
+		return ParsedConfig{}, fmt.Errorf("failover.timeout %q 需不小于两倍心跳间隔", cfg.Failover.Timeout)
+	}
+
+	updateInterval, err := time.ParseDuration(cfg.Update.Interval)
+	if err != nil {
+		return ParsedConfig{}, fmt.Errorf("invalid update interval %q: %w", cfg.Update.Interval, err)
+	}
+
 	resolved := resolveProfiles(cfg)
 
 	return ParsedConfig{
@@ -214,7 +994,26 @@ func Load(path string) (ParsedConfig, error) {
 		NewsCacheTTL:       newsCacheDuration,
 		RiskCheckDuration:  riskCheckDuration,
 		CoinPoolTTL:        coinPoolTTL,
+		OnboardingRecheck:  onboardingRecheck,
+		LiquidationWindow:  liquidationWindow,
+		OrderFlowWindow:    orderFlowWindow,
+		OrderFlowMaxDelay:  orderFlowMaxDelay,
+		WatchlistRefresh:   watchlistRefresh,
+		AICacheTTL:         aiCacheTTL,
+		NewsDedupTTL:       newsDedupTTL,
+		NewsAnalyzeTimeout: newsAnalyzeTimeout,
+		AILearningLookback: aiLearningLookback,
+		AIHealthInterval:   aiHealthInterval,
+		AIHealthTimeout:    aiHealthTimeout,
+		ShadowMatchWindow:  shadowMatchWindow,
+		OutcomeHorizons:    outcomeHorizons,
+		OutcomeEvery:       outcomeEvery,
+		OutcomeLookback:    outcomeLookback,
+		FailoverHeartbeat:  failoverHeartbeat,
+		FailoverTimeout:    failoverTimeout,
+		UpdateInterval:     updateInterval,
 		TraderProfiles:     resolved,
+		Deprecations:       deprecations,
 	}, nil
 }
 
@@ -222,10 +1021,13 @@ func applyDefaults(cfg *Config) {
 	if cfg.Global.EvaluationInterval == "" {
 		cfg.Global.EvaluationInterval = "30s"
 	}
+	if cfg.Global.ExistingPositions == "" {
+		cfg.Global.ExistingPositions = ExistingPositionsAsk
+	}
 	// 默认交易参数
 	defaults := &cfg.Global.Defaults
 	if defaults.ContractType == "" {
-		defaults.ContractType = "PERPETUAL"
+		defaults.ContractType = ContractTypePerpetual
 	}
 	if defaults.Leverage == 0 {
 		defaults.Leverage = 5
@@ -281,6 +1083,24 @@ func applyDefaults(cfg *Config) {
 	if defaults.MACDSignalPeriod == 0 {
 		defaults.MACDSignalPeriod = 9
 	}
+	if defaults.CandleType == "" {
+		defaults.CandleType = CandleTypeStandard
+	}
+	if defaults.KlineSource == "" {
+		defaults.KlineSource = KlineSourceLast
+	}
+	if defaults.RenkoBrickPercent == 0 {
+		defaults.RenkoBrickPercent = 0.5
+	}
+	if defaults.RampProfitableDays == 0 {
+		defaults.RampProfitableDays = 5
+	}
+	if defaults.FlatNoEntryMinutes == 0 {
+		defaults.FlatNoEntryMinutes = 30
+	}
+	if defaults.FlatResumeAt == "" {
+		defaults.FlatResumeAt = "00:00"
+	}
 
 	if cfg.Deepseek.BaseURL == "" {
 		cfg.Deepseek.BaseURL = "https://api.deepseek.com"
@@ -317,12 +1137,164 @@ func applyDefaults(cfg *Config) {
 	if cfg.News.Lookback == "" {
 		cfg.News.Lookback = "2h"
 	}
+	if cfg.Watchlist.Interval == "" {
+		cfg.Watchlist.Interval = "15m"
+	}
+	// K线周期统一为规范写法，无法识别的留给 validate 报错
+	for i := range cfg.Traders {
+		if interval, err := NormalizeInterval(cfg.Traders[i].Interval); err == nil {
+			cfg.Traders[i].Interval = interval
+		}
+		for j, timeframe := range cfg.Traders[i].Timeframes {
+			if interval, err := NormalizeInterval(timeframe); err == nil {
+				cfg.Traders[i].Timeframes[j] = interval
+			}
+		}
+	}
+	if interval, err := NormalizeInterval(cfg.Watchlist.Interval); err == nil {
+		cfg.Watchlist.Interval = interval
+	}
+	if cfg.Watchlist.RefreshInterval == "" {
+		cfg.Watchlist.RefreshInterval = "5m"
+	}
+	if cfg.AICache.TTL == "" {
+		cfg.AICache.TTL = "30s"
+	}
+	if cfg.AIMemory.Embedder == "" {
+		cfg.AIMemory.Embedder = EmbedderHash
+	}
+	switch cfg.AIMemory.Embedder {
+	case EmbedderOllama:
+		if cfg.AIMemory.BaseURL == "" {
+			cfg.AIMemory.BaseURL = cfg.Ollama.Host
+		}
+		if cfg.AIMemory.BaseURL == "" {
+			cfg.AIMemory.BaseURL = "http://localhost:11434"
+		}
+		if cfg.AIMemory.Model == "" {
+			cfg.AIMemory.Model = "nomic-embed-text"
+		}
+	case EmbedderOpenAI:
+		if cfg.AIMemory.BaseURL == "" {
+			cfg.AIMemory.BaseURL = "https://api.openai.com/v1"
+		}
+		if cfg.AIMemory.Model == "" {
+			cfg.AIMemory.Model = "text-embedding-3-small"
+		}
+	}
+	if cfg.AIMemory.TopK == 0 {
+		cfg.AIMemory.TopK = 3
+	}
+	if cfg.AIMemory.MinScore == 0 {
+		cfg.AIMemory.MinScore = 0.8
+	}
+	if cfg.AIMemory.MaxEntries == 0 {
+		cfg.AIMemory.MaxEntries = 5000
+	}
+	if cfg.AITools.MaxRounds == 0 {
+		cfg.AITools.MaxRounds = 3
+	}
+	if cfg.Mock.Mode == "" {
+		cfg.Mock.Mode = MockModeFollow
+	}
+	if cfg.AILearning.Trades == 0 {
+		cfg.AILearning.Trades = 3
+	}
+	if cfg.AILearning.Lookback == "" {
+		cfg.AILearning.Lookback = "168h"
+	}
+	if cfg.AIHealth.Interval == "" {
+		cfg.AIHealth.Interval = "5m"
+	}
+	if cfg.AIHealth.Timeout == "" {
+		cfg.AIHealth.Timeout = "10s"
+	}
+	if cfg.Update.URL == "" {
+		cfg.Update.URL = DefaultUpdateURL
+	}
+	if cfg.Update.Interval == "" {
+		cfg.Update.Interval = "24h"
+	}
+	if cfg.Shadow.RunAt == "" {
+		cfg.Shadow.RunAt = "00:30"
+	}
+	if cfg.Shadow.WarmupBars == 0 {
+		cfg.Shadow.WarmupBars = 200
+	}
+	if cfg.Shadow.MatchWindow == "" {
+		cfg.Shadow.MatchWindow = "30m"
+	}
+	if cfg.Shadow.MaxSlippageBps == 0 {
+		cfg.Shadow.MaxSlippageBps = 15
+	}
+	if cfg.Shadow.MaxMismatchRatio == 0 {
+		cfg.Shadow.MaxMismatchRatio = 0.3
+	}
+	if cfg.Failover.Heartbeat == "" {
+		cfg.Failover.Heartbeat = "5s"
+	}
+	if cfg.Failover.Timeout == "" {
+		cfg.Failover.Timeout = "30s"
+	}
+	if cfg.Failover.NodeID == "" {
+		if host, err := os.Hostname(); err == nil {
+			cfg.Failover.NodeID = host
+		}
+	}
+	if len(cfg.Outcome.Horizons) == 0 {
+		cfg.Outcome.Horizons = []string{"1h", "4h", "24h"}
+	}
+	if cfg.Outcome.NeutralPercent == 0 {
+		cfg.Outcome.NeutralPercent = 0.3
+	}
+	if cfg.Outcome.Interval == "" {
+		cfg.Outcome.Interval = "15m"
+	}
+	if interval, err := NormalizeInterval(cfg.Outcome.Interval); err == nil {
+		cfg.Outcome.Interval = interval
+	}
+	if cfg.Outcome.Every == "" {
+		cfg.Outcome.Every = "1h"
+	}
+	if cfg.Outcome.Lookback == "" {
+		cfg.Outcome.Lookback = "168h"
+	}
+	if cfg.Claude.BaseURL == "" {
+		cfg.Claude.BaseURL = "https://api.anthropic.com"
+	}
+	if cfg.Claude.Model == "" {
+		cfg.Claude.Model = "claude-sonnet-4-5"
+	}
+	if cfg.Claude.MaxTokens == 0 {
+		cfg.Claude.MaxTokens = 2000
+	}
+	if cfg.Ollama.Host == "" {
+		cfg.Ollama.Host = "http://localhost:11434"
+	}
+	if cfg.Ollama.Model == "" {
+		cfg.Ollama.Model = "llama3.1"
+	}
+	if cfg.Ollama.Temperature == 0 {
+		cfg.Ollama.Temperature = 0.3
+	}
+	if cfg.Ollama.TimeoutSeconds == 0 {
+		cfg.Ollama.TimeoutSeconds = 180
+	}
 	if cfg.News.Provider == "" {
 		cfg.News.Provider = "cryptopanic"
 	}
 	if cfg.News.CacheTTL == "" {
 		cfg.News.CacheTTL = "2m"
 	}
+	if cfg.News.DedupTTL == "" {
+		cfg.News.DedupTTL = "24h"
+	}
+	if cfg.News.Sentiment == "" {
+		cfg.News.Sentiment = NewsSentimentAI
+	}
+	if cfg.News.AnalyzeTimeout == "" {
+		cfg.News.AnalyzeTimeout = "30s"
+	}
 
 	if cfg.Risk.MaxDailyLossPercent == 0 {
 		cfg.Risk.MaxDailyLossPercent = 5
@@ -345,6 +1317,31 @@ func applyDefaults(cfg *Config) {
 	if cfg.Risk.MinRiskRewardRatio == 0 {
 		cfg.Risk.MinRiskRewardRatio = 3
 	}
+	if cfg.Risk.LevelMinStrength == 0 {
+		cfg.Risk.LevelMinStrength = 2
+	}
+	if cfg.Risk.EdgeFundingPeriods == 0 {
+		cfg.Risk.EdgeFundingPeriods = 1
+	}
+	guard := &cfg.Risk.AdjustmentGuard
+	if guard.Mode == "" {
+		guard.Mode = GuardModeClamp
+	}
+	if guard.SizeMultiplier == (Bounds{}) {
+		guard.SizeMultiplier = Bounds{Min: 0.1, Max: 2}
+	}
+	if guard.TargetLeverage == (Bounds{}) {
+		guard.TargetLeverage = Bounds{Min: 1, Max: cfg.Risk.MaxLeverage}
+	}
+	if guard.StopLossPercent == (Bounds{}) {
+		guard.StopLossPercent = Bounds{Min: 0.2, Max: 10}
+	}
+	if guard.TakeProfitPercent == (Bounds{}) {
+		guard.TakeProfitPercent = Bounds{Min: 0.3, Max: 30}
+	}
+	if guard.TrailingStopPercent == (Bounds{}) {
+		guard.TrailingStopPercent = Bounds{Min: 0.1, Max: 10}
+	}
 
 	if cfg.Storage.Type == "" {
 		cfg.Storage.Type = "file"
@@ -352,6 +1349,15 @@ func applyDefaults(cfg *Config) {
 	if cfg.Storage.Path == "" {
 		cfg.Storage.Path = "data"
 	}
+	if cfg.Storage.OrderJournalMaxMB == 0 {
+		cfg.Storage.OrderJournalMaxMB = 50
+	}
+	if cfg.Storage.OrderJournalKeep == 0 {
+		cfg.Storage.OrderJournalKeep = 10
+	}
+	if cfg.Failover.LeaseFile == "" {
+		cfg.Failover.LeaseFile = filepath.Join(cfg.Storage.Path, "failover.json")
+	}
 
 	if cfg.Logging.Directory == "" {
 		cfg.Logging.Directory = "logs"
@@ -363,6 +1369,49 @@ func applyDefaults(cfg *Config) {
 	if cfg.CoinPool.MaxCombined == 0 {
 		cfg.CoinPool.MaxCombined = 24
 	}
+	if cfg.CoinPool.LiquiditySlippagePercent == 0 {
+		cfg.CoinPool.LiquiditySlippagePercent = 0.2
+	}
+	if cfg.CoinPool.LiquidityBookShare == 0 {
+		cfg.CoinPool.LiquidityBookShare = 0.25
+	}
+	if cfg.CoinPool.Onboarding.Interval == "" {
+		cfg.CoinPool.Onboarding.Interval = "1h"
+	}
+	if cfg.CoinPool.Onboarding.MinCandles == 0 {
+		cfg.CoinPool.Onboarding.MinCandles = 100
+	}
+	if cfg.CoinPool.Onboarding.MinQuoteVolume == 0 {
+		cfg.CoinPool.Onboarding.MinQuoteVolume = 10000000
+	}
+	if cfg.CoinPool.Onboarding.RequiredLeverage == 0 {
+		cfg.CoinPool.Onboarding.RequiredLeverage = float64(cfg.Global.Defaults.Leverage)
+	}
+	if cfg.CoinPool.Onboarding.RecheckInterval == "" {
+		cfg.CoinPool.Onboarding.RecheckInterval = "30m"
+	}
+	if cfg.Liquidations.MinNotionalUSD == 0 {
+		cfg.Liquidations.MinNotionalUSD = 100000
+	}
+	if cfg.Liquidations.Window == "" {
+		cfg.Liquidations.Window = "15m"
+	}
+	if cfg.Simulator.InitialBalance == 0 {
+		cfg.Simulator.InitialBalance = 10000
+	}
+	if cfg.Simulator.SlippagePercent == 0 {
+		cfg.Simulator.SlippagePercent = 0.05
+	}
+	if cfg.OrderFlow.Window == "" {
+		cfg.OrderFlow.Window = "30s"
+	}
+	if cfg.OrderFlow.MaxEntryDelay == "" {
+		cfg.OrderFlow.MaxEntryDelay = "20s"
+	}
+	if cfg.OrderFlow.MinImbalance == 0 {
+		cfg.OrderFlow.MinImbalance = 0.5
+	}
+
 	if cfg.CoinPool.CoinPoolAPIURL == "" && cfg.CoinPool.OITopAPIURL == "" && !cfg.CoinPool.UseDefaultCoins {
 		// 当未配置外部源时，默认启用主流币种作为兜底
 		cfg.CoinPool.UseDefaultCoins = true
@@ -381,7 +1430,38 @@ func validate(cfg Config) error {
 		if trader.Interval == "" {
 			return fmt.Errorf("trader %q 缺少 interval", trader.Name)
 		}
+		if _, err := CheckInterval(trader.Exchange, trader.Interval); err != nil {
+			return fmt.Errorf("trader %s interval: %w", trader.Name, err)
+		}
 		settings := mergeSettings(cfg.Global.Defaults, trader.Settings)
+		switch strings.ToUpper(strings.TrimSpace(settings.ContractType)) {
+		case ContractTypePerpetual, ContractTypeSpot:
+		default:
+			return fmt.Errorf("trader %s contractType %q 不受支持", trader.Name, settings.ContractType)
+		}
+		switch strings.ToLower(strings.TrimSpace(trader.Exchange)) {
+		case "", ExchangeBinance:
+		case ExchangeGateio, ExchangeHyperliquid:
+			if settings.IsSpot() {
+				return fmt.Errorf("trader %s: %s 仅支持永续合约", trader.Name, trader.Exchange)
+			}
+		default:
+			return fmt.Errorf("trader %s exchange %q 不受支持", trader.Name, trader.Exchange)
+		}
+		if trader.NewsModel != "" {
+			switch strings.ToLower(strings.TrimSpace(trader.NewsProvider)) {
+			case "":
+				return fmt.Errorf("trader %s 设置了 newsModel 但未设置 newsProvider", trader.Name)
+			case "deepseek", "qwen", "claude", "ollama":
+			default:
+				return fmt.Errorf("trader %s newsModel 只适用于 deepseek/qwen/claude/ollama，newsProvider 为 %q", trader.Name, trader.NewsProvider)
+			}
+		}
+		if trader.Account != "" {
+			if _, ok := cfg.Exchanges.Account(trader.Exchange, trader.Account); !ok {
+				return fmt.Errorf("trader %s account %q 未在 exchanges.accounts 中为 %s 定义", trader.Name, trader.Account, exchangeName(trader.Exchange))
+			}
+		}
 		if settings.FastEMAPeriod >= settings.SlowEMAPeriod {
 			return fmt.Errorf("trader %s fastEmaPeriod must be smaller than slowEmaPeriod", trader.Name)
 		}
@@ -409,6 +1489,90 @@ func validate(cfg Config) error {
 		if settings.RSIUpper <= settings.RSILower {
 			return fmt.Errorf("trader %s rsiUpper must be greater than rsiLower", trader.Name)
 		}
+		if settings.ProfileStopBufferPercent < 0 {
+			return fmt.Errorf("trader %s profileStopBufferPercent must not be negative", trader.Name)
+		}
+		if settings.DonchianPeriod < 0 || settings.DonchianExitPeriod < 0 || settings.ATRPeriod < 0 || settings.ATRStopMultiple < 0 {
+			return fmt.Errorf("trader %s donchian/atr parameters must not be negative", trader.Name)
+		}
+		if settings.VolumeConfirmMultiple < 0 || settings.VolumeConfirmPeriod < 0 {
+			return fmt.Errorf("trader %s volumeConfirmMultiple/volumeConfirmPeriod must not be negative", trader.Name)
+		}
+		if settings.SuperTrendPeriod < 0 || settings.SuperTrendMultiplier < 0 {
+			return fmt.Errorf("trader %s superTrendPeriod/superTrendMultiplier must not be negative", trader.Name)
+		}
+		if settings.StopLossATRMultiple < 0 || settings.TakeProfitATRMultiple < 0 {
+			return fmt.Errorf("trader %s stopLossAtrMultiple/takeProfitAtrMultiple must not be negative", trader.Name)
+		}
+		if settings.ADXPeriod < 0 || settings.ADXMinStrength < 0 || settings.ADXMinStrength > 100 {
+			return fmt.Errorf("trader %s adxPeriod must not be negative and adxMinStrength must be within [0, 100]", trader.Name)
+		}
+		if settings.CCIPeriod < 0 {
+			return fmt.Errorf("trader %s cciPeriod must not be negative", trader.Name)
+		}
+		for key, value := range trader.StrategyParams {
+			if value < 0 {
+				return fmt.Errorf("trader %s strategyParams.%s must not be negative", trader.Name, key)
+			}
+		}
+		if settings.IntentHoldSeconds < 0 {
+			return fmt.Errorf("trader %s intentHoldSeconds must not be negative", trader.Name)
+		}
+		if settings.TrendEMAPeriod < 0 {
+			return fmt.Errorf("trader %s trendEmaPeriod must not be negative", trader.Name)
+		}
+		seenTimeframes := map[string]bool{trader.Interval: true}
+		for _, timeframe := range trader.Timeframes {
+			normalized, err := CheckInterval(trader.Exchange, timeframe)
+			if err != nil {
+				return fmt.Errorf("trader %s timeframes: %w", trader.Name, err)
+			}
+			if seenTimeframes[normalized] {
+				return fmt.Errorf("trader %s timeframes 中的 %s 与 interval 或其他周期重复", trader.Name, normalized)
+			}
+			seenTimeframes[normalized] = true
+		}
+		if settings.TakerFlowMinImbalance < 0 || settings.TakerFlowMinImbalance > 1 {
+			return fmt.Errorf("trader %s takerFlowMinImbalance must be within [0, 1]", trader.Name)
+		}
+		switch strings.ToLower(strings.TrimSpace(settings.CandleType)) {
+		case "", CandleTypeStandard, CandleTypeHeikinAshi, CandleTypeRenko:
+		default:
+			return fmt.Errorf("trader %s candleType %q 不受支持", trader.Name, settings.CandleType)
+		}
+		if settings.RenkoBrickPercent < 0 {
+			return fmt.Errorf("trader %s renkoBrickPercent must not be negative", trader.Name)
+		}
+		for _, source := range []string{settings.KlineSource, settings.StopKlineSource} {
+			switch strings.ToLower(strings.TrimSpace(source)) {
+			case "", KlineSourceLast:
+			case KlineSourceMark:
+				if settings.IsSpot() {
+					return fmt.Errorf("trader %s 现货没有标记价格K线，klineSource/stopKlineSource 只能为 last", trader.Name)
+				}
+			default:
+				return fmt.Errorf("trader %s klineSource/stopKlineSource %q 不受支持，可选 last/mark", trader.Name, source)
+			}
+		}
+		if settings.RampStartPercent < 0 || settings.RampStartPercent > 100 {
+			return fmt.Errorf("trader %s rampStartPercent 需在 0~100 之间", trader.Name)
+		}
+		if settings.RampProfitableDays < 0 {
+			return fmt.Errorf("trader %s rampProfitableDays must not be negative", trader.Name)
+		}
+		if err := validateFlat(settings); err != nil {
+			return fmt.Errorf("trader %s %w", trader.Name, err)
+		}
+		seen := map[float64]bool{}
+		for _, bracket := range settings.ConfidenceSizing {
+			if bracket.Min <= 0 || bracket.Min > 1 || bracket.Multiplier <= 0 {
+				return fmt.Errorf("trader %s confidenceSizing 的 min 需在 (0,1] 之间，multiplier 需为正数", trader.Name)
+			}
+			if seen[bracket.Min] {
+				return fmt.Errorf("trader %s confidenceSizing 中 min=%g 重复", trader.Name, bracket.Min)
+			}
+			seen[bracket.Min] = true
+		}
 	}
 
 	if cfg.Risk.MaxDailyLossPercent <= 0 {
@@ -420,6 +1584,9 @@ func validate(cfg Config) error {
 	if cfg.Risk.MaxLeverage <= 0 {
 		return errors.New("maxLeverage必须为正数")
 	}
+	if cfg.Risk.MaxMajorPositions < 0 || cfg.Risk.MaxAltPositions < 0 {
+		return errors.New("maxMajorPositions/maxAltPositions不能为负数")
+	}
 	if cfg.Risk.BtcEthNotionalMultiple <= 0 {
 		return errors.New("btcEthNotionalMultiple必须为正数")
 	}
@@ -429,9 +1596,287 @@ func validate(cfg Config) error {
 	if cfg.Risk.MinRiskRewardRatio <= 1 {
 		return errors.New("minRiskRewardRatio必须大于1")
 	}
+	if cfg.Risk.CloseAuditTolerance() < 0 {
+		return errors.New("closeAuditTolerancePercent不能为负数")
+	}
+	if cfg.Risk.ResistanceBufferPercent < 0 {
+		return errors.New("resistanceBufferPercent不能为负数")
+	}
+	if cfg.Risk.LevelMinStrength < 0 {
+		return errors.New("levelMinStrength不能为负数")
+	}
+	switch cfg.Global.ExistingPositions {
+	case ExistingPositionsAsk, ExistingPositionsAdopt, ExistingPositionsIgnore:
+	default:
+		return fmt.Errorf("global.existingPositions %q 无效，可选 ask/adopt/ignore", cfg.Global.ExistingPositions)
+	}
+	if len(cfg.Watchlist.Symbols) > 0 {
+		if _, err := CheckInterval(cfg.Watchlist.Exchange, cfg.Watchlist.Interval); err != nil {
+			return fmt.Errorf("watchlist.interval: %w", err)
+		}
+	}
+	for _, symbol := range cfg.Watchlist.Symbols {
+		for _, trader := range cfg.Traders {
+			if strings.EqualFold(trader.Symbol, symbol) {
+				return fmt.Errorf("watchlist 交易对 %s 同时被 trader %s 交易，观察列表只能包含不交易的交易对", symbol, trader.Name)
+			}
+		}
+	}
+	for name, plugin := range cfg.Plugins {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "", "deepseek", "qwen", "claude", "ollama", "ensemble", "mock":
+			return fmt.Errorf("plugins.%s 与内置提供商重名", name)
+		}
+		if strings.TrimSpace(plugin.Command) == "" {
+			return fmt.Errorf("plugins.%s.command 不能为空", name)
+		}
+		if plugin.TimeoutSeconds < 0 {
+			return fmt.Errorf("plugins.%s.timeoutSeconds 不能为负数", name)
+		}
+	}
+	for kind, limits := range map[string]map[string]BudgetLimits{"providers": cfg.AIBudget.Providers, "traders": cfg.AIBudget.Traders} {
+		for name, limit := range limits {
+			if limit.MaxCallsPerHour < 0 || limit.MaxTokensPerDay < 0 || limit.MaxUSDPerDay < 0 {
+				return fmt.Errorf("aiBudget.%s.%s 上限不能为负数", kind, name)
+			}
+		}
+	}
+	switch cfg.AIMemory.Embedder {
+	case EmbedderHash, EmbedderOllama, EmbedderOpenAI:
+	default:
+		return fmt.Errorf("aiMemory.embedder 仅支持 hash/ollama/openai，当前为 %q", cfg.AIMemory.Embedder)
+	}
+	if cfg.AIMemory.TopK < 0 || cfg.AIMemory.MaxEntries < 0 {
+		return errors.New("aiMemory.topK/maxEntries 不能为负数")
+	}
+	if cfg.AIMemory.MinScore < -1 || cfg.AIMemory.MinScore > 1 {
+		return errors.New("aiMemory.minScore 需在 -1~1 之间")
+	}
+	if cfg.AITools.MaxRounds < 0 || cfg.AITools.MaxRounds > 10 {
+		return errors.New("aiTools.maxRounds 需在 1~10 之间")
+	}
+	if g := cfg.Guardrails; g.MaxFundingForLong < 0 || g.MinFundingForShort > 0 {
+		return errors.New("guardrails.maxFundingForLong 不能为负，minFundingForShort 不能为正")
+	}
+	if g := cfg.Guardrails; g.MaxSizeMultiplier < 0 || g.ReduceMarginUsage < 0 || g.ReduceMarginUsage > 100 {
+		return errors.New("guardrails.maxSizeMultiplier 不能为负，reduceMarginUsage 需在 0~100 之间")
+	}
+	switch cfg.Mock.Mode {
+	case MockModeFollow, MockModeHold:
+	case MockModeScript:
+		if len(cfg.Mock.Responses) == 0 {
+			return errors.New("mock.mode=script 需要至少一条 mock.responses")
+		}
+	default:
+		return fmt.Errorf("mock.mode %q 无效，可选 follow/hold/script", cfg.Mock.Mode)
+	}
+	if cfg.AILearning.Trades < 0 {
+		return errors.New("aiLearning.trades 不能为负数")
+	}
+	if _, err := time.Parse("15:04", cfg.Shadow.RunAt); err != nil {
+		return fmt.Errorf("shadow.runAt 需为 HH:MM 格式，当前为 %q", cfg.Shadow.RunAt)
+	}
+	if cfg.Shadow.WarmupBars < 0 || cfg.Shadow.MaxSlippageBps < 0 || cfg.Shadow.MaxMismatchRatio < 0 {
+		return errors.New("shadow.warmupBars/maxSlippageBps/maxMismatchRatio 不能为负数")
+	}
+	switch cfg.Failover.Role {
+	case "", "primary", "standby":
+	default:
+		return fmt.Errorf("failover.role %q 无效，可选 primary/standby", cfg.Failover.Role)
+	}
+	if cfg.Failover.Role != "" && cfg.Failover.NodeID == "" {
+		return errors.New("failover.nodeId 不能为空")
+	}
+	if cfg.Storage.OrderJournalMaxMB < 0 || cfg.Storage.OrderJournalKeep < 0 {
+		return errors.New("storage.orderJournalMaxMb/orderJournalKeep 不能为负数")
+	}
+	if cfg.Outcome.NeutralPercent < 0 {
+		return errors.New("outcome.neutralPercent 不能为负数")
+	}
+	if _, err := CheckInterval(ExchangeBinance, cfg.Outcome.Interval); err != nil {
+		return fmt.Errorf("outcome.interval: %w", err)
+	}
+	for symbol, decimals := range cfg.Dashboard.PriceDecimals {
+		if decimals < 0 || decimals > 12 {
+			return fmt.Errorf("dashboard.priceDecimals.%s 需在 0~12 之间", symbol)
+		}
+	}
+	if cfg.Dashboard.ManualMaxNotional < 0 {
+		return errors.New("dashboard.manualMaxNotional 不能为负数")
+	}
+	for model, price := range cfg.AIPricing {
+		if price.InputPerMillion < 0 || price.OutputPerMillion < 0 {
+			return fmt.Errorf("aiPricing.%s 单价不能为负数", model)
+		}
+	}
+	for _, lang := range cfg.News.Filter.Languages {
+		switch strings.ToLower(strings.TrimSpace(lang)) {
+		case "zh", "en":
+		default:
+			return fmt.Errorf("news.filter.languages 仅支持 zh、en，收到 %q", lang)
+		}
+	}
+	for source, limit := range cfg.News.Filter.SourceMaxItems {
+		if limit < 0 {
+			return fmt.Errorf("news.filter.sourceMaxItems.%s 不能为负数", source)
+		}
+	}
+	switch cfg.News.Sentiment {
+	case NewsSentimentAI, NewsSentimentLexicon:
+	default:
+		return fmt.Errorf("news.sentiment 仅支持 ai/lexicon，当前为 %q", cfg.News.Sentiment)
+	}
+	for word, weight := range cfg.News.Lexicon {
+		if weight < -1 || weight > 1 {
+			return fmt.Errorf("news.lexicon.%s 权重需在 -1~1 之间", word)
+		}
+	}
+	if cfg.News.Enabled && strings.EqualFold(cfg.News.Provider, NewsProviderWebhook) {
+		webhook := cfg.News.Webhook
+		if strings.TrimSpace(cfg.News.APIURL) == "" {
+			return errors.New("news.provider 为 webhook 时 news.apiUrl 不能为空")
+		}
+		if strings.TrimSpace(webhook.Title) == "" {
+			return errors.New("news.webhook.title 不能为空")
+		}
+		for field, path := range map[string]string{
+			"items": webhook.Items, "title": webhook.Title, "summary": webhook.Summary,
+			"url": webhook.URL, "time": webhook.Time, "source": webhook.Source,
+		} {
+			if path != "" && !strings.HasPrefix(strings.TrimSpace(path), "$") {
+				return fmt.Errorf("news.webhook.%s 需为以 $ 开头的 JSONPath，当前为 %q", field, path)
+			}
+		}
+	}
+	for name, exec := range cfg.ExecStrategies {
+		if strings.TrimSpace(exec.Command) == "" {
+			return fmt.Errorf("execStrategies.%s.command 不能为空", name)
+		}
+		if exec.TimeoutSeconds < 0 || exec.Window < 0 {
+			return fmt.Errorf("execStrategies.%s 的 timeoutSeconds/window 不能为负数", name)
+		}
+	}
+	for name, meta := range cfg.MetaStrategies {
+		if _, ok := cfg.ExecStrategies[name]; ok {
+			return fmt.Errorf("metaStrategies.%s 与 execStrategies 重名", name)
+		}
+		if len(meta.Strategies) < 2 {
+			return fmt.Errorf("metaStrategies.%s 至少需要2个子策略", name)
+		}
+		if meta.MinAgreement < 0 || meta.MinAgreement > 1 {
+			return fmt.Errorf("metaStrategies.%s.minAgreement 需在 0~1 之间", name)
+		}
+		for _, child := range meta.Strategies {
+			if strings.TrimSpace(child.Name) == "" {
+				return fmt.Errorf("metaStrategies.%s 的子策略名不能为空", name)
+			}
+			if child.Weight < 0 {
+				return fmt.Errorf("metaStrategies.%s.%s 的 weight 不能为负数", name, child.Name)
+			}
+		}
+	}
+	if len(cfg.Ensemble.Providers) > 0 {
+		if len(cfg.Ensemble.Providers) < 2 {
+			return errors.New("ensemble.providers 至少需要2个提供商")
+		}
+		for _, name := range cfg.Ensemble.Providers {
+			if strings.EqualFold(strings.TrimSpace(name), "ensemble") {
+				return errors.New("ensemble.providers 不能包含 ensemble 自身")
+			}
+		}
+		if cfg.Ensemble.MinVotes < 0 || cfg.Ensemble.MinVotes > len(cfg.Ensemble.Providers) {
+			return errors.New("ensemble.minVotes 需在 0 与提供商数量之间")
+		}
+	}
+	if cfg.Claude.MaxTokens < 0 {
+		return errors.New("claude.maxTokens不能为负数")
+	}
+	if cfg.Deepseek.ContextWindow < 0 || cfg.Qwen.ContextWindow < 0 || cfg.Claude.ContextWindow < 0 {
+		return errors.New("contextWindow 不能为负数")
+	}
 	if cfg.CoinPool.MaxCombined <= 0 {
 		return errors.New("coinPool.max_combined必须为正数")
 	}
+	if w := cfg.CoinPool.SentimentWeighting(); w < 0 || w > 1 {
+		return errors.New("coinPool.sentiment_weight需在 0 与 1 之间")
+	}
+	if w := cfg.CoinPool.LiquidityWeight; w < 0 || w > 1 {
+		return errors.New("coinPool.liquidity_weight需在 0 与 1 之间")
+	}
+	if cfg.CoinPool.LiquiditySlippagePercent < 0 || cfg.CoinPool.LiquidityBookShare < 0 || cfg.CoinPool.LiquidityBookShare > 1 {
+		return errors.New("coinPool.liquidity_slippage_percent不能为负数，liquidity_book_share需在 0 与 1 之间")
+	}
+	if ob := cfg.CoinPool.Onboarding; ob.MinCandles < 0 || ob.MinQuoteVolume < 0 || ob.MaxTakerFeePercent < 0 || ob.RequiredLeverage < 0 {
+		return errors.New("coinPool.onboarding 的门槛不能为负数")
+	}
+	if _, err := NormalizeInterval(cfg.CoinPool.Onboarding.Interval); err != nil {
+		return fmt.Errorf("coinPool.onboarding.interval: %w", err)
+	}
+	if cfg.Simulator.InitialBalance < 0 || cfg.Simulator.SlippagePercent < 0 || cfg.Simulator.FeePercent < 0 {
+		return errors.New("simulator 参数不能为负数")
+	}
+	for name, fees := range cfg.Exchanges.Fees {
+		if fees.MakerPercent < 0 || fees.TakerPercent < 0 || fees.BNBDiscountPercent < 0 || fees.BNBDiscountPercent >= 100 {
+			return fmt.Errorf("exchanges.fees.%s 费率不能为负数，bnbDiscountPercent 需小于100", name)
+		}
+	}
+	if cfg.Risk.MinEdgeCostMultiple < 0 {
+		return errors.New("minEdgeCostMultiple不能为负数")
+	}
+	if cfg.Risk.EdgeFundingPeriods < 0 {
+		return errors.New("edgeFundingPeriods不能为负数")
+	}
+	if cfg.Risk.AdjustmentGuard.Mode != GuardModeClamp && cfg.Risk.AdjustmentGuard.Mode != GuardModeReject {
+		return fmt.Errorf("adjustmentGuard.mode %q 无效，可选 clamp/reject", cfg.Risk.AdjustmentGuard.Mode)
+	}
+	if err := validateGuardBounds(cfg.Risk.AdjustmentGuard); err != nil {
+		return err
+	}
+	if cfg.OrderFlow.MinImbalance < 0 {
+		return errors.New("orderFlow.minImbalance不能为负数")
+	}
+	seen := make(map[string]bool, len(cfg.Exchanges.Accounts))
+	for _, account := range cfg.Exchanges.Accounts {
+		if strings.TrimSpace(account.Name) == "" {
+			return errors.New("exchanges.accounts 存在未命名账户")
+		}
+		exchange := exchangeName(account.Exchange)
+		switch exchange {
+		case ExchangeBinance, ExchangeGateio, ExchangeHyperliquid:
+		default:
+			return fmt.Errorf("account %s exchange %q 不受支持", account.Name, account.Exchange)
+		}
+		key := exchange + "/" + strings.ToLower(account.Name)
+		if seen[key] {
+			return fmt.Errorf("account %s 在 %s 下重复定义", account.Name, exchange)
+		}
+		seen[key] = true
+		if account.Risk.MaxDailyLossPercent < 0 || account.Risk.MaxPositionNotionalUSD < 0 ||
+			account.Risk.MaxConcurrentPositions < 0 || account.Risk.MaxLeverage < 0 ||
+			account.Risk.MaxMajorPositions < 0 || account.Risk.MaxAltPositions < 0 {
+			return fmt.Errorf("account %s 风控覆盖项不能为负数", account.Name)
+		}
+		if account.Risk.MinRiskRewardRatio != 0 && account.Risk.MinRiskRewardRatio <= 1 {
+			return fmt.Errorf("account %s minRiskRewardRatio必须大于1", account.Name)
+		}
+		if account.Risk.BtcEthNotionalMultiple < 0 || account.Risk.AltNotionalMultiple < 0 ||
+			account.Risk.ResistanceBufferPercent < 0 || account.Risk.LevelMinStrength < 0 ||
+			account.Risk.MinEdgeCostMultiple < 0 || account.Risk.EdgeFundingPeriods < 0 ||
+			account.Risk.CloseAuditTolerance() < 0 {
+			return fmt.Errorf("account %s 风控覆盖项不能为负数", account.Name)
+		}
+		// 风控检查周期由进程统一调度，不能按账户覆盖
+		if account.Risk.CheckInterval != "" {
+			return fmt.Errorf("account %s 不支持覆盖 checkInterval，请在全局 risk 中设置", account.Name)
+		}
+		guard := account.Risk.AdjustmentGuard
+		if guard.Mode != "" && guard.Mode != GuardModeClamp && guard.Mode != GuardModeReject {
+			return fmt.Errorf("account %s adjustmentGuard.mode %q 无效，可选 clamp/reject", account.Name, guard.Mode)
+		}
+		if err := validateGuardBounds(guard); err != nil {
+			return fmt.Errorf("account %s %w", account.Name, err)
+		}
+	}
 
 	return nil
 }
@@ -440,10 +1885,24 @@ func resolveProfiles(cfg Config) []TraderProfileResolved {
 	resolved := make([]TraderProfileResolved, 0, len(cfg.Traders))
 	for _, profile := range cfg.Traders {
 		settings := mergeSettings(cfg.Global.Defaults, profile.Settings)
+		if settings.IsSpot() {
+			// 现货不支持杠杆，统一按1倍处理
+			settings.ContractType = ContractTypeSpot
+			settings.Leverage = 1
+		}
+		if settings.StopKlineSource == "" {
+			settings.StopKlineSource = settings.KlineSource
+		}
+		risk := cfg.Risk
+		if account, ok := cfg.Exchanges.Account(profile.Exchange, profile.Account); ok {
+			risk = mergeRisk(cfg.Risk, account.Risk)
+		}
 		resolved = append(resolved, TraderProfileResolved{
 			TraderProfile: profile,
 			Settings:      settings,
 			DryRun:        cfg.Global.DryRun,
+			Risk:          risk,
+			Fees:          cfg.Exchanges.FeeSchedule(profile.Exchange, settings.IsSpot()),
 		})
 	}
 	return resolved
@@ -508,12 +1967,185 @@ func mergeSettings(base TradeSettings, override TradeSettings) TradeSettings {
 	if override.MACDSignalPeriod != 0 {
 		result.MACDSignalPeriod = override.MACDSignalPeriod
 	}
+	if override.PatternConfirmationBars != 0 {
+		result.PatternConfirmationBars = override.PatternConfirmationBars
+	}
+	if override.ProfileStopBufferPercent != 0 {
+		result.ProfileStopBufferPercent = override.ProfileStopBufferPercent
+	}
+	if override.DonchianPeriod != 0 {
+		result.DonchianPeriod = override.DonchianPeriod
+	}
+	if override.DonchianExitPeriod != 0 {
+		result.DonchianExitPeriod = override.DonchianExitPeriod
+	}
+	if override.ATRPeriod != 0 {
+		result.ATRPeriod = override.ATRPeriod
+	}
+	if override.ATRStopMultiple != 0 {
+		result.ATRStopMultiple = override.ATRStopMultiple
+	}
+	if override.VolumeConfirmMultiple != 0 {
+		result.VolumeConfirmMultiple = override.VolumeConfirmMultiple
+	}
+	if override.VolumeConfirmPeriod != 0 {
+		result.VolumeConfirmPeriod = override.VolumeConfirmPeriod
+	}
+	if override.SuperTrendPeriod != 0 {
+		result.SuperTrendPeriod = override.SuperTrendPeriod
+	}
+	if override.SuperTrendMultiplier != 0 {
+		result.SuperTrendMultiplier = override.SuperTrendMultiplier
+	}
+	if override.StopLossATRMultiple != 0 {
+		result.StopLossATRMultiple = override.StopLossATRMultiple
+	}
+	if override.TakeProfitATRMultiple != 0 {
+		result.TakeProfitATRMultiple = override.TakeProfitATRMultiple
+	}
+	if override.ADXPeriod != 0 {
+		result.ADXPeriod = override.ADXPeriod
+	}
+	if override.ADXMinStrength != 0 {
+		result.ADXMinStrength = override.ADXMinStrength
+	}
+	if override.CCIPeriod != 0 {
+		result.CCIPeriod = override.CCIPeriod
+	}
+	if override.IntentHoldSeconds != 0 {
+		result.IntentHoldSeconds = override.IntentHoldSeconds
+	}
+	if override.TrendEMAPeriod != 0 {
+		result.TrendEMAPeriod = override.TrendEMAPeriod
+	}
+	if override.TakerFlowMinImbalance != 0 {
+		result.TakerFlowMinImbalance = override.TakerFlowMinImbalance
+	}
+	if override.CandleType != "" {
+		result.CandleType = override.CandleType
+	}
+	if override.RenkoBrickPercent != 0 {
+		result.RenkoBrickPercent = override.RenkoBrickPercent
+	}
+	if override.KlineSource != "" {
+		result.KlineSource = override.KlineSource
+	}
+	if override.StopKlineSource != "" {
+		result.StopKlineSource = override.StopKlineSource
+	}
+	if override.RampStartPercent != 0 {
+		result.RampStartPercent = override.RampStartPercent
+	}
+	if override.RampProfitableDays != 0 {
+		result.RampProfitableDays = override.RampProfitableDays
+	}
+	if override.FlatBy != "" {
+		result.FlatBy = override.FlatBy
+	}
+	if override.FlatNoEntryMinutes != 0 {
+		result.FlatNoEntryMinutes = override.FlatNoEntryMinutes
+	}
+	if override.FlatResumeAt != "" {
+		result.FlatResumeAt = override.FlatResumeAt
+	}
 	if len(override.CandidateSymbols) > 0 {
 		result.CandidateSymbols = append([]string{}, override.CandidateSymbols...)
 	}
+	if len(override.ConfidenceSizing) > 0 {
+		result.ConfidenceSizing = append([]ConfidenceBracket{}, override.ConfidenceSizing...)
+	}
 	return result
 }
 
+// mergeRisk 以账户风控中的非零项覆盖全局风控。
+func mergeRisk(base RiskConfig, override RiskConfig) RiskConfig {
+	result := base
+	if override.MaxDailyLossPercent != 0 {
+		result.MaxDailyLossPercent = override.MaxDailyLossPercent
+	}
+	if override.MaxPositionNotionalUSD != 0 {
+		result.MaxPositionNotionalUSD = override.MaxPositionNotionalUSD
+	}
+	if override.MaxConcurrentPositions != 0 {
+		result.MaxConcurrentPositions = override.MaxConcurrentPositions
+	}
+	if override.MaxLeverage != 0 {
+		result.MaxLeverage = override.MaxLeverage
+	}
+	if override.MaxMajorPositions != 0 {
+		result.MaxMajorPositions = override.MaxMajorPositions
+	}
+	if override.MaxAltPositions != 0 {
+		result.MaxAltPositions = override.MaxAltPositions
+	}
+	if override.MinRiskRewardRatio != 0 {
+		result.MinRiskRewardRatio = override.MinRiskRewardRatio
+	}
+	if override.ResistanceBufferPercent != 0 {
+		result.ResistanceBufferPercent = override.ResistanceBufferPercent
+	}
+	if override.MinEdgeCostMultiple != 0 {
+		result.MinEdgeCostMultiple = override.MinEdgeCostMultiple
+	}
+	if override.BtcEthNotionalMultiple != 0 {
+		result.BtcEthNotionalMultiple = override.BtcEthNotionalMultiple
+	}
+	if override.AltNotionalMultiple != 0 {
+		result.AltNotionalMultiple = override.AltNotionalMultiple
+	}
+	if override.LevelMinStrength != 0 {
+		result.LevelMinStrength = override.LevelMinStrength
+	}
+	if override.CloseAuditTolerancePercent != nil {
+		result.CloseAuditTolerancePercent = override.CloseAuditTolerancePercent
+	}
+	if override.EdgeFundingPeriods != 0 {
+		result.EdgeFundingPeriods = override.EdgeFundingPeriods
+	}
+	result.AdjustmentGuard = mergeAdjustmentGuard(base.AdjustmentGuard, override.AdjustmentGuard)
+	return result
+}
+
+// mergeAdjustmentGuard 以 override 中非空的模式与区间覆盖 base。
+func mergeAdjustmentGuard(base AdjustmentGuardConfig, override AdjustmentGuardConfig) AdjustmentGuardConfig {
+	result := base
+	if override.Mode != "" {
+		result.Mode = override.Mode
+	}
+	if override.SizeMultiplier != (Bounds{}) {
+		result.SizeMultiplier = override.SizeMultiplier
+	}
+	if override.TargetLeverage != (Bounds{}) {
+		result.TargetLeverage = override.TargetLeverage
+	}
+	if override.StopLossPercent != (Bounds{}) {
+		result.StopLossPercent = override.StopLossPercent
+	}
+	if override.TakeProfitPercent != (Bounds{}) {
+		result.TakeProfitPercent = override.TakeProfitPercent
+	}
+	if override.TrailingStopPercent != (Bounds{}) {
+		result.TrailingStopPercent = override.TrailingStopPercent
+	}
+	return result
+}
+
+// validateGuardBounds 检查异常值防护的各区间：不能为负，设置了上限时下限不能高于上限。
+func validateGuardBounds(guard AdjustmentGuardConfig) error {
+	for name, bounds := range map[string]Bounds{
+		"sizeMultiplier":      guard.SizeMultiplier,
+		"targetLeverage":      guard.TargetLeverage,
+		"stopLossPercent":     guard.StopLossPercent,
+		"takeProfitPercent":   guard.TakeProfitPercent,
+		"trailingStopPercent": guard.TrailingStopPercent,
+	} {
+		if bounds.Min < 0 || bounds.Max < 0 || (bounds.Max > 0 && bounds.Min > bounds.Max) {
+			return fmt.Errorf("adjustmentGuard.%s 区间无效", name)
+		}
+	}
+	return nil
+}
+
 // LoggingConfig 控制日志输出。
 type LoggingConfig struct {
 	Directory    string `json:"directory"`
@@ -529,10 +2161,187 @@ func (l LoggingConfig) MirrorToStdout() bool {
 }
 
 type ExchangeConfig struct {
-	Binance BinanceCredentials `json:"binance"`
+	Binance     BinanceCredentials     `json:"binance"`
+	Gateio      GateioCredentials      `json:"gateio"`
+	Hyperliquid HyperliquidCredentials `json:"hyperliquid"`
+
+	// Accounts 为命名的子账户密钥，交易者通过 account 字段引用。
+	Accounts []ExchangeAccount `json:"accounts"`
+	// Fees 按交易所名称覆盖默认费率，现货可用 "<交易所>_spot" 单独配置，例如 binance_spot。
+	Fees map[string]FeeSchedule `json:"fees"`
+}
+
+// FeeSchedule 为某交易所的挂单/吃单费率（百分比）。BNBDiscountPercent 为使用 BNB
+// 抵扣手续费时的折扣比例，例如 10 表示费率打九折。
+type FeeSchedule struct {
+	MakerPercent       float64 `json:"makerPercent"`
+	TakerPercent       float64 `json:"takerPercent"`
+	BNBDiscountPercent float64 `json:"bnbDiscountPercent"`
+}
+
+// defaultFees 为各交易所基础档位费率，未配置 exchanges.fees 时使用。
+var defaultFees = map[string]FeeSchedule{
+	ExchangeBinance:           {MakerPercent: 0.02, TakerPercent: 0.05},
+	ExchangeBinance + "_spot": {MakerPercent: 0.1, TakerPercent: 0.1},
+	ExchangeGateio:            {MakerPercent: 0.02, TakerPercent: 0.05},
+	ExchangeHyperliquid:       {MakerPercent: 0.015, TakerPercent: 0.045},
+}
+
+// Maker 返回扣除 BNB 折扣后的挂单费率（百分比）。
+func (f FeeSchedule) Maker() float64 {
+	return f.MakerPercent * (1 - f.BNBDiscountPercent/100)
+}
+
+// Taker 返回扣除 BNB 折扣后的吃单费率（百分比）。
+func (f FeeSchedule) Taker() float64 {
+	return f.TakerPercent * (1 - f.BNBDiscountPercent/100)
+}
+
+// RoundTripPercent 返回以市价开平仓一个来回的手续费占名义金额的百分比。
+func (f FeeSchedule) RoundTripPercent() float64 {
+	return 2 * f.Taker()
+}
+
+// FeeSchedule 返回交易所的费率：现货优先查找 "<交易所>_spot"，配置项覆盖默认值。
+func (c ExchangeConfig) FeeSchedule(exchange string, spot bool) FeeSchedule {
+	name := exchangeName(exchange)
+	keys := []string{name}
+	if spot {
+		keys = []string{name + "_spot", name}
+	}
+	for _, key := range keys {
+		if fees, ok := c.Fees[key]; ok {
+			return fees
+		}
+	}
+	for _, key := range keys {
+		if fees, ok := defaultFees[key]; ok {
+			return fees
+		}
+	}
+	return FeeSchedule{}
+}
+
+// TokenPrice 为模型每百万 token 的美元单价。
+type TokenPrice struct {
+	InputPerMillion  float64 `json:"inputPerMillion"`
+	OutputPerMillion float64 `json:"outputPerMillion"`
+}
+
+// Cost 返回给定输入/输出 token 数的费用（美元）。
+func (p TokenPrice) Cost(promptTokens, completionTokens int) float64 {
+	return (float64(promptTokens)*p.InputPerMillion + float64(completionTokens)*p.OutputPerMillion) / 1e6
+}
+
+// AIPricing 以模型名（或提供商名）为键的价目表。
+type AIPricing map[string]TokenPrice
+
+// defaultTokenPrices 为常用模型的公开标价（缓存未命中），价格可能调整，请以账单为准并在 aiPricing 中覆盖。
+var defaultTokenPrices = AIPricing{
+	"deepseek-chat":     {InputPerMillion: 0.28, OutputPerMillion: 0.42},
+	"deepseek-reasoner": {InputPerMillion: 0.28, OutputPerMillion: 0.42},
+	"qwen-turbo":        {InputPerMillion: 0.05, OutputPerMillion: 0.2},
+	"qwen-plus":         {InputPerMillion: 0.4, OutputPerMillion: 1.2},
+	"qwen-max":          {InputPerMillion: 1.6, OutputPerMillion: 6.4},
+	"claude-sonnet-4-5": {InputPerMillion: 3, OutputPerMillion: 15},
+	"claude-haiku-4-5":  {InputPerMillion: 1, OutputPerMillion: 5},
+	"ollama":            {},
 }
 
+// Price 依次按模型名、提供商名查找单价，先查配置再查内置价目表，都没有时返回零价。
+func (p AIPricing) Price(provider, model string) TokenPrice {
+	keys := []string{strings.ToLower(model), strings.ToLower(provider)}
+	for _, table := range []AIPricing{p, defaultTokenPrices} {
+		for _, key := range keys {
+			if price, ok := table[key]; ok && key != "" {
+				return price
+			}
+		}
+	}
+	return TokenPrice{}
+}
+
+// ExchangeAccount 描述某交易所下的一个命名账户。只需填写对应交易所用到的字段：
+// binance/gateio 使用 apiKey/apiSecret，hyperliquid 使用 privateKey/accountAddress/testnet。
+// Risk 中的非零项覆盖全局风控，使子账户按各自额度独立风控。
+type ExchangeAccount struct {
+	Name           string     `json:"name"`
+	Exchange       string     `json:"exchange"`
+	APIKey         string     `json:"apiKey"`
+	APISecret      string     `json:"apiSecret"`
+	BaseURL        string     `json:"baseUrl"`
+	PrivateKey     string     `json:"privateKey"`
+	AccountAddress string     `json:"accountAddress"`
+	Testnet        bool       `json:"testnet"`
+	Risk           RiskConfig `json:"risk"`
+}
+
+// Account 按交易所与名称（不区分大小写）查找命名账户。
+func (c ExchangeConfig) Account(exchange, name string) (ExchangeAccount, bool) {
+	if name == "" {
+		return ExchangeAccount{}, false
+	}
+	exchange = exchangeName(exchange)
+	for _, account := range c.Accounts {
+		if exchangeName(account.Exchange) == exchange && strings.EqualFold(account.Name, name) {
+			return account, true
+		}
+	}
+	return ExchangeAccount{}, false
+}
+
+// ForAccount 返回以命名账户密钥替换对应交易所默认密钥后的配置；name 为空时原样返回。
+func (c ExchangeConfig) ForAccount(exchange, name string) (ExchangeConfig, error) {
+	if name == "" {
+		return c, nil
+	}
+	account, ok := c.Account(exchange, name)
+	if !ok {
+		return c, fmt.Errorf("account %q 未在 %s 下定义", name, exchangeName(exchange))
+	}
+	switch exchangeName(exchange) {
+	case ExchangeBinance:
+		c.Binance = BinanceCredentials{APIKey: account.APIKey, APISecret: account.APISecret}
+	case ExchangeGateio:
+		c.Gateio = GateioCredentials{APIKey: account.APIKey, APISecret: account.APISecret, BaseURL: account.BaseURL}
+	case ExchangeHyperliquid:
+		c.Hyperliquid = HyperliquidCredentials{PrivateKey: account.PrivateKey, AccountAddress: account.AccountAddress, Testnet: account.Testnet}
+	}
+	return c, nil
+}
+
+// exchangeName 规范化交易所名称，留空视为 binance。
+func exchangeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ExchangeBinance
+	}
+	return name
+}
+
+// 交易者 exchange 字段取值。
+const (
+	ExchangeBinance     = "binance"
+	ExchangeGateio      = "gateio"
+	ExchangeHyperliquid = "hyperliquid"
+)
+
 type BinanceCredentials struct {
 	APIKey    string `json:"apiKey"`
 	APISecret string `json:"apiSecret"`
 }
+
+// GateioCredentials 为 Gate.io USDT 永续合约密钥，BaseURL 留空使用官方地址。
+type GateioCredentials struct {
+	APIKey    string `json:"apiKey"`
+	APISecret string `json:"apiSecret"`
+	BaseURL   string `json:"baseUrl"`
+}
+
+// HyperliquidCredentials 为 Hyperliquid 钱包签名配置。AccountAddress 留空时使用私钥对应地址，
+// 通过 API 代理钱包交易时需填写主账户地址。
+type HyperliquidCredentials struct {
+	PrivateKey     string `json:"privateKey"`
+	AccountAddress string `json:"accountAddress"`
+	Testnet        bool   `json:"testnet"`
+}
@@ -0,0 +1,73 @@
+package config
+
+import "testing"
+
+func TestMergeRiskOverridesEveryField(t *testing.T) {
+	one, zero := 1.0, 0.0
+	base := RiskConfig{
+		MaxDailyLossPercent:        5,
+		BtcEthNotionalMultiple:     10,
+		AltNotionalMultiple:        1.5,
+		LevelMinStrength:           2,
+		EdgeFundingPeriods:         1,
+		CloseAuditTolerancePercent: &one,
+		AdjustmentGuard: AdjustmentGuardConfig{
+			Mode:           GuardModeClamp,
+			SizeMultiplier: Bounds{Min: 0.1, Max: 2},
+			TargetLeverage: Bounds{Min: 1, Max: 10},
+		},
+	}
+	override := RiskConfig{
+		BtcEthNotionalMultiple:     5,
+		AltNotionalMultiple:        1,
+		LevelMinStrength:           3,
+		EdgeFundingPeriods:         3,
+		CloseAuditTolerancePercent: &zero,
+		AdjustmentGuard: AdjustmentGuardConfig{
+			Mode:           GuardModeReject,
+			TargetLeverage: Bounds{Min: 1, Max: 3},
+		},
+	}
+
+	got := mergeRisk(base, override)
+	if got.MaxDailyLossPercent != 5 {
+		t.Errorf("unset field changed: maxDailyLossPercent = %v", got.MaxDailyLossPercent)
+	}
+	if got.BtcEthNotionalMultiple != 5 || got.AltNotionalMultiple != 1 || got.LevelMinStrength != 3 || got.EdgeFundingPeriods != 3 {
+		t.Errorf("overrides not applied: %+v", got)
+	}
+	if got.CloseAuditTolerance() != 0 {
+		t.Errorf("closeAuditTolerance = %v, want explicit 0", got.CloseAuditTolerance())
+	}
+	guard := got.AdjustmentGuard
+	if guard.Mode != GuardModeReject || guard.TargetLeverage != (Bounds{Min: 1, Max: 3}) || guard.SizeMultiplier != (Bounds{Min: 0.1, Max: 2}) {
+		t.Errorf("adjustmentGuard = %+v", guard)
+	}
+}
+
+func TestCloseAuditToleranceDefault(t *testing.T) {
+	if got := (RiskConfig{}).CloseAuditTolerance(); got != 1 {
+		t.Fatalf("default tolerance = %v, want 1", got)
+	}
+}
+
+func TestValidateFlatRejectsResumeInsideWindow(t *testing.T) {
+	cases := []struct {
+		flatBy, resume string
+		noEntry        int
+		ok             bool
+	}{
+		{"23:50", "00:00", 30, true},
+		{"00:30", "01:00", 30, true},
+		{"00:30", "00:00", 30, false}, // 恢复时刻等于停止开仓时刻
+		{"00:30", "00:10", 30, false},
+		{"00:30", "00:30", 30, false}, // 平仓后立即恢复
+		{"12:00", "12:00", 0, false},
+	}
+	for _, tc := range cases {
+		err := validateFlat(TradeSettings{FlatBy: tc.flatBy, FlatResumeAt: tc.resume, FlatNoEntryMinutes: tc.noEntry})
+		if (err == nil) != tc.ok {
+			t.Errorf("flatBy %s resume %s noEntry %d: err = %v", tc.flatBy, tc.resume, tc.noEntry, err)
+		}
+	}
+}
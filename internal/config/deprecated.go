@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// renamedField 为改名的配置字段，路径以点分隔，"*" 匹配数组的每个元素。
+type renamedField struct {
+	Old string
+	New string
+}
+
+// renamedFields 为已弃用的旧字段及其新名称；Load 读取时自动迁移并给出提示。
+var renamedFields = []renamedField{
+	{Old: "risk.minEdgeFeeMultiple", New: "risk.minEdgeCostMultiple"},
+	{Old: "exchanges.accounts.*.risk.minEdgeFeeMultiple", New: "exchanges.accounts.*.risk.minEdgeCostMultiple"},
+}
+
+// Deprecation 描述配置文件中使用的一个已弃用字段。
+type Deprecation struct {
+	// Path 为旧字段的实际路径（数组元素以下标表示），Replacement 为新字段路径。
+	Path        string
+	Replacement string
+	// Ignored 为 true 时新字段已同时设置，旧字段的值被忽略。
+	Ignored bool
+}
+
+func (d Deprecation) String() string {
+	if d.Ignored {
+		return fmt.Sprintf("配置字段 %s 已弃用，且已设置 %s，旧字段被忽略，请删除", d.Path, d.Replacement)
+	}
+	return fmt.Sprintf("配置字段 %s 已弃用，已按 %s 读取，请改用新名称", d.Path, d.Replacement)
+}
+
+// migrateDeprecated 把配置 JSON 中的旧字段改为新名称，返回迁移后的 JSON 与使用到的旧字段。
+// 没有旧字段时原样返回 data。
+func migrateDeprecated(data []byte) ([]byte, []Deprecation, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		// 交给 Load 的正式解析报告语法错误
+		return data, nil, nil
+	}
+	var found []Deprecation
+	for _, field := range renamedFields {
+		oldParts := strings.Split(field.Old, ".")
+		newParts := strings.Split(field.New, ".")
+		found = append(found, renameAt(root, oldParts, newParts, nil)...)
+	}
+	if len(found) == 0 {
+		return data, nil, nil
+	}
+	migrated, err := json.Marshal(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("migrate deprecated config fields: %w", err)
+	}
+	return migrated, found, nil
+}
+
+// renameAt 沿 oldParts 查找旧字段并在同一对象中改名为 newParts 的最后一段。
+// 新旧路径只有最后一段不同，prefix 为已走过的实际路径。
+func renameAt(node any, oldParts, newParts, prefix []string) []Deprecation {
+	if len(oldParts) == 0 {
+		return nil
+	}
+	if oldParts[0] == "*" {
+		items, ok := node.([]any)
+		if !ok {
+			return nil
+		}
+		var found []Deprecation
+		for i, item := range items {
+			found = append(found, renameAt(item, oldParts[1:], newParts[1:], append(prefix, fmt.Sprint(i)))...)
+		}
+		return found
+	}
+	object, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if len(oldParts) > 1 {
+		return renameAt(object[oldParts[0]], oldParts[1:], newParts[1:], append(prefix, oldParts[0]))
+	}
+	value, ok := object[oldParts[0]]
+	if !ok {
+		return nil
+	}
+	delete(object, oldParts[0])
+	path := strings.Join(append(prefix, oldParts[0]), ".")
+	replacement := strings.Join(append(prefix, newParts[0]), ".")
+	if _, exists := object[newParts[0]]; exists {
+		return []Deprecation{{Path: path, Replacement: replacement, Ignored: true}}
+	}
+	object[newParts[0]] = value
+	return []Deprecation{{Path: path, Replacement: replacement}}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 各交易所适配器支持的K线周期（规范写法）。秒级与月线没有固定时长，暂不支持。
+var exchangeIntervals = map[string][]string{
+	ExchangeBinance:     {"1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "3d", "1w"},
+	ExchangeGateio:      {"1m", "5m", "15m", "30m", "1h", "4h", "8h", "1d", "1w"},
+	ExchangeHyperliquid: {"1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "8h", "12h", "1d", "3d", "1w"},
+}
+
+// intervalUnits 为周期单位的别名及其秒数，月单独处理。多字母别名不区分大小写；
+// 单字母时小写 m 为分钟、大写 M 为月，H/D/W 兼容 OKX 等交易所的大写写法。
+var intervalUnits = map[string]int{
+	"s": 1, "sec": 1, "secs": 1, "second": 1, "seconds": 1,
+	"m": 60, "min": 60, "mins": 60, "minute": 60, "minutes": 60,
+	"h": 3600, "H": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
+	"d": 86400, "D": 86400, "day": 86400, "days": 86400,
+	"w": 604800, "W": 604800, "wk": 604800, "week": 604800, "weeks": 604800,
+}
+
+// NormalizeInterval 把各种写法的K线周期转换为规范写法（数字加 s/m/h/d/w，月为 M），
+// 并换算为能整除的最大单位：1hour、1H、60m、60（纯数字按分钟）均为 1h，OKX 的 1Dutc 为 1d，7d 为 1w。
+func NormalizeInterval(interval string) (string, error) {
+	raw := strings.TrimSpace(interval)
+	i := 0
+	for i < len(raw) && raw[i] >= '0' && raw[i] <= '9' {
+		i++
+	}
+	n := 1
+	if i > 0 {
+		parsed, err := strconv.Atoi(raw[:i])
+		if err != nil || parsed <= 0 {
+			return "", fmt.Errorf("无效的K线周期 %q", interval)
+		}
+		n = parsed
+	}
+	unit := raw[i:]
+	if len(unit) > 3 && strings.EqualFold(unit[len(unit)-3:], "utc") {
+		unit = unit[:len(unit)-3]
+	}
+	if unit == "" {
+		if i == 0 {
+			return "", fmt.Errorf("无效的K线周期 %q", interval)
+		}
+		unit = "m"
+	}
+	if len(unit) > 1 {
+		unit = strings.ToLower(unit)
+	}
+	switch unit {
+	case "M", "mo", "mon", "month", "months":
+		return strconv.Itoa(n) + "M", nil
+	}
+	seconds, ok := intervalUnits[unit]
+	if !ok {
+		return "", fmt.Errorf("无效的K线周期 %q", interval)
+	}
+	total := n * seconds
+	for _, u := range []struct {
+		suffix  string
+		seconds int
+	}{{"w", 604800}, {"d", 86400}, {"h", 3600}, {"m", 60}, {"s", 1}} {
+		if total%u.seconds == 0 {
+			return strconv.Itoa(total/u.seconds) + u.suffix, nil
+		}
+	}
+	return "", fmt.Errorf("无效的K线周期 %q", interval)
+}
+
+// ExchangeIntervals 返回交易所支持的K线周期，exchange 为空时按 binance。
+func ExchangeIntervals(exchange string) []string {
+	return exchangeIntervals[exchangeName(exchange)]
+}
+
+// CheckInterval 规范化周期并检查交易所是否支持，返回规范写法。
+func CheckInterval(exchange, interval string) (string, error) {
+	normalized, err := NormalizeInterval(interval)
+	if err != nil {
+		return "", err
+	}
+	supported := ExchangeIntervals(exchange)
+	for _, candidate := range supported {
+		if candidate == normalized {
+			return normalized, nil
+		}
+	}
+	return "", fmt.Errorf("K线周期 %s 不受 %s 支持，可选 %s", normalized, exchangeName(exchange), strings.Join(supported, "/"))
+}
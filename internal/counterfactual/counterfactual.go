@@ -0,0 +1,210 @@
+// Package counterfactual 在每个周期的AI决策旁记录纯规则策略的信号，用相同的单位仓位分别模拟
+// “按AI决策”与“只按策略信号”两条路径的盈亏，持续回答“如果只跟策略会怎样”。
+package counterfactual
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+// 模拟持仓方向。
+const (
+	SideLong  = "long"
+	SideShort = "short"
+	SideFlat  = "flat"
+)
+
+// Book 为一条路径的模拟账本：每次开仓使用相同的名义金额，盈亏以占名义金额的百分比累计，
+// 开平仓各扣一次吃单手续费。Side 为空表示空仓。
+type Book struct {
+	Side              string  `json:"side,omitempty"`
+	Entry             float64 `json:"entry,omitempty"`
+	RealizedPercent   float64 `json:"realizedPercent"`
+	UnrealizedPercent float64 `json:"unrealizedPercent"`
+	Trades            int     `json:"trades"`
+	Wins              int     `json:"wins"`
+}
+
+// TotalPercent 返回已实现与未实现盈亏之和（%）。
+func (b Book) TotalPercent() float64 {
+	return b.RealizedPercent + b.UnrealizedPercent
+}
+
+// WinRate 返回已平仓模拟交易的胜率，没有平仓时为 0。
+func (b Book) WinRate() float64 {
+	if b.Trades == 0 {
+		return 0
+	}
+	return float64(b.Wins) / float64(b.Trades)
+}
+
+// apply 按目标方向调整模拟持仓：target 为空时保持不变，flat 平仓，反向时先平后开。
+func (b *Book) apply(target string, price, feePercent float64) {
+	if target == "" || target == b.Side || (target == SideFlat && b.Side == "") {
+		return
+	}
+	if b.Side != "" {
+		move := b.move(price)
+		b.RealizedPercent += move - feePercent
+		b.Trades++
+		// 开仓手续费已在开仓时扣除，胜负按扣除来回手续费后的结果判断
+		if move-2*feePercent > 0 {
+			b.Wins++
+		}
+		b.Side, b.Entry = "", 0
+	}
+	if target == SideLong || target == SideShort {
+		b.Side, b.Entry = target, price
+		b.RealizedPercent -= feePercent
+	}
+	b.mark(price)
+}
+
+func (b *Book) mark(price float64) {
+	b.UnrealizedPercent = 0
+	if b.Side != "" {
+		b.UnrealizedPercent = b.move(price)
+	}
+}
+
+// move 返回持仓自开仓以来的顺向涨跌幅（%）。
+func (b *Book) move(price float64) float64 {
+	if b.Entry <= 0 {
+		return 0
+	}
+	move := (price - b.Entry) / b.Entry * 100
+	if b.Side == SideShort {
+		move = -move
+	}
+	return move
+}
+
+// Comparison 为一个交易者在一个交易对上两条路径的对比。Disagreements 为两条路径模拟持仓方向不同的周期数。
+type Comparison struct {
+	Trader        string    `json:"trader"`
+	Symbol        string    `json:"symbol"`
+	AI            Book      `json:"ai"`
+	Strategy      Book      `json:"strategy"`
+	Cycles        int       `json:"cycles"`
+	Disagreements int       `json:"disagreements"`
+	Since         time.Time `json:"since"`
+	Updated       time.Time `json:"updated"`
+}
+
+// EdgePercent 返回AI路径相对纯策略路径的超额收益（%），为正表示AI的判断带来了增益。
+func (c Comparison) EdgePercent() float64 {
+	return c.AI.TotalPercent() - c.Strategy.TotalPercent()
+}
+
+// AITarget 返回AI动作对应的模拟持仓目标：开仓/加仓取动作方向，平仓为 flat，其余（持有、观望、减仓）保持不变。
+func AITarget(action string) string {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "open_long", "increase_long":
+		return SideLong
+	case "open_short", "increase_short":
+		return SideShort
+	case "close", "exit":
+		return SideFlat
+	}
+	return ""
+}
+
+// SignalTarget 返回策略信号（strategy.Signal 的字符串形式）对应的模拟持仓目标，hold 保持不变。
+func SignalTarget(signal string) string {
+	switch strings.ToLower(strings.TrimSpace(signal)) {
+	case "long":
+		return SideLong
+	case "short":
+		return SideShort
+	case "exit":
+		return SideFlat
+	}
+	return ""
+}
+
+// TraderFees 返回各交易者的吃单费率（%），作为模拟开平仓的手续费。
+func TraderFees(cfg config.ParsedConfig) map[string]float64 {
+	fees := make(map[string]float64, len(cfg.TraderProfiles))
+	for _, profile := range cfg.TraderProfiles {
+		fees[profile.Name] = profile.Fees.Taker()
+	}
+	return fees
+}
+
+// Tracker 按交易者与交易对维护两条路径的模拟账本，可被多个交易者并发调用。
+type Tracker struct {
+	fees map[string]float64
+
+	mu          sync.Mutex
+	comparisons map[string]*Comparison
+}
+
+// NewTracker 创建对比器，fees 为各交易者的吃单费率（%），缺省为 0。
+func NewTracker(fees map[string]float64) *Tracker {
+	return &Tracker{fees: fees, comparisons: make(map[string]*Comparison)}
+}
+
+// Observe 用一条决策记录推进两条路径：AI路径按 Action，策略路径按 StrategySignal，均以 Price 成交。
+// 没有策略信号或价格的记录（如观察列表、旧版本写入的记录）返回 false。
+func (t *Tracker) Observe(record storage.DecisionRecord) (Comparison, bool) {
+	if record.StrategySignal == "" || record.Price <= 0 {
+		return Comparison{}, false
+	}
+	at := time.UnixMilli(record.CreatedAt)
+	if record.CreatedAt == 0 {
+		at = time.Now()
+	}
+	fee := t.fees[record.Trader]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	key := record.Trader + "|" + strings.ToUpper(record.Symbol)
+	c, ok := t.comparisons[key]
+	if !ok {
+		c = &Comparison{Trader: record.Trader, Symbol: strings.ToUpper(record.Symbol), Since: at}
+		t.comparisons[key] = c
+	}
+	c.AI.apply(AITarget(record.Action), record.Price, fee)
+	c.Strategy.apply(SignalTarget(record.StrategySignal), record.Price, fee)
+	c.AI.mark(record.Price)
+	c.Strategy.mark(record.Price)
+	c.Cycles++
+	if c.AI.Side != c.Strategy.Side {
+		c.Disagreements++
+	}
+	c.Updated = at
+	return *c, true
+}
+
+// Comparisons 返回当前所有对比，按交易者与交易对排序。
+func (t *Tracker) Comparisons() []Comparison {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Comparison, 0, len(t.comparisons))
+	for _, c := range t.comparisons {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Trader != out[j].Trader {
+			return out[i].Trader < out[j].Trader
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+	return out
+}
+
+// Replay 按时间顺序用历史决策记录重算对比，供报表使用；结果与交易进程中 Tracker 的累计值一致。
+func Replay(records []storage.DecisionRecord, fees map[string]float64) []Comparison {
+	sorted := append([]storage.DecisionRecord(nil), records...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt < sorted[j].CreatedAt })
+	tracker := NewTracker(fees)
+	for _, record := range sorted {
+		tracker.Observe(record)
+	}
+	return tracker.Comparisons()
+}
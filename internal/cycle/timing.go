@@ -0,0 +1,166 @@
+// Package cycle 记录交易周期各阶段的耗时：写入决策记录、导出 Prometheus 直方图，并供看板汇总。
+package cycle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"autobot/internal/metrics"
+)
+
+// 交易周期的阶段，按执行顺序排列。
+const (
+	PhasePool      = "pool"
+	PhaseMarket    = "market"
+	PhaseNews      = "news"
+	PhasePrompt    = "prompt"
+	PhaseAI        = "ai"
+	PhaseRisk      = "risk"
+	PhaseExecution = "execution"
+)
+
+// Phases 为全部阶段的固定顺序，汇总与显示按此排列，其余阶段名排在最后。
+var Phases = []string{PhasePool, PhaseMarket, PhaseNews, PhasePrompt, PhaseAI, PhaseRisk, PhaseExecution}
+
+var phaseSeconds = metrics.NewHistogram("cycle_phase_seconds", "Duration of each trading cycle phase.",
+	[]float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120}, "trader", "phase")
+
+// PhaseTiming 为单个阶段的耗时（毫秒）。
+type PhaseTiming struct {
+	Phase string `json:"phase"`
+	Ms    int64  `json:"ms"`
+}
+
+// Timings 为一个周期的耗时明细。TotalMs 为周期开始到结束的墙钟时间，可能大于各阶段之和
+// （阶段之间的等待与未计时的步骤）。
+type Timings struct {
+	TotalMs int64         `json:"totalMs"`
+	Phases  []PhaseTiming `json:"phases,omitempty"`
+}
+
+// Ms 返回阶段 phase 的耗时，未记录时为 0。
+func (t Timings) Ms(phase string) int64 {
+	var ms int64
+	for _, p := range t.Phases {
+		if p.Phase == phase {
+			ms += p.Ms
+		}
+	}
+	return ms
+}
+
+// String 返回紧凑的单行描述，如 "92.3s = ai 61.0s, market 18.2s, news 7.1s"，阶段按耗时从大到小排列。
+func (t Timings) String() string {
+	phases := append([]PhaseTiming(nil), t.Phases...)
+	sort.SliceStable(phases, func(i, j int) bool { return phases[i].Ms > phases[j].Ms })
+	parts := make([]string, 0, len(phases))
+	for _, p := range phases {
+		parts = append(parts, fmt.Sprintf("%s %s", p.Phase, formatMs(p.Ms)))
+	}
+	return fmt.Sprintf("%s = %s", formatMs(t.TotalMs), strings.Join(parts, ", "))
+}
+
+// Timer 在一个周期内累计各阶段耗时，可以在多个 goroutine 中使用；同一阶段多次计时会累加。
+type Timer struct {
+	trader string
+	start  time.Time
+
+	mu     sync.Mutex
+	phases map[string]time.Duration
+}
+
+// Start 开始为 trader 的一个周期计时。
+func Start(trader string) *Timer {
+	return &Timer{trader: trader, start: time.Now(), phases: map[string]time.Duration{}}
+}
+
+// Phase 开始为 phase 计时，返回结束计时的函数：
+//
+//	done := timer.Phase(cycle.PhaseAI)
+//	decision, err := provider.GenerateDecision(ctx, req)
+//	done()
+func (t *Timer) Phase(phase string) func() {
+	start := time.Now()
+	return func() { t.Observe(phase, time.Since(start)) }
+}
+
+// Observe 为 phase 累加一段耗时。nil 计时器忽略，调用方无需判断是否启用了计时。
+func (t *Timer) Observe(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.phases[phase] += d
+	t.mu.Unlock()
+	phaseSeconds.Observe(d.Seconds(), t.trader, phase)
+}
+
+// Finish 结束计时并返回本周期的耗时明细，阶段按 Phases 的顺序排列。
+func (t *Timer) Finish() Timings {
+	if t == nil {
+		return Timings{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timings := Timings{TotalMs: time.Since(t.start).Milliseconds()}
+	for _, phase := range orderedPhases(t.phases) {
+		timings.Phases = append(timings.Phases, PhaseTiming{Phase: phase, Ms: t.phases[phase].Milliseconds()})
+	}
+	phaseSeconds.Observe(float64(timings.TotalMs)/1000, t.trader, "total")
+	return timings
+}
+
+// Average 返回多个周期各阶段的平均耗时，阶段按 Phases 的顺序排列。
+func Average(history []Timings) Timings {
+	if len(history) == 0 {
+		return Timings{}
+	}
+	sums := map[string]time.Duration{}
+	var total int64
+	for _, t := range history {
+		total += t.TotalMs
+		for _, p := range t.Phases {
+			sums[p.Phase] += time.Duration(p.Ms) * time.Millisecond
+		}
+	}
+	n := int64(len(history))
+	avg := Timings{TotalMs: total / n}
+	for _, phase := range orderedPhases(sums) {
+		avg.Phases = append(avg.Phases, PhaseTiming{Phase: phase, Ms: sums[phase].Milliseconds() / n})
+	}
+	return avg
+}
+
+func orderedPhases(phases map[string]time.Duration) []string {
+	out := make([]string, 0, len(phases))
+	for _, phase := range Phases {
+		if _, ok := phases[phase]; ok {
+			out = append(out, phase)
+		}
+	}
+	var extra []string
+	for phase := range phases {
+		known := false
+		for _, p := range Phases {
+			if p == phase {
+				known = true
+				break
+			}
+		}
+		if !known {
+			extra = append(extra, phase)
+		}
+	}
+	sort.Strings(extra)
+	return append(out, extra...)
+}
+
+func formatMs(ms int64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
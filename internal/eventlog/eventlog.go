@@ -0,0 +1,269 @@
+// Package eventlog 实现只追加、哈希链式的事件日志：每条事件带序号并包含上一条事件的哈希，
+// 任何一条被修改、删除或插入都会使之后的校验失败，用于留存自动交易对资金所做操作的防篡改记录。
+package eventlog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// 事件类别。
+const (
+	KindOrder    = "order"
+	KindDecision = "decision"
+	KindConfig   = "config"
+	KindManual   = "manual"
+)
+
+// maxLine 为单条事件的最大字节数。
+const maxLine = 4 << 20
+
+// Event 为日志中的一条事件。Hash 为 PrevHash 与事件其余字段（Hash 置空）JSON 的 SHA-256。
+type Event struct {
+	Seq      int64           `json:"seq"`
+	Time     int64           `json:"time"`
+	Kind     string          `json:"kind"`
+	Trader   string          `json:"trader,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	PrevHash string          `json:"prevHash"`
+	Hash     string          `json:"hash,omitempty"`
+}
+
+// ConfigChange 为 KindConfig 事件的内容：配置文件路径及其内容的 SHA-256。
+type ConfigChange struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Note   string `json:"note,omitempty"`
+}
+
+// ManualAction 为 KindManual 事件的内容：人工干预的操作者、动作与说明。
+type ManualAction struct {
+	Operator string `json:"operator,omitempty"`
+	Action   string `json:"action"`
+	Symbol   string `json:"symbol,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+// Log 为打开的事件日志，可并发追加。其他进程（如 cmd/chaos）追加后，下一次 Append 从文件中
+// 新的最后一条接续；跨进程写入不加锁，只适合人工操作这样的低频写入。
+type Log struct {
+	mu       sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+	size     int64
+}
+
+// Open 打开或创建 path 处的事件日志，并从最后一条事件接续序号与哈希。
+// 最后一行无法解析（如写入中断）时返回错误，避免在损坏的链上继续追加。
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open event log: %w", err)
+	}
+	last, err := lastEvent(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("read event log %s: %w", path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("stat event log %s: %w", path, err)
+	}
+	l := &Log{file: file, size: info.Size()}
+	if last != nil {
+		l.seq, l.lastHash = last.Seq, last.Hash
+	}
+	return l, nil
+}
+
+func lastEvent(r io.Reader) (*Event, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+	var last []byte
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return nil, nil
+	}
+	var event Event
+	if err := json.Unmarshal(last, &event); err != nil {
+		return nil, fmt.Errorf("last event is corrupt: %w", err)
+	}
+	return &event, nil
+}
+
+// Append 追加一条事件，data 序列化为 JSON。写入后立即落盘（fsync）。
+func (l *Log) Append(kind, trader string, data any) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode event data: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.resyncLocked(); err != nil {
+		return Event{}, err
+	}
+	event := Event{
+		Seq:      l.seq + 1,
+		Time:     time.Now().UnixMilli(),
+		Kind:     kind,
+		Trader:   trader,
+		Data:     raw,
+		PrevHash: l.lastHash,
+	}
+	event.Hash, err = hashEvent(event)
+	if err != nil {
+		return Event{}, err
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return Event{}, err
+	}
+	n, err := l.file.Write(append(line, '\n'))
+	if err != nil {
+		return Event{}, err
+	}
+	if err := l.file.Sync(); err != nil {
+		return Event{}, err
+	}
+	l.seq, l.lastHash = event.Seq, event.Hash
+	l.size += int64(n)
+	return event, nil
+}
+
+// resyncLocked 在文件被其他进程追加过（大小与上次写入后不同）时重新读取最后一条事件。
+func (l *Log) resyncLocked() error {
+	info, err := l.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == l.size {
+		return nil
+	}
+	last, err := lastEvent(io.NewSectionReader(l.file, 0, info.Size()))
+	if err != nil {
+		return fmt.Errorf("resync event log: %w", err)
+	}
+	l.seq, l.lastHash, l.size = 0, "", info.Size()
+	if last != nil {
+		l.seq, l.lastHash = last.Seq, last.Hash
+	}
+	return nil
+}
+
+// Head 返回最后一条事件的序号与哈希，可记录到日志之外以发现尾部截断。
+func (l *Log) Head() (int64, string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seq, l.lastHash
+}
+
+// Close 关闭日志文件。
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+func hashEvent(event Event) (string, error) {
+	event.Hash = ""
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ChainError 描述校验失败的位置。
+type ChainError struct {
+	Line   int
+	Seq    int64
+	Reason string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("event log broken at line %d (seq %d): %s", e.Line, e.Seq, e.Reason)
+}
+
+// Result 为一次校验的结果。
+type Result struct {
+	Events   int
+	LastSeq  int64
+	LastHash string
+	Counts   map[string]int
+}
+
+// Verify 从头校验 r 中的整条哈希链：序号连续、PrevHash 指向上一条、Hash 与内容一致。
+// 链断裂时返回 *ChainError，Result 含断裂前已通过校验的事件。
+func Verify(r io.Reader) (Result, error) {
+	result := Result{Counts: make(map[string]int)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLine)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return result, &ChainError{Line: lineNo, Seq: result.LastSeq + 1, Reason: "invalid JSON: " + err.Error()}
+		}
+		if event.Seq != result.LastSeq+1 {
+			return result, &ChainError{Line: lineNo, Seq: event.Seq, Reason: fmt.Sprintf("expected seq %d", result.LastSeq+1)}
+		}
+		if event.PrevHash != result.LastHash {
+			return result, &ChainError{Line: lineNo, Seq: event.Seq, Reason: "prevHash does not match the previous event"}
+		}
+		want, err := hashEvent(event)
+		if err != nil {
+			return result, err
+		}
+		if event.Hash != want {
+			return result, &ChainError{Line: lineNo, Seq: event.Seq, Reason: "hash does not match the event content"}
+		}
+		result.Events++
+		result.LastSeq, result.LastHash = event.Seq, event.Hash
+		result.Counts[event.Kind]++
+	}
+	return result, scanner.Err()
+}
+
+// VerifyFile 校验 path 处的事件日志。
+func VerifyFile(path string) (Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Result{}, err
+	}
+	defer file.Close()
+	return Verify(file)
+}
+
+// HashFile 返回文件内容的 SHA-256，用于 ConfigChange。
+func HashFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,120 @@
+package eventlog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeEvents(t *testing.T, path string, n int) *Log {
+	t.Helper()
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if _, err := log.Append(KindManual, "alpha", ManualAction{Action: "test", Note: strings.Repeat("x", i)}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	return log
+}
+
+func TestAppendAndVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	log := writeEvents(t, path, 3)
+	seq, hash := log.Head()
+	log.Close()
+
+	result, err := VerifyFile(path)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if result.Events != 3 || result.LastSeq != 3 || result.LastHash != hash || seq != 3 {
+		t.Fatalf("result = %+v, head = %d %s", result, seq, hash)
+	}
+	if result.Counts[KindManual] != 3 {
+		t.Fatalf("counts = %v", result.Counts)
+	}
+}
+
+func TestReopenContinuesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	writeEvents(t, path, 2).Close()
+	log := writeEvents(t, path, 2)
+	seq, _ := log.Head()
+	log.Close()
+
+	if seq != 4 {
+		t.Fatalf("seq after reopen = %d, want 4", seq)
+	}
+	if _, err := VerifyFile(path); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	writeEvents(t, path, 3).Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+
+	cases := map[string][][]byte{
+		"modified":  {lines[0], bytes.Replace(lines[1], []byte(`"test"`), []byte(`"edit"`), 1), lines[2]},
+		"deleted":   {lines[0], lines[2]},
+		"reordered": {lines[1], lines[0], lines[2]},
+	}
+	for name, tampered := range cases {
+		t.Run(name, func(t *testing.T) {
+			result, err := Verify(bytes.NewReader(bytes.Join(tampered, []byte("\n"))))
+			var chainErr *ChainError
+			if !errors.As(err, &chainErr) {
+				t.Fatalf("err = %v, want *ChainError", err)
+			}
+			if result.Events >= 3 {
+				t.Fatalf("verified %d events despite tampering", result.Events)
+			}
+		})
+	}
+}
+
+func TestOpenRejectsCorruptTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	writeEvents(t, path, 1).Close()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"seq":2,"kind"`)
+	file.Close()
+
+	if _, err := Open(path); err == nil {
+		t.Fatal("open succeeded on a truncated last event")
+	}
+}
+
+func TestAppendResyncsWithOtherWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	long := writeEvents(t, path, 1)
+	defer long.Close()
+
+	// Another process appends while the first log stays open.
+	writeEvents(t, path, 2).Close()
+	if _, err := long.Append(KindConfig, "", ConfigChange{Path: "config.json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := VerifyFile(path)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if result.Events != 4 {
+		t.Fatalf("events = %d, want 4", result.Events)
+	}
+}
@@ -0,0 +1,199 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/storage"
+)
+
+// AuditRecorder persists exchange audit records; storage.Store satisfies it.
+type AuditRecorder interface {
+	RecordExchangeAudit(ctx context.Context, record storage.ExchangeAuditRecord) error
+}
+
+// Auditable is implemented by adapters that can record their signed requests.
+type Auditable interface {
+	EnableAudit(rec AuditRecorder)
+}
+
+// EnableAudit turns on request auditing when the adapter supports it.
+func EnableAudit(ex Exchange, rec AuditRecorder) bool {
+	auditable, ok := ex.(Auditable)
+	if ok && rec != nil {
+		auditable.EnableAudit(rec)
+	}
+	return ok
+}
+
+// AuditPolicy describes how a venue authenticates. A request carrying any
+// secret header or parameter, or hitting a signed path, is recorded.
+type AuditPolicy struct {
+	// SecretHeaders are replaced with a placeholder in the record.
+	SecretHeaders []string
+	// SecretParams are query or form parameters replaced with a placeholder.
+	SecretParams []string
+	// SignedPaths are path suffixes whose bodies are signed by the client
+	// (no secret travels on the wire, so nothing is redacted).
+	SignedPaths []string
+	// OrderPaths are path suffixes of order placement, cancel and query
+	// endpoints, recorded by the order journal.
+	OrderPaths []string
+}
+
+const redacted = "***"
+
+// maxAuditBody caps stored request/response bodies.
+const maxAuditBody = 64 << 10
+
+type auditTransport struct {
+	venue  string
+	base   http.RoundTripper
+	policy AuditPolicy
+	logger *loggerpkg.ModuleLogger
+	// match selects the requests to record and sink stores them.
+	match func(req *http.Request, body []byte) bool
+	sink  func(ctx context.Context, record storage.ExchangeAuditRecord) error
+}
+
+// NewAuditTransport wraps base so every signed request and its raw response
+// are written to rec. Unsigned market-data requests pass through untouched.
+func NewAuditTransport(venue string, base http.RoundTripper, policy AuditPolicy, rec AuditRecorder) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &auditTransport{venue: venue, base: base, policy: policy, logger: loggerpkg.Get("exchange.audit"), sink: rec.RecordExchangeAudit}
+	t.match = t.signed
+	return t
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if !t.match(req, body) {
+		return t.base.RoundTrip(req)
+	}
+
+	record := storage.ExchangeAuditRecord{
+		Exchange:       t.venue,
+		Method:         req.Method,
+		URL:            t.redactURL(req.URL),
+		RequestHeaders: t.redactHeaders(req.Header),
+		RequestBody:    truncate(t.redactBody(req.Header.Get("Content-Type"), body)),
+	}
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	record.DurationMs = time.Since(start).Milliseconds()
+	record.CreatedAt = start.UnixMilli()
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.Status = resp.StatusCode
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		record.ResponseBody = truncate(string(data))
+		if readErr != nil {
+			record.Error = readErr.Error()
+		}
+	}
+	if recErr := t.sink(req.Context(), record); recErr != nil {
+		t.logger.Printf("audit.record.error exchange=%s method=%s err=%v", t.venue, req.Method, recErr)
+	}
+	return resp, err
+}
+
+func (t *auditTransport) signed(req *http.Request, body []byte) bool {
+	for _, header := range t.policy.SecretHeaders {
+		if req.Header.Get(header) != "" {
+			return true
+		}
+	}
+	for _, path := range t.policy.SignedPaths {
+		if strings.HasSuffix(req.URL.Path, path) {
+			return true
+		}
+	}
+	if len(t.policy.SecretParams) == 0 {
+		return false
+	}
+	query := req.URL.Query()
+	form, _ := url.ParseQuery(string(body))
+	for _, param := range t.policy.SecretParams {
+		if query.Has(param) || (isForm(req.Header.Get("Content-Type")) && form.Has(param)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *auditTransport) redactURL(u *url.URL) string {
+	clone := *u
+	clone.RawQuery = t.redactValues(u.RawQuery)
+	return clone.String()
+}
+
+func (t *auditTransport) redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ",")
+		for _, secret := range t.policy.SecretHeaders {
+			if strings.EqualFold(name, secret) {
+				value = redacted
+			}
+		}
+		out[name] = value
+	}
+	return out
+}
+
+func (t *auditTransport) redactBody(contentType string, body []byte) string {
+	if isForm(contentType) {
+		return t.redactValues(string(body))
+	}
+	return string(body)
+}
+
+// redactValues masks secret params in an encoded query while keeping order.
+func (t *auditTransport) redactValues(raw string) string {
+	if raw == "" || len(t.policy.SecretParams) == 0 {
+		return raw
+	}
+	parts := strings.Split(raw, "&")
+	for i, part := range parts {
+		key, _, _ := strings.Cut(part, "=")
+		if name, err := url.QueryUnescape(key); err == nil {
+			for _, secret := range t.policy.SecretParams {
+				if name == secret {
+					parts[i] = key + "=" + redacted
+				}
+			}
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func isForm(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/x-www-form-urlencoded")
+}
+
+func truncate(s string) string {
+	if len(s) > maxAuditBody {
+		return s[:maxAuditBody] + "...(truncated)"
+	}
+	return s
+}
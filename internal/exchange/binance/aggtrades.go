@@ -0,0 +1,70 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+var _ exchange.TradeSource = (*Client)(nil)
+
+// GetAggTrades fetches the most recent aggregated trades (max 1000 per call).
+func (c *Client) GetAggTrades(ctx context.Context, symbol string, limit int) ([]AggTrade, error) {
+	endpoint := fmt.Sprintf("%s/fapi/v1/aggTrades", c.baseURL)
+	if c.spot {
+		endpoint = fmt.Sprintf("%s/api/v3/aggTrades", c.baseURL)
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get agg trades: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("agg trades status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload []struct {
+		ID         int64  `json:"a"`
+		Price      string `json:"p"`
+		Quantity   string `json:"q"`
+		Time       int64  `json:"T"`
+		BuyerMaker bool   `json:"m"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode agg trades: %w", err)
+	}
+
+	trades := make([]AggTrade, 0, len(payload))
+	for _, entry := range payload {
+		price, _ := strconv.ParseFloat(entry.Price, 64)
+		qty, _ := strconv.ParseFloat(entry.Quantity, 64)
+		trades = append(trades, AggTrade{
+			ID:         entry.ID,
+			Price:      price,
+			Quantity:   qty,
+			Time:       time.UnixMilli(entry.Time),
+			BuyerMaker: entry.BuyerMaker,
+		})
+	}
+	return trades, nil
+}
@@ -0,0 +1,21 @@
+package binance
+
+import (
+	"context"
+
+	"autobot/internal/exchange"
+)
+
+// ErrCloseAuditMismatch signals that the exchange position differs from internal state.
+var ErrCloseAuditMismatch = exchange.ErrCloseAuditMismatch
+
+type (
+	ExpectedPosition = exchange.ExpectedPosition
+	CloseAudit       = exchange.CloseAudit
+)
+
+// AuditClose compares the live position with the expected one before a close;
+// see exchange.AuditClose.
+func (c *Client) AuditClose(ctx context.Context, expected ExpectedPosition, tolerancePercent float64) (CloseAudit, error) {
+	return exchange.AuditClose(ctx, c, expected, tolerancePercent)
+}
@@ -0,0 +1,286 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/ws"
+)
+
+const defaultCombinedStreamURL = "wss://fstream.binance.com/stream"
+
+// BookTicker is a best bid/ask update.
+type BookTicker struct {
+	Symbol   string
+	BidPrice float64
+	BidQty   float64
+	AskPrice float64
+	AskQty   float64
+	Time     time.Time
+}
+
+// Mid returns the bid/ask midpoint.
+func (b BookTicker) Mid() float64 {
+	return (b.BidPrice + b.AskPrice) / 2
+}
+
+// Microprice weights each side's price by the opposite side's size, leaning
+// toward the side more likely to be hit next.
+func (b BookTicker) Microprice() float64 {
+	depth := b.BidQty + b.AskQty
+	if depth == 0 {
+		return b.Mid()
+	}
+	return (b.AskPrice*b.BidQty + b.BidPrice*b.AskQty) / depth
+}
+
+// MicroSignal summarises top-of-book pressure over a short window.
+type MicroSignal struct {
+	Symbol  string
+	Window  time.Duration
+	Updates int
+	// OFI is the order-flow imbalance (Cont/Kukanov/Stoikov) summed over the
+	// window in units of average top-of-book depth; positive means net buying pressure.
+	OFI float64
+	// DriftBps is the microprice change over the window in basis points of mid.
+	DriftBps float64
+	// SkewBps is the current microprice minus mid in basis points.
+	SkewBps   float64
+	SpreadBps float64
+}
+
+// Favors reports whether micro-momentum supports entering on side: flow and
+// microprice drift both point the same way and flow exceeds minOFI.
+func (s MicroSignal) Favors(side OrderSide, minOFI float64) bool {
+	if s.Updates < 2 {
+		return false
+	}
+	if side == OrderSideBuy {
+		return s.OFI >= minOFI && s.DriftBps >= 0
+	}
+	return s.OFI <= -minOFI && s.DriftBps <= 0
+}
+
+// ComputeMicroSignal derives OFI and microprice drift from consecutive quotes.
+func ComputeMicroSignal(quotes []BookTicker) MicroSignal {
+	if len(quotes) == 0 {
+		return MicroSignal{}
+	}
+	first, last := quotes[0], quotes[len(quotes)-1]
+	signal := MicroSignal{
+		Symbol:  last.Symbol,
+		Window:  last.Time.Sub(first.Time),
+		Updates: len(quotes),
+	}
+	mid := last.Mid()
+	if mid <= 0 {
+		return signal
+	}
+	ofi, depth := 0.0, 0.0
+	for i, q := range quotes {
+		depth += (q.BidQty + q.AskQty) / 2
+		if i == 0 {
+			continue
+		}
+		prev := quotes[i-1]
+		if q.BidPrice >= prev.BidPrice {
+			ofi += q.BidQty
+		}
+		if q.BidPrice <= prev.BidPrice {
+			ofi -= prev.BidQty
+		}
+		if q.AskPrice <= prev.AskPrice {
+			ofi -= q.AskQty
+		}
+		if q.AskPrice >= prev.AskPrice {
+			ofi += prev.AskQty
+		}
+	}
+	if avg := depth / float64(len(quotes)); avg > 0 {
+		signal.OFI = ofi / avg
+	}
+	signal.DriftBps = (last.Microprice() - first.Microprice()) / mid * 1e4
+	signal.SkewBps = (last.Microprice() - mid) / mid * 1e4
+	signal.SpreadBps = (last.AskPrice - last.BidPrice) / mid * 1e4
+	return signal
+}
+
+// BookTickerMonitor streams best bid/ask for a set of symbols and keeps a
+// short rolling window per symbol for micro-momentum entry timing.
+type BookTickerMonitor struct {
+	streamURL string
+	window    time.Duration
+	logger    *loggerpkg.ModuleLogger
+
+	mu     sync.Mutex
+	quotes map[string][]BookTicker
+}
+
+// NewBookTickerMonitor subscribes to <symbol>@bookTicker for each symbol.
+// baseURL is the combined-stream endpoint and defaults to USDⓈ-M futures.
+func NewBookTickerMonitor(baseURL string, symbols []string, window time.Duration) *BookTickerMonitor {
+	if baseURL == "" {
+		baseURL = defaultCombinedStreamURL
+	}
+	if window <= 0 {
+		window = 30 * time.Second
+	}
+	streams := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		streams = append(streams, strings.ToLower(symbol)+"@bookTicker")
+	}
+	return &BookTickerMonitor{
+		streamURL: strings.TrimRight(baseURL, "/") + "?streams=" + strings.Join(streams, "/"),
+		window:    window,
+		logger:    loggerpkg.Get("exchange.bookticker"),
+		quotes:    make(map[string][]BookTicker),
+	}
+}
+
+// Run consumes the stream until ctx is cancelled, reconnecting with backoff.
+func (m *BookTickerMonitor) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		err := m.consume(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.logger.Printf("stream.disconnected err=%v retry_in=%s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (m *BookTickerMonitor) consume(ctx context.Context) error {
+	conn, err := ws.Dial(ctx, m.streamURL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	m.logger.Printf("stream.connected url=%s", m.streamURL)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		// bookTicker is very chatty; a minute of silence means the socket is dead.
+		_ = conn.SetReadDeadline(time.Now().Add(time.Minute))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		quote, err := parseBookTicker(data)
+		if err != nil {
+			m.logger.Printf("stream.parse.error err=%v", err)
+			continue
+		}
+		m.record(quote)
+	}
+}
+
+func (m *BookTickerMonitor) record(quote BookTicker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	quotes := append(m.quotes[quote.Symbol], quote)
+	cutoff := quote.Time.Add(-m.window)
+	idx := 0
+	for idx < len(quotes)-1 && quotes[idx].Time.Before(cutoff) {
+		idx++
+	}
+	if idx > 0 {
+		quotes = append([]BookTicker(nil), quotes[idx:]...)
+	}
+	m.quotes[quote.Symbol] = quotes
+}
+
+// Signal returns the current micro signal for symbol; false when no quotes
+// arrived within the window.
+func (m *BookTickerMonitor) Signal(symbol string) (MicroSignal, bool) {
+	m.mu.Lock()
+	quotes := append([]BookTicker(nil), m.quotes[strings.ToUpper(symbol)]...)
+	m.mu.Unlock()
+	if len(quotes) == 0 || time.Since(quotes[len(quotes)-1].Time) > m.window {
+		return MicroSignal{}, false
+	}
+	return ComputeMicroSignal(quotes), true
+}
+
+// WaitFavorable polls the micro signal until it favors side or maxWait
+// elapses. It returns the last signal and whether timing was favorable; on
+// timeout the caller should enter anyway rather than miss the trade.
+func (m *BookTickerMonitor) WaitFavorable(ctx context.Context, symbol string, side OrderSide, minOFI float64, maxWait time.Duration) (MicroSignal, bool) {
+	deadline := time.Now().Add(maxWait)
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		signal, ok := m.Signal(symbol)
+		if ok && signal.Favors(side, minOFI) {
+			return signal, true
+		}
+		if !time.Now().Before(deadline) {
+			m.logger.Printf("entry.timing.timeout symbol=%s side=%s ofi=%.3f drift_bps=%.2f", symbol, side, signal.OFI, signal.DriftBps)
+			return signal, false
+		}
+		select {
+		case <-ctx.Done():
+			return signal, false
+		case <-ticker.C:
+		}
+	}
+}
+
+func parseBookTicker(data []byte) (BookTicker, error) {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+	var payload struct {
+		Event     string `json:"e"`
+		Symbol    string `json:"s"`
+		BidPrice  string `json:"b"`
+		BidQty    string `json:"B"`
+		AskPrice  string `json:"a"`
+		AskQty    string `json:"A"`
+		EventTime int64  `json:"E"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return BookTicker{}, err
+	}
+	if payload.Event != "" && payload.Event != "bookTicker" {
+		return BookTicker{}, errors.New("unexpected event " + payload.Event)
+	}
+	if payload.Symbol == "" {
+		return BookTicker{}, fmt.Errorf("bookTicker without symbol")
+	}
+	quote := BookTicker{Symbol: payload.Symbol, Time: time.Now()}
+	if payload.EventTime > 0 {
+		quote.Time = time.UnixMilli(payload.EventTime)
+	}
+	quote.BidPrice, _ = strconv.ParseFloat(payload.BidPrice, 64)
+	quote.BidQty, _ = strconv.ParseFloat(payload.BidQty, 64)
+	quote.AskPrice, _ = strconv.ParseFloat(payload.AskPrice, 64)
+	quote.AskQty, _ = strconv.ParseFloat(payload.AskQty, 64)
+	return quote, nil
+}
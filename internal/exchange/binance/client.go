@@ -10,8 +10,10 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"autobot/internal/exchange"
 	"autobot/internal/strategy"
 )
 
@@ -23,6 +25,10 @@ type Client struct {
 	apiSecret  string
 	baseURL    string
 	httpClient *http.Client
+	spot       bool
+
+	rulesMu sync.Mutex
+	rules   map[string]SymbolRules
 }
 
 // New returns a ready-to-use client.
@@ -34,89 +40,75 @@ func New(apiKey, apiSecret, baseURL string) *Client {
 		apiKey:     apiKey,
 		apiSecret:  apiSecret,
 		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		httpClient: &http.Client{Timeout: 10 * time.Second, Transport: exchange.NewMetricsTransport("binance", nil)},
 	}
 }
 
-// OrderSide represents BUY or SELL.
-type OrderSide string
-
-// PositionSide indicates hedge mode orientation.
-type PositionSide string
-
-// OrderType identifies execution type.
-type OrderType string
-
-// TimeInForce instructs how a limit order lives in the book.
-type TimeInForce string
+// Order and position types are shared across venues; the aliases keep the
+// binance-qualified names working for existing callers.
+type (
+	OrderSide     = exchange.OrderSide
+	PositionSide  = exchange.PositionSide
+	OrderType     = exchange.OrderType
+	TimeInForce   = exchange.TimeInForce
+	OrderRequest  = exchange.OrderRequest
+	OrderResponse = exchange.OrderResponse
+	AccountInfo   = exchange.AccountInfo
+	PositionRisk  = exchange.PositionRisk
+	Ticker24h     = exchange.Ticker24h
+	AggTrade      = exchange.AggTrade
+	FundingEvent  = exchange.FundingEvent
+	OrderBook     = exchange.OrderBook
+	DepthLevel    = exchange.DepthLevel
+)
 
 const (
-	OrderSideBuy  OrderSide = "BUY"
-	OrderSideSell OrderSide = "SELL"
+	OrderSideBuy  = exchange.OrderSideBuy
+	OrderSideSell = exchange.OrderSideSell
 
-	PositionSideBoth  PositionSide = "BOTH"
-	PositionSideLong  PositionSide = "LONG"
-	PositionSideShort PositionSide = "SHORT"
+	PositionSideBoth  = exchange.PositionSideBoth
+	PositionSideLong  = exchange.PositionSideLong
+	PositionSideShort = exchange.PositionSideShort
 
-	OrderTypeMarket           OrderType = "MARKET"
-	OrderTypeLimit            OrderType = "LIMIT"
-	OrderTypeStopMarket       OrderType = "STOP_MARKET"
-	OrderTypeTakeProfitMarket OrderType = "TAKE_PROFIT_MARKET"
+	OrderTypeMarket           = exchange.OrderTypeMarket
+	OrderTypeLimit            = exchange.OrderTypeLimit
+	OrderTypeStopMarket       = exchange.OrderTypeStopMarket
+	OrderTypeTakeProfitMarket = exchange.OrderTypeTakeProfitMarket
 
-	TimeInForceGTC TimeInForce = "GTC"
-	TimeInForceIOC TimeInForce = "IOC"
-	TimeInForceFOK TimeInForce = "FOK"
+	TimeInForceGTC = exchange.TimeInForceGTC
+	TimeInForceIOC = exchange.TimeInForceIOC
+	TimeInForceFOK = exchange.TimeInForceFOK
 )
 
-// OrderRequest contains the minimum parameters for a futures order.
-type OrderRequest struct {
-	Symbol       string
-	Side         OrderSide
-	PositionSide PositionSide
-	Type         OrderType
-	Quantity     float64
-	ReduceOnly   bool
-	Price        float64
-	TimeInForce  TimeInForce
-	StopPrice    float64
-	WorkingType  string
-}
+var (
+	_ exchange.Exchange             = (*Client)(nil)
+	_ exchange.MarkPriceKlineSource = (*Client)(nil)
+)
 
-// OrderResponse maps the subset of response fields we care about.
-type OrderResponse struct {
-	Symbol        string    `json:"symbol"`
-	OrderID       int64     `json:"orderId"`
-	ClientOrderID string    `json:"clientOrderId"`
-	TransactTime  int64     `json:"transactTime"`
-	AvgPrice      string    `json:"avgPrice"`
-	ExecutedQty   string    `json:"executedQty"`
-	Status        string    `json:"status"`
-	UpdateTime    time.Time `json:"-"`
+// Name identifies the venue.
+func (c *Client) Name() string {
+	return "binance"
 }
 
-// AccountInfo carries wallet data relevant to risk controls.
-type AccountInfo struct {
-	TotalWalletBalance float64
-	AvailableBalance   float64
-	CrossUnrealizedPNL float64
-	LastUpdate         time.Time
+// GetKlines retrieves recent OHLCV data for the strategy evaluation.
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	endpoint := fmt.Sprintf("%s/fapi/v1/klines", c.baseURL)
+	if c.spot {
+		endpoint = fmt.Sprintf("%s/api/v3/klines", c.baseURL)
+	}
+	return c.klines(ctx, endpoint, symbol, interval, limit)
 }
 
-// PositionRisk captures futures position status.
-type PositionRisk struct {
-	Symbol        string
-	PositionSide  PositionSide
-	Quantity      float64
-	EntryPrice    float64
-	MarkPrice     float64
-	Leverage      float64
-	UnrealizedPNL float64
-	UpdateTime    time.Time
+// GetMarkPriceKlines retrieves futures klines built from the mark price.
+// Their volume fields carry no data and are zero.
+func (c *Client) GetMarkPriceKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	if c.spot {
+		return nil, exchange.ErrNoMarkPriceKlines
+	}
+	return c.klines(ctx, fmt.Sprintf("%s/fapi/v1/markPriceKlines", c.baseURL), symbol, interval, limit)
 }
 
-// GetKlines retrieves recent OHLCV data for the strategy evaluation.
-func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
-	endpoint := fmt.Sprintf("%s/fapi/v1/klines", c.baseURL)
+func (c *Client) klines(ctx context.Context, endpoint, symbol, interval string, limit int) ([]strategy.Candle, error) {
 	params := url.Values{}
 	params.Set("symbol", symbol)
 	params.Set("interval", interval)
@@ -177,6 +169,9 @@ func (c *Client) GetPositions(ctx context.Context, symbol string) ([]PositionRis
 	if c.apiKey == "" || c.apiSecret == "" {
 		return nil, errors.New("api key/secret required for position endpoints")
 	}
+	if c.spot {
+		return c.getSpotPositions(ctx, symbol)
+	}
 
 	endpoint := fmt.Sprintf("%s/fapi/v2/positionRisk", c.baseURL)
 	params := url.Values{}
@@ -250,6 +245,9 @@ func (c *Client) GetAccountInfo(ctx context.Context) (AccountInfo, error) {
 	if c.apiKey == "" || c.apiSecret == "" {
 		return AccountInfo{}, errors.New("api key/secret required for private endpoints")
 	}
+	if c.spot {
+		return c.getSpotAccountInfo(ctx)
+	}
 
 	endpoint := fmt.Sprintf("%s/fapi/v2/account", c.baseURL)
 	params := url.Values{}
@@ -302,6 +300,9 @@ func (c *Client) PlaceOrder(ctx context.Context, reqPayload OrderRequest) (Order
 	if c.apiKey == "" || c.apiSecret == "" {
 		return OrderResponse{}, errors.New("api key/secret required for trading")
 	}
+	if c.spot {
+		return c.placeSpotOrder(ctx, reqPayload)
+	}
 
 	endpoint := fmt.Sprintf("%s/fapi/v1/order", c.baseURL)
 	params := url.Values{}
@@ -372,6 +373,9 @@ func formatPrice(p float64) string {
 
 // GetFundingRate fetches the current funding rate (last funding) for the symbol.
 func (c *Client) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	if c.spot {
+		return 0, ErrSpotUnsupported
+	}
 	endpoint := fmt.Sprintf("%s/fapi/v1/premiumIndex", c.baseURL)
 	params := url.Values{}
 	params.Set("symbol", symbol)
@@ -409,6 +413,9 @@ func (c *Client) GetFundingRate(ctx context.Context, symbol string) (float64, er
 
 // GetOpenInterest fetches the current open interest for the symbol.
 func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (float64, error) {
+	if c.spot {
+		return 0, ErrSpotUnsupported
+	}
 	endpoint := fmt.Sprintf("%s/fapi/v1/openInterest", c.baseURL)
 	params := url.Values{}
 	params.Set("symbol", symbol)
@@ -443,3 +450,80 @@ func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (float64, e
 
 	return oi, nil
 }
+
+// Get24hTicker fetches rolling 24h volume and price statistics for the symbol.
+func (c *Client) Get24hTicker(ctx context.Context, symbol string) (Ticker24h, error) {
+	endpoint := fmt.Sprintf("%s/fapi/v1/ticker/24hr", c.baseURL)
+	if c.spot {
+		endpoint = fmt.Sprintf("%s/api/v3/ticker/24hr", c.baseURL)
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return Ticker24h{}, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Ticker24h{}, fmt.Errorf("get 24h ticker: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return Ticker24h{}, fmt.Errorf("24h ticker status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload struct {
+		Symbol             string `json:"symbol"`
+		LastPrice          string `json:"lastPrice"`
+		PriceChangePercent string `json:"priceChangePercent"`
+		HighPrice          string `json:"highPrice"`
+		LowPrice           string `json:"lowPrice"`
+		Volume             string `json:"volume"`
+		QuoteVolume        string `json:"quoteVolume"`
+		Count              int64  `json:"count"`
+		CloseTime          int64  `json:"closeTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return Ticker24h{}, fmt.Errorf("decode 24h ticker: %w", err)
+	}
+
+	last, _ := strconv.ParseFloat(payload.LastPrice, 64)
+	change, _ := strconv.ParseFloat(payload.PriceChangePercent, 64)
+	high, _ := strconv.ParseFloat(payload.HighPrice, 64)
+	low, _ := strconv.ParseFloat(payload.LowPrice, 64)
+	volume, _ := strconv.ParseFloat(payload.Volume, 64)
+	quoteVolume, _ := strconv.ParseFloat(payload.QuoteVolume, 64)
+
+	return Ticker24h{
+		Symbol:             payload.Symbol,
+		LastPrice:          last,
+		PriceChangePercent: change,
+		HighPrice:          high,
+		LowPrice:           low,
+		Volume:             volume,
+		QuoteVolume:        quoteVolume,
+		TradeCount:         payload.Count,
+		CloseTime:          time.UnixMilli(payload.CloseTime),
+	}, nil
+}
+
+// auditPolicy marks API-key requests as signed and hides the key and signature.
+var auditPolicy = exchange.AuditPolicy{
+	SecretHeaders: []string{"X-MBX-APIKEY"},
+	SecretParams:  []string{"signature"},
+	OrderPaths:    []string{"/fapi/v1/order", "/fapi/v1/batchOrders", "/fapi/v1/allOpenOrders", "/api/v3/order", "/api/v3/openOrders"},
+}
+
+// EnableAudit records every signed request and its raw response to rec.
+func (c *Client) EnableAudit(rec exchange.AuditRecorder) {
+	c.httpClient.Transport = exchange.NewAuditTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
+
+// EnableOrderJournal records every order request and its raw response to rec.
+func (c *Client) EnableOrderJournal(rec exchange.OrderJournalRecorder) {
+	c.httpClient.Transport = exchange.NewOrderJournalTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
@@ -0,0 +1,60 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"autobot/internal/exchange"
+)
+
+var _ exchange.PositionCloser = (*Client)(nil)
+
+// ClosePosition closes whatever remains of the expected position with a
+// market order. AuditClose runs first and a mismatch aborts the close with
+// ErrCloseAuditMismatch. The quantity is the audited exchange quantity floored
+// to the lot step, so rounding can never flip or over-close the position, and
+// split into MaxQty chunks so a large position is closed in full.
+// One-way mode orders are sent reduce-only; hedge mode orders target the
+// matching leg.
+func (c *Client) ClosePosition(ctx context.Context, expected ExpectedPosition, tolerancePercent float64) (OrderResponse, error) {
+	symbol := expected.Symbol
+	side := exchange.NormalizePositionSide(expected.Side)
+	if side == "" {
+		return OrderResponse{}, fmt.Errorf("close position %s: side must be LONG or SHORT", symbol)
+	}
+
+	audit, err := c.AuditClose(ctx, expected, tolerancePercent)
+	if err != nil {
+		return OrderResponse{}, fmt.Errorf("close position %s: %w", symbol, err)
+	}
+	target := audit.Position
+
+	remaining := math.Abs(target.Quantity)
+	chunks := []float64{remaining}
+	if rules, err := c.GetLotRules(ctx, symbol); err == nil {
+		chunks = rules.CloseChunks(remaining)
+	}
+	if len(chunks) == 0 {
+		return OrderResponse{}, fmt.Errorf("close position %s: remaining quantity %s below lot step", symbol, formatQuantity(remaining))
+	}
+
+	req := OrderRequest{
+		Symbol: symbol,
+		Side:   OrderSideSell,
+		Type:   OrderTypeMarket,
+	}
+	if side == PositionSideShort {
+		req.Side = OrderSideBuy
+	}
+	if !c.spot {
+		if target.PositionSide == PositionSideLong || target.PositionSide == PositionSideShort {
+			// Hedge mode rejects reduceOnly; the position side already restricts the order.
+			req.PositionSide = target.PositionSide
+		} else {
+			req.ReduceOnly = true
+		}
+	}
+
+	return exchange.PlaceCloseChunks(ctx, c.PlaceOrder, req, chunks)
+}
@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+var _ exchange.DepthSource = (*Client)(nil)
+
+// depthLimits are the page sizes accepted by the depth endpoints.
+var depthLimits = []int{5, 10, 20, 50, 100, 500, 1000}
+
+// GetDepth fetches an order book snapshot with at least limit levels per
+// side (rounded up to a size the endpoint accepts, at most 1000).
+func (c *Client) GetDepth(ctx context.Context, symbol string, limit int) (OrderBook, error) {
+	endpoint := fmt.Sprintf("%s/fapi/v1/depth", c.baseURL)
+	if c.spot {
+		endpoint = fmt.Sprintf("%s/api/v3/depth", c.baseURL)
+	}
+	size := depthLimits[len(depthLimits)-1]
+	for _, candidate := range depthLimits {
+		if candidate >= limit {
+			size = candidate
+			break
+		}
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("limit", strconv.Itoa(size))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return OrderBook{}, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return OrderBook{}, fmt.Errorf("get depth: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return OrderBook{}, fmt.Errorf("depth status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload struct {
+		Time int64       `json:"T"`
+		Bids [][2]string `json:"bids"`
+		Asks [][2]string `json:"asks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return OrderBook{}, fmt.Errorf("decode depth: %w", err)
+	}
+	book := OrderBook{Bids: depthLevels(payload.Bids, limit), Asks: depthLevels(payload.Asks, limit), Time: time.Now()}
+	if payload.Time > 0 {
+		book.Time = time.UnixMilli(payload.Time)
+	}
+	return book, nil
+}
+
+func depthLevels(raw [][2]string, limit int) []DepthLevel {
+	if limit > 0 && len(raw) > limit {
+		raw = raw[:limit]
+	}
+	levels := make([]DepthLevel, 0, len(raw))
+	for _, entry := range raw {
+		price, err := strconv.ParseFloat(entry[0], 64)
+		if err != nil {
+			continue
+		}
+		qty, err := strconv.ParseFloat(entry[1], 64)
+		if err != nil {
+			continue
+		}
+		levels = append(levels, DepthLevel{Price: price, Quantity: qty})
+	}
+	return levels
+}
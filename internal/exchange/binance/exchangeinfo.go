@@ -0,0 +1,179 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"autobot/internal/exchange"
+)
+
+// SymbolRules holds the trading filters Binance enforces for a symbol.
+type SymbolRules struct {
+	Symbol      string
+	TickSize    float64
+	StepSize    float64
+	MinQty      float64
+	MaxQty      float64
+	MinNotional float64
+}
+
+// FloorQuantity rounds q down to the lot step so it never exceeds the input.
+func (r SymbolRules) FloorQuantity(q float64) float64 {
+	return floorToStep(q, r.StepSize)
+}
+
+// RoundPrice rounds p to the nearest tick.
+func (r SymbolRules) RoundPrice(p float64) float64 {
+	if r.TickSize <= 0 {
+		return p
+	}
+	return roundDecimals(math.Round(p/r.TickSize)*r.TickSize, stepDecimals(r.TickSize))
+}
+
+// PriceDecimals returns the number of decimals implied by the tick size.
+func (r SymbolRules) PriceDecimals() int {
+	return stepDecimals(r.TickSize)
+}
+
+// GetSymbolRules returns cached exchange filters, loading exchangeInfo on first use.
+func (c *Client) GetSymbolRules(ctx context.Context, symbol string) (SymbolRules, error) {
+	symbol = strings.ToUpper(symbol)
+	c.rulesMu.Lock()
+	rules, ok := c.rules[symbol]
+	c.rulesMu.Unlock()
+	if ok {
+		return rules, nil
+	}
+
+	loaded, err := c.loadExchangeInfo(ctx, symbol)
+	if err != nil {
+		return SymbolRules{}, err
+	}
+	c.rulesMu.Lock()
+	if c.rules == nil {
+		c.rules = make(map[string]SymbolRules, len(loaded))
+	}
+	for _, item := range loaded {
+		c.rules[item.Symbol] = item
+	}
+	rules, ok = c.rules[symbol]
+	c.rulesMu.Unlock()
+	if !ok {
+		return SymbolRules{}, fmt.Errorf("symbol %s not found in exchange info", symbol)
+	}
+	return rules, nil
+}
+
+func (c *Client) loadExchangeInfo(ctx context.Context, symbol string) ([]SymbolRules, error) {
+	endpoint := fmt.Sprintf("%s/fapi/v1/exchangeInfo", c.baseURL)
+	if c.spot {
+		params := url.Values{}
+		params.Set("symbol", symbol)
+		endpoint = fmt.Sprintf("%s/api/v3/exchangeInfo?%s", c.baseURL, params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get exchange info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("exchange info status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload struct {
+		Symbols []struct {
+			Symbol  string `json:"symbol"`
+			Filters []struct {
+				FilterType  string `json:"filterType"`
+				TickSize    string `json:"tickSize"`
+				StepSize    string `json:"stepSize"`
+				MinQty      string `json:"minQty"`
+				MaxQty      string `json:"maxQty"`
+				Notional    string `json:"notional"`
+				MinNotional string `json:"minNotional"`
+			} `json:"filters"`
+		} `json:"symbols"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode exchange info: %w", err)
+	}
+
+	result := make([]SymbolRules, 0, len(payload.Symbols))
+	for _, item := range payload.Symbols {
+		rules := SymbolRules{Symbol: item.Symbol}
+		for _, f := range item.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				rules.TickSize, _ = strconv.ParseFloat(f.TickSize, 64)
+			case "LOT_SIZE":
+				rules.StepSize, _ = strconv.ParseFloat(f.StepSize, 64)
+				rules.MinQty, _ = strconv.ParseFloat(f.MinQty, 64)
+				rules.MaxQty, _ = strconv.ParseFloat(f.MaxQty, 64)
+			case "MIN_NOTIONAL", "NOTIONAL":
+				value := f.Notional
+				if value == "" {
+					value = f.MinNotional
+				}
+				rules.MinNotional, _ = strconv.ParseFloat(value, 64)
+			}
+		}
+		result = append(result, rules)
+	}
+	return result, nil
+}
+
+func floorToStep(q, step float64) float64 {
+	if step <= 0 {
+		return q
+	}
+	// The epsilon absorbs float error such as 0.3/0.1 = 2.9999999999999996.
+	return roundDecimals(math.Floor(q/step+1e-9)*step, stepDecimals(step))
+}
+
+func stepDecimals(step float64) int {
+	if step <= 0 {
+		return 8
+	}
+	text := strconv.FormatFloat(step, 'f', -1, 64)
+	if idx := strings.IndexByte(text, '.'); idx >= 0 {
+		return len(text) - idx - 1
+	}
+	return 0
+}
+
+func roundDecimals(v float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(v*factor) / factor
+}
+
+var _ exchange.LotRulesSource = (*Client)(nil)
+
+// GetLotRules exposes the cached exchange filters in venue-neutral form.
+func (c *Client) GetLotRules(ctx context.Context, symbol string) (exchange.LotRules, error) {
+	rules, err := c.GetSymbolRules(ctx, symbol)
+	if err != nil {
+		return exchange.LotRules{}, err
+	}
+	return exchange.LotRules{
+		Symbol:      rules.Symbol,
+		StepSize:    rules.StepSize,
+		MinQty:      rules.MinQty,
+		MaxQty:      rules.MaxQty,
+		MinNotional: rules.MinNotional,
+		TickSize:    rules.TickSize,
+	}, nil
+}
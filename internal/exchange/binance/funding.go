@@ -0,0 +1,72 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+var _ exchange.FundingHistorySource = (*Client)(nil)
+
+// fundingHistoryLimit is the maximum page size of /fapi/v1/fundingRate.
+const fundingHistoryLimit = 1000
+
+// GetFundingHistory fetches settled funding rates in [start, end], paging
+// through the endpoint as needed. Results are in ascending time order.
+func (c *Client) GetFundingHistory(ctx context.Context, symbol string, start, end time.Time) ([]FundingEvent, error) {
+	if c.spot {
+		return nil, ErrSpotUnsupported
+	}
+	endpoint := fmt.Sprintf("%s/fapi/v1/fundingRate", c.baseURL)
+	var events []FundingEvent
+	for from := start; !from.After(end); {
+		params := url.Values{}
+		params.Set("symbol", symbol)
+		params.Set("startTime", strconv.FormatInt(from.UnixMilli(), 10))
+		params.Set("endTime", strconv.FormatInt(end.UnixMilli(), 10))
+		params.Set("limit", strconv.Itoa(fundingHistoryLimit))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("get funding history: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("funding history status %d: %s", resp.StatusCode, string(data))
+		}
+		var payload []struct {
+			FundingTime int64  `json:"fundingTime"`
+			FundingRate string `json:"fundingRate"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&payload)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode funding history: %w", err)
+		}
+
+		for _, entry := range payload {
+			rate, err := strconv.ParseFloat(entry.FundingRate, 64)
+			if err != nil {
+				continue
+			}
+			events = append(events, FundingEvent{Time: time.UnixMilli(entry.FundingTime), Rate: rate})
+		}
+		if len(payload) < fundingHistoryLimit {
+			break
+		}
+		from = time.UnixMilli(payload[len(payload)-1].FundingTime + 1)
+	}
+	return events, nil
+}
@@ -0,0 +1,102 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+var (
+	_ exchange.LeverageSource = (*Client)(nil)
+	_ exchange.FeeRateSource  = (*Client)(nil)
+)
+
+// GetMaxLeverage returns the initial leverage of the symbol's first notional
+// bracket. The endpoint is signed, so API credentials are required.
+func (c *Client) GetMaxLeverage(ctx context.Context, symbol string) (float64, error) {
+	if c.spot {
+		return 0, ErrSpotUnsupported
+	}
+	var payload []struct {
+		Symbol   string `json:"symbol"`
+		Brackets []struct {
+			Bracket         int     `json:"bracket"`
+			InitialLeverage float64 `json:"initialLeverage"`
+		} `json:"brackets"`
+	}
+	if err := c.signedGet(ctx, "/fapi/v1/leverageBracket", url.Values{"symbol": {symbol}}, &payload); err != nil {
+		return 0, fmt.Errorf("get leverage bracket: %w", err)
+	}
+	max := 0.0
+	for _, item := range payload {
+		for _, bracket := range item.Brackets {
+			if bracket.InitialLeverage > max {
+				max = bracket.InitialLeverage
+			}
+		}
+	}
+	if max <= 0 {
+		return 0, fmt.Errorf("no leverage bracket for %s", symbol)
+	}
+	return max, nil
+}
+
+// GetFeeRates returns the account's maker and taker commission for the symbol
+// as percentages.
+func (c *Client) GetFeeRates(ctx context.Context, symbol string) (float64, float64, error) {
+	if c.spot {
+		return 0, 0, ErrSpotUnsupported
+	}
+	var payload struct {
+		MakerCommissionRate string `json:"makerCommissionRate"`
+		TakerCommissionRate string `json:"takerCommissionRate"`
+	}
+	if err := c.signedGet(ctx, "/fapi/v1/commissionRate", url.Values{"symbol": {symbol}}, &payload); err != nil {
+		return 0, 0, fmt.Errorf("get commission rate: %w", err)
+	}
+	maker, err := strconv.ParseFloat(payload.MakerCommissionRate, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse maker commission: %w", err)
+	}
+	taker, err := strconv.ParseFloat(payload.TakerCommissionRate, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse taker commission: %w", err)
+	}
+	return maker * 100, taker * 100, nil
+}
+
+// signedGet performs a signed USER_DATA GET and decodes the JSON response into out.
+func (c *Client) signedGet(ctx context.Context, path string, params url.Values, out any) error {
+	if c.apiKey == "" || c.apiSecret == "" {
+		return errors.New("api key/secret required for private endpoints")
+	}
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	params.Set("recvWindow", "5000")
+	params.Set("signature", sign(c.apiSecret, params.Encode()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-MBX-APIKEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(data))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
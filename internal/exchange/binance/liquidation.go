@@ -0,0 +1,256 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/ws"
+)
+
+const defaultStreamBaseURL = "wss://fstream.binance.com/ws"
+
+// LiquidationEvent describes a single forced liquidation order.
+// A SELL order liquidates a long position, a BUY order liquidates a short.
+type LiquidationEvent struct {
+	Symbol   string
+	Side     OrderSide
+	Price    float64
+	Quantity float64
+	Notional float64
+	Time     time.Time
+}
+
+// String renders a compact one-line description for dashboards.
+func (e LiquidationEvent) String() string {
+	victim := "多单"
+	if e.Side == OrderSideBuy {
+		victim = "空单"
+	}
+	return fmt.Sprintf("%s 强平 %s %s %.0f USDT @ %s", e.Time.Local().Format("15:04:05"), e.Symbol, victim, e.Notional, formatPrice(e.Price))
+}
+
+// LiquidationSummary aggregates liquidations of one symbol over the window.
+type LiquidationSummary struct {
+	Symbol             string
+	Window             time.Duration
+	Count              int
+	LongLiquidatedUSD  float64
+	ShortLiquidatedUSD float64
+	LargestUSD         float64
+	LastEvent          time.Time
+}
+
+// LiquidationMonitor subscribes to the forceOrder stream and keeps a rolling
+// window of events whose notional exceeds the configured threshold.
+type LiquidationMonitor struct {
+	streamURL   string
+	minNotional float64
+	window      time.Duration
+	logger      *loggerpkg.ModuleLogger
+
+	mu       sync.Mutex
+	events   []LiquidationEvent
+	handlers []func(LiquidationEvent)
+}
+
+// NewLiquidationMonitor creates a monitor for all symbols. baseURL defaults to
+// the USDⓈ-M futures stream endpoint.
+func NewLiquidationMonitor(baseURL string, minNotional float64, window time.Duration) *LiquidationMonitor {
+	if baseURL == "" {
+		baseURL = defaultStreamBaseURL
+	}
+	if window <= 0 {
+		window = 15 * time.Minute
+	}
+	return &LiquidationMonitor{
+		streamURL:   strings.TrimRight(baseURL, "/") + "/!forceOrder@arr",
+		minNotional: minNotional,
+		window:      window,
+		logger:      loggerpkg.Get("exchange.liquidation"),
+	}
+}
+
+// OnEvent registers a callback invoked for every event above the threshold.
+func (m *LiquidationMonitor) OnEvent(fn func(LiquidationEvent)) {
+	if fn == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, fn)
+}
+
+// Run consumes the stream until ctx is cancelled, reconnecting with backoff.
+func (m *LiquidationMonitor) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		err := m.consume(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.logger.Printf("stream.disconnected err=%v retry_in=%s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (m *LiquidationMonitor) consume(ctx context.Context) error {
+	conn, err := ws.Dial(ctx, m.streamURL, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	m.logger.Printf("stream.connected url=%s min_notional=%.0f", m.streamURL, m.minNotional)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		// Binance pings every few minutes; a silent socket for longer is dead.
+		_ = conn.SetReadDeadline(time.Now().Add(10 * time.Minute))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		event, err := parseForceOrder(data)
+		if err != nil {
+			m.logger.Printf("stream.parse.error err=%v", err)
+			continue
+		}
+		m.record(event)
+	}
+}
+
+func (m *LiquidationMonitor) record(event LiquidationEvent) {
+	if event.Notional < m.minNotional {
+		return
+	}
+	m.mu.Lock()
+	m.events = append(m.events, event)
+	m.pruneLocked(time.Now())
+	handlers := append([]func(LiquidationEvent){}, m.handlers...)
+	m.mu.Unlock()
+
+	m.logger.Printf("liquidation symbol=%s side=%s qty=%s price=%s notional=%.2f",
+		event.Symbol, event.Side, formatQuantity(event.Quantity), formatPrice(event.Price), event.Notional)
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+func (m *LiquidationMonitor) pruneLocked(now time.Time) {
+	cutoff := now.Add(-m.window)
+	idx := 0
+	for idx < len(m.events) && m.events[idx].Time.Before(cutoff) {
+		idx++
+	}
+	if idx > 0 {
+		m.events = append([]LiquidationEvent(nil), m.events[idx:]...)
+	}
+}
+
+// Recent returns events in the window, newest first. An empty symbol returns all.
+func (m *LiquidationMonitor) Recent(symbol string) []LiquidationEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pruneLocked(time.Now())
+	result := make([]LiquidationEvent, 0, len(m.events))
+	for i := len(m.events) - 1; i >= 0; i-- {
+		if symbol != "" && m.events[i].Symbol != symbol {
+			continue
+		}
+		result = append(result, m.events[i])
+	}
+	return result
+}
+
+// Summary aggregates the window for a symbol.
+func (m *LiquidationMonitor) Summary(symbol string) LiquidationSummary {
+	summary := LiquidationSummary{Symbol: symbol, Window: m.window}
+	for _, event := range m.Recent(symbol) {
+		summary.Count++
+		if event.Side == OrderSideSell {
+			summary.LongLiquidatedUSD += event.Notional
+		} else {
+			summary.ShortLiquidatedUSD += event.Notional
+		}
+		if event.Notional > summary.LargestUSD {
+			summary.LargestUSD = event.Notional
+		}
+		if event.Time.After(summary.LastEvent) {
+			summary.LastEvent = event.Time
+		}
+	}
+	return summary
+}
+
+// Summaries returns per-symbol aggregates sorted by total liquidated notional.
+func (m *LiquidationMonitor) Summaries() []LiquidationSummary {
+	symbols := map[string]struct{}{}
+	for _, event := range m.Recent("") {
+		symbols[event.Symbol] = struct{}{}
+	}
+	result := make([]LiquidationSummary, 0, len(symbols))
+	for symbol := range symbols {
+		result = append(result, m.Summary(symbol))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].LongLiquidatedUSD+result[i].ShortLiquidatedUSD > result[j].LongLiquidatedUSD+result[j].ShortLiquidatedUSD
+	})
+	return result
+}
+
+func parseForceOrder(data []byte) (LiquidationEvent, error) {
+	var payload struct {
+		Event string `json:"e"`
+		Order struct {
+			Symbol    string `json:"s"`
+			Side      string `json:"S"`
+			Quantity  string `json:"q"`
+			Price     string `json:"p"`
+			AvgPrice  string `json:"ap"`
+			TradeTime int64  `json:"T"`
+		} `json:"o"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return LiquidationEvent{}, err
+	}
+	if payload.Event != "forceOrder" {
+		return LiquidationEvent{}, errors.New("unexpected event " + payload.Event)
+	}
+
+	qty, _ := strconv.ParseFloat(payload.Order.Quantity, 64)
+	price, _ := strconv.ParseFloat(payload.Order.AvgPrice, 64)
+	if price == 0 {
+		price, _ = strconv.ParseFloat(payload.Order.Price, 64)
+	}
+	return LiquidationEvent{
+		Symbol:   payload.Order.Symbol,
+		Side:     OrderSide(payload.Order.Side),
+		Price:    price,
+		Quantity: qty,
+		Notional: qty * price,
+		Time:     time.UnixMilli(payload.Order.TradeTime),
+	}, nil
+}
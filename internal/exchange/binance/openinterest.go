@@ -0,0 +1,93 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+var _ exchange.OpenInterestHistorySource = (*Client)(nil)
+
+// openInterestHistoryLimit is the maximum page size of /futures/data/openInterestHist.
+const openInterestHistoryLimit = 500
+
+// openInterestPeriods are the sampling periods the endpoint accepts.
+var openInterestPeriods = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// GetOpenInterestHistory fetches open interest sampled every period in
+// [start, end], one page of up to 500 samples at a time. Binance only keeps
+// the most recent 30 days; older parts of the range come back empty.
+func (c *Client) GetOpenInterestHistory(ctx context.Context, symbol, period string, start, end time.Time) ([]exchange.OpenInterestPoint, error) {
+	if c.spot {
+		return nil, ErrSpotUnsupported
+	}
+	step, ok := openInterestPeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("unsupported open interest period %q", period)
+	}
+	endpoint := fmt.Sprintf("%s/futures/data/openInterestHist", c.baseURL)
+	var points []exchange.OpenInterestPoint
+	for from := start; !from.After(end); from = from.Add(step * openInterestHistoryLimit) {
+		to := from.Add(step*openInterestHistoryLimit - time.Millisecond)
+		if to.After(end) {
+			to = end
+		}
+		params := url.Values{}
+		params.Set("symbol", symbol)
+		params.Set("period", period)
+		params.Set("startTime", strconv.FormatInt(from.UnixMilli(), 10))
+		params.Set("endTime", strconv.FormatInt(to.UnixMilli(), 10))
+		params.Set("limit", strconv.Itoa(openInterestHistoryLimit))
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("get open interest history: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("open interest history status %d: %s", resp.StatusCode, string(data))
+		}
+		var payload []struct {
+			Timestamp            int64  `json:"timestamp"`
+			SumOpenInterest      string `json:"sumOpenInterest"`
+			SumOpenInterestValue string `json:"sumOpenInterestValue"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&payload)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode open interest history: %w", err)
+		}
+
+		for _, entry := range payload {
+			contracts, err := strconv.ParseFloat(entry.SumOpenInterest, 64)
+			if err != nil {
+				continue
+			}
+			notional, _ := strconv.ParseFloat(entry.SumOpenInterestValue, 64)
+			points = append(points, exchange.OpenInterestPoint{Time: time.UnixMilli(entry.Timestamp), Contracts: contracts, Notional: notional})
+		}
+	}
+	return points, nil
+}
@@ -0,0 +1,236 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/exchange"
+)
+
+const defaultSpotBaseURL = "https://api.binance.com"
+
+// ErrSpotUnsupported is returned by futures-only endpoints on a spot client.
+var ErrSpotUnsupported = fmt.Errorf("spot mode: %w", exchange.ErrUnsupported)
+
+// spotQuoteAssets lists quote currencies stripped when deriving the base asset.
+var spotQuoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "BTC", "ETH"}
+
+// SpotBalance describes a single asset balance of the spot wallet.
+type SpotBalance struct {
+	Asset  string
+	Free   float64
+	Locked float64
+}
+
+// Total returns free plus locked amount.
+func (b SpotBalance) Total() float64 {
+	return b.Free + b.Locked
+}
+
+// NewSpot returns a client bound to the Binance spot (/api/v3) endpoints.
+func NewSpot(apiKey, apiSecret, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultSpotBaseURL
+	}
+	client := New(apiKey, apiSecret, baseURL)
+	client.spot = true
+	return client
+}
+
+// IsSpot reports whether the client talks to the spot market.
+func (c *Client) IsSpot() bool {
+	return c.spot
+}
+
+// GetSpotBalances returns all non-empty spot wallet balances.
+func (c *Client) GetSpotBalances(ctx context.Context) ([]SpotBalance, error) {
+	if c.apiKey == "" || c.apiSecret == "" {
+		return nil, errors.New("api key/secret required for private endpoints")
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v3/account", c.baseURL)
+	params := url.Values{}
+	params.Set("omitZeroBalances", "true")
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	params.Set("recvWindow", "5000")
+	signature := sign(c.apiSecret, params.Encode())
+	params.Set("signature", signature)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-MBX-APIKEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get spot account: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("spot account status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload struct {
+		Balances []struct {
+			Asset  string `json:"asset"`
+			Free   string `json:"free"`
+			Locked string `json:"locked"`
+		} `json:"balances"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode spot account: %w", err)
+	}
+
+	balances := make([]SpotBalance, 0, len(payload.Balances))
+	for _, item := range payload.Balances {
+		free, _ := strconv.ParseFloat(item.Free, 64)
+		locked, _ := strconv.ParseFloat(item.Locked, 64)
+		if free == 0 && locked == 0 {
+			continue
+		}
+		balances = append(balances, SpotBalance{Asset: item.Asset, Free: free, Locked: locked})
+	}
+	return balances, nil
+}
+
+// getSpotAccountInfo maps the spot wallet onto AccountInfo using the USDT balance.
+func (c *Client) getSpotAccountInfo(ctx context.Context) (AccountInfo, error) {
+	balances, err := c.GetSpotBalances(ctx)
+	if err != nil {
+		return AccountInfo{}, err
+	}
+	info := AccountInfo{LastUpdate: time.Now()}
+	for _, balance := range balances {
+		if balance.Asset != "USDT" {
+			continue
+		}
+		info.TotalWalletBalance = balance.Total()
+		info.AvailableBalance = balance.Free
+	}
+	return info, nil
+}
+
+// getSpotPositions reports held base assets as long positions without leverage.
+// Only the free balance counts: the locked part is held by open orders and
+// cannot be sold until they are cancelled, so a close sized on it would fail
+// with insufficient balance.
+func (c *Client) getSpotPositions(ctx context.Context, symbol string) ([]PositionRisk, error) {
+	balances, err := c.GetSpotBalances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	base := spotBaseAsset(symbol)
+	positions := make([]PositionRisk, 0, 1)
+	for _, balance := range balances {
+		if base == "" || balance.Asset != base {
+			continue
+		}
+		positions = append(positions, PositionRisk{
+			Symbol:       symbol,
+			PositionSide: PositionSideLong,
+			Quantity:     balance.Free,
+			Leverage:     1,
+			UpdateTime:   time.Now(),
+		})
+	}
+	return positions, nil
+}
+
+// placeSpotOrder submits MARKET or LIMIT orders to /api/v3/order.
+func (c *Client) placeSpotOrder(ctx context.Context, reqPayload OrderRequest) (OrderResponse, error) {
+	if reqPayload.Type != OrderTypeMarket && reqPayload.Type != OrderTypeLimit {
+		return OrderResponse{}, fmt.Errorf("order type %s not supported in spot mode", reqPayload.Type)
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v3/order", c.baseURL)
+	params := url.Values{}
+	params.Set("symbol", reqPayload.Symbol)
+	params.Set("side", string(reqPayload.Side))
+	params.Set("type", string(reqPayload.Type))
+	params.Set("quantity", formatQuantity(reqPayload.Quantity))
+	if reqPayload.Type == OrderTypeLimit {
+		params.Set("price", formatPrice(reqPayload.Price))
+		if reqPayload.TimeInForce == "" {
+			params.Set("timeInForce", string(TimeInForceGTC))
+		} else {
+			params.Set("timeInForce", string(reqPayload.TimeInForce))
+		}
+	}
+	params.Set("newOrderRespType", "FULL")
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	params.Set("recvWindow", "5000")
+
+	signature := sign(c.apiSecret, params.Encode())
+	params.Set("signature", signature)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, io.NopCloser(strings.NewReader(params.Encode())))
+	if err != nil {
+		return OrderResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("X-MBX-APIKEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return OrderResponse{}, fmt.Errorf("place spot order: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return OrderResponse{}, fmt.Errorf("spot order status %d: %s", resp.StatusCode, string(data))
+	}
+
+	var payload struct {
+		Symbol              string `json:"symbol"`
+		OrderID             int64  `json:"orderId"`
+		ClientOrderID       string `json:"clientOrderId"`
+		TransactTime        int64  `json:"transactTime"`
+		ExecutedQty         string `json:"executedQty"`
+		CummulativeQuoteQty string `json:"cummulativeQuoteQty"`
+		Status              string `json:"status"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return OrderResponse{}, fmt.Errorf("decode spot order response: %w", err)
+	}
+
+	// Spot responses carry no avgPrice; derive it from the filled quote amount.
+	executed, _ := strconv.ParseFloat(payload.ExecutedQty, 64)
+	quote, _ := strconv.ParseFloat(payload.CummulativeQuoteQty, 64)
+	avgPrice := "0"
+	if executed > 0 {
+		avgPrice = formatPrice(quote / executed)
+	}
+
+	return OrderResponse{
+		Symbol:        payload.Symbol,
+		OrderID:       payload.OrderID,
+		ClientOrderID: payload.ClientOrderID,
+		TransactTime:  payload.TransactTime,
+		AvgPrice:      avgPrice,
+		ExecutedQty:   payload.ExecutedQty,
+		Status:        payload.Status,
+		UpdateTime:    time.UnixMilli(payload.TransactTime),
+	}, nil
+}
+
+func spotBaseAsset(symbol string) string {
+	upper := strings.ToUpper(strings.TrimSpace(symbol))
+	for _, quote := range spotQuoteAssets {
+		if strings.HasSuffix(upper, quote) && len(upper) > len(quote) {
+			return strings.TrimSuffix(upper, quote)
+		}
+	}
+	return ""
+}
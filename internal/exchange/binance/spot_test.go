@@ -0,0 +1,28 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSpotPositionsUseFreeBalance(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/account" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"balances":[{"asset":"BTC","free":"0.5","locked":"0.2"},{"asset":"USDT","free":"100","locked":"0"}]}`))
+	}))
+	defer srv.Close()
+
+	c := NewSpot("key", "secret", srv.URL)
+	positions, err := c.getSpotPositions(context.Background(), "BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 1 || positions[0].Quantity != 0.5 {
+		t.Fatalf("positions = %+v, want one BTC position of the free 0.5", positions)
+	}
+}
@@ -0,0 +1,203 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/storage"
+	"autobot/internal/ws"
+)
+
+const defaultSpotStreamBaseURL = "wss://stream.binance.com:9443/ws"
+
+// listenKeyKeepAlive is how often the listen key is extended; Binance
+// expires it after 60 minutes without a keepalive.
+const listenKeyKeepAlive = 30 * time.Minute
+
+// UserDataStream subscribes to the account's user-data stream and writes
+// every event (order updates, fills, balance and position changes) to the
+// order journal as raw JSON, so fills can be reconciled against what the
+// exchange actually pushed.
+type UserDataStream struct {
+	client     *Client
+	streamBase string
+	rec        exchange.OrderJournalRecorder
+	logger     *loggerpkg.ModuleLogger
+}
+
+// NewUserDataStream creates a stream for client's account. baseURL defaults
+// to the futures or spot stream endpoint matching the client.
+func NewUserDataStream(client *Client, baseURL string, rec exchange.OrderJournalRecorder) *UserDataStream {
+	if baseURL == "" {
+		baseURL = defaultStreamBaseURL
+		if client.spot {
+			baseURL = defaultSpotStreamBaseURL
+		}
+	}
+	return &UserDataStream{
+		client:     client,
+		streamBase: strings.TrimRight(baseURL, "/"),
+		rec:        rec,
+		logger:     loggerpkg.Get("exchange.journal"),
+	}
+}
+
+// Run consumes the stream until ctx is cancelled, reconnecting with backoff
+// and a fresh listen key after every disconnect.
+func (s *UserDataStream) Run(ctx context.Context) error {
+	backoff := time.Second
+	for {
+		err := s.consume(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.logger.Printf("userdata.disconnected err=%v retry_in=%s", err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+func (s *UserDataStream) consume(ctx context.Context) error {
+	key, err := s.client.listenKey(ctx, http.MethodPost, "")
+	if err != nil {
+		return err
+	}
+	conn, err := ws.Dial(ctx, s.streamBase+"/"+key, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	s.logger.Printf("userdata.connected exchange=%s", s.client.Name())
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(listenKeyKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				if _, err := s.client.listenKey(ctx, http.MethodPut, key); err != nil {
+					s.logger.Printf("userdata.keepalive.error err=%v", err)
+				}
+			}
+		}
+	}()
+
+	for {
+		// Binance pings every few minutes; a silent socket for longer is dead.
+		_ = conn.SetReadDeadline(time.Now().Add(10 * time.Minute))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		entry, eventType := userDataEntry(data)
+		entry.Exchange = s.client.Name()
+		if err := s.rec.RecordOrderJournal(ctx, entry); err != nil {
+			s.logger.Printf("userdata.record.error event=%s err=%v", eventType, err)
+		}
+		if eventType == "listenKeyExpired" {
+			return errors.New("listen key expired")
+		}
+	}
+}
+
+// userDataEntry wraps a raw stream message in a journal entry, lifting the
+// symbol and order ids out of futures ORDER_TRADE_UPDATE and spot
+// executionReport events.
+func userDataEntry(data []byte) (storage.OrderJournalEntry, string) {
+	entry := storage.OrderJournalEntry{
+		Kind:      storage.OrderJournalEvent,
+		Event:     append(json.RawMessage(nil), data...),
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	var payload struct {
+		Event         string          `json:"e"`
+		Symbol        string          `json:"s"`
+		ClientOrderID string          `json:"c"`
+		OrderID       json.Number     `json:"i"`
+		Order         json.RawMessage `json:"o"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		// Keep unparsable messages verbatim as a string so the line stays valid JSON.
+		raw, _ := json.Marshal(string(data))
+		entry.Event = raw
+		entry.Error = err.Error()
+		return entry, ""
+	}
+	if payload.Event == "ORDER_TRADE_UPDATE" && len(payload.Order) > 0 {
+		var order struct {
+			Symbol        string      `json:"s"`
+			ClientOrderID string      `json:"c"`
+			OrderID       json.Number `json:"i"`
+		}
+		if json.Unmarshal(payload.Order, &order) == nil {
+			payload.Symbol, payload.ClientOrderID, payload.OrderID = order.Symbol, order.ClientOrderID, order.OrderID
+		}
+	}
+	entry.Symbol = payload.Symbol
+	entry.ClientOrderID = payload.ClientOrderID
+	entry.OrderID = payload.OrderID.String()
+	return entry, payload.Event
+}
+
+// listenKey creates (POST) or extends (PUT) the user-data listen key. Only
+// the API key header is required; the request is not signed.
+func (c *Client) listenKey(ctx context.Context, method, key string) (string, error) {
+	if c.apiKey == "" {
+		return "", errors.New("api key required for user data stream")
+	}
+	endpoint := c.baseURL + "/fapi/v1/listenKey"
+	if c.spot {
+		endpoint = c.baseURL + "/api/v3/userDataStream"
+		if key != "" {
+			endpoint += "?" + url.Values{"listenKey": {key}}.Encode()
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-MBX-APIKEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("listen key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("listen key status %d: %s", resp.StatusCode, string(data))
+	}
+	var payload struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return "", fmt.Errorf("decode listen key: %w", err)
+	}
+	if payload.ListenKey == "" {
+		payload.ListenKey = key
+	}
+	return payload.ListenKey, nil
+}
@@ -0,0 +1,207 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// ErrCloseAuditMismatch signals that the exchange position differs from internal state.
+var ErrCloseAuditMismatch = errors.New("close audit mismatch")
+
+// PositionReader is the part of Exchange the close audit needs.
+type PositionReader interface {
+	GetPositions(ctx context.Context, symbol string) ([]PositionRisk, error)
+}
+
+// ExpectedPosition describes the position the trader believes it holds.
+type ExpectedPosition struct {
+	Symbol   string
+	Side     PositionSide
+	Quantity float64
+}
+
+// CloseAudit summarises the verification performed before a close order.
+// Position is the live position the audit matched, nil when none was found.
+type CloseAudit struct {
+	Symbol           string
+	ExpectedSide     PositionSide
+	ActualSide       PositionSide
+	ExpectedQuantity float64
+	ActualQuantity   float64
+	EntryPrice       float64
+	MarkPrice        float64
+	ExpectedPNL      float64
+	QuantityDrift    float64
+	Matched          bool
+	Reason           string
+	Position         *PositionRisk
+}
+
+// AuditClose compares the live position with the expected one and estimates the
+// realized PnL of closing it at the current mark price. tolerancePercent bounds
+// the allowed relative quantity drift; 0 demands an exact match. A mismatch is
+// logged to the risk module and returned as ErrCloseAuditMismatch so callers
+// halt instead of sending a blind reduce-only order.
+func AuditClose(ctx context.Context, src PositionReader, expected ExpectedPosition, tolerancePercent float64) (CloseAudit, error) {
+	audit := CloseAudit{
+		Symbol:           expected.Symbol,
+		ExpectedSide:     NormalizePositionSide(expected.Side),
+		ExpectedQuantity: math.Abs(expected.Quantity),
+	}
+
+	positions, err := src.GetPositions(ctx, expected.Symbol)
+	if err != nil {
+		return audit, fmt.Errorf("audit close: %w", err)
+	}
+
+	var actual *PositionRisk
+	for i := range positions {
+		if positions[i].Symbol != expected.Symbol || positions[i].Quantity == 0 {
+			continue
+		}
+		// Hedge mode may report both legs; prefer the one on the expected side.
+		if actual == nil || PositionDirection(positions[i]) == audit.ExpectedSide {
+			actual = &positions[i]
+		}
+	}
+
+	if actual == nil {
+		audit.Reason = "exchange reports no open position"
+		return audit, reportAuditMismatch(audit)
+	}
+
+	audit.Position = actual
+	audit.ActualSide = PositionDirection(*actual)
+	audit.ActualQuantity = math.Abs(actual.Quantity)
+	audit.EntryPrice = actual.EntryPrice
+	audit.MarkPrice = actual.MarkPrice
+	audit.ExpectedPNL = (actual.MarkPrice - actual.EntryPrice) * actual.Quantity
+	if audit.ExpectedQuantity > 0 {
+		audit.QuantityDrift = math.Abs(audit.ActualQuantity-audit.ExpectedQuantity) / audit.ExpectedQuantity * 100
+	}
+
+	switch {
+	case audit.ExpectedSide != "" && audit.ActualSide != audit.ExpectedSide:
+		audit.Reason = fmt.Sprintf("side mismatch expected=%s actual=%s", audit.ExpectedSide, audit.ActualSide)
+	case audit.ExpectedQuantity <= 0:
+		audit.Reason = "expected quantity is zero"
+	case audit.QuantityDrift > tolerancePercent+1e-9:
+		audit.Reason = fmt.Sprintf("quantity drift %.2f%% exceeds tolerance %.2f%%", audit.QuantityDrift, tolerancePercent)
+	}
+	if audit.Reason != "" {
+		return audit, reportAuditMismatch(audit)
+	}
+
+	audit.Matched = true
+	return audit, nil
+}
+
+func reportAuditMismatch(audit CloseAudit) error {
+	loggerpkg.Get("risk").Printf("close.audit.mismatch symbol=%s expected_side=%s actual_side=%s expected_qty=%.6f actual_qty=%.6f reason=%q",
+		audit.Symbol, audit.ExpectedSide, audit.ActualSide, audit.ExpectedQuantity, audit.ActualQuantity, audit.Reason)
+	return fmt.Errorf("%w: %s %s", ErrCloseAuditMismatch, audit.Symbol, audit.Reason)
+}
+
+// PositionDirection resolves LONG/SHORT for both hedge and one-way mode positions.
+func PositionDirection(pos PositionRisk) PositionSide {
+	switch pos.PositionSide {
+	case PositionSideLong, PositionSideShort:
+		return pos.PositionSide
+	}
+	if pos.Quantity < 0 {
+		return PositionSideShort
+	}
+	return PositionSideLong
+}
+
+// NormalizePositionSide maps long/buy and short/sell in any case to LONG and
+// SHORT, and anything else to "".
+func NormalizePositionSide(side PositionSide) PositionSide {
+	switch strings.ToUpper(string(side)) {
+	case "LONG", "BUY":
+		return PositionSideLong
+	case "SHORT", "SELL":
+		return PositionSideShort
+	default:
+		return ""
+	}
+}
+
+// PositionCloser is implemented by adapters with their own audited close, such
+// as the Binance client which also handles spot balances.
+type PositionCloser interface {
+	ClosePosition(ctx context.Context, expected ExpectedPosition, tolerancePercent float64) (OrderResponse, error)
+}
+
+// ClosePosition is the single entry point for closing a position. It defers to
+// the adapter when ex implements PositionCloser; otherwise it runs AuditClose,
+// aborts on ErrCloseAuditMismatch, and sends market orders for the audited
+// quantity floored to the lot step and split at MaxQty (see PlaceCloseChunks),
+// reduce-only in one-way mode or on the matching leg in hedge mode.
+func ClosePosition(ctx context.Context, ex Exchange, expected ExpectedPosition, tolerancePercent float64) (OrderResponse, error) {
+	if closer, ok := ex.(PositionCloser); ok {
+		return closer.ClosePosition(ctx, expected, tolerancePercent)
+	}
+
+	symbol := expected.Symbol
+	side := NormalizePositionSide(expected.Side)
+	if side == "" {
+		return OrderResponse{}, fmt.Errorf("close position %s: side must be LONG or SHORT", symbol)
+	}
+	audit, err := AuditClose(ctx, ex, expected, tolerancePercent)
+	if err != nil {
+		return OrderResponse{}, fmt.Errorf("close position %s: %w", symbol, err)
+	}
+
+	chunks := []float64{audit.ActualQuantity}
+	if source, ok := ex.(LotRulesSource); ok {
+		if rules, err := source.GetLotRules(ctx, symbol); err == nil {
+			chunks = rules.CloseChunks(audit.ActualQuantity)
+		}
+	}
+	if len(chunks) == 0 {
+		return OrderResponse{}, fmt.Errorf("close position %s: remaining quantity %g below lot step", symbol, audit.ActualQuantity)
+	}
+
+	req := OrderRequest{
+		Symbol: symbol,
+		Side:   OrderSideSell,
+		Type:   OrderTypeMarket,
+	}
+	if side == PositionSideShort {
+		req.Side = OrderSideBuy
+	}
+	if leg := audit.Position.PositionSide; leg == PositionSideLong || leg == PositionSideShort {
+		// Hedge mode rejects reduceOnly; the position side already restricts the order.
+		req.PositionSide = leg
+	} else {
+		req.ReduceOnly = true
+	}
+	return PlaceCloseChunks(ctx, ex.PlaceOrder, req, chunks)
+}
+
+// PlaceCloseChunks sends req once per chunk quantity and returns the response
+// of the last order. If an order fails, the error reports how much of the
+// total was already closed, so the caller never mistakes a partial close for
+// a flat position.
+func PlaceCloseChunks(ctx context.Context, place func(context.Context, OrderRequest) (OrderResponse, error), req OrderRequest, chunks []float64) (OrderResponse, error) {
+	var resp OrderResponse
+	total, closed := 0.0, 0.0
+	for _, chunk := range chunks {
+		total += chunk
+	}
+	for _, chunk := range chunks {
+		req.Quantity = chunk
+		var err error
+		if resp, err = place(ctx, req); err != nil {
+			return resp, fmt.Errorf("close position %s: closed %g of %g: %w", req.Symbol, closed, total, err)
+		}
+		closed += chunk
+	}
+	return resp, nil
+}
@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCloseChunks(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules LotRules
+		qty   float64
+		want  []float64
+	}{
+		{"below max", LotRules{StepSize: 0.001, MaxQty: 10}, 2.5, []float64{2.5}},
+		{"split at max", LotRules{StepSize: 0.001, MaxQty: 10}, 25.0005, []float64{10, 10, 5}},
+		{"float remainder", LotRules{StepSize: 0.1, MaxQty: 0.1}, 0.3, []float64{0.1, 0.1, 0.1}},
+		{"no step", LotRules{MaxQty: 4}, 9, []float64{4, 4, 1}},
+		{"no rules", LotRules{}, 1.23, []float64{1.23}},
+		{"dust", LotRules{StepSize: 1}, 0.5, nil},
+	}
+	for _, tc := range cases {
+		got := tc.rules.CloseChunks(tc.qty)
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: chunks = %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: chunks = %v, want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPlaceCloseChunks(t *testing.T) {
+	var sent []float64
+	place := func(ctx context.Context, req OrderRequest) (OrderResponse, error) {
+		if len(sent) == 2 {
+			return OrderResponse{}, errors.New("rejected")
+		}
+		sent = append(sent, req.Quantity)
+		return OrderResponse{Symbol: req.Symbol}, nil
+	}
+	req := OrderRequest{Symbol: "BTCUSDT", Side: OrderSideSell, Type: OrderTypeMarket, ReduceOnly: true}
+
+	if _, err := PlaceCloseChunks(context.Background(), place, req, []float64{10, 5}); err != nil || len(sent) != 2 {
+		t.Fatalf("sent = %v, err = %v", sent, err)
+	}
+	sent = nil
+	_, err := PlaceCloseChunks(context.Background(), place, req, []float64{10, 10, 5})
+	if err == nil || !strings.Contains(err.Error(), "closed 20 of 25") {
+		t.Fatalf("partial close err = %v", err)
+	}
+}
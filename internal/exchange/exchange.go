@@ -0,0 +1,173 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"autobot/internal/strategy"
+)
+
+// Exchange is the venue-neutral surface traders depend on. Quantities are
+// always expressed in base-asset units; adapters convert to contract counts.
+type Exchange interface {
+	Name() string
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+	GetPositions(ctx context.Context, symbol string) ([]PositionRisk, error)
+	GetAccountInfo(ctx context.Context) (AccountInfo, error)
+	PlaceOrder(ctx context.Context, req OrderRequest) (OrderResponse, error)
+	GetFundingRate(ctx context.Context, symbol string) (float64, error)
+	GetOpenInterest(ctx context.Context, symbol string) (float64, error)
+	Get24hTicker(ctx context.Context, symbol string) (Ticker24h, error)
+}
+
+// ErrUnsupported is wrapped by adapters for endpoints the venue or mode lacks.
+var ErrUnsupported = errors.New("endpoint not supported")
+
+// OrderSide represents BUY or SELL.
+type OrderSide string
+
+// PositionSide indicates hedge mode orientation.
+type PositionSide string
+
+// OrderType identifies execution type.
+type OrderType string
+
+// TimeInForce instructs how a limit order lives in the book.
+type TimeInForce string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+
+	OrderTypeMarket           OrderType = "MARKET"
+	OrderTypeLimit            OrderType = "LIMIT"
+	OrderTypeStopMarket       OrderType = "STOP_MARKET"
+	OrderTypeTakeProfitMarket OrderType = "TAKE_PROFIT_MARKET"
+
+	TimeInForceGTC TimeInForce = "GTC"
+	TimeInForceIOC TimeInForce = "IOC"
+	TimeInForceFOK TimeInForce = "FOK"
+)
+
+// OrderRequest contains the minimum parameters for a futures order.
+type OrderRequest struct {
+	Symbol       string
+	Side         OrderSide
+	PositionSide PositionSide
+	Type         OrderType
+	Quantity     float64
+	ReduceOnly   bool
+	Price        float64
+	TimeInForce  TimeInForce
+	StopPrice    float64
+	WorkingType  string
+}
+
+// OrderResponse maps the subset of response fields we care about.
+type OrderResponse struct {
+	Symbol        string    `json:"symbol"`
+	OrderID       int64     `json:"orderId"`
+	ClientOrderID string    `json:"clientOrderId"`
+	TransactTime  int64     `json:"transactTime"`
+	AvgPrice      string    `json:"avgPrice"`
+	ExecutedQty   string    `json:"executedQty"`
+	Status        string    `json:"status"`
+	UpdateTime    time.Time `json:"-"`
+}
+
+// AccountInfo carries wallet data relevant to risk controls.
+type AccountInfo struct {
+	TotalWalletBalance float64
+	AvailableBalance   float64
+	CrossUnrealizedPNL float64
+	LastUpdate         time.Time
+}
+
+// PositionRisk captures futures position status.
+type PositionRisk struct {
+	Symbol        string
+	PositionSide  PositionSide
+	Quantity      float64
+	EntryPrice    float64
+	MarkPrice     float64
+	Leverage      float64
+	UnrealizedPNL float64
+	UpdateTime    time.Time
+}
+
+// Ticker24h carries rolling 24 hour statistics for a symbol.
+type Ticker24h struct {
+	Symbol             string
+	LastPrice          float64
+	PriceChangePercent float64
+	HighPrice          float64
+	LowPrice           float64
+	Volume             float64
+	QuoteVolume        float64
+	TradeCount         int64
+	CloseTime          time.Time
+}
+
+// AggTrade is a compressed public trade. BuyerMaker true means the taker sold.
+type AggTrade struct {
+	ID         int64
+	Price      float64
+	Quantity   float64
+	Time       time.Time
+	BuyerMaker bool
+}
+
+// TradeSource is implemented by adapters that expose recent public trades.
+type TradeSource interface {
+	GetAggTrades(ctx context.Context, symbol string, limit int) ([]AggTrade, error)
+}
+
+// DepthLevel is one aggregated price level of the order book.
+type DepthLevel struct {
+	Price    float64
+	Quantity float64
+}
+
+// OrderBook is a depth snapshot; bids are sorted best (highest) first and
+// asks best (lowest) first.
+type OrderBook struct {
+	Bids []DepthLevel
+	Asks []DepthLevel
+	Time time.Time
+}
+
+// DepthSource is implemented by adapters that expose order book snapshots.
+type DepthSource interface {
+	GetDepth(ctx context.Context, symbol string, limit int) (OrderBook, error)
+}
+
+// FundingEvent is one settled funding rate.
+type FundingEvent struct {
+	Time time.Time
+	Rate float64
+}
+
+// FundingHistorySource is implemented by adapters that expose settled
+// funding rates, returned in ascending time order.
+type FundingHistorySource interface {
+	GetFundingHistory(ctx context.Context, symbol string, start, end time.Time) ([]FundingEvent, error)
+}
+
+// OpenInterestPoint is one open interest sample: Contracts in base units and
+// Notional in the quote asset.
+type OpenInterestPoint struct {
+	Time      time.Time
+	Contracts float64
+	Notional  float64
+}
+
+// OpenInterestHistorySource is implemented by adapters that expose open
+// interest sampled every period (e.g. "1h"), returned in ascending time order.
+type OpenInterestHistorySource interface {
+	GetOpenInterestHistory(ctx context.Context, symbol, period string, start, end time.Time) ([]OpenInterestPoint, error)
+}
@@ -0,0 +1,121 @@
+package factory
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange"
+	"autobot/internal/exchange/binance"
+	"autobot/internal/exchange/gateio"
+	"autobot/internal/exchange/hyperliquid"
+	"autobot/internal/exchange/sim"
+	loggerpkg "autobot/internal/logger"
+)
+
+// New 按交易者配置的 exchange 名称与 account 创建交易所客户端，环境变量中的密钥优先于配置文件。
+// 命名账户的环境变量带账户名，例如 BINANCE_SUB1_API_KEY、HYPERLIQUID_SUB1_PRIVATE_KEY。
+func New(name, account string, creds config.ExchangeConfig, settings config.TradeSettings) (exchange.Exchange, error) {
+	creds, err := creds.ForAccount(name, account)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", config.ExchangeBinance:
+		key, secret := credentials(envPrefix("BINANCE", account), creds.Binance.APIKey, creds.Binance.APISecret)
+		if settings.IsSpot() {
+			return binance.NewSpot(key, secret, ""), nil
+		}
+		return binance.New(key, secret, ""), nil
+	case config.ExchangeGateio:
+		if settings.IsSpot() {
+			return nil, fmt.Errorf("gateio 仅支持 USDT 永续合约")
+		}
+		key, secret := credentials(envPrefix("GATEIO", account), creds.Gateio.APIKey, creds.Gateio.APISecret)
+		return gateio.New(key, secret, creds.Gateio.BaseURL), nil
+	case config.ExchangeHyperliquid:
+		if settings.IsSpot() {
+			return nil, fmt.Errorf("hyperliquid 仅支持永续合约")
+		}
+		key := creds.Hyperliquid.PrivateKey
+		if v := os.Getenv(envPrefix("HYPERLIQUID", account) + "_PRIVATE_KEY"); v != "" {
+			key = v
+		}
+		return hyperliquid.New(key, creds.Hyperliquid.AccountAddress, creds.Hyperliquid.Testnet)
+	default:
+		return nil, fmt.Errorf("未知交易所 %q", name)
+	}
+}
+
+// ForTrader 按交易者配置创建交易所客户端；交易者处于 dryRun 模式时以 Simulate 包装，
+// 下单交给模拟撮合。交易程序为每个交易者构建下单用的交易所时应使用它，而非直接调用 New。
+func ForTrader(cfg config.ParsedConfig, profile config.TraderProfileResolved) (exchange.Exchange, error) {
+	ex, err := New(profile.Exchange, profile.Account, cfg.Exchanges, profile.Settings)
+	if err != nil {
+		return nil, err
+	}
+	if profile.DryRun {
+		ex = Simulate(ex, cfg.Simulator, profile.Fees, profile.Settings)
+	}
+	return ex, nil
+}
+
+func credentials(prefix, key, secret string) (string, string) {
+	if v := os.Getenv(prefix + "_API_KEY"); v != "" {
+		key = v
+	}
+	if v := os.Getenv(prefix + "_API_SECRET"); v != "" {
+		secret = v
+	}
+	return key, secret
+}
+
+// envPrefix 将账户名拼入环境变量前缀，非字母数字字符替换为下划线。
+func envPrefix(exchange, account string) string {
+	if account == "" {
+		return exchange
+	}
+	return exchange + "_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, account)
+}
+
+// WithAudit 在 storage.exchangeAudit 开启时为交易所客户端启用签名请求审计。
+func WithAudit(ex exchange.Exchange, cfg config.StorageConfig, rec exchange.AuditRecorder) exchange.Exchange {
+	if cfg.ExchangeAudit && rec != nil && !exchange.EnableAudit(ex, rec) {
+		loggerpkg.Get("exchange.audit").Printf("audit.unsupported exchange=%s", ex.Name())
+	}
+	return ex
+}
+
+// WithOrderJournal 在 storage.orderJournal 开启时为交易所客户端启用订单日志，记录下单、撤单请求及原始响应。
+func WithOrderJournal(ex exchange.Exchange, cfg config.StorageConfig, rec exchange.OrderJournalRecorder) exchange.Exchange {
+	if cfg.OrderJournal && rec != nil && !exchange.EnableOrderJournal(ex, rec) {
+		loggerpkg.Get("exchange.journal").Printf("journal.unsupported exchange=%s", ex.Name())
+	}
+	return ex
+}
+
+// Simulate 在 dryRun 模式下用模拟撮合包装交易所客户端：行情仍取自真实接口，
+// 下单按最新价格加滑点与手续费成交，持仓与盈亏在内存中维护。模拟成交均为吃单，
+// simulator.feePercent 未配置时使用交易所费率表的吃单费率。
+func Simulate(ex exchange.Exchange, cfg config.SimulatorConfig, fees config.FeeSchedule, settings config.TradeSettings) exchange.Exchange {
+	fee := cfg.FeePercent
+	if fee == 0 {
+		fee = fees.Taker()
+	}
+	return sim.New(ex, sim.Options{
+		InitialBalance:  cfg.InitialBalance,
+		SlippagePercent: cfg.SlippagePercent,
+		FeePercent:      fee,
+		Leverage:        float64(settings.Leverage),
+	})
+}
@@ -0,0 +1,28 @@
+package factory
+
+import (
+	"testing"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange/sim"
+)
+
+func TestForTraderSimulatesDryRun(t *testing.T) {
+	profile := config.TraderProfileResolved{DryRun: true}
+	profile.Exchange = config.ExchangeBinance
+	ex, err := ForTrader(config.ParsedConfig{}, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ex.(*sim.Exchange); !ok {
+		t.Fatalf("dry-run exchange is %T, want *sim.Exchange", ex)
+	}
+
+	profile.DryRun = false
+	if ex, err = ForTrader(config.ParsedConfig{}, profile); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ex.(*sim.Exchange); ok {
+		t.Fatal("live trader got the simulator")
+	}
+}
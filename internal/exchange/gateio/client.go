@@ -0,0 +1,502 @@
+package gateio
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/exchange"
+	"autobot/internal/strategy"
+)
+
+const (
+	defaultBaseURL = "https://api.gateio.ws"
+	apiPrefix      = "/api/v4"
+	settle         = "usdt"
+)
+
+// Client implements exchange.Exchange for Gate.io USDT-margined perpetuals.
+// Symbols are accepted and reported in Binance style (BTCUSDT) and mapped to
+// Gate contract names (BTC_USDT); quantities are converted between base-asset
+// units and integer contract counts using each contract's quanto multiplier.
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	baseURL    string
+	httpClient *http.Client
+
+	contracts *contractCache
+}
+
+var (
+	_ exchange.Exchange             = (*Client)(nil)
+	_ exchange.MarkPriceKlineSource = (*Client)(nil)
+)
+
+// New returns a ready-to-use client.
+func New(apiKey, apiSecret, baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second, Transport: exchange.NewMetricsTransport("gateio", nil)},
+		contracts:  newContractCache(),
+	}
+}
+
+// Name identifies the venue.
+func (c *Client) Name() string {
+	return "gateio"
+}
+
+// GetKlines retrieves recent OHLCV data; volume is converted to base units.
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	return c.klines(ctx, "", symbol, interval, limit)
+}
+
+// GetMarkPriceKlines retrieves klines built from the mark price, which Gate.io
+// serves as the contract name prefixed with "mark_". Volume is zero.
+func (c *Client) GetMarkPriceKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	return c.klines(ctx, "mark_", symbol, interval, limit)
+}
+
+func (c *Client) klines(ctx context.Context, prefix, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	gateInterval, err := mapInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := c.GetContract(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("contract", prefix+contract.Name)
+	params.Set("interval", gateInterval)
+	params.Set("limit", strconv.Itoa(limit))
+
+	var payload []struct {
+		Time   int64   `json:"t"`
+		Volume float64 `json:"v"`
+		Close  string  `json:"c"`
+		High   string  `json:"h"`
+		Low    string  `json:"l"`
+		Open   string  `json:"o"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/candlesticks", params, nil, false, &payload); err != nil {
+		return nil, fmt.Errorf("get klines: %w", err)
+	}
+	if len(payload) == 0 {
+		return nil, errors.New("no klines returned")
+	}
+
+	candles := make([]strategy.Candle, 0, len(payload))
+	for _, item := range payload {
+		open, _ := strconv.ParseFloat(item.Open, 64)
+		high, _ := strconv.ParseFloat(item.High, 64)
+		low, _ := strconv.ParseFloat(item.Low, 64)
+		closePrice, _ := strconv.ParseFloat(item.Close, 64)
+		candles = append(candles, strategy.Candle{
+			OpenTime: time.Unix(item.Time, 0),
+			Open:     open,
+			High:     high,
+			Low:      low,
+			Close:    closePrice,
+			Volume:   contract.ToBase(item.Volume),
+		})
+	}
+	return candles, nil
+}
+
+// GetPositions retrieves open positions, optionally filtered by symbol.
+func (c *Client) GetPositions(ctx context.Context, symbol string) ([]exchange.PositionRisk, error) {
+	var payload []struct {
+		Contract      string  `json:"contract"`
+		Size          float64 `json:"size"`
+		Leverage      string  `json:"leverage"`
+		EntryPrice    string  `json:"entry_price"`
+		MarkPrice     string  `json:"mark_price"`
+		UnrealisedPNL string  `json:"unrealised_pnl"`
+		Mode          string  `json:"mode"`
+		UpdateTime    int64   `json:"update_time"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/positions", nil, nil, true, &payload); err != nil {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+
+	want := ""
+	if symbol != "" {
+		want = contractName(symbol)
+	}
+	positions := make([]exchange.PositionRisk, 0, len(payload))
+	for _, item := range payload {
+		if item.Size == 0 || (want != "" && item.Contract != want) {
+			continue
+		}
+		contract, err := c.GetContract(ctx, item.Contract)
+		if err != nil {
+			return nil, err
+		}
+		entry, _ := strconv.ParseFloat(item.EntryPrice, 64)
+		mark, _ := strconv.ParseFloat(item.MarkPrice, 64)
+		pnl, _ := strconv.ParseFloat(item.UnrealisedPNL, 64)
+		lev, _ := strconv.ParseFloat(item.Leverage, 64)
+
+		side := exchange.PositionSideBoth
+		switch item.Mode {
+		case "dual_long":
+			side = exchange.PositionSideLong
+		case "dual_short":
+			side = exchange.PositionSideShort
+		}
+		positions = append(positions, exchange.PositionRisk{
+			Symbol:        symbolName(item.Contract),
+			PositionSide:  side,
+			Quantity:      contract.ToBase(item.Size),
+			EntryPrice:    entry,
+			MarkPrice:     mark,
+			Leverage:      lev,
+			UnrealizedPNL: pnl,
+			UpdateTime:    time.Unix(item.UpdateTime, 0),
+		})
+	}
+	return positions, nil
+}
+
+// GetAccountInfo pulls the USDT futures account for risk sizing.
+func (c *Client) GetAccountInfo(ctx context.Context) (exchange.AccountInfo, error) {
+	var payload struct {
+		Total         string `json:"total"`
+		Available     string `json:"available"`
+		UnrealisedPNL string `json:"unrealised_pnl"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/accounts", nil, nil, true, &payload); err != nil {
+		return exchange.AccountInfo{}, fmt.Errorf("get account info: %w", err)
+	}
+	total, _ := strconv.ParseFloat(payload.Total, 64)
+	avail, _ := strconv.ParseFloat(payload.Available, 64)
+	pnl, _ := strconv.ParseFloat(payload.UnrealisedPNL, 64)
+	return exchange.AccountInfo{
+		TotalWalletBalance: total,
+		AvailableBalance:   avail,
+		CrossUnrealizedPNL: pnl,
+		LastUpdate:         time.Now(),
+	}, nil
+}
+
+// PlaceOrder submits an order. Market and limit orders go to the order book;
+// STOP_MARKET and TAKE_PROFIT_MARKET become price-triggered orders.
+func (c *Client) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (exchange.OrderResponse, error) {
+	contract, err := c.GetContract(ctx, req.Symbol)
+	if err != nil {
+		return exchange.OrderResponse{}, err
+	}
+	size := contract.ToContracts(req.Quantity)
+	if size < 1 {
+		return exchange.OrderResponse{}, fmt.Errorf("quantity %s below one contract (%s per contract)", formatFloat(req.Quantity), formatFloat(contract.Multiplier))
+	}
+	if contract.OrderSizeMin > 0 && size < contract.OrderSizeMin {
+		return exchange.OrderResponse{}, fmt.Errorf("order size %d below minimum %d contracts", size, contract.OrderSizeMin)
+	}
+	if req.Side == exchange.OrderSideSell {
+		size = -size
+	}
+	// Dual mode has no position side field: closing a leg is a reduce-only order in the opposite direction.
+	reduceOnly := req.ReduceOnly ||
+		(req.PositionSide == exchange.PositionSideLong && req.Side == exchange.OrderSideSell) ||
+		(req.PositionSide == exchange.PositionSideShort && req.Side == exchange.OrderSideBuy)
+
+	switch req.Type {
+	case exchange.OrderTypeStopMarket, exchange.OrderTypeTakeProfitMarket:
+		return c.placeTriggerOrder(ctx, contract, req, size)
+	}
+
+	body := orderBody{
+		Contract:   contract.Name,
+		Size:       size,
+		Price:      "0",
+		TIF:        "ioc",
+		ReduceOnly: reduceOnly,
+		Text:       "t-autobot",
+	}
+	if req.Type == exchange.OrderTypeLimit {
+		body.Price = formatFloat(contract.RoundPrice(req.Price))
+		body.TIF = mapTimeInForce(req.TimeInForce)
+	}
+
+	var payload struct {
+		ID         int64   `json:"id"`
+		Contract   string  `json:"contract"`
+		Size       float64 `json:"size"`
+		Left       float64 `json:"left"`
+		FillPrice  string  `json:"fill_price"`
+		Status     string  `json:"status"`
+		FinishAs   string  `json:"finish_as"`
+		CreateTime float64 `json:"create_time"`
+		Text       string  `json:"text"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/futures/"+settle+"/orders", nil, body, true, &payload); err != nil {
+		return exchange.OrderResponse{}, fmt.Errorf("place order: %w", err)
+	}
+
+	filled := math.Abs(payload.Size) - math.Abs(payload.Left)
+	created := time.UnixMilli(int64(payload.CreateTime * 1000))
+	return exchange.OrderResponse{
+		Symbol:        symbolName(payload.Contract),
+		OrderID:       payload.ID,
+		ClientOrderID: payload.Text,
+		TransactTime:  created.UnixMilli(),
+		AvgPrice:      payload.FillPrice,
+		ExecutedQty:   formatFloat(contract.ToBase(filled)),
+		Status:        orderStatus(payload.Status, payload.FinishAs),
+		UpdateTime:    created,
+	}, nil
+}
+
+type orderBody struct {
+	Contract   string `json:"contract"`
+	Size       int64  `json:"size"`
+	Price      string `json:"price"`
+	TIF        string `json:"tif"`
+	ReduceOnly bool   `json:"reduce_only"`
+	Text       string `json:"text,omitempty"`
+}
+
+func (c *Client) placeTriggerOrder(ctx context.Context, contract Contract, req exchange.OrderRequest, size int64) (exchange.OrderResponse, error) {
+	if req.StopPrice <= 0 {
+		return exchange.OrderResponse{}, fmt.Errorf("%s requires stop price", req.Type)
+	}
+	// rule 1: trigger when price >= stop, rule 2: price <= stop.
+	// A sell stop-loss fires on the way down, a sell take-profit on the way up.
+	rule := 2
+	if (req.Type == exchange.OrderTypeStopMarket) == (req.Side == exchange.OrderSideBuy) {
+		rule = 1
+	}
+	priceType := 1 // mark price
+	if strings.EqualFold(req.WorkingType, "CONTRACT_PRICE") {
+		priceType = 0
+	}
+	body := map[string]any{
+		"initial": orderBody{
+			Contract:   contract.Name,
+			Size:       size,
+			Price:      "0",
+			TIF:        "ioc",
+			ReduceOnly: true,
+		},
+		"trigger": map[string]any{
+			"strategy_type": 0,
+			"price_type":    priceType,
+			"price":         formatFloat(contract.RoundPrice(req.StopPrice)),
+			"rule":          rule,
+		},
+	}
+	var payload struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/futures/"+settle+"/price_orders", nil, body, true, &payload); err != nil {
+		return exchange.OrderResponse{}, fmt.Errorf("place trigger order: %w", err)
+	}
+	now := time.Now()
+	return exchange.OrderResponse{
+		Symbol:       symbolName(contract.Name),
+		OrderID:      payload.ID,
+		TransactTime: now.UnixMilli(),
+		Status:       "NEW",
+		UpdateTime:   now,
+	}, nil
+}
+
+// GetFundingRate returns the current funding rate from the contract details.
+func (c *Client) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	contract, err := c.fetchContract(ctx, contractName(symbol))
+	if err != nil {
+		return 0, fmt.Errorf("get funding rate: %w", err)
+	}
+	return contract.FundingRate, nil
+}
+
+// GetOpenInterest returns open interest in base-asset units.
+func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (float64, error) {
+	contract, err := c.GetContract(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	params := url.Values{}
+	params.Set("contract", contract.Name)
+	params.Set("limit", "1")
+	var payload []struct {
+		OpenInterest float64 `json:"open_interest"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/contract_stats", params, nil, false, &payload); err != nil {
+		return 0, fmt.Errorf("get open interest: %w", err)
+	}
+	if len(payload) == 0 {
+		return 0, errors.New("no open interest returned")
+	}
+	return contract.ToBase(payload[len(payload)-1].OpenInterest), nil
+}
+
+// Get24hTicker fetches rolling 24h volume and price statistics for the symbol.
+func (c *Client) Get24hTicker(ctx context.Context, symbol string) (exchange.Ticker24h, error) {
+	params := url.Values{}
+	params.Set("contract", contractName(symbol))
+	var payload []struct {
+		Contract    string `json:"contract"`
+		Last        string `json:"last"`
+		Change      string `json:"change_percentage"`
+		High        string `json:"high_24h"`
+		Low         string `json:"low_24h"`
+		VolumeBase  string `json:"volume_24h_base"`
+		VolumeQuote string `json:"volume_24h_quote"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/tickers", params, nil, false, &payload); err != nil {
+		return exchange.Ticker24h{}, fmt.Errorf("get 24h ticker: %w", err)
+	}
+	if len(payload) == 0 {
+		return exchange.Ticker24h{}, fmt.Errorf("no ticker returned for %s", symbol)
+	}
+	item := payload[0]
+	ticker := exchange.Ticker24h{Symbol: symbolName(item.Contract), CloseTime: time.Now()}
+	ticker.LastPrice, _ = strconv.ParseFloat(item.Last, 64)
+	ticker.PriceChangePercent, _ = strconv.ParseFloat(item.Change, 64)
+	ticker.HighPrice, _ = strconv.ParseFloat(item.High, 64)
+	ticker.LowPrice, _ = strconv.ParseFloat(item.Low, 64)
+	ticker.Volume, _ = strconv.ParseFloat(item.VolumeBase, 64)
+	ticker.QuoteVolume, _ = strconv.ParseFloat(item.VolumeQuote, 64)
+	return ticker, nil
+}
+
+// do performs a request against the v4 API; signed requests carry the
+// KEY/Timestamp/SIGN headers.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, signed bool, out any) error {
+	if signed && (c.apiKey == "" || c.apiSecret == "") {
+		return errors.New("api key/secret required for private endpoints")
+	}
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = data
+	}
+	queryString := ""
+	if len(query) > 0 {
+		queryString = query.Encode()
+	}
+	endpoint := c.baseURL + apiPrefix + path
+	if queryString != "" {
+		endpoint += "?" + queryString
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if signed {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set("KEY", c.apiKey)
+		req.Header.Set("Timestamp", timestamp)
+		req.Header.Set("SIGN", sign(c.apiSecret, method, apiPrefix+path, queryString, payload, timestamp))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(data))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// sign implements Gate APIv4 signing: HMAC-SHA512 over
+// method\npath\nquery\nhex(sha512(body))\ntimestamp.
+func sign(secret, method, path, query string, body []byte, timestamp string) string {
+	bodyHash := sha512.Sum512(body)
+	payload := strings.Join([]string{method, path, query, hex.EncodeToString(bodyHash[:]), timestamp}, "\n")
+	mac := hmac.New(sha512.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// mapInterval converts Binance-style intervals to Gate candlestick intervals.
+func mapInterval(interval string) (string, error) {
+	switch interval {
+	case "10s", "1m", "5m", "15m", "30m", "1h", "4h", "8h", "1d", "7d":
+		return interval, nil
+	case "1w":
+		return "7d", nil
+	}
+	return "", fmt.Errorf("interval %q not supported by gateio", interval)
+}
+
+func mapTimeInForce(tif exchange.TimeInForce) string {
+	switch tif {
+	case exchange.TimeInForceIOC:
+		return "ioc"
+	case exchange.TimeInForceFOK:
+		return "fok"
+	default:
+		return "gtc"
+	}
+}
+
+func orderStatus(status, finishAs string) string {
+	switch {
+	case status == "open":
+		return "NEW"
+	case finishAs == "filled":
+		return "FILLED"
+	case finishAs != "":
+		return strings.ToUpper(finishAs)
+	}
+	return strings.ToUpper(status)
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// auditPolicy marks APIv4-signed requests and hides the key and signature.
+var auditPolicy = exchange.AuditPolicy{
+	SecretHeaders: []string{"KEY", "SIGN"},
+	OrderPaths:    []string{"/orders", "/price_orders"},
+}
+
+// EnableAudit records every signed request and its raw response to rec.
+func (c *Client) EnableAudit(rec exchange.AuditRecorder) {
+	c.httpClient.Transport = exchange.NewAuditTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
+
+// EnableOrderJournal records every order request and its raw response to rec.
+func (c *Client) EnableOrderJournal(rec exchange.OrderJournalRecorder) {
+	c.httpClient.Transport = exchange.NewOrderJournalTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
@@ -0,0 +1,156 @@
+package gateio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"autobot/internal/exchange"
+)
+
+// Contract holds the Gate contract specification needed for size conversion.
+type Contract struct {
+	Name         string
+	Multiplier   float64
+	PriceRound   float64
+	OrderSizeMin int64
+	FundingRate  float64
+	MarkPrice    float64
+	LeverageMax  float64
+}
+
+// ToContracts converts a base-asset quantity to whole contracts, rounding down
+// so an order never exceeds the requested quantity.
+func (c Contract) ToContracts(qty float64) int64 {
+	if c.Multiplier <= 0 {
+		return 0
+	}
+	return int64(math.Floor(math.Abs(qty)/c.Multiplier + 1e-9))
+}
+
+// ToBase converts a (signed) contract count to base-asset units.
+func (c Contract) ToBase(size float64) float64 {
+	if c.Multiplier <= 0 {
+		return size
+	}
+	return size * c.Multiplier
+}
+
+// RoundPrice rounds p to the contract's price increment.
+func (c Contract) RoundPrice(p float64) float64 {
+	if c.PriceRound <= 0 {
+		return p
+	}
+	decimals := 0
+	text := strconv.FormatFloat(c.PriceRound, 'f', -1, 64)
+	if idx := strings.IndexByte(text, '.'); idx >= 0 {
+		decimals = len(text) - idx - 1
+	}
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(math.Round(p/c.PriceRound)*c.PriceRound*factor) / factor
+}
+
+type contractCache struct {
+	mu    sync.Mutex
+	items map[string]Contract
+}
+
+func newContractCache() *contractCache {
+	return &contractCache{items: make(map[string]Contract)}
+}
+
+// GetContract returns the cached contract specification, fetching it on first use.
+func (c *Client) GetContract(ctx context.Context, symbol string) (Contract, error) {
+	name := contractName(symbol)
+	c.contracts.mu.Lock()
+	contract, ok := c.contracts.items[name]
+	c.contracts.mu.Unlock()
+	if ok {
+		return contract, nil
+	}
+	return c.fetchContract(ctx, name)
+}
+
+func (c *Client) fetchContract(ctx context.Context, name string) (Contract, error) {
+	var payload struct {
+		Name             string `json:"name"`
+		QuantoMultiplier string `json:"quanto_multiplier"`
+		OrderPriceRound  string `json:"order_price_round"`
+		OrderSizeMin     int64  `json:"order_size_min"`
+		FundingRate      string `json:"funding_rate"`
+		MarkPrice        string `json:"mark_price"`
+		LeverageMax      string `json:"leverage_max"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/futures/"+settle+"/contracts/"+name, nil, nil, false, &payload); err != nil {
+		return Contract{}, fmt.Errorf("get contract %s: %w", name, err)
+	}
+	contract := Contract{Name: payload.Name, OrderSizeMin: payload.OrderSizeMin}
+	contract.Multiplier, _ = strconv.ParseFloat(payload.QuantoMultiplier, 64)
+	contract.PriceRound, _ = strconv.ParseFloat(payload.OrderPriceRound, 64)
+	contract.FundingRate, _ = strconv.ParseFloat(payload.FundingRate, 64)
+	contract.MarkPrice, _ = strconv.ParseFloat(payload.MarkPrice, 64)
+	contract.LeverageMax, _ = strconv.ParseFloat(payload.LeverageMax, 64)
+	if contract.Name == "" {
+		contract.Name = name
+	}
+	if contract.Multiplier <= 0 {
+		return Contract{}, fmt.Errorf("contract %s has no quanto multiplier", name)
+	}
+
+	c.contracts.mu.Lock()
+	c.contracts.items[name] = contract
+	c.contracts.mu.Unlock()
+	return contract, nil
+}
+
+// contractName maps BTCUSDT (or btc_usdt) to Gate's BTC_USDT.
+func contractName(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if strings.Contains(symbol, "_") {
+		return symbol
+	}
+	if base := strings.TrimSuffix(symbol, "USDT"); base != symbol && base != "" {
+		return base + "_USDT"
+	}
+	return symbol
+}
+
+// symbolName maps BTC_USDT back to the BTCUSDT form used across the bot.
+func symbolName(contract string) string {
+	return strings.ReplaceAll(contract, "_", "")
+}
+
+var _ exchange.LotRulesSource = (*Client)(nil)
+
+// GetLotRules reports the contract size as the base-asset step, so quantities
+// floored to it convert to whole contracts without loss.
+func (c *Client) GetLotRules(ctx context.Context, symbol string) (exchange.LotRules, error) {
+	contract, err := c.GetContract(ctx, symbol)
+	if err != nil {
+		return exchange.LotRules{}, err
+	}
+	return exchange.LotRules{
+		Symbol:   symbolName(contract.Name),
+		StepSize: contract.Multiplier,
+		MinQty:   float64(contract.OrderSizeMin) * contract.Multiplier,
+		TickSize: contract.PriceRound,
+	}, nil
+}
+
+var _ exchange.LeverageSource = (*Client)(nil)
+
+// GetMaxLeverage returns the contract's leverage_max.
+func (c *Client) GetMaxLeverage(ctx context.Context, symbol string) (float64, error) {
+	contract, err := c.GetContract(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	if contract.LeverageMax <= 0 {
+		return 0, fmt.Errorf("contract %s has no leverage_max", contract.Name)
+	}
+	return contract.LeverageMax, nil
+}
@@ -0,0 +1,561 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/exchange"
+	"autobot/internal/strategy"
+)
+
+const (
+	mainnetURL = "https://api.hyperliquid.xyz"
+	testnetURL = "https://api.hyperliquid-testnet.xyz"
+
+	// defaultSlippage bounds the limit price of IOC orders used to emulate market orders.
+	defaultSlippage = 0.05
+	// maxPriceDecimals is the perp price precision before subtracting szDecimals.
+	maxPriceDecimals = 6
+	// minOrderValue is the smallest order notional Hyperliquid accepts, in USDC.
+	minOrderValue = 10
+)
+
+// Client implements exchange.Exchange for Hyperliquid perpetuals. Market data
+// comes from the info API; orders are signed with the wallet key and sent to
+// the exchange API. Hyperliquid is USDC-margined and one-way only; symbols are
+// accepted and reported in the bot's BTCUSDT form and mapped to coin names.
+type Client struct {
+	wallet     *wallet
+	account    string
+	baseURL    string
+	mainnet    bool
+	httpClient *http.Client
+}
+
+var _ exchange.Exchange = (*Client)(nil)
+
+type assetMeta struct {
+	Index       int
+	Name        string
+	SzDecimals  int
+	MaxLeverage float64
+}
+
+type assetCtx struct {
+	Funding      string `json:"funding"`
+	OpenInterest string `json:"openInterest"`
+	PrevDayPx    string `json:"prevDayPx"`
+	DayNtlVlm    string `json:"dayNtlVlm"`
+	DayBaseVlm   string `json:"dayBaseVlm"`
+	MarkPx       string `json:"markPx"`
+	MidPx        string `json:"midPx"`
+}
+
+// New creates a client. privateKey may be empty for read-only use; account is
+// the address whose positions are queried and defaults to the key's address
+// (set it explicitly when trading through an API agent wallet).
+func New(privateKey, account string, testnet bool) (*Client, error) {
+	c := &Client{
+		account:    strings.ToLower(strings.TrimSpace(account)),
+		baseURL:    mainnetURL,
+		mainnet:    !testnet,
+		httpClient: &http.Client{Timeout: 10 * time.Second, Transport: exchange.NewMetricsTransport("hyperliquid", nil)},
+	}
+	if testnet {
+		c.baseURL = testnetURL
+	}
+	if strings.TrimSpace(privateKey) != "" {
+		w, err := newWallet(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("hyperliquid wallet: %w", err)
+		}
+		c.wallet = w
+		if c.account == "" {
+			c.account = w.address
+		}
+	}
+	return c, nil
+}
+
+// Name identifies the venue.
+func (c *Client) Name() string {
+	return "hyperliquid"
+}
+
+// Address returns the account address used for position queries.
+func (c *Client) Address() string {
+	return c.account
+}
+
+// GetKlines retrieves recent OHLCV data via candleSnapshot.
+func (c *Client) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	step, ok := intervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("interval %q not supported by hyperliquid", interval)
+	}
+	end := time.Now()
+	start := end.Add(-time.Duration(limit) * step)
+	body := map[string]any{
+		"type": "candleSnapshot",
+		"req": map[string]any{
+			"coin":      coinName(symbol),
+			"interval":  interval,
+			"startTime": start.UnixMilli(),
+			"endTime":   end.UnixMilli(),
+		},
+	}
+	var payload []struct {
+		OpenTime int64  `json:"t"`
+		Open     string `json:"o"`
+		High     string `json:"h"`
+		Low      string `json:"l"`
+		Close    string `json:"c"`
+		Volume   string `json:"v"`
+	}
+	if err := c.post(ctx, "/info", body, &payload); err != nil {
+		return nil, fmt.Errorf("get klines: %w", err)
+	}
+	if len(payload) == 0 {
+		return nil, errors.New("no klines returned")
+	}
+	if len(payload) > limit {
+		payload = payload[len(payload)-limit:]
+	}
+
+	candles := make([]strategy.Candle, 0, len(payload))
+	for _, item := range payload {
+		candle := strategy.Candle{OpenTime: time.UnixMilli(item.OpenTime)}
+		candle.Open, _ = strconv.ParseFloat(item.Open, 64)
+		candle.High, _ = strconv.ParseFloat(item.High, 64)
+		candle.Low, _ = strconv.ParseFloat(item.Low, 64)
+		candle.Close, _ = strconv.ParseFloat(item.Close, 64)
+		candle.Volume, _ = strconv.ParseFloat(item.Volume, 64)
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+type clearinghouseState struct {
+	MarginSummary struct {
+		AccountValue string `json:"accountValue"`
+	} `json:"marginSummary"`
+	Withdrawable   string `json:"withdrawable"`
+	AssetPositions []struct {
+		Position struct {
+			Coin          string `json:"coin"`
+			Size          string `json:"szi"`
+			EntryPx       string `json:"entryPx"`
+			PositionValue string `json:"positionValue"`
+			UnrealizedPnl string `json:"unrealizedPnl"`
+			Leverage      struct {
+				Value float64 `json:"value"`
+			} `json:"leverage"`
+		} `json:"position"`
+	} `json:"assetPositions"`
+	Time int64 `json:"time"`
+}
+
+func (c *Client) clearinghouse(ctx context.Context) (clearinghouseState, error) {
+	if c.account == "" {
+		return clearinghouseState{}, errors.New("account address or private key required for private endpoints")
+	}
+	var state clearinghouseState
+	err := c.post(ctx, "/info", map[string]any{"type": "clearinghouseState", "user": c.account}, &state)
+	return state, err
+}
+
+// GetPositions retrieves open positions, optionally filtered by symbol.
+func (c *Client) GetPositions(ctx context.Context, symbol string) ([]exchange.PositionRisk, error) {
+	state, err := c.clearinghouse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get positions: %w", err)
+	}
+	want := ""
+	if symbol != "" {
+		want = coinName(symbol)
+	}
+	positions := make([]exchange.PositionRisk, 0, len(state.AssetPositions))
+	for _, item := range state.AssetPositions {
+		pos := item.Position
+		qty, _ := strconv.ParseFloat(pos.Size, 64)
+		if qty == 0 || (want != "" && pos.Coin != want) {
+			continue
+		}
+		entry, _ := strconv.ParseFloat(pos.EntryPx, 64)
+		value, _ := strconv.ParseFloat(pos.PositionValue, 64)
+		pnl, _ := strconv.ParseFloat(pos.UnrealizedPnl, 64)
+		positions = append(positions, exchange.PositionRisk{
+			Symbol:        symbolName(pos.Coin),
+			PositionSide:  exchange.PositionSideBoth,
+			Quantity:      qty,
+			EntryPrice:    entry,
+			MarkPrice:     value / math.Abs(qty),
+			Leverage:      pos.Leverage.Value,
+			UnrealizedPNL: pnl,
+			UpdateTime:    time.UnixMilli(state.Time),
+		})
+	}
+	return positions, nil
+}
+
+// GetAccountInfo reports the USDC margin account; wallet balance excludes unrealized PnL.
+func (c *Client) GetAccountInfo(ctx context.Context) (exchange.AccountInfo, error) {
+	state, err := c.clearinghouse(ctx)
+	if err != nil {
+		return exchange.AccountInfo{}, fmt.Errorf("get account info: %w", err)
+	}
+	value, _ := strconv.ParseFloat(state.MarginSummary.AccountValue, 64)
+	avail, _ := strconv.ParseFloat(state.Withdrawable, 64)
+	unrealized := 0.0
+	for _, item := range state.AssetPositions {
+		pnl, _ := strconv.ParseFloat(item.Position.UnrealizedPnl, 64)
+		unrealized += pnl
+	}
+	return exchange.AccountInfo{
+		TotalWalletBalance: value - unrealized,
+		AvailableBalance:   avail,
+		CrossUnrealizedPNL: unrealized,
+		LastUpdate:         time.UnixMilli(state.Time),
+	}, nil
+}
+
+// PlaceOrder signs and submits an order. Hyperliquid has no native market
+// order: MARKET becomes an IOC limit bounded by defaultSlippage around the mid
+// price, and STOP/TAKE_PROFIT_MARKET become reduce-only trigger orders.
+func (c *Client) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (exchange.OrderResponse, error) {
+	if c.wallet == nil {
+		return exchange.OrderResponse{}, errors.New("private key required for trading")
+	}
+	meta, actx, err := c.asset(ctx, req.Symbol)
+	if err != nil {
+		return exchange.OrderResponse{}, err
+	}
+	size := floorDecimals(req.Quantity, meta.SzDecimals)
+	if size <= 0 {
+		return exchange.OrderResponse{}, fmt.Errorf("quantity %s below size precision (%d decimals)", formatFloat(req.Quantity), meta.SzDecimals)
+	}
+	isBuy := req.Side == exchange.OrderSideBuy
+	slip := 1 - defaultSlippage
+	if isBuy {
+		slip = 1 + defaultSlippage
+	}
+
+	var price float64
+	var orderType orderedMap
+	reduceOnly := req.ReduceOnly
+	switch req.Type {
+	case exchange.OrderTypeMarket:
+		mid, _ := strconv.ParseFloat(actx.MidPx, 64)
+		if mid <= 0 {
+			mid, _ = strconv.ParseFloat(actx.MarkPx, 64)
+		}
+		if mid <= 0 {
+			return exchange.OrderResponse{}, fmt.Errorf("no reference price for %s", meta.Name)
+		}
+		price = mid * slip
+		orderType = orderedMap{{"limit", orderedMap{{"tif", "Ioc"}}}}
+	case exchange.OrderTypeLimit:
+		tif := "Gtc"
+		switch req.TimeInForce {
+		case exchange.TimeInForceIOC:
+			tif = "Ioc"
+		case exchange.TimeInForceFOK:
+			return exchange.OrderResponse{}, errors.New("hyperliquid does not support FOK orders")
+		}
+		price = req.Price
+		orderType = orderedMap{{"limit", orderedMap{{"tif", tif}}}}
+	case exchange.OrderTypeStopMarket, exchange.OrderTypeTakeProfitMarket:
+		if req.StopPrice <= 0 {
+			return exchange.OrderResponse{}, fmt.Errorf("%s requires stop price", req.Type)
+		}
+		tpsl := "sl"
+		if req.Type == exchange.OrderTypeTakeProfitMarket {
+			tpsl = "tp"
+		}
+		price = req.StopPrice * slip
+		reduceOnly = true
+		orderType = orderedMap{{"trigger", orderedMap{
+			{"isMarket", true},
+			{"triggerPx", formatWire(roundPrice(req.StopPrice, meta.SzDecimals))},
+			{"tpsl", tpsl},
+		}}}
+	default:
+		return exchange.OrderResponse{}, fmt.Errorf("order type %s not supported by hyperliquid", req.Type)
+	}
+
+	order := orderedMap{
+		{"a", meta.Index},
+		{"b", isBuy},
+		{"p", formatWire(roundPrice(price, meta.SzDecimals))},
+		{"s", formatWire(size)},
+		{"r", reduceOnly},
+		{"t", orderType},
+	}
+	action := orderedMap{
+		{"type", "order"},
+		{"orders", []any{order}},
+		{"grouping", "na"},
+	}
+	nonce := time.Now().UnixMilli()
+	sig, err := signL1Action(c.wallet, action, nonce, c.mainnet)
+	if err != nil {
+		return exchange.OrderResponse{}, fmt.Errorf("sign order: %w", err)
+	}
+
+	var payload struct {
+		Status   string          `json:"status"`
+		Response json.RawMessage `json:"response"`
+	}
+	body := map[string]any{"action": action, "nonce": nonce, "signature": sig, "vaultAddress": nil}
+	if err := c.post(ctx, "/exchange", body, &payload); err != nil {
+		return exchange.OrderResponse{}, fmt.Errorf("place order: %w", err)
+	}
+	if payload.Status != "ok" {
+		return exchange.OrderResponse{}, fmt.Errorf("place order rejected: %s", string(payload.Response))
+	}
+	var result struct {
+		Data struct {
+			Statuses []struct {
+				Resting *struct {
+					Oid int64 `json:"oid"`
+				} `json:"resting"`
+				Filled *struct {
+					TotalSz string `json:"totalSz"`
+					AvgPx   string `json:"avgPx"`
+					Oid     int64  `json:"oid"`
+				} `json:"filled"`
+				Error string `json:"error"`
+			} `json:"statuses"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload.Response, &result); err != nil {
+		return exchange.OrderResponse{}, fmt.Errorf("decode order response: %w", err)
+	}
+	if len(result.Data.Statuses) == 0 {
+		return exchange.OrderResponse{}, errors.New("empty order status")
+	}
+
+	now := time.Now()
+	resp := exchange.OrderResponse{Symbol: symbolName(meta.Name), TransactTime: now.UnixMilli(), UpdateTime: now}
+	status := result.Data.Statuses[0]
+	switch {
+	case status.Error != "":
+		return exchange.OrderResponse{}, fmt.Errorf("order rejected: %s", status.Error)
+	case status.Filled != nil:
+		resp.OrderID = status.Filled.Oid
+		resp.AvgPrice = status.Filled.AvgPx
+		resp.ExecutedQty = status.Filled.TotalSz
+		resp.Status = "FILLED"
+	case status.Resting != nil:
+		resp.OrderID = status.Resting.Oid
+		resp.ExecutedQty = "0"
+		resp.Status = "NEW"
+	}
+	return resp, nil
+}
+
+var _ exchange.LotRulesSource = (*Client)(nil)
+
+// GetLotRules derives the size step from szDecimals. Prices are limited by
+// significant figures rather than a fixed tick, so TickSize is left zero.
+func (c *Client) GetLotRules(ctx context.Context, symbol string) (exchange.LotRules, error) {
+	meta, _, err := c.asset(ctx, symbol)
+	if err != nil {
+		return exchange.LotRules{}, fmt.Errorf("get lot rules: %w", err)
+	}
+	return exchange.LotRules{
+		Symbol:      strings.ToUpper(symbol),
+		StepSize:    math.Pow(10, -float64(meta.SzDecimals)),
+		MinNotional: minOrderValue,
+	}, nil
+}
+
+var _ exchange.LeverageSource = (*Client)(nil)
+
+// GetMaxLeverage returns the asset's maxLeverage from the perp universe.
+func (c *Client) GetMaxLeverage(ctx context.Context, symbol string) (float64, error) {
+	meta, _, err := c.asset(ctx, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("get max leverage: %w", err)
+	}
+	if meta.MaxLeverage <= 0 {
+		return 0, fmt.Errorf("coin %s has no maxLeverage", meta.Name)
+	}
+	return meta.MaxLeverage, nil
+}
+
+// GetFundingRate returns the current hourly funding rate as published by Hyperliquid.
+func (c *Client) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	_, actx, err := c.asset(ctx, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("get funding rate: %w", err)
+	}
+	return strconv.ParseFloat(actx.Funding, 64)
+}
+
+// GetOpenInterest returns open interest in coin units.
+func (c *Client) GetOpenInterest(ctx context.Context, symbol string) (float64, error) {
+	_, actx, err := c.asset(ctx, symbol)
+	if err != nil {
+		return 0, fmt.Errorf("get open interest: %w", err)
+	}
+	return strconv.ParseFloat(actx.OpenInterest, 64)
+}
+
+// Get24hTicker derives 24h statistics from the asset context; high/low are not published.
+func (c *Client) Get24hTicker(ctx context.Context, symbol string) (exchange.Ticker24h, error) {
+	meta, actx, err := c.asset(ctx, symbol)
+	if err != nil {
+		return exchange.Ticker24h{}, fmt.Errorf("get 24h ticker: %w", err)
+	}
+	ticker := exchange.Ticker24h{Symbol: symbolName(meta.Name), CloseTime: time.Now()}
+	ticker.LastPrice, _ = strconv.ParseFloat(actx.MarkPx, 64)
+	prev, _ := strconv.ParseFloat(actx.PrevDayPx, 64)
+	if prev > 0 {
+		ticker.PriceChangePercent = (ticker.LastPrice/prev - 1) * 100
+	}
+	ticker.Volume, _ = strconv.ParseFloat(actx.DayBaseVlm, 64)
+	ticker.QuoteVolume, _ = strconv.ParseFloat(actx.DayNtlVlm, 64)
+	return ticker, nil
+}
+
+// asset returns the metadata and live context for a coin. Both come from one
+// metaAndAssetCtxs call, so the asset index always matches the context list.
+func (c *Client) asset(ctx context.Context, symbol string) (assetMeta, assetCtx, error) {
+	coin := coinName(symbol)
+	var payload []json.RawMessage
+	if err := c.post(ctx, "/info", map[string]any{"type": "metaAndAssetCtxs"}, &payload); err != nil {
+		return assetMeta{}, assetCtx{}, err
+	}
+	if len(payload) != 2 {
+		return assetMeta{}, assetCtx{}, errors.New("unexpected metaAndAssetCtxs response")
+	}
+	var meta struct {
+		Universe []struct {
+			Name        string  `json:"name"`
+			SzDecimals  int     `json:"szDecimals"`
+			MaxLeverage float64 `json:"maxLeverage"`
+		} `json:"universe"`
+	}
+	var ctxs []assetCtx
+	if err := json.Unmarshal(payload[0], &meta); err != nil {
+		return assetMeta{}, assetCtx{}, fmt.Errorf("decode meta: %w", err)
+	}
+	if err := json.Unmarshal(payload[1], &ctxs); err != nil {
+		return assetMeta{}, assetCtx{}, fmt.Errorf("decode asset contexts: %w", err)
+	}
+
+	for idx, item := range meta.Universe {
+		if item.Name == coin && idx < len(ctxs) {
+			return assetMeta{Index: idx, Name: item.Name, SzDecimals: item.SzDecimals, MaxLeverage: item.MaxLeverage}, ctxs[idx], nil
+		}
+	}
+	return assetMeta{}, assetCtx{}, fmt.Errorf("coin %s not listed on hyperliquid", coin)
+}
+
+func (c *Client) post(ctx context.Context, path string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(msg))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+var intervals = map[string]time.Duration{
+	"1m": time.Minute, "3m": 3 * time.Minute, "5m": 5 * time.Minute, "15m": 15 * time.Minute, "30m": 30 * time.Minute,
+	"1h": time.Hour, "2h": 2 * time.Hour, "4h": 4 * time.Hour, "8h": 8 * time.Hour, "12h": 12 * time.Hour,
+	"1d": 24 * time.Hour, "3d": 72 * time.Hour, "1w": 7 * 24 * time.Hour,
+}
+
+// coinName maps BTCUSDT / BTC-USD / btc to the Hyperliquid coin BTC.
+func coinName(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	for _, quote := range []string{"-USDC", "-USD", "USDT", "USDC"} {
+		if base := strings.TrimSuffix(symbol, quote); base != symbol && base != "" {
+			return base
+		}
+	}
+	return symbol
+}
+
+// symbolName maps a coin back to the bot's symbol convention.
+func symbolName(coin string) string {
+	return coin + "USDT"
+}
+
+// roundPrice applies Hyperliquid's tick rule: five significant figures and at
+// most maxPriceDecimals-szDecimals decimals.
+func roundPrice(price float64, szDecimals int) float64 {
+	sig, _ := strconv.ParseFloat(strconv.FormatFloat(price, 'g', 5, 64), 64)
+	decimals := maxPriceDecimals - szDecimals
+	if decimals < 0 {
+		decimals = 0
+	}
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(sig*factor) / factor
+}
+
+func floorDecimals(v float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Floor(v*factor+1e-9) / factor
+}
+
+// formatWire mirrors the SDK's float_to_wire: 8 decimals with trailing zeros removed.
+func formatWire(v float64) string {
+	text := strconv.FormatFloat(v, 'f', 8, 64)
+	text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	if text == "-0" || text == "" {
+		return "0"
+	}
+	return text
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// auditPolicy records exchange-API calls; their bodies carry a wallet
+// signature but no secret, so they are stored verbatim.
+var auditPolicy = exchange.AuditPolicy{
+	SignedPaths: []string{"/exchange"},
+	OrderPaths:  []string{"/exchange"},
+}
+
+// EnableAudit records every signed action and its raw response to rec.
+func (c *Client) EnableAudit(rec exchange.AuditRecorder) {
+	c.httpClient.Transport = exchange.NewAuditTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
+
+// EnableOrderJournal records every exchange action (orders, cancels and
+// leverage updates) and its raw response to rec.
+func (c *Client) EnableOrderJournal(rec exchange.OrderJournalRecorder) {
+	c.httpClient.Transport = exchange.NewOrderJournalTransport(c.Name(), c.httpClient.Transport, auditPolicy, rec)
+}
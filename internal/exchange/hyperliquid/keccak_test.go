@@ -0,0 +1,30 @@
+package hyperliquid
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestKeccak256KnownAnswers(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	}
+	for _, tc := range cases {
+		got := keccak256([]byte(tc.in))
+		if hex.EncodeToString(got[:]) != tc.want {
+			t.Errorf("keccak256(%q) = %x, want %s", tc.in, got, tc.want)
+		}
+	}
+
+	// Input spanning several 136-byte blocks, fed in pieces, hashes like one slice.
+	long := strings.Repeat("a", 300)
+	whole := keccak256([]byte(long))
+	if parts := keccak256([]byte(long[:100]), []byte(long[100:])); parts != whole {
+		t.Fatalf("split input hash %x, want %x", parts, whole)
+	}
+}
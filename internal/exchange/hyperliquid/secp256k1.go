@@ -0,0 +1,173 @@
+package hyperliquid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Minimal secp256k1 ECDSA for Ethereum-style recoverable signatures. The
+// standard library only ships NIST curves. Nonces are deterministic
+// (RFC 6979) so no randomness is required; arithmetic uses math/big and is
+// not constant time, which is acceptable for a bot signing its own orders.
+
+var (
+	curveP, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	curveN, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	curveGx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	curveGy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+	halfN      = new(big.Int).Rsh(curveN, 1)
+)
+
+type point struct {
+	x, y *big.Int // nil x means the point at infinity
+}
+
+func (p point) infinity() bool {
+	return p.x == nil
+}
+
+func pointAdd(a, b point) point {
+	if a.infinity() {
+		return b
+	}
+	if b.infinity() {
+		return a
+	}
+	var lambda *big.Int
+	if a.x.Cmp(b.x) == 0 {
+		sum := new(big.Int).Add(a.y, b.y)
+		if sum.Mod(sum, curveP).Sign() == 0 {
+			return point{}
+		}
+		// doubling: 3x² / 2y
+		num := new(big.Int).Mul(a.x, a.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(a.y, 1)
+		lambda = num.Mul(num, den.ModInverse(den, curveP))
+	} else {
+		num := new(big.Int).Sub(b.y, a.y)
+		den := new(big.Int).Sub(b.x, a.x)
+		den.Mod(den, curveP)
+		lambda = num.Mul(num, den.ModInverse(den, curveP))
+	}
+	lambda.Mod(lambda, curveP)
+
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, curveP)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, lambda).Sub(y, a.y).Mod(y, curveP)
+	return point{x: x, y: y}
+}
+
+func scalarBaseMult(k *big.Int) point {
+	result := point{}
+	addend := point{x: new(big.Int).Set(curveGx), y: new(big.Int).Set(curveGy)}
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = pointAdd(result, addend)
+		}
+		addend = pointAdd(addend, addend)
+	}
+	return result
+}
+
+// wallet holds a secp256k1 private key and its Ethereum address.
+type wallet struct {
+	key     *big.Int
+	address string
+}
+
+// newWallet parses a 0x-prefixed (or bare) hex private key.
+func newWallet(privateKeyHex string) (*wallet, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil || len(raw) != 32 {
+		return nil, errors.New("private key must be 32 bytes of hex")
+	}
+	key := new(big.Int).SetBytes(raw)
+	if key.Sign() == 0 || key.Cmp(curveN) >= 0 {
+		return nil, errors.New("private key out of range")
+	}
+	pub := scalarBaseMult(key)
+	hash := keccak256(pad32(pub.x), pad32(pub.y))
+	return &wallet{key: key, address: "0x" + hex.EncodeToString(hash[12:])}, nil
+}
+
+// sign returns r, s and the Ethereum v (27/28) for a 32-byte digest, with low-s normalisation.
+func (w *wallet) sign(digest [32]byte) (*big.Int, *big.Int, int, error) {
+	z := new(big.Int).SetBytes(digest[:])
+	nonces := rfc6979(w.key, digest)
+	for attempt := 0; attempt < 16; attempt++ {
+		k := nonces()
+		r := scalarBaseMult(k)
+		rx := new(big.Int).Mod(r.x, curveN)
+		if rx.Sign() == 0 {
+			continue
+		}
+		s := new(big.Int).Mul(rx, w.key)
+		s.Add(s, z).Mul(s, new(big.Int).ModInverse(k, curveN)).Mod(s, curveN)
+		if s.Sign() == 0 {
+			continue
+		}
+		recID := int(r.y.Bit(0))
+		if r.x.Cmp(curveN) >= 0 {
+			recID |= 2
+		}
+		if s.Cmp(halfN) > 0 {
+			s.Sub(curveN, s)
+			recID ^= 1
+		}
+		return rx, s, 27 + recID, nil
+	}
+	return nil, nil, 0, fmt.Errorf("failed to produce signature")
+}
+
+// rfc6979 returns a generator of deterministic nonces for key and digest.
+func rfc6979(key *big.Int, digest [32]byte) func() *big.Int {
+	x := pad32(key)
+	h := pad32(new(big.Int).Mod(new(big.Int).SetBytes(digest[:]), curveN))
+	v := make([]byte, 32)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, 32)
+	mac := func(key []byte, parts ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, p := range parts {
+			m.Write(p)
+		}
+		return m.Sum(nil)
+	}
+	k = mac(k, v, []byte{0x00}, x, h)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, x, h)
+	v = mac(k, v)
+
+	first := true
+	return func() *big.Int {
+		if !first {
+			k = mac(k, v, []byte{0x00})
+			v = mac(k, v)
+		}
+		first = false
+		for {
+			v = mac(k, v)
+			candidate := new(big.Int).SetBytes(v)
+			if candidate.Sign() > 0 && candidate.Cmp(curveN) < 0 {
+				return candidate
+			}
+			k = mac(k, v, []byte{0x00})
+			v = mac(k, v)
+		}
+	}
+}
+
+func pad32(v *big.Int) []byte {
+	out := make([]byte, 32)
+	v.FillBytes(out)
+	return out
+}
@@ -0,0 +1,104 @@
+package hyperliquid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestWalletAddress(t *testing.T) {
+	cases := map[string]string{
+		"0x0000000000000000000000000000000000000000000000000000000000000001": "0x7e5f4552091a69125d5dfcb7b8c2659029395bdf",
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80":   "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+	}
+	for key, want := range cases {
+		w, err := newWallet(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.address != want {
+			t.Errorf("address of %s = %s, want %s", key, w.address, want)
+		}
+	}
+	if _, err := newWallet("0x00"); err == nil {
+		t.Error("short key accepted")
+	}
+}
+
+// The RFC 6979 vector for key 1 and SHA-256("Satoshi Nakamoto"), low-s form.
+func TestSignKnownAnswer(t *testing.T) {
+	w, err := newWallet("0000000000000000000000000000000000000000000000000000000000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, s, _, err := w.sign(sha256.Sum256([]byte("Satoshi Nakamoto")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(pad32(r)); got != "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8" {
+		t.Errorf("r = %s", got)
+	}
+	if got := hex.EncodeToString(pad32(s)); got != "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5" {
+		t.Errorf("s = %s", got)
+	}
+}
+
+func TestSignRecoversAddress(t *testing.T) {
+	w, err := newWallet("0x0123456789012345678901234567890123456789012345678901234567890123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range []string{"order", "cancel", "updateLeverage"} {
+		digest := keccak256([]byte(msg))
+		r, s, v, err := w.sign(digest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Cmp(halfN) > 0 {
+			t.Errorf("%s: s is not low", msg)
+		}
+		if got := recoverAddress(t, digest, r, s, v); got != w.address {
+			t.Errorf("%s: recovered %s, want %s", msg, got, w.address)
+		}
+	}
+}
+
+// recoverAddress computes Q = r⁻¹(sR − zG) from an Ethereum-style signature
+// and returns the address of Q.
+func recoverAddress(t *testing.T, digest [32]byte, r, s *big.Int, v int) string {
+	t.Helper()
+	recID := v - 27
+	x := new(big.Int).Set(r)
+	if recID&2 != 0 {
+		x.Add(x, curveN)
+	}
+	// y² = x³ + 7; p ≡ 3 (mod 4) so y = (x³+7)^((p+1)/4).
+	y2 := new(big.Int).Exp(x, big.NewInt(3), curveP)
+	y2.Add(y2, big.NewInt(7)).Mod(y2, curveP)
+	y := new(big.Int).Exp(y2, new(big.Int).Rsh(new(big.Int).Add(curveP, big.NewInt(1)), 2), curveP)
+	if y.Bit(0) != uint(recID&1) {
+		y.Sub(curveP, y)
+	}
+	rInv := new(big.Int).ModInverse(r, curveN)
+	z := new(big.Int).SetBytes(digest[:])
+	sR := scalarMult(point{x: x, y: y}, new(big.Int).Mod(new(big.Int).Mul(s, rInv), curveN))
+	zG := scalarBaseMult(new(big.Int).Mod(new(big.Int).Mul(new(big.Int).Sub(curveN, z), rInv), curveN))
+	q := pointAdd(sR, zG)
+	if q.infinity() {
+		t.Fatal("recovered the point at infinity")
+	}
+	hash := keccak256(pad32(q.x), pad32(q.y))
+	return "0x" + hex.EncodeToString(hash[12:])
+}
+
+func scalarMult(p point, k *big.Int) point {
+	result := point{}
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			result = pointAdd(result, p)
+		}
+		p = pointAdd(p, p)
+	}
+	return result
+}
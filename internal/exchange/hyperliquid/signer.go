@@ -0,0 +1,192 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// kv is one entry of an ordered map. Hyperliquid hashes the msgpack encoding
+// of each action, so keys must be emitted in the same order as the reference SDK.
+type kv struct {
+	key   string
+	value any
+}
+
+type orderedMap []kv
+
+// MarshalJSON keeps key order so the JSON body mirrors the signed payload.
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, entry := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(entry.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// packMsg writes the msgpack encoding of v using the smallest formats, as msgpack-python does.
+func packMsg(buf *bytes.Buffer, v any) error {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if val {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case int:
+		packInt(buf, int64(val))
+	case int64:
+		packInt(buf, val)
+	case string:
+		n := len(val)
+		switch {
+		case n < 32:
+			buf.WriteByte(0xa0 | byte(n))
+		case n < 1<<8:
+			buf.WriteByte(0xd9)
+			buf.WriteByte(byte(n))
+		case n < 1<<16:
+			buf.WriteByte(0xda)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdb)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+		buf.WriteString(val)
+	case []any:
+		n := len(val)
+		switch {
+		case n < 16:
+			buf.WriteByte(0x90 | byte(n))
+		case n < 1<<16:
+			buf.WriteByte(0xdc)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdd)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+		for _, item := range val {
+			if err := packMsg(buf, item); err != nil {
+				return err
+			}
+		}
+	case orderedMap:
+		n := len(val)
+		switch {
+		case n < 16:
+			buf.WriteByte(0x80 | byte(n))
+		case n < 1<<16:
+			buf.WriteByte(0xde)
+			_ = binary.Write(buf, binary.BigEndian, uint16(n))
+		default:
+			buf.WriteByte(0xdf)
+			_ = binary.Write(buf, binary.BigEndian, uint32(n))
+		}
+		for _, entry := range val {
+			if err := packMsg(buf, entry.key); err != nil {
+				return err
+			}
+			if err := packMsg(buf, entry.value); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("msgpack: unsupported type %T", v)
+	}
+	return nil
+}
+
+func packInt(buf *bytes.Buffer, v int64) {
+	switch {
+	case v >= 0 && v < 128:
+		buf.WriteByte(byte(v))
+	case v >= 0 && v < 1<<8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(v))
+	case v >= 0 && v < 1<<16:
+		buf.WriteByte(0xcd)
+		_ = binary.Write(buf, binary.BigEndian, uint16(v))
+	case v >= 0 && v < 1<<32:
+		buf.WriteByte(0xce)
+		_ = binary.Write(buf, binary.BigEndian, uint32(v))
+	case v >= 0:
+		buf.WriteByte(0xcf)
+		_ = binary.Write(buf, binary.BigEndian, uint64(v))
+	case v >= -32:
+		buf.WriteByte(byte(v))
+	case v >= -128:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(v))
+	case v >= -32768:
+		buf.WriteByte(0xd1)
+		_ = binary.Write(buf, binary.BigEndian, int16(v))
+	case v >= -2147483648:
+		buf.WriteByte(0xd2)
+		_ = binary.Write(buf, binary.BigEndian, int32(v))
+	default:
+		buf.WriteByte(0xd3)
+		_ = binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+// signature is the wire form of an ECDSA signature.
+type signature struct {
+	R string `json:"r"`
+	S string `json:"s"`
+	V int    `json:"v"`
+}
+
+// signL1Action signs an exchange action the way the official SDK does:
+// the msgpack action, nonce and vault flag are hashed into a connection id,
+// which is signed as an EIP-712 "Agent" message (phantom agent).
+func signL1Action(w *wallet, action orderedMap, nonce int64, mainnet bool) (signature, error) {
+	var buf bytes.Buffer
+	if err := packMsg(&buf, action); err != nil {
+		return signature{}, err
+	}
+	_ = binary.Write(&buf, binary.BigEndian, uint64(nonce))
+	buf.WriteByte(0x00) // no vault address
+	connectionID := keccak256(buf.Bytes())
+
+	source := "b"
+	if mainnet {
+		source = "a"
+	}
+	r, s, v, err := w.sign(agentDigest(source, connectionID))
+	if err != nil {
+		return signature{}, err
+	}
+	return signature{R: "0x" + hex.EncodeToString(pad32(r)), S: "0x" + hex.EncodeToString(pad32(s)), V: v}, nil
+}
+
+// agentDigest computes the EIP-712 digest of Agent(string source,bytes32 connectionId)
+// under the fixed "Exchange" domain (chainId 1337, zero verifying contract).
+func agentDigest(source string, connectionID [32]byte) [32]byte {
+	domainType := keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	name := keccak256([]byte("Exchange"))
+	version := keccak256([]byte("1"))
+	domain := keccak256(domainType[:], name[:], version[:], pad32(big.NewInt(1337)), make([]byte, 32))
+
+	agentType := keccak256([]byte("Agent(string source,bytes32 connectionId)"))
+	sourceHash := keccak256([]byte(source))
+	message := keccak256(agentType[:], sourceHash[:], connectionID[:])
+
+	return keccak256([]byte{0x19, 0x01}, domain[:], message[:])
+}
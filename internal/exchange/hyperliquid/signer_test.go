@@ -0,0 +1,66 @@
+package hyperliquid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// dummyAction is the action of the reference SDK signing test: the float 1000
+// converted for hashing (×1e8).
+var dummyAction = orderedMap{{"type", "dummy"}, {"num", int64(100000000000)}}
+
+func TestPackMsg(t *testing.T) {
+	var buf bytes.Buffer
+	if err := packMsg(&buf, dummyAction); err != nil {
+		t.Fatal(err)
+	}
+	want := "82a474797065a564756d6d79a36e756dcf000000174876e800"
+	if got := hex.EncodeToString(buf.Bytes()); got != want {
+		t.Fatalf("msgpack = %s, want %s", got, want)
+	}
+
+	buf.Reset()
+	if err := packMsg(&buf, []any{-1, -200, 200, true, nil}); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(buf.Bytes()); got != "95ffd1ff38ccc8c3c0" {
+		t.Fatalf("msgpack = %s", got)
+	}
+	if err := packMsg(&buf, 1.5); err == nil {
+		t.Fatal("float accepted")
+	}
+}
+
+// Signatures from the reference SDK's L1 action signing test, so the msgpack
+// action hash, the EIP-712 agent digest and the signer are checked together.
+func TestSignL1ActionMatchesReference(t *testing.T) {
+	w, err := newWallet("0x0123456789012345678901234567890123456789012345678901234567890123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		mainnet bool
+		want    signature
+	}{
+		{true, signature{
+			R: "0x053749d5b30552aeb2fca34b530185976545bb22d0b3ce6f62e31be961a59298",
+			S: "0x755c40ba9bf05223521753995abb2f73ab3229be8ec921f350cb447e384d8ed8",
+			V: 27,
+		}},
+		{false, signature{
+			R: "0x542af61ef1f429707e3c76c5293c80d01f74ef853e34b76efffcb57e574f9510",
+			S: "0x17b8b32f086e8cdede991f1e2c529f5dd5297cbe8128500e00cbaf766204a613",
+			V: 28,
+		}},
+	}
+	for _, tc := range cases {
+		got, err := signL1Action(w, dummyAction, 0, tc.mainnet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("mainnet=%v: signature %+v, want %+v", tc.mainnet, got, tc.want)
+		}
+	}
+}
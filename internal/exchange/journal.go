@@ -0,0 +1,128 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/storage"
+)
+
+// OrderJournalRecorder persists order journal entries; *storage.OrderJournal
+// satisfies it.
+type OrderJournalRecorder interface {
+	RecordOrderJournal(ctx context.Context, entry storage.OrderJournalEntry) error
+}
+
+// OrderJournaled is implemented by adapters that can journal their order
+// requests.
+type OrderJournaled interface {
+	EnableOrderJournal(rec OrderJournalRecorder)
+}
+
+// EnableOrderJournal turns on order journaling when the adapter supports it.
+func EnableOrderJournal(ex Exchange, rec OrderJournalRecorder) bool {
+	journaled, ok := ex.(OrderJournaled)
+	if ok && rec != nil {
+		journaled.EnableOrderJournal(rec)
+	}
+	return ok
+}
+
+// NewOrderJournalTransport wraps base so every request to one of the
+// policy's OrderPaths is written to rec with its raw response, using the
+// same redaction as the audit transport. Other requests pass through.
+func NewOrderJournalTransport(venue string, base http.RoundTripper, policy AuditPolicy, rec OrderJournalRecorder) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &auditTransport{venue: venue, base: base, policy: policy, logger: loggerpkg.Get("exchange.journal")}
+	t.match = t.orderRequest
+	t.sink = func(ctx context.Context, record storage.ExchangeAuditRecord) error {
+		entry := storage.OrderJournalEntry{
+			Kind:         storage.OrderJournalRequest,
+			Exchange:     record.Exchange,
+			Method:       record.Method,
+			URL:          record.URL,
+			RequestBody:  record.RequestBody,
+			Status:       record.Status,
+			ResponseBody: record.ResponseBody,
+			Error:        record.Error,
+			DurationMs:   record.DurationMs,
+			CreatedAt:    record.CreatedAt,
+		}
+		entry.Symbol, entry.OrderID, entry.ClientOrderID = orderKeys(record)
+		return rec.RecordOrderJournal(ctx, entry)
+	}
+	return t
+}
+
+func (t *auditTransport) orderRequest(req *http.Request, _ []byte) bool {
+	for _, path := range t.policy.OrderPaths {
+		if strings.HasSuffix(req.URL.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Field names that carry the symbol and order identifiers across venues.
+var (
+	symbolKeys        = []string{"symbol", "contract", "currency_pair", "coin"}
+	orderIDKeys       = []string{"orderId", "id", "oid"}
+	clientOrderIDKeys = []string{"clientOrderId", "newClientOrderId", "origClientOrderId", "text", "cloid"}
+)
+
+// orderKeys extracts the symbol, order id and client order id from the
+// request parameters and the top level of a JSON response, best effort.
+func orderKeys(record storage.ExchangeAuditRecord) (symbol, orderID, clientOrderID string) {
+	values := url.Values{}
+	if u, err := url.Parse(record.URL); err == nil {
+		values = u.Query()
+	}
+	if form, err := url.ParseQuery(record.RequestBody); err == nil {
+		for key, v := range form {
+			values[key] = append(values[key], v...)
+		}
+	}
+	fields := map[string]any{}
+	for _, body := range []string{record.RequestBody, record.ResponseBody} {
+		var object map[string]any
+		decoder := json.NewDecoder(strings.NewReader(body))
+		decoder.UseNumber()
+		if decoder.Decode(&object) == nil {
+			for key, value := range object {
+				fields[key] = value
+			}
+		}
+	}
+	lookup := func(keys []string) string {
+		for _, key := range keys {
+			if value, ok := fields[key]; ok && value != nil {
+				return jsonScalar(value)
+			}
+			if value := values.Get(key); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+	return lookup(symbolKeys), lookup(orderIDKeys), lookup(clientOrderIDKeys)
+}
+
+func jsonScalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case map[string]any, []any:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,94 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/strategy"
+)
+
+// ErrNoMarkPriceKlines is returned by adapters that cannot serve mark-price
+// klines for a symbol, e.g. on spot markets.
+var ErrNoMarkPriceKlines = errors.New("mark price klines not supported")
+
+// MarkPriceKlineSource is implemented by adapters that serve klines built from
+// the mark price instead of traded prices.
+type MarkPriceKlineSource interface {
+	GetMarkPriceKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+}
+
+// KlineGetter is the kline part of Exchange.
+type KlineGetter interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+}
+
+// markFallbacks remembers which symbols already logged a mark-price fallback.
+var markFallbacks sync.Map
+
+// GetKlinesFrom fetches klines from the given price source (config
+// KlineSourceLast or KlineSourceMark; empty means last). Mark-price klines
+// fall back to last-price klines when the adapter does not serve them; the
+// fallback is logged once per symbol and the source actually used is
+// returned.
+func GetKlinesFrom(ctx context.Context, src KlineGetter, source, symbol, interval string, limit int) ([]strategy.Candle, string, error) {
+	if !strings.EqualFold(source, config.KlineSourceMark) {
+		candles, err := src.GetKlines(ctx, symbol, interval, limit)
+		return candles, config.KlineSourceLast, err
+	}
+	if mark, ok := src.(MarkPriceKlineSource); ok {
+		candles, err := mark.GetMarkPriceKlines(ctx, symbol, interval, limit)
+		if !errors.Is(err, ErrNoMarkPriceKlines) {
+			return candles, config.KlineSourceMark, err
+		}
+	}
+	if _, logged := markFallbacks.LoadOrStore(symbol, true); !logged {
+		loggerpkg.Get("exchange").Printf("klines.mark_fallback symbol=%s source=last", symbol)
+	}
+	candles, err := src.GetKlines(ctx, symbol, interval, limit)
+	return candles, config.KlineSourceLast, err
+}
+
+// WithKlineSource returns a KlineGetter whose GetKlines reads from source,
+// so code that only knows GetKlines (shadow checks, backtests fed from the
+// exchange) follows a trader's klineSource. Last-price sources return src
+// unchanged.
+func WithKlineSource(src KlineGetter, source string) KlineGetter {
+	if !strings.EqualFold(source, config.KlineSourceMark) {
+		return src
+	}
+	return sourcedKlines{src: src, source: source}
+}
+
+type sourcedKlines struct {
+	src    KlineGetter
+	source string
+}
+
+func (s sourcedKlines) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	candles, _, err := GetKlinesFrom(ctx, s.src, s.source, symbol, interval, limit)
+	return candles, err
+}
+
+// GetTimeframes fetches limit klines of interval and of every extra interval
+// from the given price source, keyed by interval, for
+// strategy.EvaluateTimeframes. Any failed interval fails the whole fetch so
+// a strategy never sees a partial set.
+func GetTimeframes(ctx context.Context, src KlineGetter, source, symbol, interval string, extra []string, limit int) (strategy.Timeframes, error) {
+	frames := make(strategy.Timeframes, len(extra)+1)
+	for _, tf := range append([]string{interval}, extra...) {
+		if _, ok := frames[tf]; ok {
+			continue
+		}
+		candles, _, err := GetKlinesFrom(ctx, src, source, symbol, tf, limit)
+		if err != nil {
+			return nil, fmt.Errorf("klines %s %s: %w", symbol, tf, err)
+		}
+		frames[tf] = candles
+	}
+	return frames, nil
+}
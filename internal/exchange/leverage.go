@@ -0,0 +1,17 @@
+package exchange
+
+import "context"
+
+// LeverageSource is implemented by adapters that report the highest leverage
+// a symbol allows. On tiered venues this is the first (smallest notional)
+// bracket, so larger positions may be capped lower.
+type LeverageSource interface {
+	GetMaxLeverage(ctx context.Context, symbol string) (float64, error)
+}
+
+// FeeRateSource is implemented by adapters that report the account's actual
+// commission for a symbol, which can differ from the venue-wide schedule.
+// Rates are percentages (0.05 = 0.05%).
+type FeeRateSource interface {
+	GetFeeRates(ctx context.Context, symbol string) (makerPercent, takerPercent float64, err error)
+}
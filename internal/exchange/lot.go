@@ -0,0 +1,87 @@
+package exchange
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LotRules are a symbol's order increments in base-asset units. Zero fields
+// mean the venue imposes no such constraint. Leverage is always set in whole
+// steps on the supported venues.
+type LotRules struct {
+	Symbol      string
+	StepSize    float64
+	MinQty      float64
+	MaxQty      float64
+	MinNotional float64
+	TickSize    float64
+}
+
+// LotRulesSource is implemented by adapters that expose lot and tick sizes.
+type LotRulesSource interface {
+	GetLotRules(ctx context.Context, symbol string) (LotRules, error)
+}
+
+// FloorQuantity rounds q down to the lot step and caps it at MaxQty, so the
+// result never exceeds the input.
+func (r LotRules) FloorQuantity(q float64) float64 {
+	if r.MaxQty > 0 && q > r.MaxQty {
+		q = r.MaxQty
+	}
+	if r.StepSize <= 0 {
+		return q
+	}
+	// The epsilon absorbs float error such as 0.3/0.1 = 2.9999999999999996.
+	decimals := StepDecimals(r.StepSize)
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(math.Floor(q/r.StepSize+1e-9)*r.StepSize*factor) / factor
+}
+
+// CloseChunks splits a close of q into order quantities that each respect
+// MaxQty and the lot step, so a position larger than MaxQty is closed with
+// several orders instead of being left partly open. A remainder below one step
+// cannot be ordered and is dropped.
+func (r LotRules) CloseChunks(q float64) []float64 {
+	var chunks []float64
+	for q > 0 {
+		chunk := math.Min(r.FloorQuantity(q), q)
+		if chunk <= 0 {
+			break
+		}
+		chunks = append(chunks, chunk)
+		if r.StepSize <= 0 {
+			if r.MaxQty <= 0 || chunk < r.MaxQty {
+				break
+			}
+			q -= chunk
+			continue
+		}
+		// Flooring to the step's decimals drops float error and sub-step dust.
+		factor := math.Pow(10, float64(StepDecimals(r.StepSize)))
+		q = math.Floor((q-chunk)*factor+1e-6) / factor
+	}
+	return chunks
+}
+
+// Tradable reports whether q at price meets the minimum quantity and notional.
+func (r LotRules) Tradable(q, price float64) bool {
+	if q <= 0 || q+1e-12 < r.MinQty {
+		return false
+	}
+	return r.MinNotional <= 0 || price <= 0 || q*price+1e-9 >= r.MinNotional
+}
+
+// StepDecimals returns the number of decimals implied by an increment such as
+// 0.001; non-positive steps report 8.
+func StepDecimals(step float64) int {
+	if step <= 0 {
+		return 8
+	}
+	text := strconv.FormatFloat(step, 'f', -1, 64)
+	if idx := strings.IndexByte(text, '.'); idx >= 0 {
+		return len(text) - idx - 1
+	}
+	return 0
+}
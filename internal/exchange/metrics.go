@@ -0,0 +1,41 @@
+package exchange
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"autobot/internal/metrics"
+)
+
+var (
+	requestsTotal   = metrics.NewCounter("exchange_requests_total", "HTTP requests sent to the exchange.", "exchange", "method", "status")
+	requestDuration = metrics.NewHistogram("exchange_request_duration_seconds", "Exchange HTTP request latency.", nil, "exchange", "method")
+)
+
+type metricsTransport struct {
+	venue string
+	base  http.RoundTripper
+}
+
+// NewMetricsTransport wraps base so every request is counted by status and
+// timed in the exchange request metrics. Status is the HTTP code, or
+// "error" when no response was received.
+func NewMetricsTransport(venue string, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &metricsTransport{venue: venue, base: base}
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	requestDuration.Observe(time.Since(start).Seconds(), t.venue, req.Method)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	requestsTotal.Inc(t.venue, req.Method, status)
+	return resp, err
+}
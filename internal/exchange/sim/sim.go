@@ -0,0 +1,424 @@
+// Package sim implements a paper-trading exchange.Exchange. Market data is
+// read from a live adapter; orders are filled against the latest kline price
+// with configurable slippage and fees, and positions, balance and PnL are
+// tracked in memory.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/strategy"
+)
+
+// Options tune the simulator.
+type Options struct {
+	InitialBalance  float64
+	SlippagePercent float64
+	FeePercent      float64
+	Leverage        float64
+}
+
+// Exchange wraps a live adapter and simulates the trading endpoints.
+type Exchange struct {
+	data   exchange.Exchange
+	opts   Options
+	logger *loggerpkg.ModuleLogger
+
+	mu        sync.Mutex
+	balance   float64
+	positions map[positionKey]*position
+	triggers  []trigger
+	checked   map[string]time.Time // open time of the last 1m candle replayed per symbol
+	nextID    int64
+}
+
+type positionKey struct {
+	symbol string
+	side   exchange.PositionSide
+}
+
+type position struct {
+	quantity   float64 // signed: positive long, negative short
+	entryPrice float64
+	updated    time.Time
+}
+
+// trigger is a resting STOP_MARKET / TAKE_PROFIT_MARKET order.
+type trigger struct {
+	id      int64
+	req     exchange.OrderRequest
+	created time.Time
+}
+
+var _ exchange.Exchange = (*Exchange)(nil)
+
+// New returns a simulator reading prices from data.
+func New(data exchange.Exchange, opts Options) *Exchange {
+	if opts.InitialBalance <= 0 {
+		opts.InitialBalance = 10000
+	}
+	if opts.Leverage <= 0 {
+		opts.Leverage = 1
+	}
+	return &Exchange{
+		data:      data,
+		opts:      opts,
+		logger:    loggerpkg.Get("exchange.sim"),
+		balance:   opts.InitialBalance,
+		positions: make(map[positionKey]*position),
+		checked:   make(map[string]time.Time),
+	}
+}
+
+// Name identifies the simulated venue, e.g. "sim:binance".
+func (e *Exchange) Name() string {
+	return "sim:" + e.data.Name()
+}
+
+func (e *Exchange) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	return e.data.GetKlines(ctx, symbol, interval, limit)
+}
+
+// GetMarkPriceKlines forwards to the data venue when it serves mark-price klines.
+func (e *Exchange) GetMarkPriceKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	mark, ok := e.data.(exchange.MarkPriceKlineSource)
+	if !ok {
+		return nil, exchange.ErrNoMarkPriceKlines
+	}
+	return mark.GetMarkPriceKlines(ctx, symbol, interval, limit)
+}
+
+func (e *Exchange) GetFundingRate(ctx context.Context, symbol string) (float64, error) {
+	return e.data.GetFundingRate(ctx, symbol)
+}
+
+func (e *Exchange) GetOpenInterest(ctx context.Context, symbol string) (float64, error) {
+	return e.data.GetOpenInterest(ctx, symbol)
+}
+
+func (e *Exchange) Get24hTicker(ctx context.Context, symbol string) (exchange.Ticker24h, error) {
+	return e.data.Get24hTicker(ctx, symbol)
+}
+
+// GetPositions returns simulated open positions marked to the latest price.
+func (e *Exchange) GetPositions(ctx context.Context, symbol string) ([]exchange.PositionRisk, error) {
+	marks, err := e.refresh(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []exchange.PositionRisk
+	for key, pos := range e.positions {
+		if symbol != "" && key.symbol != symbol {
+			continue
+		}
+		mark := marks[key.symbol]
+		result = append(result, exchange.PositionRisk{
+			Symbol:        key.symbol,
+			PositionSide:  key.side,
+			Quantity:      pos.quantity,
+			EntryPrice:    pos.entryPrice,
+			MarkPrice:     mark,
+			Leverage:      e.opts.Leverage,
+			UnrealizedPNL: unrealized(pos, mark),
+			UpdateTime:    pos.updated,
+		})
+	}
+	return result, nil
+}
+
+// GetAccountInfo reports the simulated wallet; margin is notional / leverage.
+func (e *Exchange) GetAccountInfo(ctx context.Context) (exchange.AccountInfo, error) {
+	marks, err := e.refresh(ctx, "")
+	if err != nil {
+		return exchange.AccountInfo{}, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pnl, margin := 0.0, 0.0
+	for key, pos := range e.positions {
+		mark := marks[key.symbol]
+		pnl += unrealized(pos, mark)
+		margin += math.Abs(pos.quantity) * mark / e.opts.Leverage
+	}
+	return exchange.AccountInfo{
+		TotalWalletBalance: e.balance,
+		AvailableBalance:   e.balance + pnl - margin,
+		CrossUnrealizedPNL: pnl,
+		LastUpdate:         time.Now(),
+	}, nil
+}
+
+// PlaceOrder fills market orders (and marketable limit orders) immediately at
+// the latest price plus slippage; stop and take-profit orders rest until the
+// price crosses their trigger.
+func (e *Exchange) PlaceOrder(ctx context.Context, req exchange.OrderRequest) (exchange.OrderResponse, error) {
+	if req.Quantity <= 0 {
+		return exchange.OrderResponse{}, fmt.Errorf("sim: quantity must be positive")
+	}
+	if _, err := e.refresh(ctx, req.Symbol); err != nil {
+		return exchange.OrderResponse{}, err
+	}
+	candle, err := e.lastCandle(ctx, req.Symbol)
+	if err != nil {
+		return exchange.OrderResponse{}, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	id := e.nextID
+	now := time.Now()
+
+	switch req.Type {
+	case exchange.OrderTypeStopMarket, exchange.OrderTypeTakeProfitMarket:
+		if req.StopPrice <= 0 {
+			return exchange.OrderResponse{}, fmt.Errorf("sim: %s requires a stop price", req.Type)
+		}
+		e.triggers = append(e.triggers, trigger{id: id, req: req, created: now})
+		e.logger.Printf("order.resting id=%d symbol=%s side=%s type=%s stop=%.6f qty=%.6f", id, req.Symbol, req.Side, req.Type, req.StopPrice, req.Quantity)
+		return response(req.Symbol, id, now, 0, 0, "NEW"), nil
+	case exchange.OrderTypeLimit:
+		marketable := (req.Side == exchange.OrderSideBuy && req.Price >= candle.Close) ||
+			(req.Side == exchange.OrderSideSell && req.Price <= candle.Close)
+		if !marketable {
+			return exchange.OrderResponse{}, fmt.Errorf("sim: resting limit orders are not supported")
+		}
+	}
+
+	price := e.slipped(candle.Close, req.Side)
+	if req.Type == exchange.OrderTypeLimit {
+		// a marketable limit never fills worse than its limit
+		if req.Side == exchange.OrderSideBuy {
+			price = math.Min(price, req.Price)
+		} else {
+			price = math.Max(price, req.Price)
+		}
+	}
+	qty, err := e.fillLocked(req, price, now)
+	if err != nil {
+		return exchange.OrderResponse{}, err
+	}
+	return response(req.Symbol, id, now, price, qty, "FILLED"), nil
+}
+
+// refresh fires resting triggers against the candles since the last check
+// and returns the latest close per symbol with open positions.
+func (e *Exchange) refresh(ctx context.Context, symbol string) (map[string]float64, error) {
+	e.mu.Lock()
+	symbols := map[string]bool{}
+	for key := range e.positions {
+		symbols[key.symbol] = true
+	}
+	for _, t := range e.triggers {
+		symbols[t.req.Symbol] = true
+	}
+	e.mu.Unlock()
+	if symbol != "" {
+		symbols[symbol] = true
+	}
+
+	marks := make(map[string]float64, len(symbols))
+	for sym := range symbols {
+		candles, err := e.candlesSince(ctx, sym)
+		if err != nil {
+			return nil, err
+		}
+		last := candles[len(candles)-1]
+		marks[sym] = last.Close
+		e.mu.Lock()
+		// Replay in order so a wick between two checks still fires its trigger.
+		for _, candle := range candles {
+			e.fireTriggersLocked(sym, candle)
+		}
+		if last.OpenTime.After(e.checked[sym]) {
+			e.checked[sym] = last.OpenTime
+		}
+		e.mu.Unlock()
+	}
+	return marks, nil
+}
+
+// maxReplayCandles caps how many 1m candles one refresh replays after a long gap.
+const maxReplayCandles = 1000
+
+// candlesSince returns the 1m candles from the last replayed one (inclusive,
+// since it may have still been forming) to the latest. The first check of a
+// symbol only returns the latest candle.
+func (e *Exchange) candlesSince(ctx context.Context, symbol string) ([]strategy.Candle, error) {
+	e.mu.Lock()
+	since, ok := e.checked[symbol]
+	e.mu.Unlock()
+	if !ok {
+		candle, err := e.lastCandle(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return []strategy.Candle{candle}, nil
+	}
+
+	limit := int(time.Since(since)/time.Minute) + 2
+	if limit > maxReplayCandles {
+		limit = maxReplayCandles
+	}
+	candles, err := e.data.GetKlines(ctx, symbol, "1m", limit)
+	if err != nil {
+		return nil, fmt.Errorf("sim price %s: %w", symbol, err)
+	}
+	for len(candles) > 1 && candles[0].OpenTime.Before(since) {
+		candles = candles[1:]
+	}
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("sim price %s: no candles", symbol)
+	}
+	return candles, nil
+}
+
+func (e *Exchange) lastCandle(ctx context.Context, symbol string) (strategy.Candle, error) {
+	candles, err := e.data.GetKlines(ctx, symbol, "1m", 1)
+	if err != nil {
+		return strategy.Candle{}, fmt.Errorf("sim price %s: %w", symbol, err)
+	}
+	if len(candles) == 0 {
+		return strategy.Candle{}, fmt.Errorf("sim price %s: no candles", symbol)
+	}
+	return candles[len(candles)-1], nil
+}
+
+// fireTriggersLocked fills resting orders whose stop price was touched by the
+// given 1m candle. Triggers fill at the stop price plus slippage.
+func (e *Exchange) fireTriggersLocked(symbol string, candle strategy.Candle) {
+	remaining := e.triggers[:0]
+	for _, t := range e.triggers {
+		if t.req.Symbol != symbol || !crossed(t, candle) {
+			remaining = append(remaining, t)
+			continue
+		}
+		req := t.req
+		req.ReduceOnly = true
+		price := e.slipped(req.StopPrice, req.Side)
+		if qty, err := e.fillLocked(req, price, time.Now()); err != nil || qty == 0 {
+			e.logger.Printf("trigger.skipped id=%d symbol=%s err=%v", t.id, symbol, err)
+			continue
+		}
+		e.logger.Printf("trigger.filled id=%d symbol=%s type=%s price=%.6f", t.id, symbol, req.Type, price)
+	}
+	e.triggers = remaining
+}
+
+// crossed reports whether the trigger's stop price was touched. If the candle
+// opened before the order was placed only its close counts, since the earlier
+// part of its range predates the order.
+func crossed(t trigger, candle strategy.Candle) bool {
+	if candle.OpenTime.Before(t.created) {
+		candle.High, candle.Low = candle.Close, candle.Close
+	}
+	stop := t.req.StopPrice
+	sellStop := t.req.Side == exchange.OrderSideSell
+	switch t.req.Type {
+	case exchange.OrderTypeStopMarket:
+		if sellStop {
+			return candle.Low <= stop
+		}
+		return candle.High >= stop
+	default: // take profit
+		if sellStop {
+			return candle.High >= stop
+		}
+		return candle.Low <= stop
+	}
+}
+
+// fillLocked applies a fill to the position book and balance, returning the
+// executed quantity. Reduce-only orders never open or flip a position.
+func (e *Exchange) fillLocked(req exchange.OrderRequest, price float64, now time.Time) (float64, error) {
+	side := req.PositionSide
+	if side == "" {
+		side = exchange.PositionSideBoth
+	}
+	key := positionKey{symbol: req.Symbol, side: side}
+	pos := e.positions[key]
+	if pos == nil {
+		pos = &position{}
+	}
+
+	delta := req.Quantity
+	if req.Side == exchange.OrderSideSell {
+		delta = -delta
+	}
+	reducing := pos.quantity != 0 && (pos.quantity > 0) != (delta > 0)
+	if req.ReduceOnly || (side != exchange.PositionSideBoth && reducing) {
+		if !reducing {
+			return 0, fmt.Errorf("sim: reduce-only order would increase position")
+		}
+		if math.Abs(delta) > math.Abs(pos.quantity) {
+			delta = -pos.quantity
+		}
+	}
+
+	fee := math.Abs(delta) * price * e.opts.FeePercent / 100
+	e.balance -= fee
+	if reducing {
+		closed := math.Min(math.Abs(delta), math.Abs(pos.quantity))
+		pnl := closed * (price - pos.entryPrice)
+		if pos.quantity < 0 {
+			pnl = -pnl
+		}
+		e.balance += pnl
+		e.logger.Printf("fill.close symbol=%s side=%s qty=%.6f price=%.6f pnl=%.4f fee=%.4f", req.Symbol, req.Side, closed, price, pnl, fee)
+	} else {
+		e.logger.Printf("fill.open symbol=%s side=%s qty=%.6f price=%.6f fee=%.4f", req.Symbol, req.Side, math.Abs(delta), price, fee)
+	}
+
+	next := pos.quantity + delta
+	switch {
+	case math.Abs(next) < 1e-12:
+		delete(e.positions, key)
+		return math.Abs(delta), nil
+	case !reducing:
+		pos.entryPrice = (pos.entryPrice*math.Abs(pos.quantity) + price*math.Abs(delta)) / math.Abs(next)
+	case (next > 0) != (pos.quantity > 0):
+		// flipped through zero: the remainder opens at the fill price
+		pos.entryPrice = price
+	}
+	pos.quantity = next
+	pos.updated = now
+	e.positions[key] = pos
+	return math.Abs(delta), nil
+}
+
+func (e *Exchange) slipped(price float64, side exchange.OrderSide) float64 {
+	slip := price * e.opts.SlippagePercent / 100
+	if side == exchange.OrderSideBuy {
+		return price + slip
+	}
+	return price - slip
+}
+
+func unrealized(pos *position, mark float64) float64 {
+	if mark <= 0 {
+		return 0
+	}
+	return pos.quantity * (mark - pos.entryPrice)
+}
+
+func response(symbol string, id int64, at time.Time, price, qty float64, status string) exchange.OrderResponse {
+	return exchange.OrderResponse{
+		Symbol:        symbol,
+		OrderID:       id,
+		ClientOrderID: "sim-" + strconv.FormatInt(id, 10),
+		TransactTime:  at.UnixMilli(),
+		AvgPrice:      strconv.FormatFloat(price, 'f', -1, 64),
+		ExecutedQty:   strconv.FormatFloat(qty, 'f', -1, 64),
+		Status:        status,
+		UpdateTime:    at,
+	}
+}
@@ -0,0 +1,85 @@
+package sim
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"autobot/internal/exchange"
+	"autobot/internal/strategy"
+)
+
+// feed serves a scripted 1m candle series; other calls are not used by the tests.
+type feed struct {
+	exchange.Exchange
+	mu      sync.Mutex
+	candles []strategy.Candle
+}
+
+func (f *feed) Name() string { return "feed" }
+
+func (f *feed) GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if limit > len(f.candles) {
+		limit = len(f.candles)
+	}
+	return append([]strategy.Candle(nil), f.candles[len(f.candles)-limit:]...), nil
+}
+
+func (f *feed) push(candles ...strategy.Candle) {
+	f.mu.Lock()
+	f.candles = append(f.candles, candles...)
+	f.mu.Unlock()
+}
+
+func bar(at time.Time, low, close float64) strategy.Candle {
+	return strategy.Candle{OpenTime: at, Open: close, High: close, Low: low, Close: close}
+}
+
+func TestTriggerFiresOnWickBetweenChecks(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	data := &feed{}
+	data.push(bar(now.Add(-2*time.Minute), 100, 100))
+	ex := New(data, Options{InitialBalance: 1000})
+
+	if _, err := ex.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeMarket, Quantity: 1}); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := ex.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeStopMarket, StopPrice: 95, Quantity: 1}); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	// The wick to 90 is in the middle candle; the latest candle never goes below 99.
+	data.push(bar(now.Add(time.Minute), 90, 100), bar(now.Add(2*time.Minute), 99, 100))
+	positions, err := ex.GetPositions(ctx, "BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 0 {
+		t.Fatalf("position still open after wick through stop: %+v", positions)
+	}
+}
+
+func TestTriggerIgnoresCandlesBeforeLastCheck(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	data := &feed{}
+	// An old wick below the stop must not fire an order placed afterwards.
+	data.push(bar(now.Add(-3*time.Minute), 90, 100), bar(now.Add(-2*time.Minute), 100, 100))
+	ex := New(data, Options{InitialBalance: 1000})
+
+	ex.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.OrderSideBuy, Type: exchange.OrderTypeMarket, Quantity: 1})
+	ex.PlaceOrder(ctx, exchange.OrderRequest{Symbol: "BTCUSDT", Side: exchange.OrderSideSell, Type: exchange.OrderTypeStopMarket, StopPrice: 95, Quantity: 1})
+	data.push(bar(now.Add(time.Minute), 99, 100))
+
+	positions, err := ex.GetPositions(ctx, "BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 1 {
+		t.Fatalf("positions = %+v, want the long still open", positions)
+	}
+}
@@ -0,0 +1,175 @@
+// Package execution 评估实际成交质量。
+package execution
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/storage"
+)
+
+// Fill 为一笔实际成交。Side 为 BUY 或 SELL。
+type Fill struct {
+	Trader   string
+	Symbol   string
+	Side     string
+	Price    float64
+	Quantity float64
+	Time     time.Time
+}
+
+// VWAPFunc 返回某交易对在某UTC自然日的会话VWAP。
+type VWAPFunc func(symbol string, day time.Time) (float64, error)
+
+// Benchmark 汇总某交易对某日全部成交相对会话VWAP的执行成本。
+// ShortfallBps 为按名义金额加权的执行差（基点），正数表示比VWAP成交更差；
+// CostUSD 为对应的金额。
+type Benchmark struct {
+	Symbol       string
+	Day          time.Time
+	Fills        int
+	Quantity     float64
+	Notional     float64
+	AvgBuyPrice  float64
+	AvgSellPrice float64
+	VWAP         float64
+	ShortfallBps float64
+	CostUSD      float64
+	Err          error
+}
+
+// Evaluate 按交易对与UTC自然日分组计算执行差，结果按日期、交易对排序。
+func Evaluate(fills []Fill, vwap VWAPFunc) []Benchmark {
+	type groupKey struct {
+		symbol string
+		day    time.Time
+	}
+	groups := make(map[groupKey][]Fill)
+	for _, fill := range fills {
+		if fill.Price <= 0 || fill.Quantity <= 0 {
+			continue
+		}
+		t := fill.Time.UTC()
+		key := groupKey{symbol: fill.Symbol, day: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+		groups[key] = append(groups[key], fill)
+	}
+
+	results := make([]Benchmark, 0, len(groups))
+	for key, group := range groups {
+		bench := Benchmark{Symbol: key.symbol, Day: key.day, Fills: len(group)}
+		price, err := vwap(key.symbol, key.day)
+		if err != nil || price <= 0 {
+			if err == nil {
+				err = fmt.Errorf("no vwap for %s on %s", key.symbol, key.day.Format("2006-01-02"))
+			}
+			bench.Err = err
+			results = append(results, bench)
+			continue
+		}
+		bench.VWAP = price
+		buyQty, buyNotional, sellQty, sellNotional := 0.0, 0.0, 0.0, 0.0
+		for _, fill := range group {
+			bench.Quantity += fill.Quantity
+			bench.Notional += fill.Price * fill.Quantity
+			// 买入高于VWAP、卖出低于VWAP均为成本
+			diff := fill.Price - price
+			if fill.Side == "BUY" {
+				buyQty += fill.Quantity
+				buyNotional += fill.Price * fill.Quantity
+			} else {
+				diff = -diff
+				sellQty += fill.Quantity
+				sellNotional += fill.Price * fill.Quantity
+			}
+			bench.CostUSD += diff * fill.Quantity
+		}
+		if buyQty > 0 {
+			bench.AvgBuyPrice = buyNotional / buyQty
+		}
+		if sellQty > 0 {
+			bench.AvgSellPrice = sellNotional / sellQty
+		}
+		bench.ShortfallBps = bench.CostUSD / (price * bench.Quantity) * 1e4
+		results = append(results, bench)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if !results[i].Day.Equal(results[j].Day) {
+			return results[i].Day.Before(results[j].Day)
+		}
+		return results[i].Symbol < results[j].Symbol
+	})
+	return results
+}
+
+// FillsFromTrades 将交易记录转换为成交。Side 为 BUY/SELL 时直接使用，
+// 否则按 long/short 与 Action 中的 open/close 推断买卖方向。
+func FillsFromTrades(records []storage.TradeRecord) []Fill {
+	fills := make([]Fill, 0, len(records))
+	for _, record := range records {
+		side, ok := fillSide(record)
+		if !ok {
+			continue
+		}
+		fills = append(fills, Fill{
+			Trader:   record.Trader,
+			Symbol:   record.Symbol,
+			Side:     side,
+			Price:    record.Price,
+			Quantity: record.Quantity,
+			Time:     time.UnixMilli(record.CreatedAt),
+		})
+	}
+	return fills
+}
+
+func fillSide(record storage.TradeRecord) (string, bool) {
+	side := strings.ToUpper(strings.TrimSpace(record.Side))
+	switch side {
+	case "BUY", "SELL":
+		return side, true
+	}
+	closing := strings.Contains(strings.ToLower(record.Action), "close")
+	switch side {
+	case "LONG":
+		if closing {
+			return "SELL", true
+		}
+		return "BUY", true
+	case "SHORT":
+		if closing {
+			return "BUY", true
+		}
+		return "SELL", true
+	}
+	return "", false
+}
+
+// WriteReport 输出执行基准报告，末行为全部成交的加权合计。
+func WriteReport(w io.Writer, results []Benchmark) {
+	fmt.Fprintf(w, "%-10s %-12s %5s %14s %14s %14s %14s %10s %12s\n", "日期", "交易对", "笔数", "成交额", "买均价", "卖均价", "会话VWAP", "执行差bps", "成本USD")
+	totalCost, totalNotional := 0.0, 0.0
+	for _, r := range results {
+		day := r.Day.Format("2006-01-02")
+		if r.Err != nil {
+			fmt.Fprintf(w, "%-10s %-12s %5d  错误: %v\n", day, r.Symbol, r.Fills, r.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%-10s %-12s %5d %14.2f %14s %14s %14.4f %+10.2f %+12.4f\n",
+			day, r.Symbol, r.Fills, r.Notional, optionalPrice(r.AvgBuyPrice), optionalPrice(r.AvgSellPrice), r.VWAP, r.ShortfallBps, r.CostUSD)
+		totalCost += r.CostUSD
+		totalNotional += r.VWAP * r.Quantity
+	}
+	if totalNotional > 0 {
+		fmt.Fprintf(w, "合计: 执行差 %+.2f bps，成本 %+.4f USD\n", totalCost/totalNotional*1e4, totalCost)
+	}
+}
+
+func optionalPrice(p float64) string {
+	if p == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", p)
+}
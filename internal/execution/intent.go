@@ -0,0 +1,336 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"autobot/internal/eventlog"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/storage"
+)
+
+// 下单意图的状态。pending 可被撤销；executing 已开始向交易所下单，不能再撤销。
+const (
+	IntentPending   = "pending"
+	IntentExecuting = "executing"
+	IntentFilled    = "filled"
+	IntentCancelled = "cancelled"
+	IntentFailed    = "failed"
+)
+
+// intentTransitions 为状态机允许的迁移，终态没有出边。
+var intentTransitions = map[string][]string{
+	IntentPending:   {IntentExecuting, IntentCancelled},
+	IntentExecuting: {IntentFilled, IntentFailed},
+}
+
+// 意图状态机的错误。
+var (
+	ErrIntentNotFound  = errors.New("下单意图不存在")
+	ErrIntentCancelled = errors.New("下单意图已被撤销")
+)
+
+// maxFinishedIntents 为保留展示的已结束意图条数。
+const maxFinishedIntents = 10
+
+// Intent 为决策之后、成交之前的一次下单意图。Side 为 long、short 或 close；TargetPrice 为决策时的参考价；
+// ExecuteAt 之前意图保持 pending，操作者可在此期间撤销；Note 记录撤销或失败的原因；
+// Origin 为下单来源，空为AI/策略，storage.OriginOperator 为操作者手动下单。
+type Intent struct {
+	ID          string    `json:"id"`
+	Trader      string    `json:"trader"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	Quantity    float64   `json:"quantity"`
+	TargetPrice float64   `json:"targetPrice"`
+	State       string    `json:"state"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExecuteAt   time.Time `json:"executeAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Origin      string    `json:"origin,omitempty"`
+}
+
+// Active 表示意图尚未结束（pending 或 executing）。
+func (i Intent) Active() bool {
+	return i.State == IntentPending || i.State == IntentExecuting
+}
+
+// IntentQueue 保存各交易者的下单意图并按状态机推进：交易程序 Submit 后以 Await 等待暂留期结束，
+// 通过后下单并以 Finish 记录结果；操作者在此之前可 Cancel。每次变化调用 OnChange 注册的回调。
+type IntentQueue struct {
+	mu       sync.Mutex
+	seq      int
+	intents  map[string]*Intent
+	finished []string
+	changed  map[string]chan struct{}
+	onChange []func([]Intent)
+	recorder storage.EventRecorder
+	logger   *loggerpkg.ModuleLogger
+	now      func() time.Time
+}
+
+// NewIntentQueue 创建空的意图队列。
+func NewIntentQueue() *IntentQueue {
+	return &IntentQueue{
+		intents: make(map[string]*Intent),
+		changed: make(map[string]chan struct{}),
+		logger:  loggerpkg.Get("execution"),
+		now:     time.Now,
+	}
+}
+
+// OnChange 注册状态变化回调，参数为 List 的结果；回调在锁外调用。
+func (q *IntentQueue) OnChange(fn func([]Intent)) {
+	q.mu.Lock()
+	q.onChange = append(q.onChange, fn)
+	q.mu.Unlock()
+}
+
+// SetRecorder 让 Cancel 把每次撤销作为 eventlog.KindManual 事件写入 rec（通常为 storage.Store）。
+func (q *IntentQueue) SetRecorder(rec storage.EventRecorder) {
+	q.mu.Lock()
+	q.recorder = rec
+	q.mu.Unlock()
+}
+
+// Submit 登记一个 pending 意图，hold 为撤销窗口（0 表示可立即执行），返回带ID的意图。
+func (q *IntentQueue) Submit(intent Intent, hold time.Duration) Intent {
+	q.mu.Lock()
+	q.seq++
+	now := q.now()
+	intent.ID = strconv.Itoa(q.seq)
+	intent.State = IntentPending
+	intent.Note = ""
+	intent.CreatedAt, intent.UpdatedAt = now, now
+	intent.ExecuteAt = now.Add(hold)
+	stored := intent
+	q.intents[intent.ID] = &stored
+	q.changed[intent.ID] = make(chan struct{})
+	q.mu.Unlock()
+
+	q.logger.Printf("intent.submit id=%s trader=%s symbol=%s side=%s qty=%g target=%g hold=%s",
+		intent.ID, intent.Trader, intent.Symbol, intent.Side, intent.Quantity, intent.TargetPrice, hold)
+	q.notify()
+	return intent
+}
+
+// Cancel 撤销 pending 意图；已开始执行或已结束的意图返回错误。
+func (q *IntentQueue) Cancel(id, reason string) error {
+	if reason == "" {
+		reason = "操作者撤销"
+	}
+	if err := q.transition(id, IntentCancelled, reason); err != nil {
+		return err
+	}
+	q.logger.Printf("intent.cancel id=%s reason=%q", id, reason)
+
+	q.mu.Lock()
+	recorder := q.recorder
+	intent, ok := q.intents[id]
+	if ok {
+		copied := *intent
+		intent = &copied
+	}
+	q.mu.Unlock()
+	if recorder != nil && ok {
+		action := eventlog.ManualAction{Action: "intent.cancel", Symbol: intent.Symbol, Note: fmt.Sprintf("id=%s side=%s qty=%g %s", id, intent.Side, intent.Quantity, reason)}
+		if err := recorder.RecordEvent(context.Background(), eventlog.KindManual, intent.Trader, action); err != nil {
+			q.logger.Printf("intent.cancel.record_failed id=%s err=%v", id, err)
+		}
+	}
+	return nil
+}
+
+// Await 等待意图的撤销窗口结束并将其置为 executing，之后调用方即可下单。窗口内被撤销时返回
+// ErrIntentCancelled；ctx 结束时意图按撤销结束并返回 ctx 的错误。
+func (q *IntentQueue) Await(ctx context.Context, id string) error {
+	for {
+		q.mu.Lock()
+		intent, ok := q.intents[id]
+		if !ok {
+			q.mu.Unlock()
+			return ErrIntentNotFound
+		}
+		state, wait, changed := intent.State, intent.ExecuteAt.Sub(q.now()), q.changed[id]
+		q.mu.Unlock()
+
+		switch {
+		case state == IntentCancelled:
+			return ErrIntentCancelled
+		case state != IntentPending:
+			return fmt.Errorf("下单意图 %s 状态为 %s，不能执行", id, state)
+		case wait <= 0:
+			if err := q.transition(id, IntentExecuting, ""); err != nil {
+				// 与撤销竞争失败，下一轮返回撤销
+				continue
+			}
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			_ = q.transition(id, IntentCancelled, "交易程序退出")
+			return ctx.Err()
+		case <-changed:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// Execute 走完一笔下单的完整流程：Submit 登记意图，Await 等待撤销窗口，通过后调用 place 下单，
+// 再以 Finish 记录结果。窗口内被撤销时不调用 place，返回 ErrIntentCancelled。所有下单路径都应
+// 经由 Execute，q 为 nil 时直接调用 place，便于未启用意图队列的调用方共用同一路径。
+func (q *IntentQueue) Execute(ctx context.Context, intent Intent, hold time.Duration, place func(ctx context.Context) error) (Intent, error) {
+	if q == nil {
+		return intent, place(ctx)
+	}
+	intent = q.Submit(intent, hold)
+	if err := q.Await(ctx, intent.ID); err != nil {
+		if current, ok := q.Get(intent.ID); ok {
+			intent = current
+		}
+		return intent, err
+	}
+	err := place(ctx)
+	if ferr := q.Finish(intent.ID, err); ferr != nil {
+		q.logger.Printf("intent.finish_failed id=%s err=%v", intent.ID, ferr)
+	}
+	if current, ok := q.Get(intent.ID); ok {
+		intent = current
+	}
+	return intent, err
+}
+
+// Finish 记录 executing 意图的下单结果：err 为空时为 filled，否则为 failed。
+func (q *IntentQueue) Finish(id string, err error) error {
+	state, note := IntentFilled, ""
+	if err != nil {
+		state, note = IntentFailed, err.Error()
+	}
+	if terr := q.transition(id, state, note); terr != nil {
+		return terr
+	}
+	q.logger.Printf("intent.%s id=%s note=%q", state, id, note)
+	return nil
+}
+
+// Get 返回意图的当前状态。
+func (q *IntentQueue) Get(id string) (Intent, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	intent, ok := q.intents[id]
+	if !ok {
+		return Intent{}, false
+	}
+	return *intent, true
+}
+
+// List 返回未结束的意图（按创建先后）及最近结束的若干条（最新在前）。
+func (q *IntentQueue) List() []Intent {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.listLocked()
+}
+
+func (q *IntentQueue) listLocked() []Intent {
+	var active []Intent
+	for _, intent := range q.intents {
+		if intent.Active() {
+			active = append(active, *intent)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].CreatedAt.Before(active[j].CreatedAt) })
+	for i := len(q.finished) - 1; i >= 0; i-- {
+		if intent, ok := q.intents[q.finished[i]]; ok {
+			active = append(active, *intent)
+		}
+	}
+	return active
+}
+
+// transition 按状态机推进意图，唤醒等待者并通知回调；结束的意图只保留最近 maxFinishedIntents 条。
+func (q *IntentQueue) transition(id, state, note string) error {
+	q.mu.Lock()
+	intent, ok := q.intents[id]
+	if !ok {
+		q.mu.Unlock()
+		return ErrIntentNotFound
+	}
+	allowed := false
+	for _, next := range intentTransitions[intent.State] {
+		allowed = allowed || next == state
+	}
+	if !allowed {
+		current := intent.State
+		q.mu.Unlock()
+		if current == IntentCancelled {
+			return ErrIntentCancelled
+		}
+		return fmt.Errorf("下单意图 %s 不能从 %s 变为 %s", id, current, state)
+	}
+	intent.State, intent.UpdatedAt = state, q.now()
+	if note != "" {
+		intent.Note = note
+	}
+	if ch, ok := q.changed[id]; ok {
+		close(ch)
+		q.changed[id] = make(chan struct{})
+	}
+	if !intent.Active() {
+		delete(q.changed, id)
+		q.finished = append(q.finished, id)
+		if len(q.finished) > maxFinishedIntents {
+			delete(q.intents, q.finished[0])
+			q.finished = q.finished[1:]
+		}
+	}
+	q.mu.Unlock()
+	q.notify()
+	return nil
+}
+
+func (q *IntentQueue) notify() {
+	q.mu.Lock()
+	callbacks := append([]func([]Intent){}, q.onChange...)
+	list := q.listLocked()
+	q.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(list)
+	}
+}
+
+// Handler 提供意图查询与撤销接口：GET 返回 List 的结果；POST 带 id（可选 reason）撤销该意图，
+// 意图已开始执行或已结束时返回 409。参数取自查询串或表单。
+func (q *IntentQueue) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			err := q.Cancel(r.FormValue("id"), r.FormValue("reason"))
+			switch {
+			case errors.Is(err, ErrIntentNotFound):
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			case err != nil:
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(q.List())
+	})
+}
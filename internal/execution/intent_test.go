@@ -0,0 +1,195 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"autobot/internal/eventlog"
+)
+
+func TestIntentLifecycle(t *testing.T) {
+	q := NewIntentQueue()
+	var changes int
+	q.OnChange(func([]Intent) { changes++ })
+
+	intent := q.Submit(Intent{Trader: "alpha", Symbol: "BTCUSDT", Side: "long", Quantity: 1}, 0)
+	if intent.ID == "" || intent.State != IntentPending {
+		t.Fatalf("submitted intent = %+v", intent)
+	}
+	if err := q.Await(context.Background(), intent.ID); err != nil {
+		t.Fatalf("await: %v", err)
+	}
+	if got, _ := q.Get(intent.ID); got.State != IntentExecuting {
+		t.Fatalf("state after await = %s", got.State)
+	}
+	if err := q.Cancel(intent.ID, ""); err == nil {
+		t.Fatal("cancelled an executing intent")
+	}
+	if err := q.Finish(intent.ID, nil); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if got, _ := q.Get(intent.ID); got.State != IntentFilled || got.Active() {
+		t.Fatalf("final intent = %+v", got)
+	}
+	if err := q.Finish(intent.ID, errors.New("again")); err == nil {
+		t.Fatal("finished an intent twice")
+	}
+	if changes != 3 {
+		t.Fatalf("OnChange called %d times, want 3", changes)
+	}
+}
+
+func TestIntentFinishFailure(t *testing.T) {
+	q := NewIntentQueue()
+	intent := q.Submit(Intent{Symbol: "ETHUSDT"}, 0)
+	if err := q.Await(context.Background(), intent.ID); err != nil {
+		t.Fatal(err)
+	}
+	q.Finish(intent.ID, errors.New("insufficient margin"))
+	got, _ := q.Get(intent.ID)
+	if got.State != IntentFailed || got.Note != "insufficient margin" {
+		t.Fatalf("intent = %+v", got)
+	}
+}
+
+func TestIntentCancelDuringHold(t *testing.T) {
+	q := NewIntentQueue()
+	intent := q.Submit(Intent{Symbol: "BTCUSDT"}, time.Hour)
+
+	done := make(chan error, 1)
+	go func() { done <- q.Await(context.Background(), intent.ID) }()
+	if err := q.Cancel(intent.ID, "too risky"); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrIntentCancelled) {
+			t.Fatalf("await err = %v, want ErrIntentCancelled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("await did not return after cancel")
+	}
+	if got, _ := q.Get(intent.ID); got.State != IntentCancelled || got.Note != "too risky" {
+		t.Fatalf("intent = %+v", got)
+	}
+}
+
+func TestIntentAwaitContextDone(t *testing.T) {
+	q := NewIntentQueue()
+	intent := q.Submit(Intent{Symbol: "BTCUSDT"}, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := q.Await(ctx, intent.ID); !errors.Is(err, context.Canceled) {
+		t.Fatalf("await err = %v", err)
+	}
+	if got, _ := q.Get(intent.ID); got.State != IntentCancelled {
+		t.Fatalf("state = %s, want cancelled", got.State)
+	}
+}
+
+func TestIntentListKeepsRecentFinished(t *testing.T) {
+	q := NewIntentQueue()
+	for i := 0; i < maxFinishedIntents+3; i++ {
+		intent := q.Submit(Intent{Symbol: "BTCUSDT"}, time.Hour)
+		q.Cancel(intent.ID, "")
+	}
+	pending := q.Submit(Intent{Symbol: "ETHUSDT"}, time.Hour)
+
+	list := q.List()
+	if len(list) != maxFinishedIntents+1 {
+		t.Fatalf("list has %d intents, want %d", len(list), maxFinishedIntents+1)
+	}
+	if list[0].ID != pending.ID {
+		t.Fatalf("first intent = %s, want the pending one", list[0].ID)
+	}
+	if _, ok := q.Get("1"); ok {
+		t.Fatal("oldest finished intent was not evicted")
+	}
+}
+
+func TestIntentHandler(t *testing.T) {
+	q := NewIntentQueue()
+	intent := q.Submit(Intent{Symbol: "BTCUSDT"}, time.Hour)
+	handler := q.Handler()
+
+	cases := []struct {
+		id   string
+		want int
+	}{
+		{"missing", http.StatusNotFound},
+		{intent.ID, http.StatusOK},
+		{intent.ID, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/intents", strings.NewReader("id="+tc.id))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("POST id=%s: status %d, want %d", tc.id, rec.Code, tc.want)
+		}
+	}
+}
+
+type eventSink struct {
+	kinds   []string
+	traders []string
+}
+
+func (s *eventSink) RecordEvent(ctx context.Context, kind, trader string, data any) error {
+	s.kinds = append(s.kinds, kind)
+	s.traders = append(s.traders, trader)
+	return nil
+}
+
+func TestIntentCancelRecordsManualEvent(t *testing.T) {
+	q := NewIntentQueue()
+	sink := &eventSink{}
+	q.SetRecorder(sink)
+	intent := q.Submit(Intent{Trader: "alpha", Symbol: "BTCUSDT"}, time.Hour)
+	if err := q.Cancel(intent.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.kinds) != 1 || sink.kinds[0] != eventlog.KindManual || sink.traders[0] != "alpha" {
+		t.Fatalf("recorded %v %v", sink.kinds, sink.traders)
+	}
+}
+
+func TestIntentExecute(t *testing.T) {
+	q := NewIntentQueue()
+	placed := 0
+	place := func(context.Context) error { placed++; return nil }
+
+	intent, err := q.Execute(context.Background(), Intent{Symbol: "BTCUSDT"}, 0, place)
+	if err != nil || intent.State != IntentFilled || placed != 1 {
+		t.Fatalf("intent = %+v, err = %v, placed = %d", intent, err, placed)
+	}
+
+	intent, err = q.Execute(context.Background(), Intent{Symbol: "BTCUSDT"}, 0, func(context.Context) error { return errors.New("rejected") })
+	if err == nil || intent.State != IntentFailed {
+		t.Fatalf("failed order: intent = %+v, err = %v", intent, err)
+	}
+
+	// A cancel during the hold window skips the order.
+	q.OnChange(func(list []Intent) {
+		for _, i := range list {
+			if i.State == IntentPending && i.Symbol == "ETHUSDT" {
+				go q.Cancel(i.ID, "")
+			}
+		}
+	})
+	intent, err = q.Execute(context.Background(), Intent{Symbol: "ETHUSDT"}, time.Hour, place)
+	if !errors.Is(err, ErrIntentCancelled) || intent.State != IntentCancelled || placed != 1 {
+		t.Fatalf("cancelled: intent = %+v, err = %v, placed = %d", intent, err, placed)
+	}
+
+	var nilQueue *IntentQueue
+	if _, err := nilQueue.Execute(context.Background(), Intent{}, time.Hour, place); err != nil || placed != 2 {
+		t.Fatalf("nil queue: err = %v, placed = %d", err, placed)
+	}
+}
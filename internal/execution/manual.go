@@ -0,0 +1,173 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/eventlog"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/risk"
+	"autobot/internal/storage"
+)
+
+// ManualOrder 为操作者在终端表单中填写的开仓单。Side 为 long 或 short；StopLoss/TakeProfit 为价格，0 表示不设。
+type ManualOrder struct {
+	Trader     string  `json:"trader"`
+	Symbol     string  `json:"symbol"`
+	Side       string  `json:"side"`
+	Quantity   float64 `json:"quantity"`
+	StopLoss   float64 `json:"stopLoss,omitempty"`
+	TakeProfit float64 `json:"takeProfit,omitempty"`
+}
+
+// Validate 按参考价 price 检查手动单：数量为正，止损/止盈位于开仓价的正确一侧，
+// maxNotional 大于0时名义价值不得超过它。
+func (o ManualOrder) Validate(price, maxNotional float64) error {
+	switch {
+	case strings.TrimSpace(o.Symbol) == "":
+		return errors.New("手动下单缺少交易对")
+	case o.Side != "long" && o.Side != "short":
+		return fmt.Errorf("手动下单方向需为 long 或 short，当前为 %q", o.Side)
+	case o.Quantity <= 0:
+		return errors.New("手动下单数量需大于0")
+	case price <= 0:
+		return fmt.Errorf("%s 没有有效的参考价", o.Symbol)
+	}
+	long := o.Side == "long"
+	if o.StopLoss > 0 && (long && o.StopLoss >= price || !long && o.StopLoss <= price) {
+		return fmt.Errorf("止损价 %g 位于 %s 开仓价 %g 的错误一侧", o.StopLoss, o.Side, price)
+	}
+	if o.TakeProfit > 0 && (long && o.TakeProfit <= price || !long && o.TakeProfit >= price) {
+		return fmt.Errorf("止盈价 %g 位于 %s 开仓价 %g 的错误一侧", o.TakeProfit, o.Side, price)
+	}
+	if notional := o.Quantity * price; maxNotional > 0 && notional > maxNotional {
+		return fmt.Errorf("名义价值 %.2f 超过手动下单上限 %.2f", notional, maxNotional)
+	}
+	return nil
+}
+
+// Intent 返回该手动单对应的下单意图，Origin 标记为操作者。
+func (o ManualOrder) Intent(price float64) Intent {
+	return Intent{
+		Trader:      o.Trader,
+		Symbol:      o.Symbol,
+		Side:        o.Side,
+		Quantity:    o.Quantity,
+		TargetPrice: price,
+		Origin:      storage.OriginOperator,
+	}
+}
+
+// TradeRecord 返回手动单成交后写入存储的记录，Origin 标记为操作者，Notes 记录止损止盈。
+func (o ManualOrder) TradeRecord(price float64, at time.Time) storage.TradeRecord {
+	return storage.TradeRecord{
+		Trader:    o.Trader,
+		Symbol:    o.Symbol,
+		Side:      o.Side,
+		Quantity:  o.Quantity,
+		Price:     price,
+		Action:    "open_" + o.Side,
+		Notes:     fmt.Sprintf("manual sl=%g tp=%g", o.StopLoss, o.TakeProfit),
+		CreatedAt: at.UnixMilli(),
+		Origin:    storage.OriginOperator,
+	}
+}
+
+// ManualTrader 为手动单所属交易者的下单依赖，与该交易者的AI开仓共用。
+type ManualTrader struct {
+	// Gate 为交易者的风控闸门，nil 放行。
+	Gate *risk.Gate
+	// Hold 为撤销窗口，通常为 settings.intentHoldSeconds。
+	Hold time.Duration
+	// Market 返回 symbol 的最新行情快照及账户当前有持仓的交易对。
+	Market func(ctx context.Context, symbol string) (ai.MarketDataSnapshot, []string, error)
+	// Place 按参考价 price 下单并挂止损止盈，应与AI开仓使用同一下单实现。
+	Place func(ctx context.Context, order ManualOrder, price float64) error
+}
+
+// ManualRecorder 保存手动单的成交与事件；storage.Store 满足该接口。
+type ManualRecorder interface {
+	RecordTrade(ctx context.Context, record storage.TradeRecord) error
+	RecordEvent(ctx context.Context, kind, trader string, data any) error
+}
+
+// ManualDesk 执行终端表单确认的手动单，Submit 可直接作为看板 RunManualOrders 的回调：
+// 按最新价校验（含 MaxNotional 名义价值上限）→ 风控闸门 → 经 Queue.Execute 登记意图、等待撤销窗口并下单 →
+// 以 OriginOperator 写入成交。每笔手动单无论成败都作为 eventlog.KindManual 事件写入 Recorder。
+type ManualDesk struct {
+	MaxNotional float64
+	Traders     map[string]ManualTrader
+	Queue       *IntentQueue
+	Recorder    ManualRecorder
+
+	now func() time.Time
+}
+
+// NewManualDesk 创建手动下单入口，maxNotional 通常为 dashboard.manualMaxNotional；queue、rec 可为 nil。
+func NewManualDesk(maxNotional float64, traders map[string]ManualTrader, queue *IntentQueue, rec ManualRecorder) *ManualDesk {
+	return &ManualDesk{MaxNotional: maxNotional, Traders: traders, Queue: queue, Recorder: rec, now: time.Now}
+}
+
+// Submit 执行一笔手动单，任一步失败即返回错误且不再继续。
+func (d *ManualDesk) Submit(ctx context.Context, order ManualOrder) (err error) {
+	trader, ok := d.Traders[order.Trader]
+	if !ok || trader.Market == nil || trader.Place == nil {
+		return fmt.Errorf("交易者 %q 未接入手动下单", order.Trader)
+	}
+	price := 0.0
+	defer func() { d.record(ctx, order, price, err) }()
+
+	snapshot, openSymbols, err := trader.Market(ctx, order.Symbol)
+	if err != nil {
+		return fmt.Errorf("获取 %s 行情: %w", order.Symbol, err)
+	}
+	price = snapshot.CurrentPrice
+	if err := order.Validate(price, d.MaxNotional); err != nil {
+		return err
+	}
+	entry := risk.Entry{
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		Price:       price,
+		TargetPrice: order.TakeProfit,
+		Snapshot:    snapshot,
+		OpenSymbols: openSymbols,
+		Time:        d.now(),
+	}
+	if err := trader.Gate.CheckEntry(entry); err != nil {
+		return err
+	}
+	if _, err := d.Queue.Execute(ctx, order.Intent(price), trader.Hold, func(ctx context.Context) error {
+		return trader.Place(ctx, order, price)
+	}); err != nil {
+		return err
+	}
+	if d.Recorder == nil {
+		return nil
+	}
+	return d.Recorder.RecordTrade(ctx, order.TradeRecord(price, d.now()))
+}
+
+// record 把一笔手动单的内容与结果写入事件日志，写入失败只记日志，不影响下单结果。
+func (d *ManualDesk) record(ctx context.Context, order ManualOrder, price float64, err error) {
+	if d.Recorder == nil {
+		return
+	}
+	result := "filled"
+	if err != nil {
+		result = "rejected: " + err.Error()
+	}
+	action := eventlog.ManualAction{
+		Action: "manual.order",
+		Symbol: order.Symbol,
+		Note: fmt.Sprintf("side=%s qty=%g price=%g sl=%g tp=%g %s",
+			order.Side, order.Quantity, price, order.StopLoss, order.TakeProfit, result),
+	}
+	if recErr := d.Recorder.RecordEvent(ctx, eventlog.KindManual, order.Trader, action); recErr != nil {
+		loggerpkg.Get("execution").Printf("manual.order.record_failed trader=%s symbol=%s err=%v", order.Trader, order.Symbol, recErr)
+	}
+}
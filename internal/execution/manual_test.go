@@ -0,0 +1,92 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	"autobot/internal/eventlog"
+	"autobot/internal/risk"
+	"autobot/internal/storage"
+)
+
+type manualSink struct {
+	trades []storage.TradeRecord
+	events []eventlog.ManualAction
+}
+
+func (s *manualSink) RecordTrade(ctx context.Context, record storage.TradeRecord) error {
+	s.trades = append(s.trades, record)
+	return nil
+}
+
+func (s *manualSink) RecordEvent(ctx context.Context, kind, trader string, data any) error {
+	if action, ok := data.(eventlog.ManualAction); ok && kind == eventlog.KindManual {
+		s.events = append(s.events, action)
+	}
+	return nil
+}
+
+func newTestDesk(gate *risk.Gate, placed *int) (*ManualDesk, *manualSink, *IntentQueue) {
+	sink := &manualSink{}
+	queue := NewIntentQueue()
+	trader := ManualTrader{
+		Gate: gate,
+		Market: func(ctx context.Context, symbol string) (ai.MarketDataSnapshot, []string, error) {
+			return ai.MarketDataSnapshot{CurrentPrice: 100}, []string{"ETHUSDT"}, nil
+		},
+		Place: func(ctx context.Context, order ManualOrder, price float64) error {
+			*placed++
+			return nil
+		},
+	}
+	return NewManualDesk(500, map[string]ManualTrader{"alpha": trader}, queue, sink), sink, queue
+}
+
+func TestManualDeskSubmit(t *testing.T) {
+	placed := 0
+	desk, sink, queue := newTestDesk(nil, &placed)
+	order := ManualOrder{Trader: "alpha", Symbol: "BTCUSDT", Side: "long", Quantity: 2, StopLoss: 95}
+	if err := desk.Submit(context.Background(), order); err != nil {
+		t.Fatal(err)
+	}
+	if placed != 1 || len(sink.trades) != 1 || sink.trades[0].Origin != storage.OriginOperator || sink.trades[0].Price != 100 {
+		t.Fatalf("placed = %d, trades = %+v", placed, sink.trades)
+	}
+	intents := queue.List()
+	if len(intents) != 1 || intents[0].State != IntentFilled || intents[0].Origin != storage.OriginOperator {
+		t.Fatalf("intents = %+v", intents)
+	}
+	if len(sink.events) != 1 || sink.events[0].Action != "manual.order" || !strings.HasSuffix(sink.events[0].Note, "filled") {
+		t.Fatalf("events = %+v", sink.events)
+	}
+}
+
+func TestManualDeskRejects(t *testing.T) {
+	placed := 0
+	desk, sink, _ := newTestDesk(nil, &placed)
+	// 名义价值 10 × 100 超过上限 500
+	if err := desk.Submit(context.Background(), ManualOrder{Trader: "alpha", Symbol: "BTCUSDT", Side: "long", Quantity: 10}); err == nil {
+		t.Fatal("order above manualMaxNotional was accepted")
+	}
+
+	gate := risk.NewGate(config.RiskConfig{MaxMajorPositions: 1}, config.FeeSchedule{})
+	gated, gatedSink, _ := newTestDesk(gate, &placed)
+	var rejection *risk.Rejection
+	if err := gated.Submit(context.Background(), ManualOrder{Trader: "alpha", Symbol: "BTCUSDT", Side: "short", Quantity: 1}); !errors.As(err, &rejection) {
+		t.Fatalf("gate err = %v", err)
+	}
+
+	if err := desk.Submit(context.Background(), ManualOrder{Trader: "nobody", Symbol: "BTCUSDT", Side: "long", Quantity: 1}); err == nil {
+		t.Fatal("order for unknown trader was accepted")
+	}
+	if placed != 0 || len(sink.trades) != 0 {
+		t.Fatalf("placed = %d, trades = %+v", placed, sink.trades)
+	}
+	if len(sink.events) != 1 || !strings.Contains(sink.events[0].Note, "rejected") || len(gatedSink.events) != 1 {
+		t.Fatalf("events = %+v / %+v", sink.events, gatedSink.events)
+	}
+}
@@ -0,0 +1,233 @@
+// Package failover 实现主备热切换：主实例定期在共享存储目录的租约文件中写入心跳，
+// 备用实例预先加载配置、连接交易所并预热行情，心跳超时或收到人工切换请求时接管交易；
+// 旧主实例发现租约被接管后立即停止交易，避免两个实例同时下单。
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// 实例角色。
+const (
+	RolePrimary = "primary"
+	RoleStandby = "standby"
+)
+
+// LeaseFile 返回存储目录下的租约文件路径。
+func LeaseFile(dir string) string {
+	return filepath.Join(dir, "failover.json")
+}
+
+// Lease 为租约文件内容。Holder 为当前持有交易权的实例，Epoch 每次换主加一；
+// PromoteTo 非空时为人工切换请求，持有者在下一次心跳时让出租约，由该实例接管。
+type Lease struct {
+	Holder     string    `json:"holder"`
+	Epoch      int64     `json:"epoch"`
+	Heartbeat  time.Time `json:"heartbeat"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	PromoteTo  string    `json:"promoteTo,omitempty"`
+	Released   bool      `json:"released,omitempty"`
+}
+
+// Expired 报告租约在 now 时是否已无人持有：已让出，或心跳超过 timeout 未更新。
+func (l Lease) Expired(now time.Time, timeout time.Duration) bool {
+	return l.Holder == "" || l.Released || now.Sub(l.Heartbeat) > timeout
+}
+
+// ReadLease 读取租约文件，文件不存在时返回空租约。
+func ReadLease(path string) (Lease, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Lease{}, nil
+	}
+	if err != nil {
+		return Lease{}, err
+	}
+	var lease Lease
+	if err := json.Unmarshal(data, &lease); err != nil {
+		return Lease{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return lease, nil
+}
+
+func writeLease(path string, lease Lease) error {
+	data, err := json.MarshalIndent(lease, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Promote 写入人工切换请求：当前持有者在下一次心跳时让出租约，由 node 接管。
+func Promote(path, node string) (Lease, error) {
+	lease, err := ReadLease(path)
+	if err != nil {
+		return lease, err
+	}
+	if lease.Holder == node && !lease.Released {
+		return lease, fmt.Errorf("%s 已是主实例", node)
+	}
+	lease.PromoteTo = node
+	return lease, writeLease(path, lease)
+}
+
+// Node 为参与主备切换的一个实例。
+type Node struct {
+	Path      string
+	ID        string
+	Heartbeat time.Duration
+	Timeout   time.Duration
+}
+
+// Run 按角色运行直到 ctx 结束：持有租约期间调用 active 交易，active 的 ctx 在租约被接管时取消；
+// 未持有租约时每个心跳周期调用一次 warm（可为 nil）保持预热，并在租约超时或被点名切换时接管。
+// 主实例启动时若租约仍由其他存活实例持有，同样以备用身份等待，不会抢占。
+func (n Node) Run(ctx context.Context, role string, active func(ctx context.Context) error, warm func(ctx context.Context)) error {
+	logger := loggerpkg.Get("failover")
+	logger.Printf("failover.start node=%s role=%s heartbeat=%s timeout=%s", n.ID, role, n.Heartbeat, n.Timeout)
+	ticker := time.NewTicker(n.Heartbeat)
+	defer ticker.Stop()
+	for {
+		lease, acquired, err := n.tryAcquire(role, time.Now())
+		if err != nil {
+			logger.Printf("failover.lease_error node=%s err=%v", n.ID, err)
+		}
+		if acquired {
+			logger.Printf("failover.promoted node=%s epoch=%d", n.ID, lease.Epoch)
+			if err := n.lead(ctx, lease, active); err != nil && !errors.Is(err, context.Canceled) {
+				logger.Printf("failover.active_stopped node=%s err=%v", n.ID, err)
+			}
+			// 被接管或交易退出后只做备用，不再主动抢回
+			role = RoleStandby
+		} else if warm != nil {
+			warm(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			n.release()
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire 在租约可接管时写入自己为持有者：租约为空、已让出或心跳超时；primary 在租约原本由
+// 自己持有（重启）时直接接管。人工切换点名其他实例时让其接管，除非心跳已超过两倍超时（被点名的实例
+// 也不在线）。写入后再次读取确认，避免两个备用实例同时接管。
+func (n Node) tryAcquire(role string, now time.Time) (Lease, bool, error) {
+	lease, err := ReadLease(n.Path)
+	if err != nil {
+		return lease, false, err
+	}
+	switch {
+	case lease.PromoteTo != "" && lease.PromoteTo != n.ID && now.Sub(lease.Heartbeat) <= 2*n.Timeout:
+		return lease, false, nil
+	case lease.Holder == n.ID && role == RolePrimary:
+	case lease.Expired(now, n.Timeout):
+	default:
+		return lease, false, nil
+	}
+	next := Lease{Holder: n.ID, Epoch: lease.Epoch + 1, Heartbeat: now, AcquiredAt: now}
+	if err := writeLease(n.Path, next); err != nil {
+		return lease, false, err
+	}
+	// 给同时写入的其他实例留出覆盖的时间，再确认租约归属
+	time.Sleep(n.Heartbeat / 5)
+	confirmed, err := ReadLease(n.Path)
+	if err != nil {
+		return confirmed, false, err
+	}
+	return confirmed, confirmed.Holder == n.ID && confirmed.Epoch == next.Epoch, nil
+}
+
+// lead 持有租约期间运行 active 并按心跳续约；续约时发现租约被接管或收到切换请求即取消 active。
+func (n Node) lead(ctx context.Context, lease Lease, active func(ctx context.Context) error) error {
+	logger := loggerpkg.Get("failover")
+	activeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- active(activeCtx) }()
+
+	ticker := time.NewTicker(n.Heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			n.release()
+			return err
+		case <-ticker.C:
+		}
+		current, err := ReadLease(n.Path)
+		if err != nil {
+			if n.unreachable(lease, err) {
+				cancel()
+				return <-done
+			}
+			continue
+		}
+		switch {
+		case current.Holder != n.ID || current.Epoch != lease.Epoch:
+			logger.Printf("failover.demoted node=%s reason=taken_over holder=%s epoch=%d", n.ID, current.Holder, current.Epoch)
+			cancel()
+			return <-done
+		case current.PromoteTo != "" && current.PromoteTo != n.ID:
+			logger.Printf("failover.demoted node=%s reason=promote_request to=%s", n.ID, current.PromoteTo)
+			cancel()
+			err := <-done
+			current.Released = true
+			if werr := writeLease(n.Path, current); werr != nil {
+				logger.Printf("failover.release_failed node=%s err=%v", n.ID, werr)
+			}
+			return err
+		}
+		current.Heartbeat = time.Now()
+		current.PromoteTo = ""
+		if err := writeLease(n.Path, current); err != nil {
+			if n.unreachable(lease, err) {
+				cancel()
+				return <-done
+			}
+			continue
+		}
+		lease = current
+	}
+}
+
+// unreachable 记录一次续约失败，并报告距上次成功心跳是否已超过超时：此时备用实例可能已经接管，
+// 本实例无法确认仍持有租约，必须停止交易。
+func (n Node) unreachable(lease Lease, err error) bool {
+	logger := loggerpkg.Get("failover")
+	if time.Since(lease.Heartbeat) > n.Timeout {
+		logger.Printf("failover.demoted node=%s reason=storage_unreachable err=%v", n.ID, err)
+		return true
+	}
+	logger.Printf("failover.heartbeat_failed node=%s err=%v", n.ID, err)
+	return false
+}
+
+// release 在正常退出时让出仍由本实例持有的租约，使备用实例无需等待超时即可接管。
+func (n Node) release() {
+	lease, err := ReadLease(n.Path)
+	if err != nil || lease.Holder != n.ID || lease.Released {
+		return
+	}
+	lease.Released = true
+	if err := writeLease(n.Path, lease); err != nil {
+		loggerpkg.Get("failover").Printf("failover.release_failed node=%s err=%v", n.ID, err)
+	}
+}
@@ -0,0 +1,93 @@
+package failover
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testNode(t *testing.T, path, id string) Node {
+	t.Helper()
+	return Node{Path: path, ID: id, Heartbeat: 5 * time.Millisecond, Timeout: time.Minute}
+}
+
+func TestLeaseExpired(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name  string
+		lease Lease
+		want  bool
+	}{
+		{"empty", Lease{}, true},
+		{"released", Lease{Holder: "a", Heartbeat: now, Released: true}, true},
+		{"stale", Lease{Holder: "a", Heartbeat: now.Add(-2 * time.Minute)}, true},
+		{"live", Lease{Holder: "a", Heartbeat: now.Add(-30 * time.Second)}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.lease.Expired(now, time.Minute); got != tc.want {
+			t.Errorf("%s: Expired = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestReadLeaseMissingFile(t *testing.T) {
+	lease, err := ReadLease(filepath.Join(t.TempDir(), "failover.json"))
+	if err != nil || lease.Holder != "" {
+		t.Fatalf("lease = %+v, err = %v", lease, err)
+	}
+}
+
+func TestTryAcquire(t *testing.T) {
+	path := LeaseFile(t.TempDir())
+	a, b := testNode(t, path, "a"), testNode(t, path, "b")
+	now := time.Now()
+
+	lease, ok, err := a.tryAcquire(RolePrimary, now)
+	if err != nil || !ok || lease.Holder != "a" || lease.Epoch != 1 {
+		t.Fatalf("first acquire: lease = %+v, ok = %v, err = %v", lease, ok, err)
+	}
+
+	// A live lease held by another node is not taken over.
+	if _, ok, _ := b.tryAcquire(RolePrimary, now.Add(30*time.Second)); ok {
+		t.Fatal("b took over a live lease")
+	}
+
+	// A primary restarting with its own lease takes it back at once.
+	lease, ok, err = a.tryAcquire(RolePrimary, now.Add(time.Second))
+	if err != nil || !ok || lease.Epoch != 2 {
+		t.Fatalf("primary restart: lease = %+v, ok = %v, err = %v", lease, ok, err)
+	}
+
+	// Once the heartbeat times out the standby takes over with a new epoch.
+	lease, ok, err = b.tryAcquire(RoleStandby, now.Add(2*time.Minute))
+	if err != nil || !ok || lease.Holder != "b" || lease.Epoch != 3 {
+		t.Fatalf("takeover: lease = %+v, ok = %v, err = %v", lease, ok, err)
+	}
+}
+
+func TestPromoteNamesNode(t *testing.T) {
+	path := LeaseFile(t.TempDir())
+	a, b, c := testNode(t, path, "a"), testNode(t, path, "b"), testNode(t, path, "c")
+	now := time.Now()
+	if _, ok, err := a.tryAcquire(RolePrimary, now); err != nil || !ok {
+		t.Fatalf("acquire: ok = %v, err = %v", ok, err)
+	}
+
+	if _, err := Promote(path, "a"); err == nil {
+		t.Fatal("promoting the current holder succeeded")
+	}
+	lease, err := Promote(path, "b")
+	if err != nil || lease.PromoteTo != "b" {
+		t.Fatalf("promote: lease = %+v, err = %v", lease, err)
+	}
+
+	// While the promote request is fresh only the named node may take over.
+	later := now.Add(90 * time.Second)
+	if _, ok, _ := c.tryAcquire(RoleStandby, later); ok {
+		t.Fatal("c took over a lease promoted to b")
+	}
+	lease, ok, err := b.tryAcquire(RoleStandby, later)
+	if err != nil || !ok || lease.Holder != "b" || lease.PromoteTo != "" {
+		t.Fatalf("b acquire: lease = %+v, ok = %v, err = %v", lease, ok, err)
+	}
+}
@@ -0,0 +1,73 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// ADX calculates Wilder's Average Directional Index together with the +DI and
+// −DI lines. DI values start at index period and ADX at index 2*period-1;
+// earlier values are zero.
+func ADX(highs, lows, closes []float64, period int) (adx, plusDI, minusDI []float64, err error) {
+	if period <= 0 {
+		return nil, nil, nil, errors.New("period must be positive")
+	}
+	if len(highs) != len(lows) || len(highs) != len(closes) {
+		return nil, nil, nil, errors.New("series lengths differ")
+	}
+	if len(closes) < 2*period {
+		return nil, nil, nil, errors.New("series length smaller than twice the period")
+	}
+
+	n := len(closes)
+	adx = make([]float64, n)
+	plusDI = make([]float64, n)
+	minusDI = make([]float64, n)
+	dx := make([]float64, n)
+
+	var tr, plusDM, minusDM float64
+	for i := 1; i < n; i++ {
+		up := highs[i] - highs[i-1]
+		down := lows[i-1] - lows[i]
+		pdm, mdm := 0.0, 0.0
+		if up > down && up > 0 {
+			pdm = up
+		}
+		if down > up && down > 0 {
+			mdm = down
+		}
+		t := trueRange(highs[i], lows[i], closes[i-1])
+
+		if i <= period {
+			tr += t
+			plusDM += pdm
+			minusDM += mdm
+			if i < period {
+				continue
+			}
+		} else {
+			tr = tr - tr/float64(period) + t
+			plusDM = plusDM - plusDM/float64(period) + pdm
+			minusDM = minusDM - minusDM/float64(period) + mdm
+		}
+
+		if tr > 0 {
+			plusDI[i] = 100 * plusDM / tr
+			minusDI[i] = 100 * minusDM / tr
+		}
+		if sum := plusDI[i] + minusDI[i]; sum > 0 {
+			dx[i] = 100 * math.Abs(plusDI[i]-minusDI[i]) / sum
+		}
+	}
+
+	first := 2*period - 1
+	sum := 0.0
+	for i := period; i <= first; i++ {
+		sum += dx[i]
+	}
+	adx[first] = sum / float64(period)
+	for i := first + 1; i < n; i++ {
+		adx[i] = (adx[i-1]*float64(period-1) + dx[i]) / float64(period)
+	}
+	return adx, plusDI, minusDI, nil
+}
@@ -0,0 +1,35 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// ATR calculates the Average True Range using Wilder's smoothing. Values
+// before the first full period are zero.
+func ATR(highs, lows, closes []float64, period int) ([]float64, error) {
+	if period <= 0 {
+		return nil, errors.New("period must be positive")
+	}
+	if len(highs) != len(lows) || len(highs) != len(closes) {
+		return nil, errors.New("series lengths differ")
+	}
+	if len(closes) < period+1 {
+		return nil, errors.New("series length smaller than period")
+	}
+
+	atr := make([]float64, len(closes))
+	sum := 0.0
+	for i := 1; i <= period; i++ {
+		sum += trueRange(highs[i], lows[i], closes[i-1])
+	}
+	atr[period] = sum / float64(period)
+	for i := period + 1; i < len(closes); i++ {
+		atr[i] = (atr[i-1]*float64(period-1) + trueRange(highs[i], lows[i], closes[i-1])) / float64(period)
+	}
+	return atr, nil
+}
+
+func trueRange(high, low, prevClose float64) float64 {
+	return math.Max(high-low, math.Max(math.Abs(high-prevClose), math.Abs(low-prevClose)))
+}
@@ -0,0 +1,40 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// Bollinger calculates Bollinger Bands: a simple moving average of `period`
+// closes with bands stddevs population standard deviations above and below
+// it. Values before the first full period are zero.
+func Bollinger(closes []float64, period int, stddevs float64) (middle, upper, lower []float64, err error) {
+	if period <= 0 {
+		return nil, nil, nil, errors.New("period must be positive")
+	}
+	if stddevs <= 0 {
+		return nil, nil, nil, errors.New("stddevs must be positive")
+	}
+	if len(closes) < period {
+		return nil, nil, nil, errors.New("series length smaller than period")
+	}
+
+	middle = make([]float64, len(closes))
+	upper = make([]float64, len(closes))
+	lower = make([]float64, len(closes))
+	for i := period - 1; i < len(closes); i++ {
+		window := closes[i-period+1 : i+1]
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= float64(period)
+		variance := 0.0
+		for _, v := range window {
+			variance += (v - mean) * (v - mean)
+		}
+		dev := math.Sqrt(variance/float64(period)) * stddevs
+		middle[i], upper[i], lower[i] = mean, mean+dev, mean-dev
+	}
+	return middle, upper, lower, nil
+}
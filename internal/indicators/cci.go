@@ -0,0 +1,48 @@
+package indicators
+
+import (
+	"errors"
+	"math"
+)
+
+// cciConstant scales CCI so roughly 70-80% of values fall within ±100.
+const cciConstant = 0.015
+
+// CCI calculates the Commodity Channel Index: the distance of the typical
+// price (high+low+close)/3 from its `period` simple moving average, divided by
+// 0.015 × the mean absolute deviation over the same window. Values before the
+// first full period, and bars with zero deviation, are zero.
+func CCI(highs, lows, closes []float64, period int) ([]float64, error) {
+	if period <= 0 {
+		return nil, errors.New("period must be positive")
+	}
+	if len(highs) != len(lows) || len(highs) != len(closes) {
+		return nil, errors.New("series lengths differ")
+	}
+	if len(closes) < period {
+		return nil, errors.New("series length smaller than period")
+	}
+
+	typical := make([]float64, len(closes))
+	for i := range closes {
+		typical[i] = typicalPrice(highs[i], lows[i], closes[i])
+	}
+	cci := make([]float64, len(closes))
+	for i := period - 1; i < len(closes); i++ {
+		window := typical[i-period+1 : i+1]
+		mean := 0.0
+		for _, v := range window {
+			mean += v
+		}
+		mean /= float64(period)
+		deviation := 0.0
+		for _, v := range window {
+			deviation += math.Abs(v - mean)
+		}
+		deviation /= float64(period)
+		if deviation > 0 {
+			cci[i] = (typical[i] - mean) / (cciConstant * deviation)
+		}
+	}
+	return cci, nil
+}
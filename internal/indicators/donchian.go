@@ -0,0 +1,33 @@
+package indicators
+
+import "errors"
+
+// Donchian returns the highest high and lowest low of the `period` bars
+// ending at each index (inclusive). Values before the first full period are zero.
+func Donchian(highs, lows []float64, period int) (upper, lower []float64, err error) {
+	if period <= 0 {
+		return nil, nil, errors.New("period must be positive")
+	}
+	if len(highs) != len(lows) {
+		return nil, nil, errors.New("series lengths differ")
+	}
+	if len(highs) < period {
+		return nil, nil, errors.New("series length smaller than period")
+	}
+
+	upper = make([]float64, len(highs))
+	lower = make([]float64, len(lows))
+	for i := period - 1; i < len(highs); i++ {
+		hi, lo := highs[i], lows[i]
+		for j := i - period + 1; j < i; j++ {
+			if highs[j] > hi {
+				hi = highs[j]
+			}
+			if lows[j] < lo {
+				lo = lows[j]
+			}
+		}
+		upper[i], lower[i] = hi, lo
+	}
+	return upper, lower, nil
+}
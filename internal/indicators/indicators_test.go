@@ -0,0 +1,151 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func assertSeries(t *testing.T, name string, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: len %d, want %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("%s[%d] = %v, want NaN", name, i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > epsilon {
+			t.Errorf("%s[%d] = %v, want %v", name, i, got[i], want[i])
+		}
+	}
+}
+
+func TestEMA(t *testing.T) {
+	got, err := EMA([]float64{1, 2, 3, 4, 5}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "ema", got, []float64{math.NaN(), math.NaN(), 2, 3, 4})
+
+	if _, err := EMA([]float64{1, 2}, 3); err == nil {
+		t.Error("short series accepted")
+	}
+	if _, err := EMA([]float64{1, 2}, 0); err == nil {
+		t.Error("zero period accepted")
+	}
+}
+
+func TestRSI(t *testing.T) {
+	got, err := RSI([]float64{1, 2, 3, 2, 3}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "rsi", got, []float64{math.NaN(), math.NaN(), 100, 50, 75})
+
+	if _, err := RSI([]float64{1, 2}, 2); err == nil {
+		t.Error("series without period+1 values accepted")
+	}
+}
+
+func TestATR(t *testing.T) {
+	highs := []float64{10, 11, 12, 14}
+	lows := []float64{9, 10, 11, 12}
+	closes := []float64{9.5, 10.5, 11.5, 13}
+	got, err := ATR(highs, lows, closes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "atr", got, []float64{0, 0, 1.5, 2})
+
+	if _, err := ATR(highs, lows[:3], closes, 2); err == nil {
+		t.Error("mismatched series accepted")
+	}
+}
+
+func TestDonchian(t *testing.T) {
+	upper, lower, err := Donchian([]float64{1, 3, 2, 5, 4}, []float64{0, 2, 1, 1, 3}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "upper", upper, []float64{0, 0, 3, 5, 5})
+	assertSeries(t, "lower", lower, []float64{0, 0, 0, 1, 1})
+}
+
+func TestADX(t *testing.T) {
+	highs := []float64{2, 3, 4, 5}
+	lows := []float64{1, 2, 3, 4}
+	closes := []float64{1.5, 2.5, 3.5, 4.5}
+	adx, plusDI, minusDI, err := ADX(highs, lows, closes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "adx", adx, []float64{0, 0, 0, 100})
+	assertSeries(t, "+di", plusDI, []float64{0, 0, 200.0 / 3, 200.0 / 3})
+	assertSeries(t, "-di", minusDI, []float64{0, 0, 0, 0})
+
+	if _, _, _, err := ADX(highs[:3], lows[:3], closes[:3], 2); err == nil {
+		t.Error("series shorter than twice the period accepted")
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	middle, upper, lower, err := Bollinger([]float64{1, 2, 3}, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev := 2 * math.Sqrt(2.0/3)
+	assertSeries(t, "middle", middle, []float64{0, 0, 2})
+	assertSeries(t, "upper", upper, []float64{0, 0, 2 + dev})
+	assertSeries(t, "lower", lower, []float64{0, 0, 2 - dev})
+}
+
+func TestOBV(t *testing.T) {
+	got, err := OBV([]float64{1, 2, 2, 1}, []float64{10, 20, 30, 40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "obv", got, []float64{0, 20, 20, -20})
+}
+
+func TestVWAP(t *testing.T) {
+	prices := []float64{10, 20, 30}
+	volumes := []float64{1, 1, 2}
+	got, err := VWAP(prices, prices, prices, volumes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "vwap", got, []float64{0, 15, 80.0 / 3})
+
+	anchored, err := AnchoredVWAP(prices, prices, prices, volumes, []bool{false, true, false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "anchored", anchored, []float64{0, 20, 80.0 / 3})
+}
+
+func TestCCI(t *testing.T) {
+	prices := []float64{1, 2, 3}
+	got, err := CCI(prices, prices, prices, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSeries(t, "cci", got, []float64{0, 0, 100})
+
+	flat := []float64{5, 5, 5}
+	if got, _ := CCI(flat, flat, flat, 3); got[2] != 0 {
+		t.Errorf("cci of a flat series = %v, want 0", got[2])
+	}
+}
+
+func TestClassicPivots(t *testing.T) {
+	got := ClassicPivots(12, 8, 10)
+	want := Pivots{P: 10, R1: 12, R2: 14, R3: 16, S1: 8, S2: 6, S3: 4}
+	if got != want {
+		t.Fatalf("pivots = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+package indicators
+
+import "errors"
+
+// Keltner calculates Keltner Channels: an EMA(emaPeriod) of the close as the
+// middle line with bands multiplier × ATR(atrPeriod) above and below it.
+// Values before both averages are warmed up are zero.
+func Keltner(highs, lows, closes []float64, emaPeriod, atrPeriod int, multiplier float64) (middle, upper, lower []float64, err error) {
+	if multiplier <= 0 {
+		return nil, nil, nil, errors.New("multiplier must be positive")
+	}
+	atr, err := ATR(highs, lows, closes, atrPeriod)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ema, err := EMA(closes, emaPeriod)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	start := atrPeriod
+	if emaPeriod-1 > start {
+		start = emaPeriod - 1
+	}
+	middle = make([]float64, len(closes))
+	upper = make([]float64, len(closes))
+	lower = make([]float64, len(closes))
+	for i := start; i < len(closes); i++ {
+		middle[i] = ema[i]
+		upper[i] = ema[i] + multiplier*atr[i]
+		lower[i] = ema[i] - multiplier*atr[i]
+	}
+	return middle, upper, lower, nil
+}
+
+// Squeeze reports, per bar, whether the Bollinger Bands lie entirely inside
+// the Keltner Channels, i.e. volatility has contracted below its usual range
+// and a breakout setup is forming; the squeeze "fires" on the first bar it
+// turns false. Bars where either indicator is not yet warmed up are false.
+func Squeeze(bbUpper, bbLower, kcUpper, kcLower []float64) ([]bool, error) {
+	if len(bbUpper) != len(bbLower) || len(bbUpper) != len(kcUpper) || len(bbUpper) != len(kcLower) {
+		return nil, errors.New("series lengths differ")
+	}
+	on := make([]bool, len(bbUpper))
+	for i := range bbUpper {
+		if bbUpper[i] == 0 || kcUpper[i] == 0 {
+			continue
+		}
+		on[i] = bbUpper[i] < kcUpper[i] && bbLower[i] > kcLower[i]
+	}
+	return on, nil
+}
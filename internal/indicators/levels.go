@@ -0,0 +1,107 @@
+package indicators
+
+import (
+	"math"
+	"sort"
+)
+
+// Level is a support/resistance price. Strength grows with the number of
+// times price reacted near the level.
+type Level struct {
+	Name     string
+	Price    float64
+	Strength int
+}
+
+// Pivots holds classic floor-trader pivot levels.
+type Pivots struct {
+	P  float64
+	R1 float64
+	R2 float64
+	R3 float64
+	S1 float64
+	S2 float64
+	S3 float64
+}
+
+// ClassicPivots computes pivots from the previous session's high, low and close.
+func ClassicPivots(high, low, close float64) Pivots {
+	p := (high + low + close) / 3
+	return Pivots{
+		P:  p,
+		R1: 2*p - low,
+		S1: 2*p - high,
+		R2: p + (high - low),
+		S2: p - (high - low),
+		R3: high + 2*(p-low),
+		S3: low - 2*(high-p),
+	}
+}
+
+// Levels lists the pivots as levels. Pivots are widely watched, so the
+// central pivot and first two bands count as strength 2.
+func (p Pivots) Levels() []Level {
+	return []Level{
+		{Name: "P", Price: p.P, Strength: 2},
+		{Name: "R1", Price: p.R1, Strength: 2},
+		{Name: "R2", Price: p.R2, Strength: 2},
+		{Name: "R3", Price: p.R3, Strength: 1},
+		{Name: "S1", Price: p.S1, Strength: 2},
+		{Name: "S2", Price: p.S2, Strength: 2},
+		{Name: "S3", Price: p.S3, Strength: 1},
+	}
+}
+
+// SwingLevels finds swing highs and lows (a bar whose high/low is the extreme
+// of `strength` bars on each side) and clusters swings within tolerancePercent
+// of each other into one level whose Strength is the number of swings.
+func SwingLevels(highs, lows []float64, strength int, tolerancePercent float64) []Level {
+	if strength <= 0 || len(highs) != len(lows) || len(highs) < 2*strength+1 {
+		return nil
+	}
+	var swingHighs, swingLows []float64
+	for i := strength; i < len(highs)-strength; i++ {
+		isHigh, isLow := true, true
+		for j := i - strength; j <= i+strength; j++ {
+			if j == i {
+				continue
+			}
+			if highs[j] >= highs[i] {
+				isHigh = false
+			}
+			if lows[j] <= lows[i] {
+				isLow = false
+			}
+		}
+		if isHigh {
+			swingHighs = append(swingHighs, highs[i])
+		}
+		if isLow {
+			swingLows = append(swingLows, lows[i])
+		}
+	}
+	levels := clusterLevels("swing_high", swingHighs, tolerancePercent)
+	return append(levels, clusterLevels("swing_low", swingLows, tolerancePercent)...)
+}
+
+func clusterLevels(name string, prices []float64, tolerancePercent float64) []Level {
+	if len(prices) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), prices...)
+	sort.Float64s(sorted)
+
+	var levels []Level
+	sum, count := sorted[0], 1
+	for _, price := range sorted[1:] {
+		mean := sum / float64(count)
+		if math.Abs(price-mean)/mean*100 <= tolerancePercent {
+			sum += price
+			count++
+			continue
+		}
+		levels = append(levels, Level{Name: name, Price: mean, Strength: count})
+		sum, count = price, 1
+	}
+	return append(levels, Level{Name: name, Price: sum / float64(count), Strength: count})
+}
@@ -0,0 +1,28 @@
+package indicators
+
+import "errors"
+
+// OBV calculates On-Balance Volume: a running total that adds the bar's
+// volume when the close rises, subtracts it when the close falls and leaves
+// it unchanged otherwise. The series starts at zero.
+func OBV(closes, volumes []float64) ([]float64, error) {
+	if len(closes) != len(volumes) {
+		return nil, errors.New("series lengths differ")
+	}
+	if len(closes) == 0 {
+		return nil, errors.New("empty series")
+	}
+
+	obv := make([]float64, len(closes))
+	for i := 1; i < len(closes); i++ {
+		switch {
+		case closes[i] > closes[i-1]:
+			obv[i] = obv[i-1] + volumes[i]
+		case closes[i] < closes[i-1]:
+			obv[i] = obv[i-1] - volumes[i]
+		default:
+			obv[i] = obv[i-1]
+		}
+	}
+	return obv, nil
+}
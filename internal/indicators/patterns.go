@@ -0,0 +1,170 @@
+package indicators
+
+import "math"
+
+// OHLC is the price data needed for candlestick pattern detection.
+type OHLC struct {
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+// Pattern names reported by DetectPatterns.
+const (
+	PatternBullishEngulfing   = "bullish_engulfing"
+	PatternBearishEngulfing   = "bearish_engulfing"
+	PatternBullishPinBar      = "bullish_pin_bar"
+	PatternBearishPinBar      = "bearish_pin_bar"
+	PatternDojiCluster        = "doji_cluster"
+	PatternThreeWhiteSoldiers = "three_white_soldiers"
+	PatternThreeBlackCrows    = "three_black_crows"
+)
+
+// Pattern is a candlestick pattern completed on bar Index.
+// Bias is 1 for bullish, -1 for bearish and 0 for indecision.
+type Pattern struct {
+	Name  string
+	Bias  int
+	Index int
+}
+
+// DetectPatterns returns the patterns that complete on the last bar.
+func DetectPatterns(bars []OHLC) []Pattern {
+	if len(bars) == 0 {
+		return nil
+	}
+	last := len(bars) - 1
+	var found []Pattern
+	add := func(name string, bias int) {
+		found = append(found, Pattern{Name: name, Bias: bias, Index: last})
+	}
+
+	if bias := engulfing(bars); bias > 0 {
+		add(PatternBullishEngulfing, 1)
+	} else if bias < 0 {
+		add(PatternBearishEngulfing, -1)
+	}
+	if bias := pinBar(bars[last]); bias > 0 {
+		add(PatternBullishPinBar, 1)
+	} else if bias < 0 {
+		add(PatternBearishPinBar, -1)
+	}
+	if dojiCluster(bars) {
+		add(PatternDojiCluster, 0)
+	}
+	if bias := threeSoldiers(bars); bias > 0 {
+		add(PatternThreeWhiteSoldiers, 1)
+	} else if bias < 0 {
+		add(PatternThreeBlackCrows, -1)
+	}
+	return found
+}
+
+// ScanPatterns runs DetectPatterns on each of the last window bars.
+func ScanPatterns(bars []OHLC, window int) []Pattern {
+	start := len(bars) - window
+	if start < 0 {
+		start = 0
+	}
+	var found []Pattern
+	for end := start + 1; end <= len(bars); end++ {
+		found = append(found, DetectPatterns(bars[:end])...)
+	}
+	return found
+}
+
+func body(b OHLC) float64 {
+	return math.Abs(b.Close - b.Open)
+}
+
+func barRange(b OHLC) float64 {
+	return b.High - b.Low
+}
+
+// engulfing requires the last body to fully cover the opposite-colored previous body.
+func engulfing(bars []OHLC) int {
+	if len(bars) < 2 {
+		return 0
+	}
+	prev, last := bars[len(bars)-2], bars[len(bars)-1]
+	if body(prev) == 0 || body(last) <= body(prev) {
+		return 0
+	}
+	switch {
+	case prev.Close < prev.Open && last.Close > last.Open && last.Open <= prev.Close && last.Close >= prev.Open:
+		return 1
+	case prev.Close > prev.Open && last.Close < last.Open && last.Open >= prev.Close && last.Close <= prev.Open:
+		return -1
+	}
+	return 0
+}
+
+// pinBar detects a long rejection wick (at least 2x body and 60% of range) with a small opposite wick.
+func pinBar(b OHLC) int {
+	rng := barRange(b)
+	if rng <= 0 {
+		return 0
+	}
+	upper := b.High - math.Max(b.Open, b.Close)
+	lower := math.Min(b.Open, b.Close) - b.Low
+	bd := body(b)
+	switch {
+	case lower >= 2*bd && lower >= 0.6*rng && upper <= 0.25*rng:
+		return 1
+	case upper >= 2*bd && upper >= 0.6*rng && lower <= 0.25*rng:
+		return -1
+	}
+	return 0
+}
+
+func isDoji(b OHLC) bool {
+	rng := barRange(b)
+	return rng > 0 && body(b) <= 0.1*rng
+}
+
+// dojiCluster reports at least two dojis among the last three bars, ending on a doji.
+func dojiCluster(bars []OHLC) bool {
+	if len(bars) < 3 || !isDoji(bars[len(bars)-1]) {
+		return false
+	}
+	count := 0
+	for _, b := range bars[len(bars)-3:] {
+		if isDoji(b) {
+			count++
+		}
+	}
+	return count >= 2
+}
+
+// threeSoldiers detects three same-colored bars with rising (falling) closes,
+// each opening inside the previous body and closing near its extreme.
+func threeSoldiers(bars []OHLC) int {
+	if len(bars) < 3 {
+		return 0
+	}
+	tail := bars[len(bars)-3:]
+	bullish, bearish := true, true
+	for i, b := range tail {
+		rng := barRange(b)
+		if rng <= 0 || body(b) < 0.5*rng {
+			return 0
+		}
+		up := b.Close > b.Open
+		bullish = bullish && up && b.High-b.Close <= 0.3*body(b)
+		bearish = bearish && !up && b.Close-b.Low <= 0.3*body(b)
+		if i == 0 {
+			continue
+		}
+		p := tail[i-1]
+		bullish = bullish && b.Close > p.Close && b.Open >= p.Open && b.Open <= p.Close
+		bearish = bearish && b.Close < p.Close && b.Open <= p.Open && b.Open >= p.Close
+	}
+	switch {
+	case bullish:
+		return 1
+	case bearish:
+		return -1
+	}
+	return 0
+}
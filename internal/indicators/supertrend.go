@@ -0,0 +1,53 @@
+package indicators
+
+import "errors"
+
+// SuperTrend calculates the SuperTrend line: ATR(period) bands of
+// multiplier × ATR around the bar midpoint (high+low)/2 that only ratchet in
+// the trend's direction. The line follows the lower band while the trend is
+// up and the upper band while it is down; the trend flips when the close
+// crosses the active band. Values before the first full period are zero and
+// up is false there.
+func SuperTrend(highs, lows, closes []float64, period int, multiplier float64) (line []float64, up []bool, err error) {
+	if multiplier <= 0 {
+		return nil, nil, errors.New("multiplier must be positive")
+	}
+	atr, err := ATR(highs, lows, closes, period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	line = make([]float64, len(closes))
+	up = make([]bool, len(closes))
+	var upper, lower float64
+	for i := period; i < len(closes); i++ {
+		mid := (highs[i] + lows[i]) / 2
+		basicUpper := mid + multiplier*atr[i]
+		basicLower := mid - multiplier*atr[i]
+		if i == period {
+			upper, lower = basicUpper, basicLower
+			up[i] = closes[i] >= mid
+		} else {
+			if basicUpper < upper || closes[i-1] > upper {
+				upper = basicUpper
+			}
+			if basicLower > lower || closes[i-1] < lower {
+				lower = basicLower
+			}
+			switch {
+			case up[i-1] && closes[i] < lower:
+				up[i] = false
+			case !up[i-1] && closes[i] > upper:
+				up[i] = true
+			default:
+				up[i] = up[i-1]
+			}
+		}
+		if up[i] {
+			line[i] = lower
+		} else {
+			line[i] = upper
+		}
+	}
+	return line, up, nil
+}
@@ -0,0 +1,100 @@
+package indicators
+
+import "math"
+
+// ProfileNode is one price bin of a volume profile.
+type ProfileNode struct {
+	Low    float64
+	High   float64
+	Volume float64
+}
+
+// Profile summarises how volume was distributed across price. POC is the
+// midpoint of the busiest bin; the value area is the contiguous range around
+// it holding valueAreaPercent of the total volume.
+type Profile struct {
+	POC           float64
+	ValueAreaHigh float64
+	ValueAreaLow  float64
+	Nodes         []ProfileNode
+}
+
+// VolumeProfile splits the window's price range into bins and spreads each
+// bar's volume evenly across the bins its high-low range touches.
+func VolumeProfile(highs, lows, volumes []float64, bins int, valueAreaPercent float64) (Profile, bool) {
+	if bins <= 0 || len(highs) == 0 || len(highs) != len(lows) || len(highs) != len(volumes) {
+		return Profile{}, false
+	}
+	minPrice, maxPrice := math.Inf(1), math.Inf(-1)
+	for i := range highs {
+		minPrice = math.Min(minPrice, lows[i])
+		maxPrice = math.Max(maxPrice, highs[i])
+	}
+	if !(maxPrice > minPrice) {
+		return Profile{}, false
+	}
+
+	step := (maxPrice - minPrice) / float64(bins)
+	nodes := make([]ProfileNode, bins)
+	for i := range nodes {
+		nodes[i].Low = minPrice + float64(i)*step
+		nodes[i].High = nodes[i].Low + step
+	}
+	binOf := func(price float64) int {
+		idx := int((price - minPrice) / step)
+		if idx >= bins {
+			idx = bins - 1
+		}
+		return idx
+	}
+	total := 0.0
+	for i := range highs {
+		if volumes[i] <= 0 {
+			continue
+		}
+		lo, hi := binOf(lows[i]), binOf(highs[i])
+		share := volumes[i] / float64(hi-lo+1)
+		for b := lo; b <= hi; b++ {
+			nodes[b].Volume += share
+		}
+		total += volumes[i]
+	}
+	if total == 0 {
+		return Profile{}, false
+	}
+
+	poc := 0
+	for i, node := range nodes {
+		if node.Volume > nodes[poc].Volume {
+			poc = i
+		}
+	}
+
+	// Grow the value area from the POC toward whichever neighbour holds more volume.
+	target := total * valueAreaPercent / 100
+	lo, hi := poc, poc
+	covered := nodes[poc].Volume
+	for covered < target && (lo > 0 || hi < bins-1) {
+		below, above := -1.0, -1.0
+		if lo > 0 {
+			below = nodes[lo-1].Volume
+		}
+		if hi < bins-1 {
+			above = nodes[hi+1].Volume
+		}
+		if above >= below {
+			hi++
+			covered += above
+		} else {
+			lo--
+			covered += below
+		}
+	}
+
+	return Profile{
+		POC:           (nodes[poc].Low + nodes[poc].High) / 2,
+		ValueAreaHigh: nodes[hi].High,
+		ValueAreaLow:  nodes[lo].Low,
+		Nodes:         nodes,
+	}, true
+}
@@ -0,0 +1,75 @@
+package indicators
+
+import "errors"
+
+// VWAP returns the rolling volume-weighted average of the typical price
+// (high+low+close)/3 over the `period` bars ending at each index. Values
+// before the first full period, or for windows with no volume, are zero.
+func VWAP(highs, lows, closes, volumes []float64, period int) ([]float64, error) {
+	if period <= 0 {
+		return nil, errors.New("period must be positive")
+	}
+	if err := checkVWAPSeries(highs, lows, closes, volumes); err != nil {
+		return nil, err
+	}
+	if len(closes) < period {
+		return nil, errors.New("series length smaller than period")
+	}
+
+	vwap := make([]float64, len(closes))
+	pv, vol := 0.0, 0.0
+	for i := range closes {
+		pv += typicalPrice(highs[i], lows[i], closes[i]) * volumes[i]
+		vol += volumes[i]
+		if i >= period {
+			j := i - period
+			pv -= typicalPrice(highs[j], lows[j], closes[j]) * volumes[j]
+			vol -= volumes[j]
+		}
+		if i >= period-1 && vol > 0 {
+			vwap[i] = pv / vol
+		}
+	}
+	return vwap, nil
+}
+
+// AnchoredVWAP returns the cumulative VWAP of the typical price, restarting at
+// every index where anchors is true (e.g. the first bar of each session). Bars
+// before the first anchor, or with no volume since the anchor, are zero.
+func AnchoredVWAP(highs, lows, closes, volumes []float64, anchors []bool) ([]float64, error) {
+	if err := checkVWAPSeries(highs, lows, closes, volumes); err != nil {
+		return nil, err
+	}
+	if len(anchors) != len(closes) {
+		return nil, errors.New("series lengths differ")
+	}
+
+	vwap := make([]float64, len(closes))
+	pv, vol := 0.0, 0.0
+	started := false
+	for i := range closes {
+		if anchors[i] {
+			pv, vol, started = 0, 0, true
+		}
+		if !started {
+			continue
+		}
+		pv += typicalPrice(highs[i], lows[i], closes[i]) * volumes[i]
+		vol += volumes[i]
+		if vol > 0 {
+			vwap[i] = pv / vol
+		}
+	}
+	return vwap, nil
+}
+
+func checkVWAPSeries(highs, lows, closes, volumes []float64) error {
+	if len(highs) != len(lows) || len(highs) != len(closes) || len(highs) != len(volumes) {
+		return errors.New("series lengths differ")
+	}
+	return nil
+}
+
+func typicalPrice(high, low, close float64) float64 {
+	return (high + low + close) / 3
+}
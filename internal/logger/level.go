@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log line. Lines below a module's level are dropped.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// DefaultLevel applies to modules without an override.
+const DefaultLevel = LevelInfo
+
+// MaxLevelTTL caps how long a runtime override may last before it reverts.
+const MaxLevelTTL = 24 * time.Hour
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// ParseLevel accepts debug, info, warn/warning and error in any case.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return DefaultLevel, fmt.Errorf("unknown log level %q", s)
+}
+
+// LevelOverride is a temporary level set for a module and its sub-modules.
+type LevelOverride struct {
+	Module  string    `json:"module"`
+	Level   string    `json:"level"`
+	Expires time.Time `json:"expires"`
+}
+
+type override struct {
+	level   Level
+	expires time.Time
+	timer   *time.Timer
+}
+
+var levels = struct {
+	sync.RWMutex
+	overrides map[string]*override
+}{overrides: make(map[string]*override)}
+
+// SetLevel sets the level of module until ttl elapses, then reverts it to
+// DefaultLevel. The override also covers sub-modules, so "ai" applies to
+// "ai.deepseek" unless that module has its own override. Setting a module
+// again replaces its previous override and timer.
+func SetLevel(module string, level Level, ttl time.Duration) error {
+	module = strings.TrimSpace(module)
+	if module == "" {
+		return fmt.Errorf("module is required")
+	}
+	if ttl <= 0 || ttl > MaxLevelTTL {
+		return fmt.Errorf("ttl must be within (0, %s]", MaxLevelTTL)
+	}
+	entry := &override{level: level, expires: time.Now().Add(ttl)}
+
+	// Store the entry and start its timer under the lock, so the revert can
+	// never run before the entry is in the map or race with the assignment.
+	levels.Lock()
+	if previous := levels.overrides[module]; previous != nil {
+		previous.timer.Stop()
+	}
+	levels.overrides[module] = entry
+	entry.timer = time.AfterFunc(ttl, func() {
+		levels.Lock()
+		current := levels.overrides[module]
+		if current == entry {
+			delete(levels.overrides, module)
+		}
+		levels.Unlock()
+		if current == entry {
+			Get("logger").Printf("level.revert module=%s level=%s", module, DefaultLevel)
+		}
+	})
+	levels.Unlock()
+
+	Get("logger").Printf("level.set module=%s level=%s ttl=%s", module, level, ttl)
+	return nil
+}
+
+// ResetLevel removes the override of module, reporting whether one existed.
+func ResetLevel(module string) bool {
+	levels.Lock()
+	entry := levels.overrides[module]
+	if entry != nil {
+		entry.timer.Stop()
+		delete(levels.overrides, module)
+	}
+	levels.Unlock()
+	if entry != nil {
+		Get("logger").Printf("level.reset module=%s level=%s", module, DefaultLevel)
+	}
+	return entry != nil
+}
+
+// Overrides lists the active overrides sorted by module.
+func Overrides() []LevelOverride {
+	levels.RLock()
+	out := make([]LevelOverride, 0, len(levels.overrides))
+	for module, entry := range levels.overrides {
+		out = append(out, LevelOverride{Module: module, Level: entry.level.String(), Expires: entry.expires})
+	}
+	levels.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Module < out[j].Module })
+	return out
+}
+
+// levelFor returns the level of module: the override of the module itself or
+// of its closest parent ("ai" for "ai.deepseek"), else DefaultLevel.
+func levelFor(module string) Level {
+	levels.RLock()
+	defer levels.RUnlock()
+	if len(levels.overrides) == 0 {
+		return DefaultLevel
+	}
+	for name := module; ; {
+		if entry, ok := levels.overrides[name]; ok {
+			return entry.level
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return DefaultLevel
+		}
+		name = name[:i]
+	}
+}
+
+// Handler serves the runtime level API. GET lists active overrides; POST
+// with module, level and optional ttl (default 10m) sets one; DELETE with
+// module removes one. Parameters come from the query string or a form body.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			level, err := ParseLevel(r.FormValue("level"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			ttl := 10 * time.Minute
+			if raw := r.FormValue("ttl"); raw != "" {
+				if ttl, err = time.ParseDuration(raw); err != nil {
+					http.Error(w, fmt.Sprintf("invalid ttl %q", raw), http.StatusBadRequest)
+					return
+				}
+			}
+			if err := SetLevel(r.FormValue("module"), level, ttl); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			if !ResetLevel(strings.TrimSpace(r.FormValue("module"))) {
+				http.Error(w, "no override for module", http.StatusNotFound)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(struct {
+			Default   string          `json:"default"`
+			Overrides []LevelOverride `json:"overrides"`
+		}{DefaultLevel.String(), Overrides()})
+	})
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetLevelCoversSubModulesAndReverts(t *testing.T) {
+	if err := SetLevel("test.level", LevelDebug, 20*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if got := levelFor("test.level.child"); got != LevelDebug {
+		t.Fatalf("sub-module level = %s, want DEBUG", got)
+	}
+	deadline := time.Now().Add(time.Second)
+	for levelFor("test.level") != DefaultLevel {
+		if time.Now().After(deadline) {
+			t.Fatal("override did not revert after its ttl")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSetLevelShortTTLRace(t *testing.T) {
+	// A ttl that fires immediately must still remove the override it belongs
+	// to and never leave a stale entry behind.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = SetLevel("test.race", LevelWarn, time.Nanosecond)
+		}()
+	}
+	wg.Wait()
+	deadline := time.Now().Add(time.Second)
+	for levelFor("test.race") != DefaultLevel {
+		if time.Now().After(deadline) {
+			t.Fatalf("stale override left behind: %+v", Overrides())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSetLevelRejectsBadInput(t *testing.T) {
+	if err := SetLevel(" ", LevelDebug, time.Minute); err == nil {
+		t.Error("empty module accepted")
+	}
+	if err := SetLevel("test.bad", LevelDebug, MaxLevelTTL+time.Second); err == nil {
+		t.Error("ttl above MaxLevelTTL accepted")
+	}
+}
@@ -116,11 +116,38 @@ func (l *ModuleLogger) resetWriters() {
 }
 
 func (l *ModuleLogger) Printf(format string, args ...interface{}) {
-	l.write("INFO", fmt.Sprintf(format, args...))
+	l.logf(LevelInfo, format, args...)
 }
 
 func (l *ModuleLogger) Println(args ...interface{}) {
-	l.write("INFO", fmt.Sprintln(args...))
+	if l.Enabled(LevelInfo) {
+		l.write(LevelInfo.String(), fmt.Sprintln(args...))
+	}
+}
+
+// Debugf logs only while the module is at DEBUG, see SetLevel.
+func (l *ModuleLogger) Debugf(format string, args ...interface{}) {
+	l.logf(LevelDebug, format, args...)
+}
+
+func (l *ModuleLogger) Warnf(format string, args ...interface{}) {
+	l.logf(LevelWarn, format, args...)
+}
+
+func (l *ModuleLogger) Errorf(format string, args ...interface{}) {
+	l.logf(LevelError, format, args...)
+}
+
+// Enabled reports whether lines at level are currently written, so callers
+// can skip building expensive debug output.
+func (l *ModuleLogger) Enabled(level Level) bool {
+	return level >= levelFor(l.module)
+}
+
+func (l *ModuleLogger) logf(level Level, format string, args ...interface{}) {
+	if l.Enabled(level) {
+		l.write(level.String(), fmt.Sprintf(format, args...))
+	}
 }
 
 func (l *ModuleLogger) Fatal(args ...interface{}) {
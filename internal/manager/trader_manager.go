@@ -8,6 +8,7 @@ import (
 
 	loggerpkg "autobot/internal/logger"
 	"autobot/internal/trader"
+	"autobot/internal/version"
 )
 
 // TraderManager 负责管理多个自动交易实例。
@@ -48,6 +49,7 @@ func (m *TraderManager) Run(ctx context.Context) error {
 
 	var wg sync.WaitGroup
 	errCh := make(chan error, len(m.traders))
+	loggerpkg.Get("manager").Printf("startup %s", version.Banner("trader"))
 	loggerpkg.Get("manager").Printf("starting %d traders", len(m.traders))
 
 	for name, at := range m.traders {
@@ -0,0 +1,136 @@
+package market
+
+import (
+	"math"
+	"strings"
+
+	"autobot/internal/config"
+	"autobot/internal/strategy"
+)
+
+// TransformCandles 按 candleType 将原始K线转换为策略使用的K线，standard 或空值原样返回。
+func TransformCandles(candles []strategy.Candle, settings config.TradeSettings) []strategy.Candle {
+	switch strings.ToLower(strings.TrimSpace(settings.CandleType)) {
+	case config.CandleTypeHeikinAshi:
+		return HeikinAshi(candles)
+	case config.CandleTypeRenko:
+		return Renko(candles, settings.RenkoBrickPercent)
+	default:
+		return candles
+	}
+}
+
+// HeikinAshi 计算平均K线：收盘为四价均值，开盘为前一根平均K线开收盘的中点。
+func HeikinAshi(candles []strategy.Candle) []strategy.Candle {
+	out := make([]strategy.Candle, len(candles))
+	for i, c := range candles {
+		haClose := (c.Open + c.High + c.Low + c.Close) / 4
+		haOpen := (c.Open + c.Close) / 2
+		if i > 0 {
+			haOpen = (out[i-1].Open + out[i-1].Close) / 2
+		}
+		out[i] = strategy.Candle{
+			OpenTime: c.OpenTime,
+			Open:     haOpen,
+			High:     math.Max(c.High, math.Max(haOpen, haClose)),
+			Low:      math.Min(c.Low, math.Min(haOpen, haClose)),
+			Close:    haClose,
+			Volume:   c.Volume,
+		}
+	}
+	return out
+}
+
+// Renko 按收盘价生成砖块，砖块大小为首根收盘价的 brickPercent%。
+// 同向延续需再走一块砖，反转需越过上一块砖的开盘价再走一块砖；砖块的成交量为形成期间的累计量。
+func Renko(candles []strategy.Candle, brickPercent float64) []strategy.Candle {
+	if len(candles) == 0 || brickPercent <= 0 {
+		return nil
+	}
+	size := candles[0].Close * brickPercent / 100
+	if size <= 0 {
+		return nil
+	}
+
+	var bricks []strategy.Candle
+	top, bottom := candles[0].Close, candles[0].Close
+	volume := 0.0
+	for _, c := range candles[1:] {
+		volume += c.Volume
+		for c.Close >= top+size {
+			bricks = append(bricks, brick(c, top, top+size, volume))
+			bottom, top = top, top+size
+			volume = 0
+		}
+		for c.Close <= bottom-size {
+			bricks = append(bricks, brick(c, bottom, bottom-size, volume))
+			top, bottom = bottom, bottom-size
+			volume = 0
+		}
+	}
+	return bricks
+}
+
+func brick(at strategy.Candle, open, close, volume float64) strategy.Candle {
+	return strategy.Candle{
+		OpenTime: at.OpenTime,
+		Open:     open,
+		High:     math.Max(open, close),
+		Low:      math.Min(open, close),
+		Close:    close,
+		Volume:   volume,
+	}
+}
+
+// Resample 把K线聚合为 interval 周期，按 UTC 对齐分桶（周线从周一开始）；最后一桶可能尚未走完，
+// 与交易所返回的当前K线一致。interval 无法识别时返回 false。
+func Resample(candles []strategy.Candle, interval string) ([]strategy.Candle, bool) {
+	length, ok := IntervalDuration(interval)
+	if !ok {
+		return nil, false
+	}
+	var out []strategy.Candle
+	for _, c := range candles {
+		// Truncate 以公元1年1月1日（周一）UTC 零点为基准，周线自然从周一开始
+		open := c.OpenTime.UTC().Truncate(length)
+		if n := len(out); n > 0 && out[n-1].OpenTime.Equal(open) {
+			last := &out[n-1]
+			last.High = math.Max(last.High, c.High)
+			last.Low = math.Min(last.Low, c.Low)
+			last.Close = c.Close
+			last.Volume += c.Volume
+			continue
+		}
+		out = append(out, strategy.Candle{OpenTime: open, Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume})
+	}
+	return out, true
+}
+
+// ResampleTimeframes 从信号周期 interval 的K线聚合出 extra 中的各周期，返回含 interval 本身的 周期→K线，
+// 供回测在只有单一周期数据时评估多周期策略。短于 interval、不是其整数倍或无法识别的周期跳过。
+func ResampleTimeframes(candles []strategy.Candle, interval string, extra []string) strategy.Timeframes {
+	frames := strategy.Timeframes{interval: candles}
+	base, ok := IntervalDuration(interval)
+	if !ok {
+		return frames
+	}
+	for _, tf := range extra {
+		length, ok := IntervalDuration(tf)
+		if !ok || length <= base || length%base != 0 {
+			continue
+		}
+		if resampled, ok := Resample(candles, tf); ok {
+			frames[tf] = resampled
+		}
+	}
+	return frames
+}
+
+// TransformTimeframes 对每个周期的K线应用 TransformCandles。
+func TransformTimeframes(frames strategy.Timeframes, settings config.TradeSettings) strategy.Timeframes {
+	out := make(strategy.Timeframes, len(frames))
+	for interval, candles := range frames {
+		out[interval] = TransformCandles(candles, settings)
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package market
+
+import (
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+	"autobot/internal/strategy"
+)
+
+// FlowWindows 为计算主动买卖量的回看窗口。
+var FlowWindows = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
+
+// flowTradeLimit 为单次拉取的归集成交笔数（币安上限1000）。
+const flowTradeLimit = 1000
+
+// TakerFlows 统计截至 now 的各窗口主动买入/卖出量。成交不足以覆盖的窗口仍按已有数据统计。
+func TakerFlows(trades []exchange.AggTrade, now time.Time, windows []time.Duration) []strategy.TakerFlow {
+	flows := make([]strategy.TakerFlow, len(windows))
+	for i, window := range windows {
+		flows[i].Window = window
+		since := now.Add(-window)
+		for _, trade := range trades {
+			if trade.Time.Before(since) || trade.Time.After(now) {
+				continue
+			}
+			if trade.BuyerMaker {
+				flows[i].SellVolume += trade.Quantity
+			} else {
+				flows[i].BuyVolume += trade.Quantity
+			}
+			flows[i].Trades++
+		}
+	}
+	return flows
+}
+
+// ApplyFlows 将主动买卖统计写入快照。
+func ApplyFlows(snapshot *ai.MarketDataSnapshot, flows []strategy.TakerFlow) {
+	snapshot.TakerFlow = nil
+	for _, flow := range flows {
+		if flow.Trades == 0 {
+			continue
+		}
+		snapshot.TakerFlow = append(snapshot.TakerFlow, ai.TakerFlow{
+			Window:     flow.Window.String(),
+			BuyVolume:  flow.BuyVolume,
+			SellVolume: flow.SellVolume,
+			Imbalance:  flow.Imbalance(),
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange"
+	"autobot/internal/storage"
+)
+
+// IngestResult 为一个交易对的历史数据下载结果，Funding/OpenInterest 为新增条数，Skipped 为交易所不支持的项。
+type IngestResult struct {
+	Symbol       string
+	Funding      int
+	OpenInterest int
+	Skipped      []string
+}
+
+// IngestHistory 把 symbol 自 since 至 now 的资金费率与持仓量（按 period 采样，如 1h）增量下载到本地存储：
+// 已保存过的交易对从最新记录之后续传，重复运行不会写入重复数据。交易所未实现
+// exchange.FundingHistorySource / exchange.OpenInterestHistorySource 时跳过对应项。
+func IngestHistory(ctx context.Context, src exchange.Exchange, cfg config.StorageConfig, symbol, period string, since, now time.Time) (IngestResult, error) {
+	result := IngestResult{Symbol: symbol}
+
+	if source, ok := src.(exchange.FundingHistorySource); ok {
+		start, err := resumeFrom(storage.LastFundingTime(cfg, symbol))
+		if err != nil {
+			return result, err
+		}
+		if start.Before(since) {
+			start = since
+		}
+		events, err := source.GetFundingHistory(ctx, symbol, start, now)
+		if err != nil {
+			return result, fmt.Errorf("funding %s: %w", symbol, err)
+		}
+		records := make([]storage.FundingRecord, 0, len(events))
+		for _, event := range events {
+			records = append(records, storage.FundingRecord{Time: event.Time.UnixMilli(), Rate: event.Rate})
+		}
+		if result.Funding, err = storage.AppendFunding(cfg, symbol, records); err != nil {
+			return result, err
+		}
+	} else {
+		result.Skipped = append(result.Skipped, "funding")
+	}
+
+	if source, ok := src.(exchange.OpenInterestHistorySource); ok {
+		start, err := resumeFrom(storage.LastOpenInterestTime(cfg, symbol))
+		if err != nil {
+			return result, err
+		}
+		if start.Before(since) {
+			start = since
+		}
+		points, err := source.GetOpenInterestHistory(ctx, symbol, period, start, now)
+		if err != nil {
+			return result, fmt.Errorf("open interest %s: %w", symbol, err)
+		}
+		records := make([]storage.OpenInterestRecord, 0, len(points))
+		for _, point := range points {
+			records = append(records, storage.OpenInterestRecord{Time: point.Time.UnixMilli(), OpenInterest: point.Contracts, Notional: point.Notional})
+		}
+		if result.OpenInterest, err = storage.AppendOpenInterest(cfg, symbol, records); err != nil {
+			return result, err
+		}
+	} else {
+		result.Skipped = append(result.Skipped, "open_interest")
+	}
+	return result, nil
+}
+
+// resumeFrom 返回续传的起点：已保存的最新记录之后1毫秒，没有记录时为零值。
+func resumeFrom(last time.Time, err error) (time.Time, error) {
+	if err != nil || last.IsZero() {
+		return time.Time{}, err
+	}
+	return last.Add(time.Millisecond), nil
+}
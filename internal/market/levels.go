@@ -0,0 +1,102 @@
+package market
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/indicators"
+	"autobot/internal/strategy"
+)
+
+// 摆动点识别参数：左右各 swingStrength 根K线，价差 swingTolerancePercent 内的摆动点合并为同一价位。
+const (
+	swingStrength         = 3
+	swingTolerancePercent = 0.3
+)
+
+// Levels 汇总K线窗口内的摆动高低点与经典枢轴点。daily 为前一日K线，
+// 为空时尝试用窗口内完整覆盖的前一个UTC自然日计算枢轴点。
+func Levels(candles []strategy.Candle, daily *strategy.Candle) []indicators.Level {
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+	}
+	levels := indicators.SwingLevels(highs, lows, swingStrength, swingTolerancePercent)
+
+	if daily == nil {
+		if prev, ok := previousDay(candles); ok {
+			daily = &prev
+		}
+	}
+	if daily != nil && daily.High > 0 {
+		levels = append(levels, indicators.ClassicPivots(daily.High, daily.Low, daily.Close).Levels()...)
+	}
+	return levels
+}
+
+// ApplyLevels 写入全部关键位，并标出现价上方最近的阻力与下方最近的支撑。
+func ApplyLevels(snapshot *ai.MarketDataSnapshot, levels []indicators.Level) {
+	snapshot.Levels = nil
+	snapshot.Resistance = nil
+	snapshot.Support = nil
+	price := snapshot.CurrentPrice
+	if price <= 0 {
+		return
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Price < levels[j].Price })
+	for _, level := range levels {
+		if level.Price <= 0 || math.IsNaN(level.Price) {
+			continue
+		}
+		item := ai.PriceLevel{
+			Name:            level.Name,
+			Price:           level.Price,
+			Strength:        level.Strength,
+			DistancePercent: (level.Price/price - 1) * 100,
+		}
+		snapshot.Levels = append(snapshot.Levels, item)
+		if level.Price > price && snapshot.Resistance == nil {
+			resistance := item
+			snapshot.Resistance = &resistance
+		}
+		if level.Price < price {
+			support := item
+			snapshot.Support = &support
+		}
+	}
+}
+
+// previousDay 聚合窗口内完整的前一个UTC自然日，窗口未覆盖整日时返回 false。
+func previousDay(candles []strategy.Candle) (strategy.Candle, bool) {
+	if len(candles) == 0 {
+		return strategy.Candle{}, false
+	}
+	last := candles[len(candles)-1].OpenTime.UTC()
+	dayEnd := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC)
+	dayStart := dayEnd.AddDate(0, 0, -1)
+	if candles[0].OpenTime.After(dayStart) {
+		return strategy.Candle{}, false
+	}
+
+	day := strategy.Candle{OpenTime: dayStart}
+	found := false
+	for _, c := range candles {
+		t := c.OpenTime.UTC()
+		if t.Before(dayStart) || !t.Before(dayEnd) {
+			continue
+		}
+		if !found {
+			day.Open, day.High, day.Low = c.Open, c.High, c.Low
+			found = true
+		}
+		day.High = math.Max(day.High, c.High)
+		day.Low = math.Min(day.Low, c.Low)
+		day.Close = c.Close
+		day.Volume += c.Volume
+	}
+	return day, found
+}
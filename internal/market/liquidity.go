@@ -0,0 +1,133 @@
+package market
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+)
+
+// 流动性估算的默认参数。
+const (
+	DefaultLiquiditySlippagePercent = 0.2
+	DefaultLiquidityBookShare       = 0.25
+	liquidityDepthLimit             = 100
+)
+
+// LiquidityOptions 控制候选币种按流动性排序：Weight 为流动性排名在综合评分中的占比（0~1），
+// SlippagePercent 为计入可成交深度的价格范围（距中间价百分比），BookShare 为单笔最多吃掉
+// 该范围内深度的比例。
+type LiquidityOptions struct {
+	Weight          float64
+	SlippagePercent float64
+	BookShare       float64
+}
+
+// BookLiquidity 返回距中间价 slippagePercent 以内买卖盘各自的名义价值（USDT）。盘口为空时均为 0。
+func BookLiquidity(book exchange.OrderBook, slippagePercent float64) (bid, ask float64) {
+	if len(book.Bids) == 0 || len(book.Asks) == 0 {
+		return 0, 0
+	}
+	mid := (book.Bids[0].Price + book.Asks[0].Price) / 2
+	if mid <= 0 {
+		return 0, 0
+	}
+	band := mid * slippagePercent / 100
+	for _, level := range book.Bids {
+		if level.Price < mid-band {
+			break
+		}
+		bid += level.Price * level.Quantity
+	}
+	for _, level := range book.Asks {
+		if level.Price > mid+band {
+			break
+		}
+		ask += level.Price * level.Quantity
+	}
+	return bid, ask
+}
+
+// MaxSafeNotional 返回单笔安全下单额：较薄一侧在滑点范围内的深度乘以 BookShare。
+// 开多吃卖盘、开空吃买盘，取较薄的一侧使两个方向都不必缩单。
+func MaxSafeNotional(book exchange.OrderBook, opts LiquidityOptions) float64 {
+	opts = opts.withDefaults()
+	bid, ask := BookLiquidity(book, opts.SlippagePercent)
+	return math.Min(bid, ask) * opts.BookShare
+}
+
+func (o LiquidityOptions) withDefaults() LiquidityOptions {
+	if o.SlippagePercent <= 0 {
+		o.SlippagePercent = DefaultLiquiditySlippagePercent
+	}
+	if o.BookShare <= 0 {
+		o.BookShare = DefaultLiquidityBookShare
+	}
+	return o
+}
+
+// RankByLiquidity 拉取每个候选币种的盘口，标注 MaxSafeNotionalUSD，并按综合评分重新排序：
+// (1-Weight)×币种池排名分 + Weight×流动性排名分，两者均按名次归一到 0~1（第一名为 1）。
+// 盘口获取失败的币种不标注，流动性排名分记为 0。Weight 不大于 0 或 src 为 nil 时原样返回。
+func RankByLiquidity(ctx context.Context, src exchange.DepthSource, candidates []ai.CandidateContext, opts LiquidityOptions) []ai.CandidateContext {
+	if src == nil || opts.Weight <= 0 || len(candidates) == 0 {
+		return candidates
+	}
+	opts = opts.withDefaults()
+	logger := loggerpkg.Get("market")
+	out := append([]ai.CandidateContext(nil), candidates...)
+	for i := range out {
+		if ctx.Err() != nil {
+			break
+		}
+		book, err := src.GetDepth(ctx, out[i].Symbol, liquidityDepthLimit)
+		if err != nil {
+			logger.Printf("liquidity.depth_error symbol=%s err=%v", out[i].Symbol, err)
+			continue
+		}
+		out[i].MaxSafeNotionalUSD = math.Round(MaxSafeNotional(book, opts))
+	}
+
+	n := len(out)
+	poolRank := rankScores(n, func(i, j int) bool { return i < j })
+	liquidityRank := rankScores(n, func(i, j int) bool { return out[i].MaxSafeNotionalUSD > out[j].MaxSafeNotionalUSD })
+	combined := make([]float64, n)
+	for i := range out {
+		liquidity := liquidityRank[i]
+		if out[i].MaxSafeNotionalUSD <= 0 {
+			liquidity = 0
+		}
+		combined[i] = (1-opts.Weight)*poolRank[i] + opts.Weight*liquidity
+	}
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return combined[order[a]] > combined[order[b]] })
+	ranked := make([]ai.CandidateContext, n)
+	for i, idx := range order {
+		ranked[i] = out[idx]
+	}
+	return ranked
+}
+
+// rankScores 按 less 排序后把名次归一到 0~1：第一名为 1，最后一名为 0，只有一个时为 1。
+func rankScores(n int, less func(i, j int) bool) []float64 {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return less(order[a], order[b]) })
+	scores := make([]float64, n)
+	for rank, idx := range order {
+		if n == 1 {
+			scores[idx] = 1
+			continue
+		}
+		scores[idx] = 1 - float64(rank)/float64(n-1)
+	}
+	return scores
+}
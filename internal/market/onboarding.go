@@ -0,0 +1,223 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+)
+
+// OnboardingConfig 为新币种准入检查的门槛，为 0 的项不检查。
+type OnboardingConfig struct {
+	// Interval 与 MinCandles：该周期下至少要有 MinCandles 根历史K线，指标才有足够的预热数据。
+	Interval   string
+	MinCandles int
+	// MinQuoteVolume 为24小时成交额（计价币）下限。
+	MinQuoteVolume float64
+	// MaxTakerFeePercent 为吃单费率上限（百分比）；交易所不提供按币种费率时按 DefaultTakerFeePercent 判断。
+	MaxTakerFeePercent     float64
+	DefaultTakerFeePercent float64
+	// RequiredLeverage 为交易者使用的杠杆，该币种最大杠杆低于此值时不准入。
+	RequiredLeverage float64
+	// RecheckInterval 为试用期币种的重检间隔。
+	RecheckInterval time.Duration
+}
+
+// OnboardingStatus 为一个币种的准入状态：未通过检查的币种留在试用期，Failures 为未通过的原因，
+// Skipped 为交易所不支持而跳过的检查。
+type OnboardingStatus struct {
+	Symbol     string    `json:"symbol"`
+	FirstSeen  time.Time `json:"firstSeen"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	Approved   bool      `json:"approved"`
+	ApprovedAt time.Time `json:"approvedAt"`
+	Failures   []string  `json:"failures,omitempty"`
+	Skipped    []string  `json:"skipped,omitempty"`
+}
+
+// Onboarding 跟踪首次进入币种池的币种：检查通过前不交给交易者，通过后不再检查。
+// 状态保存在文件中，重启后已准入的币种不会重新进入试用期。
+type Onboarding struct {
+	cfg    OnboardingConfig
+	source exchange.Exchange
+	path   string
+	logger *loggerpkg.ModuleLogger
+
+	mu     sync.Mutex
+	states map[string]*OnboardingStatus
+}
+
+// OnboardingFile 返回存储目录下的准入状态文件路径。
+func OnboardingFile(dir string) string {
+	return filepath.Join(dir, "onboarding.json")
+}
+
+// NewOnboarding 创建准入检查器并读取 path 中已有的状态。source 应为真实行情数据源（dryRun 时为
+// 模拟撮合包装的底层交易所），实现 exchange.FeeRateSource、exchange.LeverageSource 时才检查对应项。
+func NewOnboarding(path string, source exchange.Exchange, cfg OnboardingConfig) (*Onboarding, error) {
+	if cfg.RecheckInterval <= 0 {
+		cfg.RecheckInterval = 30 * time.Minute
+	}
+	o := &Onboarding{cfg: cfg, source: source, path: path, logger: loggerpkg.Get("market.onboarding"), states: map[string]*OnboardingStatus{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []OnboardingStatus
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for i := range list {
+		o.states[list[i].Symbol] = &list[i]
+	}
+	return o, nil
+}
+
+// Filter 返回 candidates 中已准入的币种（保持原顺序）。首次出现的币种立即检查，未通过的留在试用期，
+// 到 RecheckInterval 后再次检查；状态有变化时写回文件。
+func (o *Onboarding) Filter(ctx context.Context, candidates []ai.CandidateContext) []ai.CandidateContext {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := time.Now().UTC()
+	changed := false
+	out := make([]ai.CandidateContext, 0, len(candidates))
+	for _, coin := range candidates {
+		state, ok := o.states[coin.Symbol]
+		if !ok {
+			state = &OnboardingStatus{Symbol: coin.Symbol, FirstSeen: now}
+			o.states[coin.Symbol] = state
+			changed = true
+			o.logger.Printf("onboarding.new symbol=%s", coin.Symbol)
+		}
+		if !state.Approved && now.Sub(state.CheckedAt) >= o.cfg.RecheckInterval {
+			o.check(ctx, state, now)
+			changed = true
+		}
+		if state.Approved {
+			out = append(out, coin)
+		}
+	}
+	if changed {
+		if err := o.save(); err != nil {
+			o.logger.Printf("onboarding.save_failed err=%v", err)
+		}
+	}
+	return out
+}
+
+// check 运行全部准入检查并更新 state。
+func (o *Onboarding) check(ctx context.Context, state *OnboardingStatus, now time.Time) {
+	state.Failures, state.Skipped = nil, nil
+	fail := func(format string, args ...any) {
+		state.Failures = append(state.Failures, fmt.Sprintf(format, args...))
+	}
+	symbol := state.Symbol
+
+	if o.cfg.MinCandles > 0 {
+		candles, err := o.source.GetKlines(ctx, symbol, o.cfg.Interval, o.cfg.MinCandles)
+		switch {
+		case err != nil:
+			fail("K线获取失败: %v", err)
+		case len(candles) < o.cfg.MinCandles:
+			fail("%s K线仅 %d 根，需要 %d 根", o.cfg.Interval, len(candles), o.cfg.MinCandles)
+		}
+	}
+
+	if o.cfg.MinQuoteVolume > 0 {
+		ticker, err := o.source.Get24hTicker(ctx, symbol)
+		switch {
+		case err != nil:
+			fail("24h行情获取失败: %v", err)
+		case ticker.QuoteVolume < o.cfg.MinQuoteVolume:
+			fail("24h成交额 %.0f 低于 %.0f", ticker.QuoteVolume, o.cfg.MinQuoteVolume)
+		}
+	}
+
+	if o.cfg.MaxTakerFeePercent > 0 {
+		taker := o.cfg.DefaultTakerFeePercent
+		if src, ok := o.source.(exchange.FeeRateSource); ok {
+			if _, rate, err := src.GetFeeRates(ctx, symbol); err == nil {
+				taker = rate
+			} else if !errors.Is(err, exchange.ErrUnsupported) {
+				o.logger.Printf("onboarding.fee_rate_failed symbol=%s err=%v", symbol, err)
+			}
+		}
+		if taker > o.cfg.MaxTakerFeePercent {
+			fail("吃单费率 %.4f%% 高于 %.4f%%", taker, o.cfg.MaxTakerFeePercent)
+		}
+	}
+
+	if o.cfg.RequiredLeverage > 0 {
+		src, ok := o.source.(exchange.LeverageSource)
+		if !ok {
+			state.Skipped = append(state.Skipped, "杠杆档位")
+		} else if maxLeverage, err := src.GetMaxLeverage(ctx, symbol); errors.Is(err, exchange.ErrUnsupported) {
+			state.Skipped = append(state.Skipped, "杠杆档位")
+		} else if err != nil {
+			fail("杠杆档位获取失败: %v", err)
+		} else if maxLeverage < o.cfg.RequiredLeverage {
+			fail("最大杠杆 %gx 低于所需 %gx", maxLeverage, o.cfg.RequiredLeverage)
+		}
+	}
+
+	state.CheckedAt = now
+	if len(state.Failures) == 0 {
+		state.Approved, state.ApprovedAt = true, now
+		o.logger.Printf("onboarding.approved symbol=%s skipped=%d", symbol, len(state.Skipped))
+		return
+	}
+	o.logger.Printf("onboarding.probation symbol=%s failures=%d", symbol, len(state.Failures))
+}
+
+// Statuses 返回全部币种的准入状态：试用期币种在前，其余按币种排序。
+func (o *Onboarding) Statuses() []OnboardingStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make([]OnboardingStatus, 0, len(o.states))
+	for _, state := range o.states {
+		out = append(out, *state)
+	}
+	sortStatuses(out)
+	return out
+}
+
+func sortStatuses(list []OnboardingStatus) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Approved != list[j].Approved {
+			return !list[i].Approved
+		}
+		return list[i].Symbol < list[j].Symbol
+	})
+}
+
+func (o *Onboarding) save() error {
+	list := make([]OnboardingStatus, 0, len(o.states))
+	for _, state := range o.states {
+		list = append(list, *state)
+	}
+	sortStatuses(list)
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(o.path), 0o755); err != nil {
+		return err
+	}
+	tmp := o.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path)
+}
@@ -0,0 +1,207 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+	"autobot/internal/indicators"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/strategy"
+)
+
+// Source 为构建行情快照所需的数据接口。
+type Source interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+	GetFundingRate(ctx context.Context, symbol string) (float64, error)
+	GetOpenInterest(ctx context.Context, symbol string) (float64, error)
+	Get24hTicker(ctx context.Context, symbol string) (exchange.Ticker24h, error)
+}
+
+// Collect 拉取K线、资金费率、持仓量、24h统计及（数据源支持时）归集成交并生成AI使用的市场快照。
+// K线失败时返回错误，其余数据源失败仅记录日志并保留零值。
+func Collect(ctx context.Context, src Source, symbol, interval string, limit int) (ai.MarketDataSnapshot, error) {
+	if src == nil {
+		return ai.MarketDataSnapshot{}, errors.New("market source is nil")
+	}
+	candles, err := src.GetKlines(ctx, symbol, interval, limit)
+	if err != nil {
+		return ai.MarketDataSnapshot{}, fmt.Errorf("collect %s klines: %w", symbol, err)
+	}
+	snapshot := BuildSnapshot(symbol, interval, candles)
+
+	logger := loggerpkg.Get("market")
+	if rate, err := src.GetFundingRate(ctx, symbol); err == nil {
+		snapshot.FundingRate = rate
+	} else if !errors.Is(err, exchange.ErrUnsupported) {
+		logger.Printf("snapshot.funding.error symbol=%s err=%v", symbol, err)
+	}
+	if oi, err := src.GetOpenInterest(ctx, symbol); err == nil {
+		snapshot.OpenInterest = oi
+	} else if !errors.Is(err, exchange.ErrUnsupported) {
+		logger.Printf("snapshot.oi.error symbol=%s err=%v", symbol, err)
+	}
+	if ticker, err := src.Get24hTicker(ctx, symbol); err == nil {
+		ApplyTicker(&snapshot, ticker)
+	} else {
+		logger.Printf("snapshot.ticker.error symbol=%s err=%v", symbol, err)
+	}
+	if trades, ok := src.(exchange.TradeSource); ok {
+		if recent, err := trades.GetAggTrades(ctx, symbol, flowTradeLimit); err == nil && len(recent) > 0 {
+			ApplyFlows(&snapshot, TakerFlows(recent, recent[len(recent)-1].Time, FlowWindows))
+		} else if err != nil && !errors.Is(err, exchange.ErrUnsupported) {
+			logger.Printf("snapshot.aggtrades.error symbol=%s err=%v", symbol, err)
+		}
+	}
+	// 日线枢轴点取上一根完整日K线
+	if daily, err := src.GetKlines(ctx, symbol, "1d", 2); err == nil && len(daily) >= 2 {
+		ApplyLevels(&snapshot, Levels(candles, &daily[len(daily)-2]))
+	} else if err != nil {
+		logger.Printf("snapshot.daily.error symbol=%s err=%v", symbol, err)
+	}
+	return snapshot, nil
+}
+
+// ApplyTicker 将24h统计写入快照。
+func ApplyTicker(snapshot *ai.MarketDataSnapshot, ticker exchange.Ticker24h) {
+	if snapshot == nil {
+		return
+	}
+	snapshot.Volume24h = ticker.Volume
+	snapshot.QuoteVolume24h = ticker.QuoteVolume
+	snapshot.PriceChange24h = ticker.PriceChangePercent
+	snapshot.High24h = ticker.HighPrice
+	snapshot.Low24h = ticker.LowPrice
+	if snapshot.CurrentPrice == 0 {
+		snapshot.CurrentPrice = ticker.LastPrice
+	}
+}
+
+// BuildSnapshot 根据K线计算价格变化与技术指标。
+func BuildSnapshot(symbol, interval string, candles []strategy.Candle) ai.MarketDataSnapshot {
+	snapshot := ai.MarketDataSnapshot{Symbol: symbol, DataInterval: interval}
+	if len(candles) == 0 {
+		return snapshot
+	}
+
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	last := len(closes) - 1
+	snapshot.CurrentPrice = closes[last]
+
+	if step, ok := IntervalDuration(interval); ok {
+		snapshot.PriceChange1h = changeOver(closes, int(time.Hour/step))
+		snapshot.PriceChange4h = changeOver(closes, int(4*time.Hour/step))
+	}
+
+	if ema, err := indicators.EMA(closes, 20); err == nil {
+		snapshot.EMA20 = finite(ema[last])
+	}
+	if macd, signal, _, err := indicators.MACD(closes, 12, 26, 9); err == nil {
+		snapshot.MACD = finite(macd[last])
+		snapshot.MACDSignal = finite(signal[last])
+	}
+	if rsi, err := indicators.RSI(closes, 7); err == nil {
+		snapshot.RSI7 = finite(rsi[last])
+	}
+	if rsi, err := indicators.RSI(closes, 14); err == nil {
+		snapshot.RSI14 = finite(rsi[last])
+	}
+	seen := make(map[string]bool)
+	for _, pattern := range strategy.CandlePatterns(candles, patternWindow) {
+		if !seen[pattern.Name] {
+			seen[pattern.Name] = true
+			snapshot.Patterns = append(snapshot.Patterns, pattern.Name)
+		}
+	}
+	ApplyLevels(&snapshot, Levels(candles, nil))
+	if strength, ok := strategy.LatestADX(candles, strategy.DefaultADXPeriod); ok {
+		snapshot.ADX = finite(strength.ADX)
+		snapshot.PlusDI = finite(strength.PlusDI)
+		snapshot.MinusDI = finite(strength.MinusDI)
+	}
+	ApplyCCI(&snapshot, candles, strategy.DefaultCCIPeriod)
+	if vwap, ok := strategy.RollingVWAP(candles, strategy.DefaultVWAPPeriod); ok {
+		snapshot.VWAP = vwap
+	}
+	if vwap, ok := strategy.SessionVWAP(candles); ok {
+		snapshot.SessionVWAP = vwap
+	}
+	if profile, ok := strategy.VolumeProfile(candles); ok {
+		snapshot.VolumeProfile = &ai.VolumeProfile{
+			POC:           profile.POC,
+			ValueAreaHigh: profile.ValueAreaHigh,
+			ValueAreaLow:  profile.ValueAreaLow,
+		}
+	}
+	return snapshot
+}
+
+// ApplyCCI 按 period（交易者的 cciPeriod，0 为默认20）重新计算快照中的 CCI；K线不足时清空。
+func ApplyCCI(snapshot *ai.MarketDataSnapshot, candles []strategy.Candle, period int) {
+	if snapshot == nil {
+		return
+	}
+	if period <= 0 {
+		period = strategy.DefaultCCIPeriod
+	}
+	snapshot.CCI, snapshot.CCIPeriod = 0, 0
+	if cci, ok := strategy.LatestCCI(candles, period); ok {
+		snapshot.CCI, snapshot.CCIPeriod = finite(cci), period
+	}
+}
+
+// patternWindow 为快照中识别K线形态的最近K线数量。
+const patternWindow = 3
+
+// IntervalDuration 将K线周期字符串（如 1m/4h/1d/1w）转换为时长。
+func IntervalDuration(interval string) (time.Duration, bool) {
+	interval = strings.TrimSpace(interval)
+	if len(interval) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	var unit time.Duration
+	switch interval[len(interval)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+func changeOver(closes []float64, bars int) float64 {
+	if bars <= 0 || len(closes) <= bars {
+		return 0
+	}
+	base := closes[len(closes)-1-bars]
+	if base == 0 {
+		return 0
+	}
+	return (closes[len(closes)-1]/base - 1) * 100
+}
+
+func finite(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
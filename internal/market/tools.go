@@ -0,0 +1,185 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+)
+
+// 数据工具单次返回的上限，避免一次调用塞满上下文窗口。
+const (
+	toolMaxKlines       = 200
+	toolMaxDepth        = 50
+	toolMaxFundingHours = 7 * 24
+)
+
+// Tools 以行情数据源实现 ai.Toolbox：get_klines 返回K线，数据源实现 exchange.DepthSource 时
+// 提供 get_depth，实现 exchange.FundingHistorySource 时提供 get_funding_history。
+// Interval 为 get_klines 未指定周期时使用的周期。
+type Tools struct {
+	Source   Source
+	Interval string
+}
+
+var _ ai.Toolbox = Tools{}
+
+func (t Tools) Specs() []ai.ToolSpec {
+	symbol := map[string]any{"type": "string", "description": "交易对，如 BTCUSDT"}
+	specs := []ai.ToolSpec{{
+		Name:        ai.ToolGetKlines,
+		Description: fmt.Sprintf("获取某交易对最近的K线（开高低收量），最多 %d 根，用于查看其他周期或更长的历史走势。", toolMaxKlines),
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"symbol":   symbol,
+				"interval": map[string]any{"type": "string", "description": "K线周期，如 15m、1h、4h、1d，默认 " + t.Interval},
+				"limit":    map[string]any{"type": "integer", "minimum": 1, "maximum": toolMaxKlines},
+			},
+			"required": []string{"symbol"},
+		},
+	}}
+	if _, ok := t.Source.(exchange.DepthSource); ok {
+		specs = append(specs, ai.ToolSpec{
+			Name:        ai.ToolGetDepth,
+			Description: fmt.Sprintf("获取当前盘口深度（买卖各最多 %d 档），用于判断挂单墙与流动性。", toolMaxDepth),
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"symbol": symbol,
+					"limit":  map[string]any{"type": "integer", "minimum": 1, "maximum": toolMaxDepth},
+				},
+				"required": []string{"symbol"},
+			},
+		})
+	}
+	if _, ok := t.Source.(exchange.FundingHistorySource); ok {
+		specs = append(specs, ai.ToolSpec{
+			Name:        ai.ToolGetFundingHistory,
+			Description: fmt.Sprintf("获取最近若干小时已结算的资金费率（最多 %d 小时），用于判断多空拥挤程度。", toolMaxFundingHours),
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"symbol": symbol,
+					"hours":  map[string]any{"type": "integer", "minimum": 1, "maximum": toolMaxFundingHours},
+				},
+				"required": []string{"symbol"},
+			},
+		})
+	}
+	return specs
+}
+
+// toolArgs 为各工具参数的并集。
+type toolArgs struct {
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	Limit    int    `json:"limit"`
+	Hours    int    `json:"hours"`
+}
+
+func (t Tools) Call(ctx context.Context, name string, raw json.RawMessage) (string, error) {
+	var args toolArgs
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return "", fmt.Errorf("参数不是合法JSON: %w", err)
+		}
+	}
+	args.Symbol = strings.ToUpper(strings.TrimSpace(args.Symbol))
+	if args.Symbol == "" {
+		return "", fmt.Errorf("缺少 symbol")
+	}
+	var result any
+	var err error
+	switch name {
+	case ai.ToolGetKlines:
+		result, err = t.klines(ctx, args)
+	case ai.ToolGetDepth:
+		result, err = t.depth(ctx, args)
+	case ai.ToolGetFundingHistory:
+		result, err = t.funding(ctx, args)
+	default:
+		return "", fmt.Errorf("未知工具 %s", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (t Tools) klines(ctx context.Context, args toolArgs) (any, error) {
+	interval := args.Interval
+	if interval == "" {
+		interval = t.Interval
+	}
+	if _, ok := IntervalDuration(interval); !ok {
+		return nil, fmt.Errorf("不支持的K线周期 %q", interval)
+	}
+	limit := clampInt(args.Limit, 50, toolMaxKlines)
+	candles, err := t.Source.GetKlines(ctx, args.Symbol, interval, limit)
+	if err != nil {
+		return nil, err
+	}
+	// 紧凑的列式输出：[开盘时间, 开, 高, 低, 收, 量]
+	rows := make([][6]any, len(candles))
+	for i, c := range candles {
+		rows[i] = [6]any{c.OpenTime.UTC().Format("2006-01-02 15:04"), c.Open, c.High, c.Low, c.Close, c.Volume}
+	}
+	return map[string]any{"symbol": args.Symbol, "interval": interval, "columns": "time,open,high,low,close,volume", "klines": rows}, nil
+}
+
+func (t Tools) depth(ctx context.Context, args toolArgs) (any, error) {
+	src, ok := t.Source.(exchange.DepthSource)
+	if !ok {
+		return nil, fmt.Errorf("数据源不支持盘口深度")
+	}
+	book, err := src.GetDepth(ctx, args.Symbol, clampInt(args.Limit, 20, toolMaxDepth))
+	if err != nil {
+		return nil, err
+	}
+	levels := func(in []exchange.DepthLevel) [][2]float64 {
+		out := make([][2]float64, len(in))
+		for i, level := range in {
+			out[i] = [2]float64{level.Price, level.Quantity}
+		}
+		return out
+	}
+	return map[string]any{"symbol": args.Symbol, "time": book.Time.UTC().Format(time.RFC3339), "bids": levels(book.Bids), "asks": levels(book.Asks)}, nil
+}
+
+func (t Tools) funding(ctx context.Context, args toolArgs) (any, error) {
+	src, ok := t.Source.(exchange.FundingHistorySource)
+	if !ok {
+		return nil, fmt.Errorf("数据源不支持资金费率历史")
+	}
+	hours := clampInt(args.Hours, 72, toolMaxFundingHours)
+	end := time.Now()
+	events, err := src.GetFundingHistory(ctx, args.Symbol, end.Add(-time.Duration(hours)*time.Hour), end)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][2]any, len(events))
+	for i, event := range events {
+		rows[i] = [2]any{event.Time.UTC().Format("2006-01-02 15:04"), event.Rate}
+	}
+	return map[string]any{"symbol": args.Symbol, "hours": hours, "columns": "time,rate", "funding": rows}, nil
+}
+
+// clampInt 把 v 限制在 1~max，未指定（不大于 0）时取 def。
+func clampInt(v, def, max int) int {
+	if v <= 0 {
+		return def
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
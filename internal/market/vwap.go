@@ -0,0 +1,28 @@
+package market
+
+import (
+	"time"
+
+	"autobot/internal/strategy"
+)
+
+// SessionVWAP 计算 day 所在UTC自然日内K线的成交量加权均价（典型价 (H+L+C)/3）。
+// 当日尚未结束时为截至最新K线的部分时段VWAP；无成交量时返回 false。
+func SessionVWAP(candles []strategy.Candle, day time.Time) (float64, bool) {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	notional, volume := 0.0, 0.0
+	for _, c := range candles {
+		t := c.OpenTime.UTC()
+		if t.Before(start) || !t.Before(end) {
+			continue
+		}
+		notional += (c.High + c.Low + c.Close) / 3 * c.Volume
+		volume += c.Volume
+	}
+	if volume == 0 {
+		return 0, false
+	}
+	return notional / volume, true
+}
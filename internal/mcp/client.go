@@ -164,8 +164,20 @@ func isNetworkError(err error) bool {
 	return false
 }
 
-// CallWithMessages 带重试的AI调用
-func CallWithMessages(systemPrompt, userPrompt string) (string, error) {
+// Wait 等待 d 或直到 ctx 结束，ctx 先结束时返回 ctx.Err()，用于重试间隔，关停时不必等满退避时间。
+func Wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// CallWithMessages 带重试的AI调用，ctx 取消或超时后不再重试，进行中的请求随之中断。
+func CallWithMessages(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	config := GetConfig()
 	
 	// 构建 messages 数组
@@ -185,15 +197,23 @@ func CallWithMessages(systemPrompt, userPrompt string) (string, error) {
 	var lastErr error
 	
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		response, err := callOnce(config, messages)
+		response, err := callOnce(ctx, config, messages)
 		if err == nil {
 			return response, nil  // 成功返回
 		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
 		
 		// 网络错误时智能重试
 		if isNetworkError(err) {
 			lastErr = err
-			time.Sleep(time.Duration(attempt) * 2 * time.Second)  // 指数退避
+			if attempt == maxRetries {
+				break
+			}
+			if err := Wait(ctx, time.Duration(attempt)*2*time.Second); err != nil {
+				return "", fmt.Errorf("重试等待中止: %w（上次错误: %v）", err, lastErr)
+			}
 			continue
 		}
 		
@@ -204,7 +224,7 @@ func CallWithMessages(systemPrompt, userPrompt string) (string, error) {
 }
 
 // callOnce 单次调用AI API
-func callOnce(config Config, messages []map[string]string) (string, error) {
+func callOnce(ctx context.Context, config Config, messages []map[string]string) (string, error) {
 	// 构建请求体
 	requestBody := map[string]interface{}{
 		"model":       config.Model,
@@ -219,7 +239,7 @@ func callOnce(config Config, messages []map[string]string) (string, error) {
 	}
 
 	// 创建HTTP请求
-	req, err := http.NewRequest("POST", config.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.BaseURL+"/chat/completions", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("创建请求失败: %w", err)
 	}
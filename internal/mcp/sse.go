@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PostSSE 发送 JSON 请求并按 Server-Sent Events 读取响应，逐条回调 data 字段，
+// 收到 [DONE] 或连接结束时返回。请求体需自行携带 stream=true。
+func (c *Client) PostSSE(ctx context.Context, path string, headers map[string]string, reqPayload any, onData func(data []byte) error) error {
+	if c == nil {
+		return fmt.Errorf("mcp client is nil")
+	}
+	data, err := json.Marshal(reqPayload)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+	for k, v := range headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("http status %d", resp.StatusCode)
+	}
+	return ReadSSE(resp.Body, onData)
+}
+
+// ReadSSE 解析 SSE 流：多行 data 按规范以换行拼接，事件以空行结束，data 为 [DONE] 时停止。
+func ReadSSE(r io.Reader, onData func(data []byte) error) error {
+	reader := bufio.NewReader(r)
+	var event bytes.Buffer
+	dispatch := func() (bool, error) {
+		if event.Len() == 0 {
+			return false, nil
+		}
+		payload := bytes.TrimSpace(event.Bytes())
+		event.Reset()
+		if string(payload) == "[DONE]" {
+			return true, nil
+		}
+		return false, onData(payload)
+	}
+	for {
+		line, err := reader.ReadBytes('\n')
+		trimmed := bytes.TrimRight(line, "\r\n")
+		switch {
+		case len(trimmed) == 0 && len(line) > 0:
+			if done, dispatchErr := dispatch(); done || dispatchErr != nil {
+				return dispatchErr
+			}
+		case bytes.HasPrefix(trimmed, []byte("data:")):
+			if event.Len() > 0 {
+				event.WriteByte('\n')
+			}
+			event.Write(bytes.TrimPrefix(bytes.TrimPrefix(trimmed, []byte("data:")), []byte(" ")))
+		}
+		if err == io.EOF {
+			_, dispatchErr := dispatch()
+			return dispatchErr
+		}
+		if err != nil {
+			return fmt.Errorf("read stream: %w", err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+// Package metrics implements a small Prometheus-compatible registry
+// (counters, gauges and histograms with labels) rendered in the text
+// exposition format, so the bot can be scraped without extra dependencies.
+//
+// Naming conventions, relied on by the bundled Grafana dashboard:
+//   - every metric is prefixed with "autobot_";
+//   - values use base units: seconds, USD, ratios in 0-1;
+//   - counters end in "_total", unit suffixes precede it (e.g. _usd_total);
+//   - label names are fixed: trader, symbol, exchange, provider, model,
+//     kind, action, side, method, status. A label that does not apply is
+//     omitted rather than set to a placeholder.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Prefix is the namespace shared by all metrics.
+const Prefix = "autobot_"
+
+// DefaultBuckets suit request latencies in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+)
+
+// Registry holds metric families. The zero value is not usable; use
+// NewRegistry or the package-level Default.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Default is the registry served by Handler.
+var Default = NewRegistry()
+
+type family struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	sum         float64
+	count       uint64
+}
+
+func (r *Registry) register(name, help, kind string, labels []string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		if f.kind != kind || strings.Join(f.labels, ",") != strings.Join(labels, ",") {
+			panic(fmt.Sprintf("metrics: %s re-registered with a different type or labels", name))
+		}
+		return f
+	}
+	f := &family{name: name, help: help, kind: kind, labels: labels, buckets: buckets, series: make(map[string]*series)}
+	r.families[name] = f
+	return f
+}
+
+func (f *family) get(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: append([]string(nil), values...)}
+		if f.kind == typeHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// deleteMatching removes every series whose label equals value.
+func (f *family) deleteMatching(label, value string) {
+	idx := -1
+	for i, name := range f.labels {
+		if name == label {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for key, s := range f.series {
+		if s.labelValues[idx] == value {
+			delete(f.series, key)
+		}
+	}
+}
+
+// CounterVec is a monotonically increasing value per label set.
+type CounterVec struct{ f *family }
+
+// NewCounter registers a counter in Default. name excludes Prefix.
+func NewCounter(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{f: Default.register(Prefix+name, help, typeCounter, labels, nil)}
+}
+
+// Add increases the counter; negative values are ignored.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	if v < 0 || math.IsNaN(v) {
+		return
+	}
+	c.f.mu.Lock()
+	c.f.get(labelValues).value += v
+	c.f.mu.Unlock()
+}
+
+// Inc adds one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// GaugeVec is a value per label set that can go up and down.
+type GaugeVec struct{ f *family }
+
+// NewGauge registers a gauge in Default. name excludes Prefix.
+func NewGauge(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{f: Default.register(Prefix+name, help, typeGauge, labels, nil)}
+}
+
+// Set replaces the gauge value.
+func (g *GaugeVec) Set(v float64, labelValues ...string) {
+	g.f.mu.Lock()
+	g.f.get(labelValues).value = v
+	g.f.mu.Unlock()
+}
+
+// Add changes the gauge by v, which may be negative (e.g. a running PnL).
+func (g *GaugeVec) Add(v float64, labelValues ...string) {
+	g.f.mu.Lock()
+	g.f.get(labelValues).value += v
+	g.f.mu.Unlock()
+}
+
+// DeleteMatching drops all series whose label equals value, e.g. the
+// positions of a trader before publishing the current set.
+func (g *GaugeVec) DeleteMatching(label, value string) {
+	g.f.deleteMatching(label, value)
+}
+
+// HistogramVec counts observations into cumulative buckets per label set.
+type HistogramVec struct{ f *family }
+
+// NewHistogram registers a histogram in Default. name excludes Prefix;
+// nil buckets use DefaultBuckets.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &HistogramVec{f: Default.register(Prefix+name, help, typeHistogram, labels, buckets)}
+}
+
+// Observe records one value.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	if math.IsNaN(v) {
+		return
+	}
+	h.f.mu.Lock()
+	defer h.f.mu.Unlock()
+	s := h.f.get(labelValues)
+	for i, bound := range h.f.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+// WriteText renders all families in the Prometheus text format, sorted by
+// name and label values so the output is stable.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+func (f *family) write(w *bufio.Writer) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.series) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		if f.kind != typeHistogram {
+			fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), formatValue(s.value))
+			continue
+		}
+		for i, bound := range f.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", formatValue(bound)), s.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), s.count)
+	}
+}
+
+func formatLabels(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", name, escapeLabel(values[i]))
+	}
+	if extraName != "" {
+		if len(names) > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", extraName, extraValue)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// escapeLabel replaces invalid UTF-8; %q then escapes backslashes, quotes and
+// newlines as the exposition format requires while leaving printable
+// non-ASCII text (e.g. Chinese trader names) as is.
+func escapeLabel(value string) string {
+	return strings.ToValidUTF8(value, "?")
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// Handler serves Default in the Prometheus text format.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = Default.WriteText(w)
+	})
+}
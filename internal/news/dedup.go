@@ -0,0 +1,105 @@
+package news
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// SeenRecord 为一条已见新闻的哈希及首次出现时间（毫秒）。
+type SeenRecord struct {
+	Hash   string `json:"hash"`
+	SeenAt int64  `json:"seenAt"`
+}
+
+// SeenRecorder 持久化已见新闻；storage.Store 满足该接口。
+type SeenRecorder interface {
+	RecordSeenArticle(ctx context.Context, record SeenRecord) error
+}
+
+// ArticleHash 按链接与标题计算新闻的哈希，忽略大小写与首尾空白；同一快讯重复抓取得到相同的值。
+func ArticleHash(article Article) string {
+	key := strings.ToLower(strings.TrimSpace(article.URL)) + "\n" + strings.ToLower(strings.TrimSpace(article.Title))
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetHash 返回一组新闻的哈希，与顺序无关，用作情绪分析结果的缓存键。
+func SetHash(articles []Article) string {
+	hashes := make([]string, len(articles))
+	for i, article := range articles {
+		hashes[i] = ArticleHash(article)
+	}
+	sort.Strings(hashes)
+	sum := sha256.Sum256([]byte(strings.Join(hashes, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Dedup 记录 TTL 内已见过的新闻，提醒前用 Fresh 过滤掉已处理的条目；recorder 不为空时
+// 新见的哈希同时落盘，重启后用 Seed 恢复。
+type Dedup struct {
+	ttl      time.Duration
+	recorder SeenRecorder
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewDedup 创建去重器，recorder 可为空（只在内存中去重）。
+func NewDedup(ttl time.Duration, recorder SeenRecorder) *Dedup {
+	return &Dedup{ttl: ttl, recorder: recorder, seen: make(map[string]time.Time)}
+}
+
+// Seed 恢复已落盘的记录，过期的记录被忽略。
+func (d *Dedup) Seed(records []SeenRecord) {
+	cutoff := time.Now().Add(-d.ttl)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, rec := range records {
+		at := time.UnixMilli(rec.SeenAt)
+		if at.After(cutoff) && at.After(d.seen[rec.Hash]) {
+			d.seen[rec.Hash] = at
+		}
+	}
+}
+
+// Fresh 返回 TTL 内未见过的新闻并把它们标记为已见。
+func (d *Dedup) Fresh(ctx context.Context, articles []Article) []Article {
+	now := time.Now()
+	var (
+		fresh   []Article
+		records []SeenRecord
+	)
+	d.mu.Lock()
+	for hash, at := range d.seen {
+		if !now.Before(at.Add(d.ttl)) {
+			delete(d.seen, hash)
+		}
+	}
+	for _, article := range articles {
+		hash := ArticleHash(article)
+		if _, ok := d.seen[hash]; ok {
+			continue
+		}
+		d.seen[hash] = now
+		fresh = append(fresh, article)
+		records = append(records, SeenRecord{Hash: hash, SeenAt: now.UnixMilli()})
+	}
+	d.mu.Unlock()
+
+	if d.recorder != nil {
+		for _, rec := range records {
+			if err := d.recorder.RecordSeenArticle(ctx, rec); err != nil {
+				loggerpkg.Get("news.dedup").Printf("seen.record.error: %v", err)
+				break
+			}
+		}
+	}
+	return fresh
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"sort"
@@ -32,6 +33,42 @@ type SentimentSummary struct {
 	Score       float64  `json:"score"`
 	Highlights  []string `json:"highlights"`
 	RiskFactors []string `json:"riskFactors"`
+
+	// Symbols 为新闻涉及币种的情绪分，-1 利空到 1 利好；键为基础币种（如 BTC），模型未给出时为空。
+	Symbols map[string]float64 `json:"symbols,omitempty"`
+
+	// LowConfidence 表示结果来自本地词典等兜底评分而非模型分析，Source 记录来源（如 lexicon）。
+	LowConfidence bool   `json:"lowConfidence,omitempty"`
+	Source        string `json:"source,omitempty"`
+}
+
+// SymbolScore 返回交易对（BTCUSDT、BTC-USDT 或 BTC 均可）对应币种的情绪分，限制在 [-1, 1]。
+func (s SentimentSummary) SymbolScore(symbol string) (float64, bool) {
+	base := BaseAsset(symbol)
+	if base == "" {
+		return 0, false
+	}
+	for key, score := range s.Symbols {
+		if BaseAsset(key) == base {
+			return math.Max(-1, math.Min(1, score)), true
+		}
+	}
+	return 0, false
+}
+
+// quoteSuffixes 为识别基础币种时去掉的计价币后缀，较长的在前。
+var quoteSuffixes = []string{"USDT", "USDC", "BUSD", "USD"}
+
+// BaseAsset 提取交易对的基础币种：大写并去掉分隔符与计价币后缀。
+func BaseAsset(symbol string) string {
+	base := strings.ToUpper(strings.TrimSpace(symbol))
+	base = strings.NewReplacer("-", "", "/", "", "_", "", ":", "").Replace(base)
+	for _, quote := range quoteSuffixes {
+		if len(base) > len(quote) && strings.HasSuffix(base, quote) {
+			return strings.TrimSuffix(base, quote)
+		}
+	}
+	return base
 }
 
 // Fetcher负责从外部接口拉取新闻。
@@ -97,6 +134,8 @@ func (f *Fetcher) FetchLatest(ctx context.Context) ([]Article, error) {
 		items, err = f.fetchCryptoPanic(ctx)
 	case "blockbeats":
 		items, err = f.fetchBlockBeats(ctx)
+	case config.NewsProviderWebhook:
+		items, err = f.fetchWebhook(ctx)
 	default:
 		items, err = f.fetchGeneric(ctx)
 	}
@@ -109,6 +148,15 @@ func (f *Fetcher) FetchLatest(ctx context.Context) ([]Article, error) {
 	if len(items) == 0 {
 		return nil, errors.New("新闻源未返回有效内容")
 	}
+	if filtered := Filter(items, f.cfg.Filter); len(filtered) != len(items) {
+		if f.logger != nil {
+			f.logger.Printf("filter provider=%s kept=%d dropped=%d", f.cfg.Provider, len(filtered), len(items)-len(filtered))
+		}
+		items = filtered
+		if len(items) == 0 {
+			return nil, errors.New("新闻经过滤后没有剩余内容")
+		}
+	}
 
 	f.storeCache(items)
 	if f.logger != nil {
@@ -523,22 +571,8 @@ func strVal(obj map[string]any, key string) string {
 func parseBlockBeatsTime(obj map[string]any) time.Time {
 	for _, key := range []string{"publish_time", "publishTime", "publish_time_str", "flash_time", "add_time", "created_at", "createdAt", "updated_at", "updatedAt", "createdTime", "post_time", "release_time"} {
 		if v, ok := obj[key]; ok {
-			switch t := v.(type) {
-			case float64:
-				if t > 1e12 {
-					return time.UnixMilli(int64(t))
-				}
-				return time.Unix(int64(t), 0)
-			case string:
-				if ts := parseTime(t); !ts.IsZero() {
-					return ts
-				}
-				if unix, err := strconv.ParseInt(strings.TrimSpace(t), 10, 64); err == nil {
-					if unix > 1e12 {
-						return time.UnixMilli(unix)
-					}
-					return time.Unix(unix, 0)
-				}
+			if ts := parseTimeValue(v, ""); !ts.IsZero() {
+				return ts
 			}
 		}
 	}
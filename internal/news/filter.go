@@ -0,0 +1,95 @@
+package news
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+
+	"autobot/internal/config"
+)
+
+// DetectLanguage 按标题与摘要中汉字所占比例粗略判断语言：含汉字较多时为 zh，否则为 en。
+func DetectLanguage(article Article) string {
+	var han, letters int
+	for _, r := range article.Title + " " + article.Summary {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.IsLetter(r):
+			letters++
+		}
+	}
+	// 中文快讯常夹杂英文币种代码，汉字每个字符的信息量远大于字母，按 1:4 折算
+	if han > 0 && han*4 >= letters {
+		return "zh"
+	}
+	return "en"
+}
+
+// ArticleDomain 返回新闻链接的小写主机名（去掉 www.），链接缺失或无法解析时为空。
+func ArticleDomain(article Article) string {
+	parsed, err := url.Parse(strings.TrimSpace(article.URL))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// Filter 按配置过滤新闻，保持原有顺序；每个来源超过 SourceMaxItems 的部分丢弃。
+func Filter(articles []Article, cfg config.NewsFilterConfig) []Article {
+	languages := lowerSet(cfg.Languages)
+	excludeSources := lowerSet(cfg.ExcludeSources)
+	limits := make(map[string]int, len(cfg.SourceMaxItems))
+	for source, limit := range cfg.SourceMaxItems {
+		limits[strings.ToLower(strings.TrimSpace(source))] = limit
+	}
+
+	counts := make(map[string]int)
+	out := make([]Article, 0, len(articles))
+	for _, article := range articles {
+		source := strings.ToLower(strings.TrimSpace(article.Source))
+		if excludeSources[source] {
+			continue
+		}
+		if len(languages) > 0 && !languages[DetectLanguage(article)] {
+			continue
+		}
+		domain := ArticleDomain(article)
+		if len(cfg.Domains) > 0 && !matchDomain(domain, cfg.Domains) {
+			continue
+		}
+		if matchDomain(domain, cfg.ExcludeDomains) {
+			continue
+		}
+		if limit, ok := limits[source]; ok && counts[source] >= limit {
+			continue
+		}
+		counts[source]++
+		out = append(out, article)
+	}
+	return out
+}
+
+// matchDomain 判断 domain 是否等于列表中的某个域名或为其子域名。
+func matchDomain(domain string, domains []string) bool {
+	if domain == "" {
+		return false
+	}
+	for _, d := range domains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "www.")
+		if d != "" && (domain == d || strings.HasSuffix(domain, "."+d)) {
+			return true
+		}
+	}
+	return false
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			set[v] = true
+		}
+	}
+	return set
+}
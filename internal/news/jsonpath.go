@@ -0,0 +1,108 @@
+package news
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pathStep 为 JSONPath 中的一段：对象键、数组下标或通配 [*]。
+type pathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// compilePath 解析 JSONPath 子集：以 $ 开头，支持 .key、.*、['key']、["key"]、[n]（负数从末尾计）与 [*]。
+func compilePath(path string) ([]pathStep, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("jsonpath %q 需以 $ 开头", path)
+	}
+	var steps []pathStep
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return nil, fmt.Errorf("jsonpath %q 中有空的键名", path)
+			}
+			if key == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+			} else {
+				steps = append(steps, pathStep{key: key})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("jsonpath %q 缺少 ]", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			switch {
+			case inner == "*":
+				steps = append(steps, pathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, pathStep{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath %q 中的下标 %q 无效", path, inner)
+				}
+				steps = append(steps, pathStep{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("jsonpath %q 在 %q 处无法解析", path, rest)
+		}
+	}
+	return steps, nil
+}
+
+// evalPath 在 json.Unmarshal 得到的值上求路径，返回全部匹配值；通配对象时按键名排序。
+func evalPath(root any, steps []pathStep) []any {
+	nodes := []any{root}
+	for _, step := range steps {
+		var next []any
+		for _, node := range nodes {
+			switch typed := node.(type) {
+			case map[string]any:
+				if step.wildcard {
+					keys := make([]string, 0, len(typed))
+					for key := range typed {
+						keys = append(keys, key)
+					}
+					sort.Strings(keys)
+					for _, key := range keys {
+						next = append(next, typed[key])
+					}
+				} else if value, ok := typed[step.key]; ok && !step.isIndex {
+					next = append(next, value)
+				}
+			case []any:
+				switch {
+				case step.wildcard:
+					next = append(next, typed...)
+				case step.isIndex:
+					index := step.index
+					if index < 0 {
+						index += len(typed)
+					}
+					if index >= 0 && index < len(typed) {
+						next = append(next, typed[index])
+					}
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
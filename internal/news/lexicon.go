@@ -0,0 +1,197 @@
+package news
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LexiconSource 为本地词典评分结果的来源标记。
+const LexiconSource = "lexicon"
+
+// DefaultLexicon 为内置的加密新闻情绪词典，权重为正表示利好、为负表示利空；
+// 英文关键词按小写整词匹配（ban 不命中 bank），中文按子串匹配。
+var DefaultLexicon = map[string]float64{
+	"surge":         0.6,
+	"surges":        0.6,
+	"soar":          0.6,
+	"rally":         0.5,
+	"all-time high": 0.7,
+	"breakout":      0.4,
+	"approval":      0.6,
+	"approved":      0.6,
+	"etf inflow":    0.5,
+	"adoption":      0.4,
+	"partnership":   0.3,
+	"upgrade":       0.3,
+	"listing":       0.3,
+	"bullish":       0.5,
+	"buyback":       0.4,
+	"plunge":        -0.6,
+	"plunges":       -0.6,
+	"crash":         -0.7,
+	"crashes":       -0.7,
+	"dump":          -0.5,
+	"hack":          -0.8,
+	"hacked":        -0.8,
+	"exploit":       -0.8,
+	"stolen":        -0.7,
+	"lawsuit":       -0.5,
+	"sec sues":      -0.6,
+	"ban":           -0.6,
+	"delist":        -0.6,
+	"liquidation":   -0.4,
+	"outflow":       -0.4,
+	"bearish":       -0.5,
+	"bankruptcy":    -0.8,
+	"insolvent":     -0.8,
+	"上涨":            0.5,
+	"大涨":            0.6,
+	"暴涨":            0.7,
+	"新高":            0.6,
+	"突破":            0.4,
+	"利好":            0.6,
+	"获批":            0.6,
+	"通过":            0.3,
+	"增持":            0.4,
+	"流入":            0.4,
+	"上线":            0.3,
+	"合作":            0.3,
+	"回购":            0.4,
+	"下跌":            -0.5,
+	"大跌":            -0.6,
+	"暴跌":            -0.7,
+	"利空":            -0.6,
+	"被盗":            -0.8,
+	"黑客":            -0.7,
+	"漏洞":            -0.6,
+	"攻击":            -0.6,
+	"起诉":            -0.5,
+	"禁止":            -0.6,
+	"下架":            -0.6,
+	"清算":            -0.4,
+	"爆仓":            -0.5,
+	"流出":            -0.4,
+	"破产":            -0.8,
+	"减持":            -0.4,
+}
+
+// lexiconThreshold 为判定 bullish/bearish 的平均分阈值，介于其间视为 neutral。
+const lexiconThreshold = 0.15
+
+// tickerPattern 识别标题中的大写币种代码（如 BTC、ETH）。
+var tickerPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}\b`)
+
+// tickerStopwords 为常见的非币种大写缩写。
+var tickerStopwords = map[string]bool{
+	"ETF": true, "SEC": true, "CEO": true, "USD": true, "USDT": true, "USDC": true,
+	"US": true, "UK": true, "EU": true, "AI": true, "CPI": true, "FOMC": true, "FED": true,
+	"GDP": true, "IPO": true, "NFT": true, "DEX": true, "CEX": true, "TVL": true, "API": true,
+}
+
+// ScoreLexicon 用关键词词典为新闻打分，供AI不可用或预算用尽时兜底。extra 中的词覆盖或
+// 补充内置词典。结果标记为低置信：Score 为平均情绪强度（0-1），Highlights 取倾向最明显
+// 的标题，Symbols 为标题中出现的币种代码的平均分。
+func ScoreLexicon(articles []Article, extra map[string]float64) SentimentSummary {
+	lexicon := make(map[string]float64, len(DefaultLexicon)+len(extra))
+	for word, weight := range DefaultLexicon {
+		lexicon[word] = weight
+	}
+	for word, weight := range extra {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			lexicon[word] = weight
+		}
+	}
+
+	summary := SentimentSummary{Sentiment: "neutral", LowConfidence: true, Source: LexiconSource}
+	type scored struct {
+		title string
+		score float64
+	}
+	var (
+		items        []scored
+		total        float64
+		symbolScores = make(map[string][]float64)
+	)
+	for _, article := range articles {
+		text := strings.ToLower(article.Title + " " + article.Summary)
+		sum := 0.0
+		for word, weight := range lexicon {
+			if containsKeyword(text, word) {
+				sum += weight
+			}
+		}
+		score := math.Max(-1, math.Min(1, sum))
+		total += score
+		if title := strings.TrimSpace(article.Title); title != "" && score != 0 {
+			items = append(items, scored{title: title, score: score})
+		}
+		if score == 0 {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, ticker := range tickerPattern.FindAllString(article.Title, -1) {
+			base := BaseAsset(ticker)
+			if tickerStopwords[ticker] || tickerStopwords[base] || seen[base] {
+				continue
+			}
+			seen[base] = true
+			symbolScores[base] = append(symbolScores[base], score)
+		}
+	}
+	if len(articles) == 0 {
+		return summary
+	}
+
+	mean := total / float64(len(articles))
+	switch {
+	case mean >= lexiconThreshold:
+		summary.Sentiment = "bullish"
+	case mean <= -lexiconThreshold:
+		summary.Sentiment = "bearish"
+	}
+	summary.Score = math.Abs(mean)
+
+	sort.SliceStable(items, func(i, j int) bool { return math.Abs(items[i].score) > math.Abs(items[j].score) })
+	for _, item := range items {
+		if item.score > 0 && len(summary.Highlights) < 3 {
+			summary.Highlights = append(summary.Highlights, item.title)
+		}
+		if item.score < 0 && len(summary.RiskFactors) < 3 {
+			summary.RiskFactors = append(summary.RiskFactors, item.title)
+		}
+	}
+	for symbol, scores := range symbolScores {
+		if summary.Symbols == nil {
+			summary.Symbols = make(map[string]float64, len(symbolScores))
+		}
+		sum := 0.0
+		for _, s := range scores {
+			sum += s
+		}
+		summary.Symbols[symbol] = sum / float64(len(scores))
+	}
+	return summary
+}
+
+// containsKeyword 判断 text 是否包含 word；word 首尾为英文字母或数字时要求两侧不是字母数字。
+func containsKeyword(text, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		if !(isWordByte(word[0]) && start > 0 && isWordByte(text[start-1])) &&
+			!(isWordByte(word[len(word)-1]) && end < len(text) && isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9'
+}
@@ -0,0 +1,185 @@
+package news
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+)
+
+// webhookMapping 为编译后的 news.webhook 字段映射，未配置的字段为 nil。
+type webhookMapping struct {
+	items, title, summary, url, published, source []pathStep
+}
+
+func compileWebhook(cfg config.NewsWebhookConfig) (webhookMapping, error) {
+	var mapping webhookMapping
+	for _, field := range []struct {
+		name  string
+		path  string
+		steps *[]pathStep
+	}{
+		{"items", cfg.Items, &mapping.items},
+		{"title", cfg.Title, &mapping.title},
+		{"summary", cfg.Summary, &mapping.summary},
+		{"url", cfg.URL, &mapping.url},
+		{"time", cfg.Time, &mapping.published},
+		{"source", cfg.Source, &mapping.source},
+	} {
+		if strings.TrimSpace(field.path) == "" {
+			continue
+		}
+		steps, err := compilePath(field.path)
+		if err != nil {
+			return mapping, fmt.Errorf("news.webhook.%s: %w", field.name, err)
+		}
+		*field.steps = steps
+	}
+	if mapping.title == nil {
+		return mapping, errors.New("news.webhook.title 不能为空")
+	}
+	return mapping, nil
+}
+
+func (f *Fetcher) fetchWebhook(ctx context.Context) ([]Article, error) {
+	if f.cfg.APIURL == "" {
+		return nil, errors.New("news apiUrl为空")
+	}
+	mapping, err := compileWebhook(f.cfg.Webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.cfg.APIURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for key, value := range f.cfg.Webhook.Headers {
+		req.Header.Set(key, value)
+	}
+	if f.apiKey != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+f.apiKey)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch webhook news: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("webhook news status %d", resp.StatusCode)
+	}
+
+	var raw any
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode webhook news: %w", err)
+	}
+
+	articles := extractWebhook(raw, mapping, f.cfg.Webhook)
+	if len(articles) == 0 {
+		return nil, errors.New("webhook 新闻源按字段映射未取到有效文章")
+	}
+	if f.cfg.MaxItems > 0 && len(articles) > f.cfg.MaxItems {
+		articles = articles[:f.cfg.MaxItems]
+	}
+	return articles, nil
+}
+
+// extractWebhook 按字段映射从响应中取出新闻；Items 匹配到数组时展开其元素，没有标题的条目跳过。
+func extractWebhook(raw any, mapping webhookMapping, cfg config.NewsWebhookConfig) []Article {
+	var list []any
+	for _, value := range evalPath(raw, mapping.items) {
+		if items, ok := value.([]any); ok {
+			list = append(list, items...)
+		} else {
+			list = append(list, value)
+		}
+	}
+
+	sourceName := strings.TrimSpace(cfg.SourceName)
+	if sourceName == "" {
+		sourceName = "webhook"
+	}
+	articles := make([]Article, 0, len(list))
+	for _, item := range list {
+		title := pathString(item, mapping.title)
+		if title == "" {
+			continue
+		}
+		article := Article{
+			Title:   title,
+			Summary: pathString(item, mapping.summary),
+			URL:     pathString(item, mapping.url),
+			Source:  pathString(item, mapping.source),
+		}
+		if article.Source == "" {
+			article.Source = sourceName
+		}
+		if mapping.published != nil {
+			if values := evalPath(item, mapping.published); len(values) > 0 {
+				article.PublishedAt = parseTimeValue(values[0], cfg.TimeLayout)
+			}
+		}
+		articles = append(articles, article)
+	}
+	return articles
+}
+
+// pathString 返回路径的第一个匹配值的文本形式，未配置路径、没有匹配或匹配到对象/数组时为空。
+func pathString(item any, steps []pathStep) string {
+	if steps == nil {
+		return ""
+	}
+	values := evalPath(item, steps)
+	if len(values) == 0 {
+		return ""
+	}
+	switch v := values[0].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// parseTimeValue 解析 JSON 中的时间：数字按秒或毫秒时间戳，字符串先按 layout（非空时），
+// 再按常见日期格式与数字时间戳识别，无法识别时返回零值。
+func parseTimeValue(v any, layout string) time.Time {
+	switch t := v.(type) {
+	case float64:
+		return unixTime(int64(t))
+	case string:
+		t = strings.TrimSpace(t)
+		if layout != "" {
+			if ts, err := time.ParseInLocation(layout, t, time.Local); err == nil {
+				return ts
+			}
+		}
+		if ts := parseTime(t); !ts.IsZero() {
+			return ts
+		}
+		if unix, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return unixTime(unix)
+		}
+	}
+	return time.Time{}
+}
+
+// unixTime 把秒或毫秒时间戳（大于 1e12 视为毫秒）转为时间。
+func unixTime(v int64) time.Time {
+	if v > 1e12 {
+		return time.UnixMilli(v)
+	}
+	return time.Unix(v, 0)
+}
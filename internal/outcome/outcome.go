@@ -0,0 +1,346 @@
+// Package outcome 按决策之后的实际价格走势为历史决策标注好/中性/坏，
+// 使提供商、策略与提示词版本的对比可以基于方向准确率，而不只看盈亏。
+package outcome
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/market"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+)
+
+// 标注取值。
+const (
+	LabelGood    = "good"
+	LabelNeutral = "neutral"
+	LabelBad     = "bad"
+)
+
+// 决策隐含的方向。
+const (
+	DirectionLong  = "long"
+	DirectionShort = "short"
+	DirectionFlat  = "flat"
+)
+
+// maxKlines 为单次请求K线数量的上限（币安为1500）。
+const maxKlines = 1500
+
+// KlineSource 为标注所需的K线数据源，exchange.Exchange 满足该接口。
+type KlineSource interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+}
+
+// Config 为标注参数。NeutralPercent 为中性带：看多/看空决策的同向涨跌幅超过该值为好、反向超过为坏，
+// 其余为中性；观望决策在涨跌幅不超过该值时为好（没有错过行情），否则为中性。
+type Config struct {
+	Horizons       []time.Duration
+	NeutralPercent float64
+	// Interval 为取价使用的K线周期，Lookback 为只标注该时长内的决策（受单次K线数量上限约束）。
+	Interval string
+	Lookback time.Duration
+}
+
+// Direction 返回决策隐含的方向：开仓/加仓按动作方向，持有按持仓方向，平仓/减仓取所平持仓的反方向，
+// 观望为 flat；无法判断（如平仓时没有该交易对的持仓快照）时返回空。
+func Direction(record storage.DecisionRecord) string {
+	action := strings.ToLower(strings.TrimSpace(record.Action))
+	switch action {
+	case "open_long", "increase_long":
+		return DirectionLong
+	case "open_short", "increase_short":
+		return DirectionShort
+	case "wait":
+		return DirectionFlat
+	}
+	side := positionSide(record)
+	switch action {
+	case "hold":
+		if side == "" {
+			return DirectionFlat
+		}
+		return side
+	case "close", "exit", "reduce":
+		switch side {
+		case DirectionLong:
+			return DirectionShort
+		case DirectionShort:
+			return DirectionLong
+		}
+	}
+	return ""
+}
+
+func positionSide(record storage.DecisionRecord) string {
+	for _, pos := range record.Positions {
+		if !strings.EqualFold(pos.Symbol, record.Symbol) || pos.Quantity == 0 {
+			continue
+		}
+		switch strings.ToLower(pos.Side) {
+		case "long", "buy":
+			return DirectionLong
+		case "short", "sell":
+			return DirectionShort
+		}
+		if pos.Quantity > 0 {
+			return DirectionLong
+		}
+		return DirectionShort
+	}
+	return ""
+}
+
+// Label 按方向与涨跌幅（%）给出标注。
+func Label(direction string, returnPercent, neutralPercent float64) string {
+	switch direction {
+	case DirectionLong, DirectionShort:
+		move := returnPercent
+		if direction == DirectionShort {
+			move = -move
+		}
+		switch {
+		case move > neutralPercent:
+			return LabelGood
+		case move < -neutralPercent:
+			return LabelBad
+		}
+		return LabelNeutral
+	case DirectionFlat:
+		if returnPercent <= neutralPercent && returnPercent >= -neutralPercent {
+			return LabelGood
+		}
+	}
+	return LabelNeutral
+}
+
+// LabelDecisions 为 records 中已走完观察周期、且不在 done（以 DecisionLabel.Key 为键）中的决策生成标注。
+// candles 以交易对为键、按时间升序；价格取决策之后第一根K线开盘价到 决策时刻+周期 之后第一根K线开盘价，
+// 与币种池分析相同，只用决策之后的价格。方向无法判断或缺少K线的决策跳过。
+func LabelDecisions(records []storage.DecisionRecord, candles map[string][]strategy.Candle, done map[string]bool, cfg Config, now time.Time) []storage.DecisionLabel {
+	var out []storage.DecisionLabel
+	for _, record := range records {
+		direction := Direction(record)
+		if record.ID == "" || direction == "" {
+			continue
+		}
+		at := time.UnixMilli(record.CreatedAt)
+		for _, horizon := range cfg.Horizons {
+			label := storage.DecisionLabel{
+				DecisionID:    record.ID,
+				Trader:        record.Trader,
+				Provider:      record.Provider,
+				PromptVersion: record.PromptVersion,
+				Symbol:        record.Symbol,
+				Action:        record.Action,
+				Direction:     direction,
+				DecisionAt:    record.CreatedAt,
+				Horizon:       FormatHorizon(horizon),
+			}
+			if done[label.Key()] || at.Add(horizon).After(now) {
+				continue
+			}
+			ret, ok := forwardReturn(candles[record.Symbol], at, horizon)
+			if !ok {
+				continue
+			}
+			label.ReturnPercent = ret
+			label.Label = Label(direction, ret, cfg.NeutralPercent)
+			label.LabeledAt = now.UnixMilli()
+			out = append(out, label)
+		}
+	}
+	return out
+}
+
+// forwardReturn 返回 at 之后第一根K线开盘价到 at+horizon 之后第一根K线开盘价的涨跌幅（%）。
+func forwardReturn(candles []strategy.Candle, at time.Time, horizon time.Duration) (float64, bool) {
+	start := sort.Search(len(candles), func(i int) bool { return !candles[i].OpenTime.Before(at) })
+	end := sort.Search(len(candles), func(i int) bool { return !candles[i].OpenTime.Before(at.Add(horizon)) })
+	if end >= len(candles) || end <= start || candles[start].Open <= 0 {
+		return 0, false
+	}
+	return (candles[end].Open - candles[start].Open) / candles[start].Open * 100, true
+}
+
+// FormatHorizon 把观察周期格式化为 30m、4h、1d 等写法。
+func FormatHorizon(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// Labeler 为后台标注任务：定期读取已保存的决策与标注，为走完观察周期的决策拉取K线并追加标注。
+type Labeler struct {
+	Storage config.StorageConfig
+	Source  KlineSource
+	Config  Config
+}
+
+// Run 立即标注一次，之后每隔 every 标注一次，直到 ctx 结束。
+func (l Labeler) Run(ctx context.Context, every time.Duration) {
+	logger := loggerpkg.Get("outcome")
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		if n, err := l.LabelOnce(ctx, time.Now()); err != nil {
+			logger.Printf("outcome.label_failed err=%v", err)
+		} else if n > 0 {
+			logger.Printf("outcome.labeled count=%d", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LabelOnce 标注 Lookback 内尚未标注的决策并追加到存储，返回新增的标注数。
+func (l Labeler) LabelOnce(ctx context.Context, now time.Time) (int, error) {
+	if len(l.Config.Horizons) == 0 {
+		return 0, errors.New("outcome horizons are empty")
+	}
+	step, ok := market.IntervalDuration(l.Config.Interval)
+	if !ok {
+		return 0, fmt.Errorf("不支持的K线周期 %q", l.Config.Interval)
+	}
+	since := now.Add(-l.Config.Lookback)
+	records, err := storage.LoadDecisions(l.Storage, since)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+	existing, err := storage.LoadDecisionLabels(l.Storage, since)
+	if err != nil {
+		return 0, err
+	}
+	done := make(map[string]bool, len(existing))
+	for _, label := range existing {
+		done[label.Key()] = true
+	}
+
+	// 只为仍有待标注决策的交易对拉取K线，范围覆盖最早的待标注决策
+	oldest := map[string]time.Time{}
+	for _, record := range records {
+		if Direction(record) == "" || !pending(record, l.Config.Horizons, done, now) {
+			continue
+		}
+		at := time.UnixMilli(record.CreatedAt)
+		if first, ok := oldest[record.Symbol]; !ok || at.Before(first) {
+			oldest[record.Symbol] = at
+		}
+	}
+	candles := make(map[string][]strategy.Candle, len(oldest))
+	for symbol, first := range oldest {
+		limit := int(now.Sub(first)/step) + 2
+		if limit > maxKlines {
+			limit = maxKlines
+		}
+		data, err := l.Source.GetKlines(ctx, symbol, l.Config.Interval, limit)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			loggerpkg.Get("outcome").Printf("outcome.klines_failed symbol=%s err=%v", symbol, err)
+			continue
+		}
+		candles[symbol] = data
+	}
+	labels := LabelDecisions(records, candles, done, l.Config, now)
+	if err := storage.AppendDecisionLabels(l.Storage, labels); err != nil {
+		return 0, err
+	}
+	return len(labels), nil
+}
+
+func pending(record storage.DecisionRecord, horizons []time.Duration, done map[string]bool, now time.Time) bool {
+	at := time.UnixMilli(record.CreatedAt)
+	for _, horizon := range horizons {
+		key := storage.DecisionLabel{DecisionID: record.ID, Horizon: FormatHorizon(horizon)}.Key()
+		if !done[key] && !at.Add(horizon).After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Accuracy 为一组标注的汇总：Accuracy 为好/(好+坏)，AvgMove 为按决策方向计的平均涨跌幅（%，
+// 看空决策取反，观望决策不计入）。
+type Accuracy struct {
+	Key      string
+	Horizon  string
+	Good     int
+	Neutral  int
+	Bad      int
+	Accuracy float64
+	AvgMove  float64
+}
+
+// Summarize 按 key(label) 与观察周期汇总标注，结果按键与周期排序。
+func Summarize(labels []storage.DecisionLabel, key func(storage.DecisionLabel) string) []Accuracy {
+	type group struct {
+		acc         Accuracy
+		moves       float64
+		directional int
+	}
+	groups := map[[2]string]*group{}
+	for _, label := range labels {
+		k := [2]string{key(label), label.Horizon}
+		g := groups[k]
+		if g == nil {
+			g = &group{acc: Accuracy{Key: k[0], Horizon: k[1]}}
+			groups[k] = g
+		}
+		switch label.Label {
+		case LabelGood:
+			g.acc.Good++
+		case LabelBad:
+			g.acc.Bad++
+		default:
+			g.acc.Neutral++
+		}
+		switch label.Direction {
+		case DirectionLong:
+			g.moves += label.ReturnPercent
+			g.directional++
+		case DirectionShort:
+			g.moves -= label.ReturnPercent
+			g.directional++
+		}
+	}
+	out := make([]Accuracy, 0, len(groups))
+	for _, g := range groups {
+		if decided := g.acc.Good + g.acc.Bad; decided > 0 {
+			g.acc.Accuracy = float64(g.acc.Good) / float64(decided)
+		}
+		if g.directional > 0 {
+			g.acc.AvgMove = g.moves / float64(g.directional)
+		}
+		out = append(out, g.acc)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Key != out[j].Key {
+			return out[i].Key < out[j].Key
+		}
+		return horizonOrder(out[i].Horizon) < horizonOrder(out[j].Horizon)
+	})
+	return out
+}
+
+// horizonOrder 把 FormatHorizon 的写法还原为时长，用于排序。
+func horizonOrder(h string) time.Duration {
+	d, _ := market.IntervalDuration(h)
+	return d
+}
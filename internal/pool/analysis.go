@@ -0,0 +1,127 @@
+package pool
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"autobot/internal/strategy"
+)
+
+// SourceAll 为统计全部入选币种时使用的来源名。
+const SourceAll = "all"
+
+// SelectionStats 为某个来源的入选币种在选出后 Horizon 内的表现。
+type SelectionStats struct {
+	Source  string
+	Horizon time.Duration
+	Samples int
+	// AvgReturn 为入选后 Horizon 的平均涨跌幅（%），AvgExcess 为相对基准的平均超额（%）。
+	AvgReturn float64
+	AvgExcess float64
+	// HitRate 为跑赢基准的样本占比，没有基准数据时为上涨样本占比。
+	HitRate float64
+	// ScoreCorr 为评分与超额收益的皮尔逊相关系数，样本不足时为 0；为正说明评分越高表现越好。
+	ScoreCorr float64
+}
+
+// AnalyzeSelections 对每个快照中的每个入选币种，按 candles 计算选出时刻之后 horizons 内的涨跌幅，
+// 与同期 benchmark 的涨跌幅比较，按来源汇总。candles 以交易对为键、按时间升序；缺少数据或
+// 尚未走完 horizon 的样本被跳过。结果按来源、周期排序，SourceAll 在前。
+func AnalyzeSelections(snapshots []Snapshot, candles map[string][]strategy.Candle, benchmark string, horizons []time.Duration) []SelectionStats {
+	type sample struct {
+		score, ret, excess float64
+		hit                bool
+	}
+	type key struct {
+		source  string
+		horizon time.Duration
+	}
+	samples := make(map[key][]sample)
+	for _, snap := range snapshots {
+		at := time.UnixMilli(snap.CreatedAt)
+		for _, horizon := range horizons {
+			bench, hasBench := forwardReturn(candles[benchmark], at, horizon)
+			for _, coin := range snap.Coins {
+				ret, ok := forwardReturn(candles[coin.Symbol], at, horizon)
+				if !ok {
+					continue
+				}
+				smp := sample{score: coin.Score, ret: ret, excess: ret, hit: ret > 0}
+				if hasBench {
+					smp.excess, smp.hit = ret-bench, ret > bench
+				}
+				samples[key{SourceAll, horizon}] = append(samples[key{SourceAll, horizon}], smp)
+				for _, source := range coin.Sources {
+					samples[key{source, horizon}] = append(samples[key{source, horizon}], smp)
+				}
+			}
+		}
+	}
+
+	out := make([]SelectionStats, 0, len(samples))
+	for k, list := range samples {
+		stats := SelectionStats{Source: k.source, Horizon: k.horizon, Samples: len(list)}
+		hits := 0
+		scores := make([]float64, len(list))
+		excess := make([]float64, len(list))
+		for i, smp := range list {
+			stats.AvgReturn += smp.ret
+			stats.AvgExcess += smp.excess
+			if smp.hit {
+				hits++
+			}
+			scores[i], excess[i] = smp.score, smp.excess
+		}
+		n := float64(len(list))
+		stats.AvgReturn /= n
+		stats.AvgExcess /= n
+		stats.HitRate = float64(hits) / n
+		stats.ScoreCorr = pearson(scores, excess)
+		out = append(out, stats)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Source != out[j].Source {
+			if out[i].Source == SourceAll || out[j].Source == SourceAll {
+				return out[i].Source == SourceAll
+			}
+			return out[i].Source < out[j].Source
+		}
+		return out[i].Horizon < out[j].Horizon
+	})
+	return out
+}
+
+// forwardReturn 返回 at 之后第一根K线开盘价到 at+horizon 之后第一根K线开盘价的涨跌幅（%），
+// 只用选出之后的价格，避免前视。
+func forwardReturn(candles []strategy.Candle, at time.Time, horizon time.Duration) (float64, bool) {
+	start := sort.Search(len(candles), func(i int) bool { return !candles[i].OpenTime.Before(at) })
+	end := sort.Search(len(candles), func(i int) bool { return !candles[i].OpenTime.Before(at.Add(horizon)) })
+	if end >= len(candles) || end <= start || candles[start].Open <= 0 {
+		return 0, false
+	}
+	return (candles[end].Open - candles[start].Open) / candles[start].Open * 100, true
+}
+
+func pearson(xs, ys []float64) float64 {
+	if len(xs) < 3 {
+		return 0
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i]
+		my += ys[i]
+	}
+	mx /= float64(len(xs))
+	my /= float64(len(ys))
+	var cov, vx, vy float64
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+		vx += (xs[i] - mx) * (xs[i] - mx)
+		vy += (ys[i] - my) * (ys[i] - my)
+	}
+	if vx == 0 || vy == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(vx*vy)
+}
@@ -5,13 +5,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"autobot/internal/ai"
 	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
 )
 
 var defaultMainstreamCoins = []string{
@@ -34,6 +38,8 @@ type Config struct {
 	OITopAPIKey    string
 	CacheTTL       time.Duration
 	MaxCombined    int
+	// SentimentWeight 为币种新闻情绪对评分的影响：评分乘以 (1 + SentimentWeight*情绪分)，0 表示不加权。
+	SentimentWeight float64
 }
 
 // CoinInfo 描述单个币种的来源与评分。
@@ -41,6 +47,31 @@ type CoinInfo struct {
 	Symbol  string
 	Score   float64
 	Sources []string
+	// Sentiment 为该币种的新闻情绪分（-1~1），无相关新闻时为 0。
+	Sentiment float64
+	// Details 为入选依据，每个来源一条（如 "OI +32.0% 24h"），由 Reason 拼接。
+	Details []string
+}
+
+// Reason 返回币种入选的具体理由，例如 "OI +32.0% 24h, vol rank #5, ai500 score 1.10, 新闻情绪 +0.40"。
+func (c CoinInfo) Reason() string {
+	parts := append([]string(nil), c.Details...)
+	if len(parts) == 0 {
+		parts = append(parts, c.Sources...)
+	}
+	if c.Sentiment != 0 {
+		parts = append(parts, fmt.Sprintf("新闻情绪 %+.2f", c.Sentiment))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CandidateContexts 把币种池结果转换为决策请求中的候选币种，评分作为权重、Reason 作为理由。
+func CandidateContexts(coins []CoinInfo) []ai.CandidateContext {
+	out := make([]ai.CandidateContext, 0, len(coins))
+	for _, coin := range coins {
+		out = append(out, ai.CandidateContext{Symbol: coin.Symbol, Weight: coin.Score, Reason: coin.Reason(), Sentiment: coin.Sentiment})
+	}
+	return out
 }
 
 // Service 负责聚合多源币种池并提供缓存。
@@ -51,6 +82,20 @@ type Service struct {
 	mu      sync.Mutex
 	cache   []CoinInfo
 	expires time.Time
+
+	sentiment news.SentimentSummary
+	recorder  SnapshotRecorder
+}
+
+// Snapshot 为一次刷新后的选币结果，按时间落盘后用于分析币种池的事后表现。
+type Snapshot struct {
+	CreatedAt int64
+	Coins     []CoinInfo
+}
+
+// SnapshotRecorder 持久化选币快照；storage.Store 满足该接口。
+type SnapshotRecorder interface {
+	RecordPoolSnapshot(ctx context.Context, snapshot Snapshot) error
 }
 
 // NewService 创建币种池服务。
@@ -70,23 +115,67 @@ func NewService(cfg Config) *Service {
 	}
 }
 
-// Select 返回推荐的币种列表，按照score降序排序。
+// SetSentiment 更新用于加权评分的新闻情绪，下一次 Select 生效。
+func (s *Service) SetSentiment(summary news.SentimentSummary) {
+	s.mu.Lock()
+	s.sentiment = summary
+	s.mu.Unlock()
+}
+
+// SetRecorder 设置快照记录器，之后每次刷新币种池都写入一条完整的选币快照。
+func (s *Service) SetRecorder(recorder SnapshotRecorder) {
+	s.mu.Lock()
+	s.recorder = recorder
+	s.mu.Unlock()
+}
+
+// Select 返回推荐的币种列表，按照score降序排序；设置了新闻情绪时评分按币种情绪加权。
 func (s *Service) Select(ctx context.Context, limit int) []CoinInfo {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	now := time.Now()
-	if len(s.cache) > 0 && now.Before(s.expires) {
-		return cloneCoins(s.cache, limit)
+	refreshed := false
+	if len(s.cache) == 0 || !now.Before(s.expires) {
+		coins := s.refresh(ctx)
+		if len(coins) == 0 {
+			coins = convertSymbolsToCoins(defaultMainstreamCoins)
+		}
+		s.cache = coins
+		s.expires = now.Add(s.cfg.CacheTTL)
+		refreshed = true
 	}
+	selected := s.cache
+	if len(s.sentiment.Symbols) > 0 && s.cfg.SentimentWeight != 0 {
+		selected = weightBySentiment(cloneCoins(s.cache, 0), s.sentiment, s.cfg.SentimentWeight)
+	}
+	if refreshed && s.recorder != nil {
+		if err := s.recorder.RecordPoolSnapshot(ctx, Snapshot{CreatedAt: now.UnixMilli(), Coins: cloneCoins(selected, 0)}); err != nil && s.logger != nil {
+			s.logger.Printf("coin_pool.snapshot.error: %v", err)
+		}
+	}
+	return cloneCoins(selected, limit)
+}
 
-	coins := s.refresh(ctx)
-	if len(coins) == 0 {
-		coins = convertSymbolsToCoins(defaultMainstreamCoins)
+// weightBySentiment 按币种情绪调整评分并重新排序，利好币种排名上升、利空币种下降。
+func weightBySentiment(coins []CoinInfo, summary news.SentimentSummary, weight float64) []CoinInfo {
+	for i := range coins {
+		if score, ok := summary.SymbolScore(coins[i].Symbol); ok {
+			coins[i].Sentiment = score
+			coins[i].Score *= math.Max(0, 1+weight*score)
+		}
 	}
-	s.cache = coins
-	s.expires = now.Add(s.cfg.CacheTTL)
-	return cloneCoins(s.cache, limit)
+	sortCoins(coins)
+	return coins
+}
+
+func sortCoins(list []CoinInfo) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if almostEqual(list[i].Score, list[j].Score) {
+			return list[i].Symbol < list[j].Symbol
+		}
+		return list[i].Score > list[j].Score
+	})
 }
 
 func (s *Service) refresh(ctx context.Context) []CoinInfo {
@@ -100,10 +189,12 @@ func (s *Service) refresh(ctx context.Context) []CoinInfo {
 			if existing, ok := aggregated[symbol]; ok {
 				existing.Score = maxFloat(existing.Score, info.Score)
 				existing.Sources = mergeSources(existing.Sources, info.Sources)
+				existing.Details = append(existing.Details, info.Details...)
 				continue
 			}
 			copySources := append([]string(nil), info.Sources...)
-			aggregated[symbol] = &CoinInfo{Symbol: symbol, Score: info.Score, Sources: copySources}
+			copyDetails := append([]string(nil), info.Details...)
+			aggregated[symbol] = &CoinInfo{Symbol: symbol, Score: info.Score, Sources: copySources, Details: copyDetails}
 		}
 	}
 
@@ -128,12 +219,7 @@ func (s *Service) refresh(ctx context.Context) []CoinInfo {
 		sort.Strings(info.Sources)
 		list = append(list, *info)
 	}
-	sort.SliceStable(list, func(i, j int) bool {
-		if almostEqual(list[i].Score, list[j].Score) {
-			return list[i].Symbol < list[j].Symbol
-		}
-		return list[i].Score > list[j].Score
-	})
+	sortCoins(list)
 
 	if s.cfg.MaxCombined > 0 && len(list) > s.cfg.MaxCombined {
 		list = list[:s.cfg.MaxCombined]
@@ -166,6 +252,8 @@ func (s *Service) fetchCoins(ctx context.Context, url, apiKey, tag string, baseS
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
 	symbols := extractSymbols(payload)
+	metrics := make(map[string]coinMetrics)
+	walkMetrics(payload, metrics)
 	coins := make([]CoinInfo, 0, len(symbols))
 	for i, sym := range symbols {
 		if sym == "" {
@@ -175,7 +263,7 @@ func (s *Service) fetchCoins(ctx context.Context, url, apiKey, tag string, baseS
 		if score < 0 {
 			score = 0
 		}
-		coins = append(coins, CoinInfo{Symbol: sym, Score: score, Sources: []string{tag}})
+		coins = append(coins, CoinInfo{Symbol: sym, Score: score, Sources: []string{tag}, Details: metrics[sym].details(tag)})
 	}
 	return coins, nil
 }
@@ -191,7 +279,7 @@ func convertSymbolsToCoins(symbols []string) []CoinInfo {
 		if score < 0.1 {
 			score = 0.1
 		}
-		coins = append(coins, CoinInfo{Symbol: norm, Score: score, Sources: []string{"default"}})
+		coins = append(coins, CoinInfo{Symbol: norm, Score: score, Sources: []string{"default"}, Details: []string{"主流币默认池"}})
 	}
 	return coins
 }
@@ -203,7 +291,8 @@ func cloneCoins(in []CoinInfo, limit int) []CoinInfo {
 	copySlice := make([]CoinInfo, 0, limit)
 	for i := 0; i < limit; i++ {
 		sources := append([]string(nil), in[i].Sources...)
-		copySlice = append(copySlice, CoinInfo{Symbol: in[i].Symbol, Score: in[i].Score, Sources: sources})
+		details := append([]string(nil), in[i].Details...)
+		copySlice = append(copySlice, CoinInfo{Symbol: in[i].Symbol, Score: in[i].Score, Sources: sources, Sentiment: in[i].Sentiment, Details: details})
 	}
 	return copySlice
 }
@@ -259,6 +348,99 @@ func walkSymbols(node interface{}, set map[string]struct{}) {
 	}
 }
 
+// coinMetrics 为来源接口返回的单个币种打分依据，缺失的指标为零值。
+type coinMetrics struct {
+	score      float64
+	hasScore   bool
+	oiChange   float64
+	oiWindow   string
+	hasOI      bool
+	volumeRank int
+}
+
+// details 把指标格式化为入选理由；没有任何指标时只给出来源名。
+func (m coinMetrics) details(tag string) []string {
+	var out []string
+	if m.hasOI {
+		text := fmt.Sprintf("OI %+.1f%%", m.oiChange)
+		if m.oiWindow != "" {
+			text += " " + m.oiWindow
+		}
+		out = append(out, text)
+	}
+	if m.volumeRank > 0 {
+		out = append(out, fmt.Sprintf("vol rank #%d", m.volumeRank))
+	}
+	if m.hasScore {
+		out = append(out, fmt.Sprintf("%s score %.2f", tag, m.score))
+	}
+	if len(out) == 0 {
+		out = append(out, tag)
+	}
+	return out
+}
+
+// walkMetrics 在接口返回中查找带交易对字段的对象，读取同一对象上的评分、持仓量变化与成交量排名。
+func walkMetrics(node interface{}, metrics map[string]coinMetrics) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		symbol := ""
+		for key, val := range v {
+			lower := strings.ToLower(key)
+			if strings.Contains(lower, "symbol") || strings.Contains(lower, "pair") {
+				if str, ok := val.(string); ok {
+					symbol = normalizeSymbol(str)
+				}
+			}
+		}
+		if symbol != "" {
+			m := metrics[symbol]
+			for key, val := range v {
+				num, ok := numberValue(val)
+				if !ok {
+					continue
+				}
+				lower := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+				switch {
+				case lower == "score" || lower == "aiscore":
+					m.score, m.hasScore = num, true
+				case strings.HasPrefix(lower, "oi") || strings.HasPrefix(lower, "openinterest"):
+					if strings.Contains(lower, "change") || strings.Contains(lower, "delta") || strings.Contains(lower, "pct") || strings.Contains(lower, "percent") {
+						m.oiChange, m.hasOI = num, true
+						for _, window := range []string{"24h", "4h", "1h"} {
+							if strings.Contains(lower, window) {
+								m.oiWindow = window
+								break
+							}
+						}
+					}
+				case lower == "volumerank" || lower == "volrank":
+					m.volumeRank = int(num)
+				}
+			}
+			metrics[symbol] = m
+		}
+		for _, val := range v {
+			walkMetrics(val, metrics)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkMetrics(item, metrics)
+		}
+	}
+}
+
+func numberValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(n), "%"), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
 func normalizeSymbol(input string) string {
 	s := strings.ToUpper(strings.TrimSpace(input))
 	if s == "" {
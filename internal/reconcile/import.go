@@ -0,0 +1,220 @@
+// Package reconcile 处理启动时交易所账户中已存在、但本地没有决策记录的持仓。
+package reconcile
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/storage"
+)
+
+// Prompt 逐个询问是否接管持仓。
+type Prompt func(pos exchange.PositionRisk) (bool, error)
+
+// Result 为一次导入的结果。Ignored 中的持仓交易者不得管理（不开仓、不平仓、不挂单）。
+type Result struct {
+	Adopted []exchange.PositionRisk
+	Ignored []exchange.PositionRisk
+}
+
+// IsIgnored 报告交易对是否存在被忽略的持仓。
+func (r Result) IsIgnored(symbol string) bool {
+	for _, pos := range r.Ignored {
+		if pos.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// Importer 在交易者启动前处理已有持仓。Mode 为 config.ExistingPositions* 之一；
+// ask 模式下无交互终端时退化为 ignore，避免无人值守时阻塞。
+type Importer struct {
+	Trader   string
+	Exchange exchange.Exchange
+	Store    storage.Store
+	Settings config.TradeSettings
+	Mode     string
+	Prompt   Prompt
+}
+
+// Run 查询 symbol 的现有持仓并按模式接管或忽略。
+func (im Importer) Run(ctx context.Context, symbol string) (Result, error) {
+	logger := loggerpkg.Get("reconcile")
+	positions, err := im.Exchange.GetPositions(ctx, symbol)
+	if err != nil {
+		return Result{}, fmt.Errorf("query existing positions: %w", err)
+	}
+
+	mode := im.Mode
+	prompt := im.Prompt
+	if mode == config.ExistingPositionsAsk && prompt == nil {
+		if !isTerminal(os.Stdin) {
+			logger.Printf("import.no_tty trader=%s fallback=ignore", im.Trader)
+			mode = config.ExistingPositionsIgnore
+		} else {
+			prompt = TerminalPrompt(os.Stdin, os.Stdout)
+		}
+	}
+
+	var result Result
+	for _, pos := range positions {
+		if pos.Quantity == 0 || pos.Symbol != symbol {
+			continue
+		}
+		adopt := mode == config.ExistingPositionsAdopt
+		if mode == config.ExistingPositionsAsk {
+			if adopt, err = prompt(pos); err != nil {
+				return result, fmt.Errorf("prompt: %w", err)
+			}
+		}
+		if !adopt {
+			logger.Printf("import.ignore trader=%s symbol=%s side=%s qty=%.6f entry=%.6f",
+				im.Trader, pos.Symbol, pos.PositionSide, pos.Quantity, pos.EntryPrice)
+			result.Ignored = append(result.Ignored, pos)
+			continue
+		}
+		if err := im.adopt(ctx, pos); err != nil {
+			return result, err
+		}
+		logger.Printf("import.adopt trader=%s symbol=%s side=%s qty=%.6f entry=%.6f",
+			im.Trader, pos.Symbol, pos.PositionSide, pos.Quantity, pos.EntryPrice)
+		result.Adopted = append(result.Adopted, pos)
+	}
+	return result, nil
+}
+
+// adopt 写入合成的开仓决策与成交记录，并按配置挂出保护性止损/止盈。
+func (im Importer) adopt(ctx context.Context, pos exchange.PositionRisk) error {
+	long := pos.Quantity > 0
+	side := "short"
+	if long {
+		side = "long"
+	}
+	qty := math.Abs(pos.Quantity)
+	now := time.Now()
+	if im.Store != nil {
+		decision := storage.DecisionRecord{
+			ID:         fmt.Sprintf("import-%s-%s-%d", im.Trader, pos.Symbol, now.UnixMilli()),
+			Trader:     im.Trader,
+			Provider:   "import",
+			Symbol:     pos.Symbol,
+			Action:     "open_" + side,
+			Confidence: 0,
+			Reason:     fmt.Sprintf("启动时接管已有持仓 qty=%.6f entry=%.6f", qty, pos.EntryPrice),
+			Success:    true,
+		}
+		if err := im.Store.RecordDecision(ctx, decision); err != nil {
+			return fmt.Errorf("record import decision: %w", err)
+		}
+		trade := storage.TradeRecord{
+			ID:       decision.ID,
+			Trader:   im.Trader,
+			Symbol:   pos.Symbol,
+			Side:     side,
+			Quantity: qty,
+			Price:    pos.EntryPrice,
+			Action:   "import",
+			Notes:    "existing position adopted at startup",
+		}
+		if err := im.Store.RecordTrade(ctx, trade); err != nil {
+			return fmt.Errorf("record import trade: %w", err)
+		}
+	}
+
+	closeSide := exchange.OrderSideSell
+	if !long {
+		closeSide = exchange.OrderSideBuy
+	}
+	for _, order := range ProtectiveOrders(pos, im.Settings) {
+		req := exchange.OrderRequest{
+			Symbol:       pos.Symbol,
+			Side:         closeSide,
+			PositionSide: pos.PositionSide,
+			Type:         order.Type,
+			Quantity:     qty,
+			StopPrice:    order.StopPrice,
+			ReduceOnly:   pos.PositionSide == "" || pos.PositionSide == exchange.PositionSideBoth,
+		}
+		if _, err := im.Exchange.PlaceOrder(ctx, req); err != nil {
+			return fmt.Errorf("place %s for imported %s: %w", order.Type, pos.Symbol, err)
+		}
+	}
+	return nil
+}
+
+// ProtectiveOrder 为一张待挂出的保护性触发单。
+type ProtectiveOrder struct {
+	Type      exchange.OrderType
+	StopPrice float64
+}
+
+// ProtectiveOrders 按 stopLossPercent/takeProfitPercent 从开仓价计算止损与止盈。若标记价
+// 已越过止损位（持仓已深度浮亏），改为从标记价计算止损，避免挂单立即触发或被交易所拒绝；
+// 已越过的止盈位不再挂出。
+func ProtectiveOrders(pos exchange.PositionRisk, settings config.TradeSettings) []ProtectiveOrder {
+	entry := pos.EntryPrice
+	if entry <= 0 {
+		entry = pos.MarkPrice
+	}
+	if entry <= 0 {
+		return nil
+	}
+	long := pos.Quantity > 0
+	sign := 1.0
+	if !long {
+		sign = -1
+	}
+	mark := pos.MarkPrice
+	var orders []ProtectiveOrder
+	if pct := settings.StopLossPercent / 100; pct > 0 {
+		stop := entry * (1 - sign*pct)
+		if mark > 0 && sign*(mark-stop) <= 0 {
+			stop = mark * (1 - sign*pct)
+		}
+		orders = append(orders, ProtectiveOrder{Type: exchange.OrderTypeStopMarket, StopPrice: stop})
+	}
+	if pct := settings.TakeProfitPercent / 100; pct > 0 {
+		take := entry * (1 + sign*pct)
+		if mark <= 0 || sign*(take-mark) > 0 {
+			orders = append(orders, ProtectiveOrder{Type: exchange.OrderTypeTakeProfitMarket, StopPrice: take})
+		}
+	}
+	return orders
+}
+
+// TerminalPrompt 在终端中逐个询问，输入 y/yes 接管，其余忽略。
+func TerminalPrompt(in io.Reader, out io.Writer) Prompt {
+	reader := bufio.NewReader(in)
+	return func(pos exchange.PositionRisk) (bool, error) {
+		side := "空"
+		if pos.Quantity > 0 {
+			side = "多"
+		}
+		fmt.Fprintf(out, "发现已有持仓 %s %s 数量=%.6f 开仓价=%.6f 浮盈=%.2f，是否接管？[y/N]: ",
+			pos.Symbol, side, math.Abs(pos.Quantity), pos.EntryPrice, pos.UnrealizedPNL)
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return false, err
+		}
+		answer := strings.ToLower(strings.TrimSpace(line))
+		return answer == "y" || answer == "yes", nil
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
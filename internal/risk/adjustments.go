@@ -0,0 +1,63 @@
+package risk
+
+import (
+	"fmt"
+	"strings"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+)
+
+// GuardAdjustments 按 adjustmentGuard 检查AI调整参数。clamp 模式返回夹紧后的参数与说明；
+// reject 模式下任一字段越界即返回 *Rejection。nil 闸门原样返回。
+func (g *Gate) GuardAdjustments(symbol string, plan ai.AdjustmentPlan) (ai.AdjustmentPlan, []string, error) {
+	if g == nil {
+		return plan, nil, nil
+	}
+	guard := g.cfg.AdjustmentGuard
+	applied := plan
+	var notes []string
+	fields := []struct {
+		name   string
+		value  *float64
+		bounds config.Bounds
+	}{
+		{"sizeMultiplier", &applied.SizeMultiplier, guard.SizeMultiplier},
+		{"targetLeverage", &applied.TargetLeverage, guard.TargetLeverage},
+		{"stopLossPercent", &applied.StopLossPercent, guard.StopLossPercent},
+		{"takeProfitPercent", &applied.TakeProfitPercent, guard.TakeProfitPercent},
+		{"trailingStopPercent", &applied.TrailingStopPercent, guard.TrailingStopPercent},
+	}
+	for _, field := range fields {
+		raw := *field.value
+		if raw == 0 {
+			continue
+		}
+		clamped := clamp(raw, field.bounds)
+		if clamped == raw {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("%s %.4g→%.4g", field.name, raw, clamped))
+		*field.value = clamped
+	}
+	if len(notes) == 0 {
+		return plan, nil, nil
+	}
+	if guard.Mode == config.GuardModeReject {
+		rejection := &Rejection{Rule: "adjustment_guard", Reason: "AI调整参数越界: " + strings.Join(notes, ", ")}
+		g.logger.Printf("gate.reject rule=%s symbol=%s reason=%q", rejection.Rule, symbol, rejection.Reason)
+		return plan, notes, rejection
+	}
+	g.logger.Printf("adjustment.clamped symbol=%s raw=%+v applied=%+v", symbol, plan, applied)
+	return applied, notes, nil
+}
+
+func clamp(value float64, bounds config.Bounds) float64 {
+	if value < bounds.Min {
+		return bounds.Min
+	}
+	if bounds.Max > 0 && value > bounds.Max {
+		return bounds.Max
+	}
+	return value
+}
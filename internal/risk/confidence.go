@@ -0,0 +1,95 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+)
+
+// ConfidenceSizing 按AI置信度分档决定开仓仓位倍数，取代模型自己给出的 sizeMultiplier：
+// 取 Min 不高于置信度的最高一档，低于最低一档时不开仓。
+type ConfidenceSizing struct {
+	brackets []config.ConfidenceBracket
+}
+
+// NewConfidenceSizing 按交易参数创建置信度分档；未配置 confidenceSizing 时返回 nil，nil 原样放行。
+func NewConfidenceSizing(settings config.TradeSettings) *ConfidenceSizing {
+	if len(settings.ConfidenceSizing) == 0 {
+		return nil
+	}
+	brackets := append([]config.ConfidenceBracket{}, settings.ConfidenceSizing...)
+	sort.Slice(brackets, func(i, j int) bool { return brackets[i].Min < brackets[j].Min })
+	return &ConfidenceSizing{brackets: brackets}
+}
+
+// String 返回看板与日志使用的描述，例如 "≥0.75→0.5x ≥0.8→1x ≥0.9→1.5x"。
+func (s *ConfidenceSizing) String() string {
+	if s == nil {
+		return "未启用"
+	}
+	parts := make([]string, len(s.brackets))
+	for i, b := range s.brackets {
+		parts[i] = fmt.Sprintf("≥%.4g→%.4gx", b.Min, b.Multiplier)
+	}
+	return strings.Join(parts, " ")
+}
+
+// NormalizeConfidence 把AI置信度限制在 0~1：决策 schema 要求 0~1，越界值按最近的边界处理，NaN 按 0。
+// 仓位分档与风控闸门的预期收益检查共用它，同一置信度在两处含义一致。
+func NormalizeConfidence(confidence float64) float64 {
+	switch {
+	case math.IsNaN(confidence) || confidence < 0:
+		return 0
+	case confidence > 1:
+		return 1
+	}
+	return confidence
+}
+
+// Multiplier 返回置信度对应的仓位倍数，低于最低一档时 ok 为 false。置信度先经 NormalizeConfidence 限制在 0~1。
+func (s *ConfidenceSizing) Multiplier(confidence float64) (float64, bool) {
+	if s == nil {
+		return 1, true
+	}
+	confidence = NormalizeConfidence(confidence)
+	for i := len(s.brackets) - 1; i >= 0; i-- {
+		if confidence >= s.brackets[i].Min {
+			return s.brackets[i].Multiplier, true
+		}
+	}
+	return 0, false
+}
+
+// Apply 把开仓或加仓决策的 sizeMultiplier 改为置信度对应的倍数，返回调整后的决策与说明，说明应追加到
+// 决策记录的 AdjustNotes；置信度低于最低一档时返回 *Rejection。应在 GuardAdjustments 之前调用，
+// 使分档倍数同样受 adjustmentGuard 上限约束。nil 或其他动作原样返回。
+func (s *ConfidenceSizing) Apply(symbol string, decision ai.DecisionResponse) (ai.DecisionResponse, []string, error) {
+	if s == nil || !isEntryAction(decision.Action) {
+		return decision, nil, nil
+	}
+	multiplier, ok := s.Multiplier(decision.Confidence)
+	if !ok {
+		rejection := &Rejection{Rule: "confidence_sizing", Reason: fmt.Sprintf("置信度 %.2f 低于最低分档 %.4g", decision.Confidence, s.brackets[0].Min)}
+		loggerpkg.Get("risk").Printf("gate.reject rule=%s symbol=%s reason=%q", rejection.Rule, symbol, rejection.Reason)
+		return decision, nil, rejection
+	}
+	requested := decision.Adjustments.SizeMultiplier
+	decision.Adjustments.SizeMultiplier = multiplier
+	if requested == multiplier {
+		return decision, nil, nil
+	}
+	return decision, []string{fmt.Sprintf("sizeMultiplier %.4g→%.4g（置信度 %.2f 分档）", requested, multiplier, decision.Confidence)}, nil
+}
+
+func isEntryAction(action string) bool {
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "open_long", "open_short", "increase_long", "increase_short":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package risk
+
+import (
+	"math"
+	"testing"
+
+	"autobot/internal/config"
+)
+
+func TestNormalizeConfidence(t *testing.T) {
+	for in, want := range map[float64]float64{-0.5: 0, 0: 0, 0.85: 0.85, 1: 1, 85: 1, math.NaN(): 0} {
+		if got := NormalizeConfidence(in); got != want {
+			t.Errorf("NormalizeConfidence(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+// 同一越界置信度在仓位分档与预期收益检查中按同一值处理。
+func TestConfidenceOutOfRangeIsClampedEverywhere(t *testing.T) {
+	sizing := NewConfidenceSizing(config.TradeSettings{ConfidenceSizing: []config.ConfidenceBracket{
+		{Min: 0.8, Multiplier: 1},
+		{Min: 0.9, Multiplier: 1.5},
+	}})
+	if multiplier, ok := sizing.Multiplier(85); !ok || multiplier != 1.5 {
+		t.Fatalf("Multiplier(85) = %v, %v; want the top bracket", multiplier, ok)
+	}
+
+	// 止盈距离 0.5%，成本 0.2%：置信度按 1 计时 0.5% ≥ 2.4×0.2%，按 0.85 计时 0.425% 不足。
+	gate := NewGate(config.RiskConfig{MinEdgeCostMultiple: 2.4}, config.FeeSchedule{TakerPercent: 0.1})
+	entry := Entry{Symbol: "BTCUSDT", Side: "long", Price: 100, TargetPrice: 100.5}
+	for _, confidence := range []float64{1, 85} {
+		entry.Confidence = confidence
+		if err := gate.CheckEntry(entry); err != nil {
+			t.Errorf("confidence %v: %v", confidence, err)
+		}
+	}
+	entry.Confidence = 0.85
+	if err := gate.CheckEntry(entry); err == nil {
+		t.Error("confidence 0.85 passed the edge check")
+	}
+}
@@ -0,0 +1,155 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+)
+
+// flatRetryInterval 为日终平仓失败后的重试间隔。
+const flatRetryInterval = time.Minute
+
+// Flat 为日终平仓：每天 FlatBy（UTC）前平掉全部持仓，从 FlatBy 前 FlatNoEntryMinutes 分钟起
+// 到 FlatResumeAt 不开新仓。时刻均以距 UTC 0 点的偏移保存，时段可跨越 0 点。
+type Flat struct {
+	at, stopAt, resumeAt time.Duration
+}
+
+// NewFlat 按交易参数创建日终平仓；未设置 flatBy 时返回 nil，nil 从不限制开仓也不平仓。
+// 时刻格式已由配置校验保证。
+func NewFlat(settings config.TradeSettings) *Flat {
+	at, err := config.ClockOffset(settings.FlatBy)
+	if settings.FlatBy == "" || err != nil {
+		return nil
+	}
+	resume, err := config.ClockOffset(settings.FlatResumeAt)
+	if err != nil {
+		resume = 0
+	}
+	stop := (at - time.Duration(settings.FlatNoEntryMinutes)*time.Minute + 24*time.Hour) % (24 * time.Hour)
+	return &Flat{at: at, stopAt: stop, resumeAt: resume}
+}
+
+// String 返回看板与日志使用的描述，例如 "23:50 平仓，23:20~00:00 不开仓"。
+func (f *Flat) String() string {
+	if f == nil {
+		return "未启用"
+	}
+	return fmt.Sprintf("%s 平仓，%s~%s 不开仓", formatClock(f.at), formatClock(f.stopAt), formatClock(f.resumeAt))
+}
+
+// Blocked 报告 now 是否处于禁止开新仓的时段（平仓前 N 分钟至恢复时刻）。
+func (f *Flat) Blocked(now time.Time) bool {
+	return f != nil && inClockWindow(clockOf(now), f.stopAt, f.resumeAt)
+}
+
+// MustBeFlat 报告 now 是否处于应当空仓的时段（平仓时刻至恢复时刻）。
+func (f *Flat) MustBeFlat(now time.Time) bool {
+	return f != nil && inClockWindow(clockOf(now), f.at, f.resumeAt)
+}
+
+// NextFlat 返回 now 及之后最近的一次平仓时刻；nil 时返回零值。
+func (f *Flat) NextFlat(now time.Time) time.Time {
+	if f == nil {
+		return time.Time{}
+	}
+	now = now.UTC()
+	next := now.Truncate(24 * time.Hour).Add(f.at)
+	if next.Before(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
+
+// Run 每天在平仓时刻调用 closeAll 平掉交易者的全部持仓，直到 ctx 结束；启动时已处于应空仓时段则立即平仓。
+// closeAll 失败时每分钟重试，直到成功或离开应空仓时段。nil 时立即返回。
+func (f *Flat) Run(ctx context.Context, trader string, closeAll func(context.Context) error) {
+	if f == nil {
+		return
+	}
+	logger := loggerpkg.Get("risk")
+	for {
+		now := time.Now()
+		var wait time.Duration
+		if !f.MustBeFlat(now) {
+			next := f.NextFlat(now)
+			logger.Printf("flat.scheduled trader=%s next=%s", trader, next.Format(time.RFC3339))
+			wait = time.Until(next)
+		}
+		if !sleepCtx(ctx, wait) {
+			return
+		}
+		for {
+			err := closeAll(ctx)
+			if err == nil {
+				logger.Printf("flat.closed trader=%s", trader)
+				break
+			}
+			logger.Printf("flat.close_failed trader=%s err=%v", trader, err)
+			if !sleepCtx(ctx, flatRetryInterval) {
+				return
+			}
+			if !f.MustBeFlat(time.Now()) {
+				break
+			}
+		}
+		// 恢复开仓后再安排下一次平仓，避免同一平仓时刻重复触发
+		if !sleepCtx(ctx, time.Until(f.resumeTime(time.Now()))) {
+			return
+		}
+	}
+}
+
+// resumeTime 返回 now 之后最近的恢复开仓时刻。
+func (f *Flat) resumeTime(now time.Time) time.Time {
+	now = now.UTC()
+	next := now.Truncate(24 * time.Hour).Add(f.resumeAt)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
+
+// sleepCtx 等待 d，ctx 先结束时返回 false。
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// checkFlat 在日终平仓的禁止开仓时段拒绝开仓。
+func checkFlat(g *Gate, entry Entry) *Rejection {
+	now := entry.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if !g.flat.Blocked(now) {
+		return nil
+	}
+	return &Rejection{Rule: "end_of_day", Reason: fmt.Sprintf("日终平仓时段（%s），当前 %s UTC", g.flat, now.UTC().Format("15:04"))}
+}
+
+func clockOf(t time.Time) time.Duration {
+	t = t.UTC()
+	return t.Sub(t.Truncate(24 * time.Hour))
+}
+
+// inClockWindow 报告 x 是否在 [start, end) 内，start 晚于 end 时时段跨越 0 点；start 等于 end 时为空。
+func inClockWindow(x, start, end time.Duration) bool {
+	if start <= end {
+		return x >= start && x < end
+	}
+	return x >= start || x < end
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
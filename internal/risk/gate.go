@@ -0,0 +1,172 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+)
+
+// Rejection 表示风控闸门拒绝了一次开仓。
+type Rejection struct {
+	Rule   string
+	Reason string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("risk gate %s: %s", r.Rule, r.Reason)
+}
+
+// Entry 描述一次待检查的开仓。Side 为 long 或 short；TargetPrice 为止盈价，未知时为 0。
+// Confidence 为AI置信度，经 NormalizeConfidence 限制在 0~1，0 表示尚无置信度，按 1 计；SlippagePercent 为单边预期滑点。
+// OpenSymbols 为账户当前有持仓的交易对，用于按币种类别限制持仓数，未提供时不检查。
+// Time 为开仓时刻，用于日终平仓时段检查，零值按当前时间。
+type Entry struct {
+	Symbol          string
+	Side            string
+	Price           float64
+	TargetPrice     float64
+	Confidence      float64
+	SlippagePercent float64
+	Snapshot        ai.MarketDataSnapshot
+	OpenSymbols     []string
+	Time            time.Time
+}
+
+type rule func(g *Gate, entry Entry) *Rejection
+
+// Gate 在下单前按配置逐条检查开仓规则。
+type Gate struct {
+	cfg    config.RiskConfig
+	fees   config.FeeSchedule
+	rules  []rule
+	flat   *Flat
+	logger *loggerpkg.ModuleLogger
+}
+
+// NewGate 按风控配置与所属交易所费率构建闸门。
+func NewGate(cfg config.RiskConfig, fees config.FeeSchedule) *Gate {
+	return &Gate{
+		cfg:    cfg,
+		fees:   fees,
+		rules:  []rule{checkFlat, checkPositionClasses, checkLevels, checkEdge},
+		logger: loggerpkg.Get("risk"),
+	}
+}
+
+// WithFlat 设置交易者的日终平仓，禁止开仓时段内拒绝开仓；返回 g 便于链式调用。
+func (g *Gate) WithFlat(flat *Flat) *Gate {
+	if g != nil {
+		g.flat = flat
+	}
+	return g
+}
+
+// CheckEntry 依次执行各规则，首个拒绝以 *Rejection 返回；nil 闸门放行一切。
+func (g *Gate) CheckEntry(entry Entry) error {
+	if g == nil {
+		return nil
+	}
+	if entry.Price <= 0 {
+		entry.Price = entry.Snapshot.CurrentPrice
+	}
+	for _, check := range g.rules {
+		if rejection := check(g, entry); rejection != nil {
+			g.logger.Printf("gate.reject rule=%s symbol=%s side=%s price=%.6f reason=%q",
+				rejection.Rule, entry.Symbol, entry.Side, entry.Price, rejection.Reason)
+			return rejection
+		}
+	}
+	return nil
+}
+
+// checkPositionClasses 按币种类别限制持仓数：开 BTC/ETH 新仓时已有 MaxMajorPositions 个 BTC/ETH 持仓、
+// 开其他币种新仓时已有 MaxAltPositions 个山寨币持仓则拒绝。已持有该交易对（加仓）时不检查。
+func checkPositionClasses(g *Gate, entry Entry) *Rejection {
+	if g.cfg.MaxMajorPositions <= 0 && g.cfg.MaxAltPositions <= 0 {
+		return nil
+	}
+	base := BaseAsset(entry.Symbol)
+	major := IsMajor(entry.Symbol)
+	count := 0
+	for _, symbol := range entry.OpenSymbols {
+		if BaseAsset(symbol) == base {
+			return nil
+		}
+		if IsMajor(symbol) == major {
+			count++
+		}
+	}
+	limit, class, rule := g.cfg.MaxAltPositions, "山寨币", "alt_positions"
+	if major {
+		limit, class, rule = g.cfg.MaxMajorPositions, "BTC/ETH", "major_positions"
+	}
+	if limit > 0 && count >= limit {
+		return &Rejection{Rule: rule, Reason: fmt.Sprintf("已持有 %d 个%s仓位，达到上限 %d", count, class, limit)}
+	}
+	return nil
+}
+
+// checkLevels 拒绝紧贴强阻力下方的开多、紧贴强支撑上方的开空。
+func checkLevels(g *Gate, entry Entry) *Rejection {
+	cfg := g.cfg
+	if cfg.ResistanceBufferPercent <= 0 || entry.Price <= 0 {
+		return nil
+	}
+	for _, level := range entry.Snapshot.Levels {
+		if level.Strength < cfg.LevelMinStrength || level.Price <= 0 {
+			continue
+		}
+		distance := (level.Price/entry.Price - 1) * 100
+		switch {
+		case entry.Side == "long" && distance > 0 && distance <= cfg.ResistanceBufferPercent:
+			return &Rejection{
+				Rule:   "resistance",
+				Reason: fmt.Sprintf("上方 %.2f%% 处存在强阻力 %s=%.4f(强度%d)", distance, level.Name, level.Price, level.Strength),
+			}
+		case entry.Side == "short" && distance < 0 && math.Abs(distance) <= cfg.ResistanceBufferPercent:
+			return &Rejection{
+				Rule:   "support",
+				Reason: fmt.Sprintf("下方 %.2f%% 处存在强支撑 %s=%.4f(强度%d)", -distance, level.Name, level.Price, level.Strength),
+			}
+		}
+	}
+	return nil
+}
+
+// checkEdge 比较预期收益与预期成本：收益为止盈距离乘以置信度，成本为往返吃单手续费、
+// 往返滑点与不利方向的资金费之和，收益低于成本的 MinEdgeCostMultiple 倍时拒绝开仓。
+func checkEdge(g *Gate, entry Entry) *Rejection {
+	if g.cfg.MinEdgeCostMultiple <= 0 || entry.Price <= 0 || entry.TargetPrice <= 0 {
+		return nil
+	}
+	confidence := NormalizeConfidence(entry.Confidence)
+	if confidence == 0 {
+		confidence = 1
+	}
+	expected := math.Abs(entry.TargetPrice/entry.Price-1) * 100 * confidence
+
+	fees := g.fees.RoundTripPercent()
+	slippage := 2 * entry.SlippagePercent
+	funding := entry.Snapshot.FundingRate * 100 * float64(g.cfg.EdgeFundingPeriods)
+	if entry.Side == "short" {
+		funding = -funding
+	}
+	// 对己方有利的资金费不计为收益
+	funding = math.Max(funding, 0)
+	cost := fees + slippage + funding
+	if cost <= 0 {
+		return nil
+	}
+	if expected < cost*g.cfg.MinEdgeCostMultiple {
+		return &Rejection{
+			Rule: "edge",
+			Reason: fmt.Sprintf("预期收益 %.3f%%(置信度%.2f) 低于成本 %.3f%%(手续费%.3f%%+滑点%.3f%%+资金费%.3f%%) 的 %.1f 倍",
+				expected, confidence, cost, fees, slippage, funding, g.cfg.MinEdgeCostMultiple),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+
+	"autobot/internal/ai"
+	"autobot/internal/exchange"
+)
+
+// Sizing 为把 sizeMultiplier 换算为下单数量所需的信息：BaseQuantity 为倍数为 1 时的数量（基础币），
+// Price 用于检查最小名义价值，Rules 为交易所的步长与下限。
+type Sizing struct {
+	BaseQuantity float64
+	Price        float64
+	Rules        exchange.LotRules
+}
+
+// QuantizeAdjustments 把AI给出的调整参数换算为交易所可执行的值，应在 GuardAdjustments 之后、
+// ai.ValidateDecision 之前调用：targetLeverage 向下取整为整数倍（至少 1x），下单数量按步长向下取整、
+// 不超过 MaxQty，sizeMultiplier 改为取整后数量对应的实际倍数。返回调整后的参数、下单数量与说明，
+// 说明应追加到决策记录的 AdjustNotes，使记录中的 Adjust 与实际下单一致。取整后低于最小下单量或
+// 最小名义价值时数量为 0，调用方不应下单。BaseQuantity 不大于 0 时只处理杠杆。
+func QuantizeAdjustments(plan ai.AdjustmentPlan, sizing Sizing) (ai.AdjustmentPlan, float64, []string) {
+	var notes []string
+	if lev := plan.TargetLeverage; lev > 0 {
+		rounded := math.Max(1, math.Floor(lev+1e-9))
+		if rounded != lev {
+			notes = append(notes, fmt.Sprintf("targetLeverage %.4g→%.0f（杠杆取整）", lev, rounded))
+			plan.TargetLeverage = rounded
+		}
+	}
+	if sizing.BaseQuantity <= 0 {
+		return plan, 0, notes
+	}
+
+	multiplier := plan.SizeMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	rules := sizing.Rules
+	wanted := sizing.BaseQuantity * multiplier
+	quantity := rules.FloorQuantity(wanted)
+	decimals := exchange.StepDecimals(rules.StepSize)
+	if !rules.Tradable(quantity, sizing.Price) {
+		notes = append(notes, fmt.Sprintf("数量 %.*f 低于最小下单量 %g 或最小名义价值 %g，不下单", decimals, quantity, rules.MinQty, rules.MinNotional))
+		return plan, 0, notes
+	}
+	if quantity == wanted {
+		return plan, quantity, notes
+	}
+	effective := math.Round(quantity/sizing.BaseQuantity*10000) / 10000
+	if plan.SizeMultiplier > 0 && effective != plan.SizeMultiplier {
+		notes = append(notes, fmt.Sprintf("sizeMultiplier %.4g→%.4g（数量 %.*f→%.*f，步长 %g）",
+			plan.SizeMultiplier, effective, decimals+2, wanted, decimals, quantity, rules.StepSize))
+		plan.SizeMultiplier = effective
+	} else {
+		notes = append(notes, fmt.Sprintf("数量 %.*f→%.*f（步长 %g）", decimals+2, wanted, decimals, quantity, rules.StepSize))
+	}
+	return plan, quantity, notes
+}
@@ -0,0 +1,110 @@
+package risk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/storage"
+)
+
+// RampState 描述爬坡进度。Percent 为当前仓位比例（0~100）。
+type RampState struct {
+	Percent        float64
+	ProfitableDays int
+	RequiredDays   int
+	Completed      bool
+}
+
+// String 返回看板展示用的进度描述。
+func (s RampState) String() string {
+	if s.Completed {
+		return "爬坡完成 100%"
+	}
+	return fmt.Sprintf("爬坡 %.0f%% (%d/%d盈利日)", s.Percent, s.ProfitableDays, s.RequiredDays)
+}
+
+// Ramp 按UTC自然日已实现盈亏控制新部署交易者的仓位比例：从 RampStartPercent 起步，
+// 每个盈利日线性提升，亏损日清零计数，连续累计满 RampProfitableDays 个盈利日后永久满仓。
+type Ramp struct {
+	start    float64
+	required int
+
+	mu    sync.Mutex
+	daily map[time.Time]float64
+}
+
+// NewRamp 按交易参数创建爬坡控制；未启用时返回 nil，nil 的倍数恒为 1。
+func NewRamp(settings config.TradeSettings) *Ramp {
+	if settings.RampStartPercent <= 0 || settings.RampStartPercent >= 100 {
+		return nil
+	}
+	required := settings.RampProfitableDays
+	if required <= 0 {
+		required = 5
+	}
+	return &Ramp{start: settings.RampStartPercent, required: required, daily: make(map[time.Time]float64)}
+}
+
+// Seed 用交易者的历史成交恢复爬坡进度，通常在启动时调用。
+func (r *Ramp) Seed(trader string, trades []storage.TradeRecord) {
+	if r == nil {
+		return
+	}
+	for _, trade := range trades {
+		if trade.Trader == trader && trade.PnL != 0 {
+			r.Record(time.UnixMilli(trade.CreatedAt), trade.PnL)
+		}
+	}
+}
+
+// Record 计入一笔已实现盈亏。
+func (r *Ramp) Record(at time.Time, pnl float64) {
+	if r == nil {
+		return
+	}
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	r.mu.Lock()
+	r.daily[day] += pnl
+	r.mu.Unlock()
+}
+
+// State 返回当前爬坡进度。
+func (r *Ramp) State() RampState {
+	if r == nil {
+		return RampState{Percent: 100, Completed: true}
+	}
+	r.mu.Lock()
+	days := make([]time.Time, 0, len(r.daily))
+	for day := range r.daily {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	streak := 0
+	for _, day := range days {
+		switch pnl := r.daily[day]; {
+		case pnl > 0:
+			streak++
+		case pnl < 0:
+			streak = 0
+		}
+		if streak >= r.required {
+			break
+		}
+	}
+	r.mu.Unlock()
+
+	if streak >= r.required {
+		return RampState{Percent: 100, ProfitableDays: r.required, RequiredDays: r.required, Completed: true}
+	}
+	percent := r.start + (100-r.start)*float64(streak)/float64(r.required)
+	return RampState{Percent: percent, ProfitableDays: streak, RequiredDays: r.required}
+}
+
+// Multiplier 返回仓位应乘的系数（0~1）。
+func (r *Ramp) Multiplier() float64 {
+	return r.State().Percent / 100
+}
@@ -0,0 +1,30 @@
+package risk
+
+import "strings"
+
+// quoteAssets 为识别基础币时剥离的计价币后缀，较长的在前。
+var quoteAssets = []string{"FDUSD", "USDT", "USDC", "BUSD", "USD"}
+
+// BaseAsset 返回交易对的基础币：BTCUSDT、BTC_USDT、BTC-USD 与 Hyperliquid 的 BTC 均为 BTC。
+func BaseAsset(symbol string) string {
+	s := strings.ToUpper(strings.TrimSpace(symbol))
+	if i := strings.IndexAny(s, "_-/"); i > 0 {
+		return s[:i]
+	}
+	for _, quote := range quoteAssets {
+		if len(s) > len(quote) && strings.HasSuffix(s, quote) {
+			return strings.TrimSuffix(s, quote)
+		}
+	}
+	return s
+}
+
+// IsMajor 报告交易对是否属于 BTC/ETH 类别，与 btcEthNotionalMultiple、maxMajorPositions 的划分一致；
+// 其余币种按山寨币处理。
+func IsMajor(symbol string) bool {
+	switch BaseAsset(symbol) {
+	case "BTC", "ETH":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/news"
+	"autobot/internal/strategy"
+)
+
+// scriptedStrategy 只在脚本指定的K线上给出信号。
+type scriptedStrategy struct {
+	signals map[time.Time]strategy.Signal
+}
+
+func (s scriptedStrategy) Name() string {
+	return "scenario_script"
+}
+
+func (s scriptedStrategy) Evaluate(candles []strategy.Candle) (strategy.Signal, error) {
+	if len(candles) == 0 {
+		return strategy.SignalHold, errors.New("no candles")
+	}
+	return s.signals[candles[len(candles)-1].OpenTime], nil
+}
+
+// scriptedProvider 按场景脚本返回AI回复，并记录断言失败。
+type scriptedProvider struct {
+	bars      map[time.Time]int
+	responses []AIResponse
+	used      []bool
+	news      map[string]NewsEvent
+	failures  []string
+}
+
+func newScriptedProvider(sc Scenario, bars map[time.Time]int) *scriptedProvider {
+	p := &scriptedProvider{
+		bars:      bars,
+		responses: sc.AI,
+		used:      make([]bool, len(sc.AI)),
+		news:      make(map[string]NewsEvent, len(sc.News)),
+	}
+	for _, event := range sc.News {
+		p.news[event.Title] = event
+	}
+	return p
+}
+
+// AnalyzeNews 取最新一条新闻的脚本情绪作为整体情绪。
+func (p *scriptedProvider) AnalyzeNews(ctx context.Context, articles []news.Article) (news.SentimentSummary, error) {
+	summary := news.SentimentSummary{Sentiment: "neutral"}
+	latest := -1
+	for _, article := range articles {
+		event, ok := p.news[article.Title]
+		if !ok {
+			continue
+		}
+		summary.Highlights = append(summary.Highlights, article.Title)
+		if event.At >= latest {
+			latest = event.At
+			summary.Sentiment = event.Sentiment
+			summary.Score = event.Score
+		}
+	}
+	return summary, nil
+}
+
+func (p *scriptedProvider) GenerateDecision(ctx context.Context, req ai.DecisionRequest) (ai.DecisionResponse, error) {
+	at, err := time.ParseInLocation("2006-01-02 15:04:05", req.Context.CurrentTime, time.UTC)
+	if err != nil {
+		return ai.DecisionResponse{}, fmt.Errorf("parse decision time: %w", err)
+	}
+	bar := p.bars[at]
+
+	idx := -1
+	for i, resp := range p.responses {
+		if !p.used[i] && resp.At == bar {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		for i, resp := range p.responses {
+			if !p.used[i] && resp.At == 0 {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		return ai.DecisionResponse{Action: "hold", Reason: "no scripted response"}, nil
+	}
+	p.used[idx] = true
+	resp := p.responses[idx]
+
+	if resp.ExpectSentiment != "" && !strings.EqualFold(resp.ExpectSentiment, req.NewsSentiment.Sentiment) {
+		p.failures = append(p.failures, fmt.Sprintf("第%d根K线: 请求新闻情绪为 %q，期望 %q", bar, req.NewsSentiment.Sentiment, resp.ExpectSentiment))
+	}
+	if resp.Error != "" {
+		return ai.DecisionResponse{}, errors.New(resp.Error)
+	}
+	return ai.DecisionResponse{
+		Action:     resp.Action,
+		Confidence: resp.Confidence,
+		Reason:     resp.Reason,
+		Adjustments: ai.AdjustmentPlan{
+			SizeMultiplier: resp.SizeMultiplier,
+		},
+	}, nil
+}
+
+// unused 返回未被消费的脚本回复，通常意味着策略未在预期位置给出信号。
+func (p *scriptedProvider) unused() []AIResponse {
+	var out []AIResponse
+	for i, resp := range p.responses {
+		if !p.used[i] {
+			out = append(out, resp)
+		}
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"autobot/internal/backtest"
+	"autobot/internal/news"
+	"autobot/internal/strategy"
+)
+
+// Event 为场景运行中产生的一次订单或风控动作。
+type Event struct {
+	Bar    int
+	Action string
+	Reason string
+	Price  float64
+}
+
+func (e Event) String() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("#%d %s(%s) @%.4f", e.Bar, e.Action, e.Reason, e.Price)
+	}
+	return fmt.Sprintf("#%d %s @%.4f", e.Bar, e.Action, e.Price)
+}
+
+// Report 为单个场景的执行结果。
+type Report struct {
+	Name     string
+	Path     string
+	Events   []Event
+	Result   backtest.Result
+	Failures []string
+}
+
+// Passed 判断场景断言是否全部通过。
+func (r Report) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// riskReasons 为视作风控动作的平仓原因。
+var riskReasons = map[string]bool{
+	"stop_loss":     true,
+	"take_profit":   true,
+	"trailing_stop": true,
+}
+
+// Run 用模拟组件（脚本行情、脚本策略、脚本AI）驱动回测管线执行场景并校验断言。
+func Run(ctx context.Context, sc Scenario) Report {
+	report := Report{Name: sc.Name, Path: sc.Path}
+	candles := sc.Candles()
+	bars := make(map[time.Time]int, len(candles))
+	for idx, candle := range candles {
+		bars[candle.OpenTime] = idx
+	}
+
+	cfg := backtest.Config{
+		Name:          sc.Name,
+		Symbol:        sc.Symbol,
+		Interval:      sc.Interval,
+		Settings:      sc.Settings,
+		InitialEquity: sc.Equity,
+	}
+	if len(sc.Signals) == 0 {
+		strat, err := strategy.New(sc.Strategy, sc.Settings)
+		if err != nil {
+			report.Failures = append(report.Failures, fmt.Sprintf("策略无效: %v", err))
+			return report
+		}
+		cfg.Strategy = strat
+	} else {
+		script := scriptedStrategy{signals: make(map[time.Time]strategy.Signal, len(sc.Signals))}
+		for _, sig := range sc.Signals {
+			if sig.At >= 0 && sig.At < len(candles) {
+				script.signals[candles[sig.At].OpenTime], _ = parseSignal(sig.Signal)
+			}
+		}
+		cfg.Strategy = script
+	}
+
+	var provider *scriptedProvider
+	if len(sc.AI) > 0 {
+		provider = newScriptedProvider(sc, bars)
+		cfg.Provider = provider
+		if len(sc.News) > 0 {
+			cfg.News = func(from, to time.Time) []news.Article {
+				var articles []news.Article
+				for _, event := range sc.News {
+					if event.At < 0 || event.At >= len(candles) {
+						continue
+					}
+					published := candles[event.At].OpenTime
+					if published.Before(from) || published.After(to) {
+						continue
+					}
+					articles = append(articles, news.Article{
+						Title:       event.Title,
+						Summary:     event.Summary,
+						Source:      "scenario",
+						PublishedAt: published,
+					})
+				}
+				return articles
+			}
+		}
+	}
+
+	result, err := backtest.Run(ctx, cfg, candles)
+	report.Result = result
+	if err != nil {
+		report.Failures = append(report.Failures, fmt.Sprintf("回测失败: %v", err))
+		return report
+	}
+
+	for _, trade := range result.Trades {
+		report.Events = append(report.Events,
+			Event{Bar: bars[trade.EntryTime], Action: "open_" + trade.Side, Price: trade.EntryPrice},
+			Event{Bar: bars[trade.ExitTime], Action: "close", Reason: trade.ExitReason, Price: trade.ExitPrice},
+		)
+	}
+
+	report.Failures = append(report.Failures, checkOrders(sc.Expect.Orders, report.Events)...)
+	report.Failures = append(report.Failures, checkRisk(sc.Expect.Risk, report.Events)...)
+	if sc.Expect.AICalls != nil && *sc.Expect.AICalls != result.AICalls {
+		report.Failures = append(report.Failures, fmt.Sprintf("AI调用次数 %d，期望 %d", result.AICalls, *sc.Expect.AICalls))
+	}
+	if provider != nil {
+		report.Failures = append(report.Failures, provider.failures...)
+		for _, resp := range provider.unused() {
+			report.Failures = append(report.Failures, fmt.Sprintf("AI回复未被使用: at=%d action=%s", resp.At, resp.Action))
+		}
+	}
+	return report
+}
+
+func checkOrders(expected []ExpectedOrder, events []Event) []string {
+	if expected == nil {
+		return nil
+	}
+	var failures []string
+	if len(expected) != len(events) {
+		failures = append(failures, fmt.Sprintf("订单数量 %d，期望 %d", len(events), len(expected)))
+	}
+	for idx, want := range expected {
+		if idx >= len(events) {
+			failures = append(failures, fmt.Sprintf("订单[%d] 缺失: 期望 %s", idx, want.Action))
+			continue
+		}
+		got := events[idx]
+		switch {
+		case !strings.EqualFold(want.Action, got.Action):
+			failures = append(failures, fmt.Sprintf("订单[%d] 为 %s，期望 %s", idx, got, want.Action))
+		case want.Bar > 0 && want.Bar != got.Bar:
+			failures = append(failures, fmt.Sprintf("订单[%d] 位于第%d根K线，期望第%d根", idx, got.Bar, want.Bar))
+		case want.Reason != "" && !strings.EqualFold(want.Reason, got.Reason):
+			failures = append(failures, fmt.Sprintf("订单[%d] 原因为 %s，期望 %s", idx, got.Reason, want.Reason))
+		}
+	}
+	return failures
+}
+
+func checkRisk(expected []string, events []Event) []string {
+	seen := make(map[string]bool)
+	for _, event := range events {
+		if riskReasons[event.Reason] {
+			seen[event.Reason] = true
+		}
+	}
+	var failures []string
+	for _, want := range expected {
+		if !seen[strings.ToLower(want)] {
+			failures = append(failures, fmt.Sprintf("未触发风控动作 %s", want))
+		}
+	}
+	return failures
+}
+
+// WriteReport 输出场景执行结果，verbose 时列出全部事件。
+func WriteReport(w io.Writer, reports []Report, verbose bool) {
+	passed := 0
+	for _, r := range reports {
+		status := "PASS"
+		if r.Passed() {
+			passed++
+		} else {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "%s  %s (%s)\n", status, r.Name, r.Path)
+		if verbose || !r.Passed() {
+			for _, event := range r.Events {
+				fmt.Fprintf(w, "      %s\n", event)
+			}
+		}
+		for _, failure := range r.Failures {
+			fmt.Fprintf(w, "    - %s\n", failure)
+		}
+	}
+	fmt.Fprintf(w, "\n场景: %d 通过 / %d 总计\n", passed, len(reports))
+}
@@ -0,0 +1,242 @@
+package scenario
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+	"autobot/internal/market"
+	"autobot/internal/strategy"
+)
+
+// Scenario 描述一个行为回归场景：脚本化的行情、新闻、AI回复与期望的订单/风控动作。
+type Scenario struct {
+	Name     string               `json:"name"`
+	Symbol   string               `json:"symbol"`
+	Interval string               `json:"interval"`
+	Equity   float64              `json:"equity"`
+	Settings config.TradeSettings `json:"settings"`
+	Market   Market               `json:"market"`
+	// Strategy 为策略注册表中的策略名，留空使用默认组合策略。
+	Strategy string `json:"strategy"`
+	// Signals 非空时替代 Strategy，在指定K线上给出信号。
+	Signals []SignalEvent `json:"signals"`
+	News    []NewsEvent   `json:"news"`
+	AI      []AIResponse  `json:"ai"`
+	Expect  Expectation   `json:"expect"`
+
+	Path string `json:"-"`
+}
+
+// Market 由起始价格与若干段走势生成K线。
+type Market struct {
+	Start float64 `json:"start"`
+	Moves []Move  `json:"moves"`
+}
+
+// Move 为一段连续走势。Change 为每根K线的涨跌幅（%）；设置 To 时线性走到目标价。
+// Wick 为上下影线幅度（%），用于模拟插针触发止损/止盈。
+type Move struct {
+	Bars   int     `json:"bars"`
+	Change float64 `json:"change"`
+	To     float64 `json:"to"`
+	Wick   float64 `json:"wick"`
+	Volume float64 `json:"volume"`
+}
+
+// SignalEvent 在第 At 根K线（从0计）给出策略信号：long/short/exit。
+type SignalEvent struct {
+	At     int    `json:"at"`
+	Signal string `json:"signal"`
+}
+
+// NewsEvent 在第 At 根K线发布一条新闻，Sentiment 为脚本化AI的情绪判断。
+type NewsEvent struct {
+	At        int     `json:"at"`
+	Title     string  `json:"title"`
+	Summary   string  `json:"summary"`
+	Sentiment string  `json:"sentiment"`
+	Score     float64 `json:"score"`
+}
+
+// AIResponse 为一条预设的AI回复。At 为0时按顺序消费，否则只在该K线生效。
+type AIResponse struct {
+	At             int     `json:"at"`
+	Action         string  `json:"action"`
+	Confidence     float64 `json:"confidence"`
+	Reason         string  `json:"reason"`
+	SizeMultiplier float64 `json:"sizeMultiplier"`
+	// Error 非空时模拟提供商调用失败。
+	Error string `json:"error"`
+	// ExpectSentiment 非空时断言请求中携带的新闻情绪。
+	ExpectSentiment string `json:"expectSentiment"`
+}
+
+// Expectation 为场景断言。Orders 出现即要求按顺序完全匹配（空列表表示不应下单）。
+type Expectation struct {
+	Orders  []ExpectedOrder `json:"orders"`
+	Risk    []string        `json:"risk"`
+	AICalls *int            `json:"aiCalls"`
+}
+
+// ExpectedOrder 描述一笔期望订单。Bar 为0表示不校验K线位置，Reason 仅用于平仓。
+type ExpectedOrder struct {
+	Action string `json:"action"`
+	Bar    int    `json:"bar"`
+	Reason string `json:"reason"`
+}
+
+// Load 读取并校验场景文件。
+func Load(path string) (Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	doc, err := parseYAML(data)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("%s: %w", path, err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("%s: %w", path, err)
+	}
+	var sc Scenario
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sc); err != nil {
+		return Scenario{}, fmt.Errorf("%s: %w", path, err)
+	}
+	sc.Path = path
+	if sc.Name == "" {
+		sc.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := sc.normalize(); err != nil {
+		return Scenario{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return sc, nil
+}
+
+// LoadDir 加载目录下全部 .yaml/.yml 场景，按文件名排序。
+func LoadDir(dir string) ([]Scenario, error) {
+	var paths []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no scenarios found in %s", dir)
+	}
+	scenarios := make([]Scenario, 0, len(paths))
+	for _, path := range paths {
+		sc, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, sc)
+	}
+	return scenarios, nil
+}
+
+func (sc *Scenario) normalize() error {
+	if sc.Symbol == "" {
+		sc.Symbol = "BTCUSDT"
+	}
+	sc.Symbol = strings.ToUpper(sc.Symbol)
+	if sc.Interval == "" {
+		sc.Interval = "1m"
+	}
+	if _, ok := market.IntervalDuration(sc.Interval); !ok {
+		return fmt.Errorf("invalid interval %q", sc.Interval)
+	}
+	if sc.Equity <= 0 {
+		sc.Equity = 1000
+	}
+	if sc.Settings.Leverage <= 0 {
+		sc.Settings.Leverage = 1
+	}
+	if sc.Settings.LookbackCandles <= 0 {
+		sc.Settings.LookbackCandles = 50
+	}
+	if sc.Market.Start <= 0 {
+		sc.Market.Start = 100
+	}
+	if len(sc.Market.Moves) == 0 {
+		return fmt.Errorf("market.moves is empty")
+	}
+	for idx, move := range sc.Market.Moves {
+		if move.Bars <= 0 {
+			return fmt.Errorf("market.moves[%d].bars must be positive", idx)
+		}
+	}
+	for idx, sig := range sc.Signals {
+		if _, ok := parseSignal(sig.Signal); !ok {
+			return fmt.Errorf("signals[%d]: unknown signal %q", idx, sig.Signal)
+		}
+	}
+	return nil
+}
+
+// scenarioEpoch 固定起始时间，保证生成的K线可复现。
+var scenarioEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Candles 按走势脚本生成K线。
+func (sc Scenario) Candles() []strategy.Candle {
+	step, _ := market.IntervalDuration(sc.Interval)
+	var candles []strategy.Candle
+	price := sc.Market.Start
+	for _, move := range sc.Market.Moves {
+		wick := move.Wick
+		if wick == 0 {
+			wick = 0.05
+		}
+		volume := move.Volume
+		if volume == 0 {
+			volume = 100
+		}
+		start := price
+		for i := 1; i <= move.Bars; i++ {
+			open := price
+			if move.To > 0 {
+				price = start + (move.To-start)*float64(i)/float64(move.Bars)
+			} else {
+				price *= 1 + move.Change/100
+			}
+			high, low := open, price
+			if price > open {
+				high, low = price, open
+			}
+			candles = append(candles, strategy.Candle{
+				OpenTime: scenarioEpoch.Add(time.Duration(len(candles)) * step),
+				Open:     open,
+				High:     high * (1 + wick/100),
+				Low:      low * (1 - wick/100),
+				Close:    price,
+				Volume:   volume,
+			})
+		}
+	}
+	return candles
+}
+
+func parseSignal(value string) (strategy.Signal, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "long":
+		return strategy.SignalLong, true
+	case "short":
+		return strategy.SignalShort, true
+	case "exit":
+		return strategy.SignalExit, true
+	case "hold":
+		return strategy.SignalHold, true
+	default:
+		return strategy.SignalHold, false
+	}
+}
@@ -0,0 +1,26 @@
+package scenario
+
+import (
+	"context"
+	"testing"
+)
+
+// TestScenarios 回放仓库自带的全部场景，任一断言不符即失败。
+func TestScenarios(t *testing.T) {
+	scenarios, err := LoadDir("../../scenarios")
+	if err != nil {
+		t.Fatalf("load scenarios: %v", err)
+	}
+	if len(scenarios) == 0 {
+		t.Fatal("no scenarios found in ../../scenarios")
+	}
+	for _, sc := range scenarios {
+		sc := sc
+		t.Run(sc.Name, func(t *testing.T) {
+			report := Run(context.Background(), sc)
+			for _, failure := range report.Failures {
+				t.Error(failure)
+			}
+		})
+	}
+}
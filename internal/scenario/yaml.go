@@ -0,0 +1,307 @@
+package scenario
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 场景文件只需要 YAML 的一个小子集：块状映射/序列、行内 [a, b] 与 {k: v}、
+// 引号字符串和 # 注释。为避免引入第三方依赖，这里实现一个够用的解析器，
+// 输出 map[string]any / []any / 标量，再经 JSON 映射到结构体。
+
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+}
+
+// parseYAML 将 YAML 子集解析为通用值。
+func parseYAML(data []byte) (any, error) {
+	var lines []yamlLine
+	for idx, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		lead := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]
+		if strings.Contains(lead, "\t") && strings.TrimSpace(raw) != "" {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", idx+1)
+		}
+		text := stripComment(raw)
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.TrimSpace(trimmed) == "" || trimmed == "---" {
+			continue
+		}
+		lines = append(lines, yamlLine{number: idx + 1, indent: len(text) - len(trimmed), text: strings.TrimRight(trimmed, " ")})
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	p := &yamlParser{lines: lines}
+	value, err := p.parseBlock(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].number)
+	}
+	return value, nil
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isSeqItem(p.lines[p.pos].text) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseSeq(indent int) (any, error) {
+	var items []any
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent || (line.indent == indent && !isSeqItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		content := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		switch {
+		case content == "":
+			p.pos++
+			if p.pos < len(p.lines) && p.lines[p.pos].indent > indent {
+				value, err := p.parseBlock(p.lines[p.pos].indent)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, value)
+			} else {
+				items = append(items, nil)
+			}
+		case isSeqItem(content) || isMapEntry(content):
+			// "- key: v" 把条目内容视为更深一级缩进的块
+			p.lines[p.pos] = yamlLine{number: line.number, indent: line.indent + len(line.text) - len(content), text: content}
+			value, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		default:
+			value, err := parseScalar(content)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			items = append(items, value)
+			p.pos++
+		}
+	}
+	return items, nil
+}
+
+func (p *yamlParser) parseMap(indent int) (any, error) {
+	result := make(map[string]any)
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent || isSeqItem(line.text) {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.number)
+		}
+		key, rest, ok := splitMapEntry(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.number)
+		}
+		if _, dup := result[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.number, key)
+		}
+		p.pos++
+		if rest != "" {
+			value, err := parseScalar(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line.number, err)
+			}
+			result[key] = value
+			continue
+		}
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			// 允许序列与父键同级缩进
+			if next.indent > indent || (next.indent == indent && isSeqItem(next.text)) {
+				value, err := p.parseBlock(next.indent)
+				if err != nil {
+					return nil, err
+				}
+				result[key] = value
+				continue
+			}
+		}
+		result[key] = nil
+	}
+	return result, nil
+}
+
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isMapEntry(text string) bool {
+	if strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'") || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "{") {
+		return false
+	}
+	_, _, ok := splitMapEntry(text)
+	return ok
+}
+
+func splitMapEntry(text string) (string, string, bool) {
+	idx := strings.Index(text, ": ")
+	if idx < 0 {
+		if !strings.HasSuffix(text, ":") {
+			return "", "", false
+		}
+		idx = len(text) - 1
+	}
+	key := strings.TrimSpace(text[:idx])
+	if key == "" {
+		return "", "", false
+	}
+	if unquoted, err := unquote(key); err == nil {
+		key = unquoted
+	}
+	return key, strings.TrimSpace(text[idx+1:]), true
+}
+
+// stripComment 去掉不在引号内的 # 注释。
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseScalar(text string) (any, error) {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.HasPrefix(text, "["):
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("unterminated flow sequence %q", text)
+		}
+		parts, err := splitFlow(text[1 : len(text)-1])
+		if err != nil {
+			return nil, err
+		}
+		items := make([]any, 0, len(parts))
+		for _, part := range parts {
+			value, err := parseScalar(part)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return nil, fmt.Errorf("unterminated flow mapping %q", text)
+		}
+		parts, err := splitFlow(text[1 : len(text)-1])
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, len(parts))
+		for _, part := range parts {
+			key, rest, ok := splitMapEntry(part)
+			if !ok {
+				return nil, fmt.Errorf("invalid flow mapping entry %q", part)
+			}
+			value, err := parseScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	case strings.HasPrefix(text, "\"") || strings.HasPrefix(text, "'"):
+		return unquote(text)
+	}
+
+	switch text {
+	case "", "~", "null":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if v, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return v, nil
+	}
+	if v, err := strconv.ParseFloat(text, 64); err == nil {
+		return v, nil
+	}
+	return text, nil
+}
+
+func unquote(text string) (string, error) {
+	if len(text) < 2 {
+		return "", fmt.Errorf("invalid quoted string %q", text)
+	}
+	switch {
+	case text[0] == '"' && text[len(text)-1] == '"':
+		return strconv.Unquote(text)
+	case text[0] == '\'' && text[len(text)-1] == '\'':
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+	}
+	return "", fmt.Errorf("invalid quoted string %q", text)
+}
+
+// splitFlow 按顶层逗号切分行内集合，忽略引号与嵌套括号中的逗号。
+func splitFlow(text string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' && quote == '"' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[' || ch == '{':
+			depth++
+		case ch == ']' || ch == '}':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced brackets in %q", text)
+			}
+		case ch == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(text[start:i]))
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("unterminated flow collection %q", text)
+	}
+	if last := strings.TrimSpace(text[start:]); last != "" || len(parts) > 0 {
+		parts = append(parts, last)
+	}
+	return parts, nil
+}
@@ -0,0 +1,23 @@
+package shadow
+
+import "autobot/internal/metrics"
+
+// 按交易者导出的最近一次影子校验结果，由 Runner.Run 更新。
+var (
+	shadowSlippageRatio = metrics.NewGauge("shadow_entry_slippage_ratio", "Average adverse entry slippage of live fills versus the shadow backtest.", "trader", "symbol")
+	shadowMismatchRatio = metrics.NewGauge("shadow_mismatch_ratio", "Share of entries present only in live trading or only in the shadow backtest.", "trader", "symbol")
+	shadowDiverged      = metrics.NewGauge("shadow_diverged", "1 when the last shadow validation raised an alert or failed.", "trader", "symbol")
+)
+
+func observeReport(report Report) {
+	diverged := 0.0
+	if report.Diverged() {
+		diverged = 1
+	}
+	shadowDiverged.Set(diverged, report.Trader, report.Symbol)
+	if report.Err != nil {
+		return
+	}
+	shadowSlippageRatio.Set(report.AvgSlippageBps/10000, report.Trader, report.Symbol)
+	shadowMismatchRatio.Set(report.MismatchRatio, report.Trader, report.Symbol)
+}
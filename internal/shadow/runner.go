@@ -0,0 +1,218 @@
+package shadow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/backtest"
+	"autobot/internal/config"
+	"autobot/internal/exchange"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/market"
+	"autobot/internal/risk"
+	"autobot/internal/storage"
+	"autobot/internal/strategy"
+)
+
+// maxKlineLimit 为单次K线请求的最大数量（Binance 合约接口上限）。
+const maxKlineLimit = 1500
+
+// KlineSource 提供最近的K线，exchange.Exchange 满足该接口。
+type KlineSource interface {
+	GetKlines(ctx context.Context, symbol, interval string, limit int) ([]strategy.Candle, error)
+}
+
+// Runner 按实盘配置为每个交易者回测指定日期并与实盘成交对比。
+type Runner struct {
+	Config config.ParsedConfig
+	// Source 返回交易者所用交易所的K线来源。
+	Source func(profile config.TraderProfileResolved) (KlineSource, error)
+	// Provider 可选；设置后回测信号需经AI确认，与实盘决策流程一致（会产生API费用）。
+	Provider func(profile config.TraderProfileResolved) (ai.Provider, error)
+	// Equity 为回测初始资金，为 0 时取 1000。
+	Equity float64
+	// Now 为当前时间，为空时取 time.Now，用于计算需要拉取的K线数量。
+	Now func() time.Time
+}
+
+// Thresholds 返回配置中的告警阈值。
+func (r Runner) Thresholds() Thresholds {
+	return Thresholds{
+		MatchWindow:      r.Config.ShadowMatchWindow,
+		MaxSlippageBps:   r.Config.Shadow.MaxSlippageBps,
+		MaxMismatchRatio: r.Config.Shadow.MaxMismatchRatio,
+	}
+}
+
+// Run 校验 day 所在的UTC自然日。单个交易者失败记录在其 Report.Err 中，不影响其他交易者。
+func (r Runner) Run(ctx context.Context, day time.Time) ([]Report, error) {
+	day = utcDay(day)
+	records, err := storage.LoadTrades(r.Config.Storage, day)
+	if err != nil {
+		return nil, fmt.Errorf("load trades: %w", err)
+	}
+	logger := loggerpkg.Get("shadow")
+	reports := make([]Report, 0, len(r.Config.TraderProfiles))
+	for _, profile := range r.Config.TraderProfiles {
+		report := r.runProfile(ctx, profile, day, records)
+		observeReport(report)
+		switch {
+		case report.Err != nil:
+			logger.Printf("shadow.error trader=%s symbol=%s day=%s err=%v", report.Trader, report.Symbol, day.Format("2006-01-02"), report.Err)
+		case len(report.Alerts) > 0:
+			for _, alert := range report.Alerts {
+				logger.Printf("shadow.alert trader=%s symbol=%s day=%s alert=%q", report.Trader, report.Symbol, day.Format("2006-01-02"), alert)
+			}
+		default:
+			logger.Printf("shadow.ok trader=%s symbol=%s day=%s matched=%d slippage_bps=%.1f", report.Trader, report.Symbol, day.Format("2006-01-02"), len(report.Matches), report.AvgSlippageBps)
+		}
+		reports = append(reports, report)
+		if ctx.Err() != nil {
+			return reports, ctx.Err()
+		}
+	}
+	return reports, nil
+}
+
+func (r Runner) runProfile(ctx context.Context, profile config.TraderProfileResolved, day time.Time, records []storage.TradeRecord) Report {
+	report := Report{Trader: profile.Name, Symbol: profile.Symbol, Interval: profile.Interval, Day: day}
+	report.LiveEntries, report.LivePnL = LiveEntries(records, profile.Name, profile.Symbol, day)
+
+	barLength, ok := market.IntervalDuration(profile.Interval)
+	if !ok {
+		report.Err = fmt.Errorf("无法识别K线周期 %q", profile.Interval)
+		return report
+	}
+	candles, err := r.candles(ctx, profile, day, barLength)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	strat, err := strategy.ForTrader(profile)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	equity := r.Equity
+	if equity <= 0 {
+		equity = 1000
+	}
+	cfg := backtest.Config{
+		Name:          profile.Name,
+		Symbol:        profile.Symbol,
+		Interval:      profile.Interval,
+		Timeframes:    profile.Timeframes,
+		Strategy:      strat,
+		Settings:      profile.Settings,
+		Limits:        riskLimits(profile.Risk),
+		InitialEquity: equity,
+		Gate:          risk.NewGate(profile.Risk, profile.Fees),
+		Fees:          profile.Fees,
+	}
+	if r.Provider != nil {
+		if cfg.Provider, err = r.Provider(profile); err != nil {
+			report.Err = err
+			return report
+		}
+	}
+	result, err := backtest.Run(ctx, cfg, candles)
+	if err != nil {
+		report.Err = fmt.Errorf("backtest: %w", err)
+		return report
+	}
+	report.SimEntries, report.SimPnL = SimEntries(result, day, barLength)
+	return Compare(report, r.Thresholds())
+}
+
+// candles 拉取覆盖预热K线与 day 全天的K线，并截掉 day 之后的部分。
+// 受单次请求上限限制时缩短预热，但必须覆盖 day 的开始。
+func (r Runner) candles(ctx context.Context, profile config.TraderProfileResolved, day time.Time, barLength time.Duration) ([]strategy.Candle, error) {
+	if r.Source == nil {
+		return nil, fmt.Errorf("未设置K线来源")
+	}
+	source, err := r.Source(profile)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if r.Now != nil {
+		now = r.Now()
+	}
+	start := day.Add(-time.Duration(r.Config.Shadow.WarmupBars) * barLength)
+	limit := int(now.Sub(start)/barLength) + 1
+	if limit > maxKlineLimit {
+		limit = maxKlineLimit
+	}
+	// 与实盘策略使用同一种价格来源的K线
+	fetched, err := exchange.WithKlineSource(source, profile.Settings.KlineSource).GetKlines(ctx, profile.Symbol, profile.Interval, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get klines: %w", err)
+	}
+	end := day.Add(24 * time.Hour)
+	candles := make([]strategy.Candle, 0, len(fetched))
+	for _, candle := range fetched {
+		if candle.OpenTime.Before(end) {
+			candles = append(candles, candle)
+		}
+	}
+	if len(candles) == 0 || candles[0].OpenTime.After(day) {
+		return nil, fmt.Errorf("K线未覆盖 %s 全天（单次最多 %d 根），请在次日尽早运行", day.Format("2006-01-02"), maxKlineLimit)
+	}
+	return candles, nil
+}
+
+// Schedule 每天在 shadow.runAt（UTC）校验前一个自然日，直到 ctx 结束；每次结果交给 onReport（可为空）。
+func (r Runner) Schedule(ctx context.Context, onReport func([]Report)) {
+	logger := loggerpkg.Get("shadow")
+	for {
+		now := time.Now().UTC()
+		next := nextRun(now, r.Config.Shadow.RunAt)
+		logger.Printf("shadow.scheduled next=%s", next.Format(time.RFC3339))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next.Sub(now)):
+		}
+		reports, err := r.Run(ctx, next.AddDate(0, 0, -1))
+		if err != nil {
+			logger.Printf("shadow.failed err=%v", err)
+		}
+		if onReport != nil && len(reports) > 0 {
+			onReport(reports)
+		}
+	}
+}
+
+// nextRun 返回 now 之后第一个 runAt（HH:MM，UTC）时刻。
+func nextRun(now time.Time, runAt string) time.Time {
+	clock, err := time.Parse("15:04", runAt)
+	if err != nil {
+		clock = time.Date(0, 1, 1, 0, 30, 0, 0, time.UTC)
+	}
+	next := utcDay(now).Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func riskLimits(risk config.RiskConfig) ai.RiskLimits {
+	return ai.RiskLimits{
+		MaxDailyLossPercent:    risk.MaxDailyLossPercent,
+		MaxPositionNotionalUSD: risk.MaxPositionNotionalUSD,
+		MaxConcurrentPositions: risk.MaxConcurrentPositions,
+		MaxLeverage:            risk.MaxLeverage,
+		BtcEthNotionalMultiple: risk.BtcEthNotionalMultiple,
+		AltNotionalMultiple:    risk.AltNotionalMultiple,
+		MinRiskRewardRatio:     risk.MinRiskRewardRatio,
+		MaxMajorPositions:      risk.MaxMajorPositions,
+		MaxAltPositions:        risk.MaxAltPositions,
+	}
+}
@@ -0,0 +1,238 @@
+// Package shadow 实现每日影子校验：用实盘配置回测前一个UTC自然日的行情，
+// 与同日实盘成交逐笔对比，发现回测与实盘之间的漂移（滑点、决策不一致）。
+package shadow
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/backtest"
+	"autobot/internal/storage"
+)
+
+// Thresholds 为触发告警的差异阈值。
+type Thresholds struct {
+	// MatchWindow 为实盘入场与回测入场视为同一次入场的最大时间差。
+	MatchWindow time.Duration
+	// MaxSlippageBps 为匹配入场的平均不利滑点上限（基点）。
+	MaxSlippageBps float64
+	// MaxMismatchRatio 为对不上的入场占全部入场的比例上限。
+	MaxMismatchRatio float64
+}
+
+// Entry 为一次入场。Side 为 long 或 short。
+type Entry struct {
+	Side  string
+	Time  time.Time
+	Price float64
+}
+
+// Match 为一对匹配上的实盘与回测入场。SlippageBps 为实盘相对回测成交价的不利滑点，正数表示实盘更差。
+type Match struct {
+	Live        Entry
+	Sim         Entry
+	SlippageBps float64
+}
+
+// Report 为某交易者某日的对比结果。
+type Report struct {
+	Trader   string
+	Symbol   string
+	Interval string
+	Day      time.Time
+
+	LiveEntries []Entry
+	SimEntries  []Entry
+	Matches     []Match
+	// LiveOnly 为实盘入场但回测没有对应信号；SimOnly 为回测入场但实盘没有成交。
+	LiveOnly []Entry
+	SimOnly  []Entry
+
+	AvgSlippageBps float64
+	MaxSlippageBps float64
+	MismatchRatio  float64
+	// LivePnL 为当日实盘平仓的已实现盈亏；SimPnL 为当日回测平仓的盈亏（按回测资金计算，仅供参考）。
+	LivePnL float64
+	SimPnL  float64
+
+	Alerts []string
+	Err    error
+}
+
+// Diverged 报告是否存在需要告警的差异或校验本身失败。
+func (r Report) Diverged() bool {
+	return r.Err != nil || len(r.Alerts) > 0
+}
+
+// LiveEntries 从成交记录中提取某交易者、某交易对在 [day, day+24h) 内的开仓。
+// 启动接管（import）等非开仓记录不计入。
+func LiveEntries(records []storage.TradeRecord, trader, symbol string, day time.Time) ([]Entry, float64) {
+	end := day.Add(24 * time.Hour)
+	var entries []Entry
+	var pnl float64
+	for _, record := range records {
+		if record.Trader != trader || record.Symbol != symbol {
+			continue
+		}
+		at := time.UnixMilli(record.CreatedAt)
+		if at.Before(day) || !at.Before(end) {
+			continue
+		}
+		action := strings.ToLower(record.Action)
+		if strings.Contains(action, "close") {
+			pnl += record.PnL
+			continue
+		}
+		if !strings.HasPrefix(action, "open") || record.Price <= 0 {
+			continue
+		}
+		side, ok := entrySide(record.Side, action)
+		if !ok {
+			continue
+		}
+		entries = append(entries, Entry{Side: side, Time: at, Price: record.Price})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, pnl
+}
+
+func entrySide(side, action string) (string, bool) {
+	switch strings.ToUpper(strings.TrimSpace(side)) {
+	case "LONG", "BUY":
+		return "long", true
+	case "SHORT", "SELL":
+		return "short", true
+	}
+	switch {
+	case strings.HasSuffix(action, "long"):
+		return "long", true
+	case strings.HasSuffix(action, "short"):
+		return "short", true
+	}
+	return "", false
+}
+
+// SimEntries 提取回测结果中在 [day, day+24h) 内入场的交易，并汇总当日平仓盈亏。
+// 回测以信号K线开盘时间记录入场、按收盘价成交，加上 barLength 后与实盘下单时刻（K线收盘）对齐。
+func SimEntries(result backtest.Result, day time.Time, barLength time.Duration) ([]Entry, float64) {
+	end := day.Add(24 * time.Hour)
+	var entries []Entry
+	var pnl float64
+	for _, trade := range result.Trades {
+		if !trade.ExitTime.Before(day) && trade.ExitTime.Before(end) {
+			pnl += trade.PnL
+		}
+		at := trade.EntryTime.Add(barLength)
+		if at.Before(day) || !at.Before(end) {
+			continue
+		}
+		entries = append(entries, Entry{Side: trade.Side, Time: at, Price: trade.EntryPrice})
+	}
+	return entries, pnl
+}
+
+// Compare 按方向与时间就近匹配实盘与回测入场，计算滑点与不一致比例，超过阈值时写入 Alerts。
+func Compare(report Report, th Thresholds) Report {
+	used := make([]bool, len(report.SimEntries))
+	report.Matches, report.LiveOnly, report.SimOnly = nil, nil, nil
+	for _, live := range report.LiveEntries {
+		best := -1
+		var bestGap time.Duration
+		for i, sim := range report.SimEntries {
+			if used[i] || sim.Side != live.Side {
+				continue
+			}
+			gap := live.Time.Sub(sim.Time)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap > th.MatchWindow {
+				continue
+			}
+			if best < 0 || gap < bestGap {
+				best, bestGap = i, gap
+			}
+		}
+		if best < 0 {
+			report.LiveOnly = append(report.LiveOnly, live)
+			continue
+		}
+		used[best] = true
+		sim := report.SimEntries[best]
+		report.Matches = append(report.Matches, Match{Live: live, Sim: sim, SlippageBps: slippageBps(live, sim)})
+	}
+	for i, sim := range report.SimEntries {
+		if !used[i] {
+			report.SimOnly = append(report.SimOnly, sim)
+		}
+	}
+
+	report.AvgSlippageBps, report.MaxSlippageBps = 0, 0
+	for _, match := range report.Matches {
+		report.AvgSlippageBps += match.SlippageBps
+		report.MaxSlippageBps = math.Max(report.MaxSlippageBps, match.SlippageBps)
+	}
+	if len(report.Matches) > 0 {
+		report.AvgSlippageBps /= float64(len(report.Matches))
+	}
+	mismatches := len(report.LiveOnly) + len(report.SimOnly)
+	report.MismatchRatio = 0
+	if total := len(report.Matches) + mismatches; total > 0 {
+		report.MismatchRatio = float64(mismatches) / float64(total)
+	}
+
+	report.Alerts = nil
+	if th.MaxSlippageBps > 0 && report.AvgSlippageBps > th.MaxSlippageBps {
+		report.Alerts = append(report.Alerts, fmt.Sprintf("平均入场滑点 %.1fbps 超过阈值 %.1fbps", report.AvgSlippageBps, th.MaxSlippageBps))
+	}
+	if th.MaxMismatchRatio > 0 && report.MismatchRatio > th.MaxMismatchRatio {
+		report.Alerts = append(report.Alerts, fmt.Sprintf("入场不一致 %d/%d（实盘独有 %d、回测独有 %d）超过阈值 %.0f%%",
+			mismatches, len(report.Matches)+mismatches, len(report.LiveOnly), len(report.SimOnly), th.MaxMismatchRatio*100))
+	}
+	return report
+}
+
+func slippageBps(live, sim Entry) float64 {
+	if sim.Price <= 0 {
+		return 0
+	}
+	bps := (live.Price - sim.Price) / sim.Price * 10000
+	if live.Side == "short" {
+		bps = -bps
+	}
+	return bps
+}
+
+// WriteReport 输出对比结果表格与告警明细。
+func WriteReport(w io.Writer, reports []Report) {
+	fmt.Fprintf(w, "%-16s %-12s %-10s %6s %6s %6s %8s %8s %10s %10s %12s %12s\n",
+		"交易者", "交易对", "日期", "实盘", "回测", "匹配", "实盘独有", "回测独有", "均滑点bps", "最大bps", "实盘盈亏", "回测盈亏")
+	for _, r := range reports {
+		if r.Err != nil {
+			fmt.Fprintf(w, "%-16s %-12s %-10s 校验失败: %v\n", r.Trader, r.Symbol, r.Day.Format("2006-01-02"), r.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%-16s %-12s %-10s %6d %6d %6d %8d %8d %10.1f %10.1f %12.2f %12.2f\n",
+			r.Trader, r.Symbol, r.Day.Format("2006-01-02"), len(r.LiveEntries), len(r.SimEntries), len(r.Matches),
+			len(r.LiveOnly), len(r.SimOnly), r.AvgSlippageBps, r.MaxSlippageBps, r.LivePnL, r.SimPnL)
+	}
+	for _, r := range reports {
+		if len(r.Alerts) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n告警 %s %s:\n", r.Trader, r.Symbol)
+		for _, alert := range r.Alerts {
+			fmt.Fprintf(w, "  - %s\n", alert)
+		}
+		for _, entry := range r.LiveOnly {
+			fmt.Fprintf(w, "    实盘独有 %s %s @ %.6f\n", entry.Time.UTC().Format("15:04:05"), entry.Side, entry.Price)
+		}
+		for _, entry := range r.SimOnly {
+			fmt.Fprintf(w, "    回测独有 %s %s @ %.6f\n", entry.Time.UTC().Format("15:04:05"), entry.Side, entry.Price)
+		}
+	}
+}
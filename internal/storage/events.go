@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"autobot/internal/config"
+	"autobot/internal/eventlog"
+)
+
+// EventRecorder 向哈希链事件日志追加事件；Store 满足该接口。
+type EventRecorder interface {
+	RecordEvent(ctx context.Context, kind, trader string, data any) error
+}
+
+// RecordConfigChange 记录一条 KindConfig 事件：配置文件路径及其内容的 SHA-256。程序启动时调用一次，
+// 事件日志中相邻两条配置事件的哈希不同即说明两次启动之间配置被改过。
+func RecordConfigChange(ctx context.Context, rec EventRecorder, path, note string) error {
+	sum, err := eventlog.HashFile(path)
+	if err != nil {
+		return fmt.Errorf("hash config: %w", err)
+	}
+	return rec.RecordEvent(ctx, eventlog.KindConfig, "", eventlog.ConfigChange{Path: path, SHA256: sum, Note: note})
+}
+
+// AppendEvent 供不持有 Store 的命令行工具（如 cmd/chaos）直接向存储目录的事件日志追加一条事件，
+// 未开启 storage.eventLog 时忽略。运行中的交易程序下一次追加时从该事件接续。
+func AppendEvent(cfg config.StorageConfig, kind, trader string, data any) error {
+	if !cfg.EventLog {
+		return nil
+	}
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	if err := os.MkdirAll(cfg.Path, 0o755); err != nil {
+		return err
+	}
+	log, err := eventlog.Open(filepath.Join(cfg.Path, eventsFileName))
+	if err != nil {
+		return err
+	}
+	if _, err := log.Append(kind, trader, data); err != nil {
+		log.Close()
+		return err
+	}
+	return log.Close()
+}
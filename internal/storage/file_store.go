@@ -10,13 +10,26 @@ import (
 	"sync"
 	"time"
 
+	"autobot/internal/ai"
+	"autobot/internal/ai/memory"
 	"autobot/internal/config"
+	"autobot/internal/eventlog"
 	loggerpkg "autobot/internal/logger"
+	"autobot/internal/news"
+	"autobot/internal/pool"
+	"autobot/internal/version"
 )
 
 const (
 	decisionsFileName = "decisions.jsonl"
 	tradesFileName    = "trades.jsonl"
+	auditFileName     = "exchange_audit.jsonl"
+	usageFileName     = "ai_usage.jsonl"
+	seenFileName      = "news_seen.jsonl"
+	sentimentFileName = "news_sentiment.jsonl"
+	poolFileName      = "pool_snapshots.jsonl"
+	memoryFileName    = "ai_memory.jsonl"
+	eventsFileName    = "events.jsonl"
 	recentLimit       = 200
 )
 
@@ -24,10 +37,25 @@ type fileStore struct {
 	cfg          config.StorageConfig
 	decFile      *os.File
 	tradeFile    *os.File
+	auditFile    *os.File
+	usageFile    *os.File
+	seenFile     *os.File
+	sentFile     *os.File
+	poolFile     *os.File
+	memoryFile   *os.File
 	mu           sync.Mutex
 	decisionsBuf []DecisionRecord
 	tradesBuf    []TradeRecord
 	logger       *loggerpkg.ModuleLogger
+
+	// events 为哈希链事件日志，未开启 storage.eventLog 时为 nil。
+	events *eventlog.Log
+
+	// traderFiles 为 perTrader 模式下各交易者的文件，按需打开。
+	traderFiles map[string]*traderFiles
+	// 按交易者分开的最近记录，一个频繁决策的交易者不会挤掉其他交易者的历史。
+	traderDecisions map[string][]DecisionRecord
+	traderTrades    map[string][]TradeRecord
 }
 
 func newFileStore(cfg config.StorageConfig) (Store, error) {
@@ -51,16 +79,100 @@ func newFileStore(cfg config.StorageConfig) (Store, error) {
 		return nil, fmt.Errorf("open trades file: %w", err)
 	}
 
+	auditPath := filepath.Join(cfg.Path, auditFileName)
+	auditFile, err := os.OpenFile(auditPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		return nil, fmt.Errorf("open exchange audit file: %w", err)
+	}
+
+	usageFile, err := os.OpenFile(filepath.Join(cfg.Path, usageFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		auditFile.Close()
+		return nil, fmt.Errorf("open ai usage file: %w", err)
+	}
+
+	seenFile, err := os.OpenFile(filepath.Join(cfg.Path, seenFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		auditFile.Close()
+		usageFile.Close()
+		return nil, fmt.Errorf("open news seen file: %w", err)
+	}
+
+	sentFile, err := os.OpenFile(filepath.Join(cfg.Path, sentimentFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		auditFile.Close()
+		usageFile.Close()
+		seenFile.Close()
+		return nil, fmt.Errorf("open news sentiment file: %w", err)
+	}
+
+	poolFile, err := os.OpenFile(filepath.Join(cfg.Path, poolFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		auditFile.Close()
+		usageFile.Close()
+		seenFile.Close()
+		sentFile.Close()
+		return nil, fmt.Errorf("open pool snapshot file: %w", err)
+	}
+
+	memoryFile, err := os.OpenFile(filepath.Join(cfg.Path, memoryFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		decFile.Close()
+		tradeFile.Close()
+		auditFile.Close()
+		usageFile.Close()
+		seenFile.Close()
+		sentFile.Close()
+		poolFile.Close()
+		return nil, fmt.Errorf("open ai memory file: %w", err)
+	}
+
+	var events *eventlog.Log
+	if cfg.EventLog {
+		events, err = eventlog.Open(filepath.Join(cfg.Path, eventsFileName))
+		if err != nil {
+			decFile.Close()
+			tradeFile.Close()
+			auditFile.Close()
+			usageFile.Close()
+			seenFile.Close()
+			sentFile.Close()
+			poolFile.Close()
+			memoryFile.Close()
+			return nil, err
+		}
+	}
+
 	logger := loggerpkg.Get("storage")
 	store := &fileStore{
-		cfg:       cfg,
-		decFile:   decFile,
-		tradeFile: tradeFile,
-		logger:    logger,
+		cfg:        cfg,
+		decFile:    decFile,
+		tradeFile:  tradeFile,
+		auditFile:  auditFile,
+		usageFile:  usageFile,
+		seenFile:   seenFile,
+		sentFile:   sentFile,
+		poolFile:   poolFile,
+		memoryFile: memoryFile,
+		logger:     logger,
+		events:     events,
+
+		traderFiles:     make(map[string]*traderFiles),
+		traderDecisions: make(map[string][]DecisionRecord),
+		traderTrades:    make(map[string][]TradeRecord),
 	}
 
-	store.decisionsBuf = store.loadRecentDecisions(decPath)
-	store.tradesBuf = store.loadRecentTrades(tradePath)
+	store.loadRecent()
 	if logger != nil {
 		logger.Printf("file store ready path=%s", cfg.Path)
 	}
@@ -82,6 +194,44 @@ func (s *fileStore) Close() error {
 			err = e
 		}
 	}
+	if s.auditFile != nil {
+		if e := s.auditFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.usageFile != nil {
+		if e := s.usageFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.seenFile != nil {
+		if e := s.seenFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.sentFile != nil {
+		if e := s.sentFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.poolFile != nil {
+		if e := s.poolFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.memoryFile != nil {
+		if e := s.memoryFile.Close(); e != nil {
+			err = e
+		}
+	}
+	if s.events != nil {
+		if e := s.events.Close(); e != nil {
+			err = e
+		}
+	}
+	if e := s.closeTraderFiles(); e != nil {
+		err = e
+	}
 	if s.logger != nil {
 		s.logger.Printf("store closed err=%v", err)
 	}
@@ -90,6 +240,9 @@ func (s *fileStore) Close() error {
 
 func (s *fileStore) RecordDecision(ctx context.Context, record DecisionRecord) error {
 	record.CreatedAt = time.Now().UnixMilli()
+	if record.Build.Version == "" {
+		record.Build = version.Get()
+	}
 	payload, err := json.Marshal(record)
 	if err != nil {
 		return err
@@ -98,13 +251,26 @@ func (s *fileStore) RecordDecision(ctx context.Context, record DecisionRecord) e
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, err := s.decFile.Write(append(payload, '\n')); err != nil {
+	files, err := s.filesFor(record.Trader)
+	if err != nil {
 		return err
 	}
-	s.decisionsBuf = append(s.decisionsBuf, record)
-	if len(s.decisionsBuf) > recentLimit {
-		s.decisionsBuf = s.decisionsBuf[len(s.decisionsBuf)-recentLimit:]
+	if _, err := files.dec.Write(append(payload, '\n')); err != nil {
+		return err
 	}
+	observeDecision(record)
+	s.appendEvent(eventlog.KindDecision, record.Trader, decisionEvent{
+		ID:         record.ID,
+		Symbol:     record.Symbol,
+		Provider:   record.Provider,
+		Action:     record.Action,
+		Confidence: record.Confidence,
+		Reason:     record.Reason,
+		Success:    record.Success,
+		Error:      record.ErrorMessage,
+	})
+	s.decisionsBuf = appendRecentDecision(s.decisionsBuf, record)
+	s.traderDecisions[record.Trader] = appendRecentDecision(s.traderDecisions[record.Trader], record)
 	if s.logger != nil {
 		s.logger.Printf("decision recorded trader=%s action=%s confidence=%.2f", record.Trader, record.Action, record.Confidence)
 	}
@@ -121,19 +287,150 @@ func (s *fileStore) RecordTrade(ctx context.Context, record TradeRecord) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, err := s.tradeFile.Write(append(payload, '\n')); err != nil {
+	files, err := s.filesFor(record.Trader)
+	if err != nil {
 		return err
 	}
-	s.tradesBuf = append(s.tradesBuf, record)
-	if len(s.tradesBuf) > recentLimit {
-		s.tradesBuf = s.tradesBuf[len(s.tradesBuf)-recentLimit:]
+	if _, err := files.trade.Write(append(payload, '\n')); err != nil {
+		return err
 	}
+	observeTrade(record)
+	s.appendEvent(eventlog.KindOrder, record.Trader, record)
+	s.tradesBuf = appendRecentTrade(s.tradesBuf, record)
+	s.traderTrades[record.Trader] = appendRecentTrade(s.traderTrades[record.Trader], record)
 	if s.logger != nil {
 		s.logger.Printf("trade recorded trader=%s action=%s qty=%.4f price=%.2f pnl=%.4f", record.Trader, record.Action, record.Quantity, record.Price, record.PnL)
 	}
 	return nil
 }
 
+// RecordExchangeAudit 追加一条交易所请求审计记录，审计记录不进入内存缓存。
+func (s *fileStore) RecordExchangeAudit(ctx context.Context, record ExchangeAuditRecord) error {
+	if record.CreatedAt == 0 {
+		record.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.auditFile.Write(append(payload, '\n'))
+	return err
+}
+
+// RecordUsage 追加一条AI调用用量记录，供费用统计使用。
+func (s *fileStore) RecordUsage(ctx context.Context, usage ai.Usage) error {
+	if usage.CreatedAt == 0 {
+		usage.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(usage)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.usageFile.Write(append(payload, '\n'))
+	return err
+}
+
+// RecordSeenArticle 追加一条已见新闻哈希，重启后用于去重。
+func (s *fileStore) RecordSeenArticle(ctx context.Context, record news.SeenRecord) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.seenFile.Write(append(payload, '\n'))
+	return err
+}
+
+// RecordSentiment 追加一条按新闻集合哈希缓存的情绪分析结果。
+func (s *fileStore) RecordSentiment(ctx context.Context, record ai.SentimentRecord) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.sentFile.Write(append(payload, '\n'))
+	return err
+}
+
+// RecordPoolSnapshot 追加一条币种池选币快照。
+func (s *fileStore) RecordPoolSnapshot(ctx context.Context, snapshot pool.Snapshot) error {
+	if snapshot.CreatedAt == 0 {
+		snapshot.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.poolFile.Write(append(payload, '\n'))
+	return err
+}
+
+// RecordMemory 追加一条长期记忆（情形、决策与向量）。
+func (s *fileStore) RecordMemory(ctx context.Context, entry memory.Entry) error {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.memoryFile.Write(append(payload, '\n'))
+	return err
+}
+
+// decisionEvent 为写入事件日志的决策摘要，不含提示词等大字段。
+type decisionEvent struct {
+	ID         string  `json:"id"`
+	Symbol     string  `json:"symbol"`
+	Provider   string  `json:"provider,omitempty"`
+	Action     string  `json:"action"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+	Success    bool    `json:"success"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// RecordEvent 向哈希链事件日志追加一条事件，未开启事件日志时直接返回。
+func (s *fileStore) RecordEvent(ctx context.Context, kind, trader string, data any) error {
+	if s.events == nil {
+		return nil
+	}
+	_, err := s.events.Append(kind, trader, data)
+	return err
+}
+
+// appendEvent 在记录决策/成交时追加事件；事件日志写入失败只记日志，不影响主记录。
+func (s *fileStore) appendEvent(kind, trader string, data any) {
+	if s.events == nil {
+		return
+	}
+	if _, err := s.events.Append(kind, trader, data); err != nil && s.logger != nil {
+		s.logger.Printf("event log append failed kind=%s trader=%s err=%v", kind, trader, err)
+	}
+}
+
 func (s *fileStore) RecentDecisions(ctx context.Context, limit int) ([]DecisionRecord, error) {
 	if limit <= 0 || limit > len(s.decisionsBuf) {
 		limit = len(s.decisionsBuf)
@@ -152,52 +449,224 @@ func (s *fileStore) RecentTrades(ctx context.Context, limit int) ([]TradeRecord,
 	return result, nil
 }
 
-func (s *fileStore) loadRecentDecisions(path string) []DecisionRecord {
-	file, err := os.Open(path)
+// LoadDecisions 读取文件存储中 since 之后的全部决策记录（含各交易者子目录），供离线报表使用。
+func LoadDecisions(cfg config.StorageConfig, since time.Time) ([]DecisionRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	return loadDecisionFiles(recordPaths(cfg.Path, decisionsFileName), "", since)
+}
+
+// LoadTrades 读取文件存储中 since 之后的全部交易记录（含各交易者子目录），供离线报表使用。
+func LoadTrades(cfg config.StorageConfig, since time.Time) ([]TradeRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	return loadTradeFiles(recordPaths(cfg.Path, tradesFileName), "", since)
+}
+
+// LoadUsage 读取 since 之后的全部AI用量记录，文件不存在时返回空。
+func LoadUsage(cfg config.StorageConfig, since time.Time) ([]ai.Usage, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	file, err := os.Open(filepath.Join(cfg.Path, usageFileName))
 	if err != nil {
-		return nil
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open ai usage file: %w", err)
 	}
 	defer file.Close()
+	cutoff := since.UnixMilli()
+	var records []ai.Usage
 	scanner := bufio.NewScanner(file)
-	records := make([]DecisionRecord, 0, recentLimit)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
 			continue
 		}
-		var rec DecisionRecord
+		var rec ai.Usage
 		if err := json.Unmarshal(line, &rec); err != nil {
 			continue
 		}
-		records = append(records, rec)
-		if len(records) > recentLimit {
-			records = records[1:]
+		if rec.CreatedAt >= cutoff {
+			records = append(records, rec)
 		}
 	}
-	return records
+	return records, scanner.Err()
 }
 
-func (s *fileStore) loadRecentTrades(path string) []TradeRecord {
-	file, err := os.Open(path)
+// LoadSeenArticles 读取 since 之后的已见新闻哈希，文件不存在时返回空。
+func LoadSeenArticles(cfg config.StorageConfig, since time.Time) ([]news.SeenRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	file, err := os.Open(filepath.Join(cfg.Path, seenFileName))
 	if err != nil {
-		return nil
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open news seen file: %w", err)
+	}
+	defer file.Close()
+	cutoff := since.UnixMilli()
+	var records []news.SeenRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec news.SeenRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue
+		}
+		if rec.SeenAt >= cutoff {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// LoadSentiments 读取 since 之后缓存的情绪分析结果，文件不存在时返回空。
+func LoadSentiments(cfg config.StorageConfig, since time.Time) ([]ai.SentimentRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	file, err := os.Open(filepath.Join(cfg.Path, sentimentFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open news sentiment file: %w", err)
+	}
+	defer file.Close()
+	cutoff := since.UnixMilli()
+	var records []ai.SentimentRecord
+	scanner := bufio.NewScanner(file)
+	// 单条记录包含完整的情绪摘要，放宽默认的 64KB 行长限制
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec ai.SentimentRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue
+		}
+		if rec.CreatedAt >= cutoff {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// LoadPoolSnapshots 读取 since 之后的币种池选币快照，文件不存在时返回空。
+func LoadPoolSnapshots(cfg config.StorageConfig, since time.Time) ([]pool.Snapshot, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	file, err := os.Open(filepath.Join(cfg.Path, poolFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open pool snapshot file: %w", err)
+	}
+	defer file.Close()
+	cutoff := since.UnixMilli()
+	var records []pool.Snapshot
+	scanner := bufio.NewScanner(file)
+	// 一条快照包含全部候选币种，放宽默认的 64KB 行长限制
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec pool.Snapshot
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue
+		}
+		if rec.CreatedAt >= cutoff {
+			records = append(records, rec)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// MemoryFile 按存储目录追加长期记忆，供未持有 Store 的调用方（如AI工厂）作为 memory.Recorder；
+// 每次写入单独打开文件并以追加方式写入整行，可与 Store 同时写同一文件。
+type MemoryFile struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewMemoryFile 返回写入 cfg 存储目录下 ai_memory.jsonl 的记录器。
+func NewMemoryFile(cfg config.StorageConfig) *MemoryFile {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	return &MemoryFile{path: filepath.Join(cfg.Path, memoryFileName)}
+}
+
+// RecordMemory 追加一条长期记忆。
+func (m *MemoryFile) RecordMemory(ctx context.Context, entry memory.Entry) error {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(m.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open ai memory file: %w", err)
+	}
+	if _, err := file.Write(append(payload, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadMemories 读取 since 之后的长期记忆，文件不存在时返回空。
+func LoadMemories(cfg config.StorageConfig, since time.Time) ([]memory.Entry, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	file, err := os.Open(filepath.Join(cfg.Path, memoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open ai memory file: %w", err)
 	}
 	defer file.Close()
+	cutoff := since.UnixMilli()
+	var records []memory.Entry
 	scanner := bufio.NewScanner(file)
-	records := make([]TradeRecord, 0, recentLimit)
+	// 向量维度可达数千，放宽默认的 64KB 行长限制
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		if len(line) == 0 {
 			continue
 		}
-		var rec TradeRecord
+		var rec memory.Entry
 		if err := json.Unmarshal(line, &rec); err != nil {
 			continue
 		}
-		records = append(records, rec)
-		if len(records) > recentLimit {
-			records = records[1:]
+		if rec.CreatedAt >= cutoff {
+			records = append(records, rec)
 		}
 	}
-	return records
+	return records, scanner.Err()
 }
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 开仓方向与资金费率的关系。
+const (
+	// FundingAgainst 为持仓需支付资金费：多单遇正费率、空单遇负费率，即逆资金费方向交易。
+	FundingAgainst = "against"
+	// FundingWith 为持仓收取资金费。
+	FundingWith = "with"
+	// FundingNeutral 为费率绝对值低于中性阈值。
+	FundingNeutral = "neutral"
+	// FundingUnknown 为开仓前没有已下载的资金费率。
+	FundingUnknown = "unknown"
+)
+
+// 开仓前持仓量的变化方向。
+const (
+	OpenInterestRising  = "rising"
+	OpenInterestFalling = "falling"
+	OpenInterestFlat    = "flat"
+	OpenInterestUnknown = "unknown"
+)
+
+// openInterestWindow 为计算开仓前持仓量变化的回看时长。
+const openInterestWindow = 24 * time.Hour
+
+// TradeFundingContext 为一笔带盈亏的成交及其开仓时的资金费率与持仓量背景。Side 取自开仓决策；
+// FundingRate 为开仓前最近一次结算的费率，OIChangePercent 为开仓前24小时持仓量（计价币价值）的变化百分比。
+type TradeFundingContext struct {
+	Trade           TradeRecord
+	Side            string
+	EntryAt         int64
+	FundingRate     float64
+	HasFunding      bool
+	OIChangePercent float64
+	HasOI           bool
+}
+
+// Alignment 按中性阈值 neutralRate（费率，如 0.0001）返回开仓方向与资金费率的关系。
+func (c TradeFundingContext) Alignment(neutralRate float64) string {
+	switch {
+	case !c.HasFunding:
+		return FundingUnknown
+	case math.Abs(c.FundingRate) < neutralRate:
+		return FundingNeutral
+	case (c.Side == "long") == (c.FundingRate > 0):
+		return FundingAgainst
+	}
+	return FundingWith
+}
+
+// OITrend 按阈值 flatPercent 返回开仓前持仓量的变化方向。
+func (c TradeFundingContext) OITrend(flatPercent float64) string {
+	switch {
+	case !c.HasOI:
+		return OpenInterestUnknown
+	case c.OIChangePercent > flatPercent:
+		return OpenInterestRising
+	case c.OIChangePercent < -flatPercent:
+		return OpenInterestFalling
+	}
+	return OpenInterestFlat
+}
+
+// JoinFundingContext 把带盈亏的成交归因到开仓决策（规则同 ComparePromptVersions），再按开仓时间
+// 关联该交易对已下载的资金费率与持仓量；funding、openInterest 以大写交易对为键、按时间升序。
+// 找不到开仓决策的成交跳过。
+func JoinFundingContext(decisions []DecisionRecord, trades []TradeRecord, funding map[string][]FundingRecord, openInterest map[string][]OpenInterestRecord) []TradeFundingContext {
+	index := newOpenIndex(decisions)
+	var out []TradeFundingContext
+	for _, t := range trades {
+		if t.PnL == 0 {
+			continue
+		}
+		d, ok := index.opening(t)
+		if !ok {
+			continue
+		}
+		ctx := TradeFundingContext{Trade: t, Side: strings.TrimPrefix(d.Action, "open_"), EntryAt: d.CreatedAt}
+		symbol := strings.ToUpper(t.Symbol)
+
+		rates := funding[symbol]
+		if i := sort.Search(len(rates), func(i int) bool { return rates[i].Time > ctx.EntryAt }); i > 0 {
+			ctx.FundingRate, ctx.HasFunding = rates[i-1].Rate, true
+		}
+
+		samples := openInterest[symbol]
+		end := sort.Search(len(samples), func(i int) bool { return samples[i].Time > ctx.EntryAt })
+		start := sort.Search(len(samples), func(i int) bool {
+			return samples[i].Time >= ctx.EntryAt-openInterestWindow.Milliseconds()
+		})
+		if end > 0 && start < end-1 && samples[start].Notional > 0 {
+			ctx.OIChangePercent = (samples[end-1].Notional - samples[start].Notional) / samples[start].Notional * 100
+			ctx.HasOI = true
+		}
+		out = append(out, ctx)
+	}
+	return out
+}
+
+// FundingBucketStats 为一组成交的胜率与盈亏，AvgFundingRate 为组内开仓时费率的平均值。
+type FundingBucketStats struct {
+	Bucket         string
+	Trades         int
+	Wins           int
+	WinRate        float64
+	TotalPnL       float64
+	AvgPnL         float64
+	AvgFundingRate float64
+}
+
+// SummarizeFunding 按 key 分组汇总成交，结果按组名排序。
+func SummarizeFunding(contexts []TradeFundingContext, key func(TradeFundingContext) string) []FundingBucketStats {
+	buckets := make(map[string]*FundingBucketStats)
+	rates := make(map[string]int)
+	for _, c := range contexts {
+		name := key(c)
+		b := buckets[name]
+		if b == nil {
+			b = &FundingBucketStats{Bucket: name}
+			buckets[name] = b
+		}
+		b.Trades++
+		b.TotalPnL += c.Trade.PnL
+		if c.Trade.PnL > 0 {
+			b.Wins++
+		}
+		if c.HasFunding {
+			b.AvgFundingRate += c.FundingRate
+			rates[name]++
+		}
+	}
+	out := make([]FundingBucketStats, 0, len(buckets))
+	for name, b := range buckets {
+		b.WinRate = float64(b.Wins) / float64(b.Trades)
+		b.AvgPnL = b.TotalPnL / float64(b.Trades)
+		if rates[name] > 0 {
+			b.AvgFundingRate /= float64(rates[name])
+		}
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Bucket < out[j].Bucket })
+	return out
+}
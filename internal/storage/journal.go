@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"autobot/internal/config"
+)
+
+const orderJournalFileName = "order_journal.jsonl"
+
+// 订单日志条目类型。
+const (
+	// OrderJournalRequest 为一次下单、撤单或查单请求及交易所的原始响应。
+	OrderJournalRequest = "request"
+	// OrderJournalEvent 为用户数据流推送的一条原始事件（订单成交、账户变动等）。
+	OrderJournalEvent = "event"
+)
+
+// OrderJournalEntry 为订单日志中的一条记录，保存原始报文以便与交易所核对成交争议。
+// 请求类记录的 API Key 与签名已替换为 ***；Symbol、OrderID、ClientOrderID 从参数与响应中尽力提取，用于检索。
+type OrderJournalEntry struct {
+	Kind          string `json:"kind"`
+	Exchange      string `json:"exchange"`
+	Symbol        string `json:"symbol,omitempty"`
+	OrderID       string `json:"orderId,omitempty"`
+	ClientOrderID string `json:"clientOrderId,omitempty"`
+	Method        string `json:"method,omitempty"`
+	URL           string `json:"url,omitempty"`
+	RequestBody   string `json:"requestBody,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	ResponseBody  string `json:"responseBody,omitempty"`
+	// Event 为用户数据流事件的原始 JSON。
+	Event      json.RawMessage `json:"event,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	DurationMs int64           `json:"durationMs,omitempty"`
+	CreatedAt  int64           `json:"createdAt"`
+}
+
+// OrderJournal 为按大小轮转的订单日志，与 trades.jsonl 分开保存：当前文件超过上限后依次改名为
+// order_journal.1.jsonl、order_journal.2.jsonl ……，只保留最近 keep 个历史文件。
+type OrderJournal struct {
+	path     string
+	maxBytes int64
+	keep     int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewOrderJournal 打开存储目录下的订单日志，单文件上限与保留个数取自 storage.orderJournalMaxMb / orderJournalKeep。
+func NewOrderJournal(cfg config.StorageConfig) (*OrderJournal, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	if err := os.MkdirAll(cfg.Path, 0o755); err != nil {
+		return nil, err
+	}
+	j := &OrderJournal{
+		path:     filepath.Join(cfg.Path, orderJournalFileName),
+		maxBytes: int64(cfg.OrderJournalMaxMB) << 20,
+		keep:     cfg.OrderJournalKeep,
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+func (j *OrderJournal) open() error {
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open order journal: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	j.file, j.size = file, info.Size()
+	return nil
+}
+
+// RecordOrderJournal 追加一条记录，写入前文件将超过上限时先轮转。
+func (j *OrderJournal) RecordOrderJournal(ctx context.Context, entry OrderJournalEntry) error {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().UnixMilli()
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return errors.New("order journal closed")
+	}
+	if j.maxBytes > 0 && j.size > 0 && j.size+int64(len(payload)) > j.maxBytes {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := j.file.Write(payload)
+	j.size += int64(n)
+	return err
+}
+
+// rotate 关闭当前文件并依次后移历史文件，超出保留个数的最旧文件被删除。
+func (j *OrderJournal) rotate() error {
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	j.file = nil
+	if j.keep <= 0 {
+		if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return j.open()
+	}
+	if err := os.Remove(rotatedPath(j.path, j.keep)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for i := j.keep - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedPath(j.path, i), rotatedPath(j.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(j.path, rotatedPath(j.path, 1)); err != nil {
+		return err
+	}
+	return j.open()
+}
+
+// Close 关闭订单日志。
+func (j *OrderJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	err := j.file.Close()
+	j.file = nil
+	return err
+}
+
+func rotatedPath(path string, n int) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + strconv.Itoa(n) + ext
+}
+
+// LoadOrderJournal 按时间顺序读取 since 之后的订单日志，包括已轮转的历史文件；没有日志时返回空。
+func LoadOrderJournal(cfg config.StorageConfig, since time.Time) ([]OrderJournalEntry, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	current := filepath.Join(cfg.Path, orderJournalFileName)
+	rotated, err := filepath.Glob(strings.TrimSuffix(current, ".jsonl") + ".*.jsonl")
+	if err != nil {
+		return nil, err
+	}
+	// 编号越大越旧，先读最旧的文件
+	sort.Slice(rotated, func(a, b int) bool { return rotatedIndex(rotated[a]) > rotatedIndex(rotated[b]) })
+	cutoff := since.UnixMilli()
+	var entries []OrderJournalEntry
+	for _, path := range append(rotated, current) {
+		err := scanRecords(path, func(line []byte) {
+			var entry OrderJournalEntry
+			if json.Unmarshal(line, &entry) == nil && entry.CreatedAt >= cutoff {
+				entries = append(entries, entry)
+			}
+		})
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func rotatedIndex(path string) int {
+	name := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, ".")+1:])
+	return n
+}
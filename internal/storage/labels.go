@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"autobot/internal/config"
+)
+
+const labelsFileName = "decision_labels.jsonl"
+
+// DecisionLabel 为一条决策在某个观察周期之后的结果标注，由 outcome 标注任务写入。
+// Direction 为决策隐含的方向：long/short 表示看多/看空（开仓、持有或平掉反向仓位），flat 表示观望。
+// ReturnPercent 为交易对在决策后 Horizon 内的涨跌幅（%），Label 为 good/neutral/bad。
+type DecisionLabel struct {
+	DecisionID    string  `json:"decisionId"`
+	Trader        string  `json:"trader"`
+	Provider      string  `json:"provider"`
+	PromptVersion string  `json:"promptVersion,omitempty"`
+	Symbol        string  `json:"symbol"`
+	Action        string  `json:"action"`
+	Direction     string  `json:"direction"`
+	DecisionAt    int64   `json:"decisionAt"`
+	Horizon       string  `json:"horizon"`
+	ReturnPercent float64 `json:"returnPercent"`
+	Label         string  `json:"label"`
+	LabeledAt     int64   `json:"labeledAt"`
+}
+
+// Key 返回标注的去重键（决策ID与观察周期）。
+func (l DecisionLabel) Key() string {
+	return l.DecisionID + "@" + l.Horizon
+}
+
+// AppendDecisionLabels 把标注追加到存储目录的 decision_labels.jsonl。
+func AppendDecisionLabels(cfg config.StorageConfig, labels []DecisionLabel) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	if err := os.MkdirAll(cfg.Path, 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(cfg.Path, labelsFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open decision labels file: %w", err)
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	for _, label := range labels {
+		if err := enc.Encode(label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDecisionLabels 读取决策时间在 since 之后的标注，文件不存在时返回空。
+func LoadDecisionLabels(cfg config.StorageConfig, since time.Time) ([]DecisionLabel, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	cutoff := since.UnixMilli()
+	var labels []DecisionLabel
+	err := scanRecords(filepath.Join(cfg.Path, labelsFileName), func(line []byte) {
+		var label DecisionLabel
+		if json.Unmarshal(line, &label) == nil && label.DecisionAt >= cutoff {
+			labels = append(labels, label)
+		}
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return labels, err
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+)
+
+// learningReasonLimit 为学习片段中开仓理由保留的最大字数，避免单个片段挤占提示词。
+const learningReasonLimit = 160
+
+// LearningSnippets 从已平仓（带盈亏）的成交中选出盈利最多与亏损最多的各 n 笔，连同归因到的开仓决策
+// 理由整理成提示词中的学习片段，让模型从自己的历史中总结经验。trader 不为空时只看该交易者的成交；
+// 找不到开仓决策的成交被跳过。盈利片段在前，各自按盈亏绝对值从大到小排列。
+func LearningSnippets(decisions []DecisionRecord, trades []TradeRecord, trader string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	var wins, losses []TradeRecord
+	for _, t := range trades {
+		if trader != "" && t.Trader != trader {
+			continue
+		}
+		switch {
+		case t.PnL > 0:
+			wins = append(wins, t)
+		case t.PnL < 0:
+			losses = append(losses, t)
+		}
+	}
+	sort.SliceStable(wins, func(i, j int) bool { return wins[i].PnL > wins[j].PnL })
+	sort.SliceStable(losses, func(i, j int) bool { return losses[i].PnL < losses[j].PnL })
+
+	index := newOpenIndex(decisions)
+	var snippets []string
+	for _, group := range []struct {
+		label  string
+		trades []TradeRecord
+	}{{"盈利", wins}, {"亏损", losses}} {
+		picked := 0
+		for _, t := range group.trades {
+			if picked == n {
+				break
+			}
+			d, ok := index.opening(t)
+			if !ok || strings.TrimSpace(d.Reason) == "" {
+				continue
+			}
+			snippets = append(snippets, formatLearningSnippet(group.label, t, d))
+			picked++
+		}
+	}
+	return snippets
+}
+
+// LoadLearningSnippets 读取 since 之后的决策与成交并生成学习片段，存储为空时返回空。
+func LoadLearningSnippets(cfg config.StorageConfig, trader string, n int, since time.Time) ([]string, error) {
+	loadTrades, loadDecisions := LoadTrades, LoadDecisions
+	if trader != "" {
+		// 只读取根目录与该交易者子目录的文件
+		loadTrades = func(cfg config.StorageConfig, since time.Time) ([]TradeRecord, error) {
+			return LoadTraderTrades(cfg, trader, since)
+		}
+		loadDecisions = func(cfg config.StorageConfig, since time.Time) ([]DecisionRecord, error) {
+			return LoadTraderDecisions(cfg, trader, since)
+		}
+	}
+	trades, err := loadTrades(cfg, since)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 开仓决策可能早于窗口内的平仓成交，多读一倍的时间范围
+	decisions, err := loadDecisions(cfg, since.Add(-time.Since(since)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return LearningSnippets(decisions, trades, trader, n), nil
+}
+
+func formatLearningSnippet(label string, t TradeRecord, d DecisionRecord) string {
+	reason := []rune(strings.Join(strings.Fields(d.Reason), " "))
+	if len(reason) > learningReasonLimit {
+		reason = append(reason[:learningReasonLimit], '…')
+	}
+	return fmt.Sprintf("%s %+.2f USDT：%s %s（%s，信心%.2f），开仓理由：%s",
+		label, t.PnL, t.Symbol, d.Action, time.UnixMilli(d.CreatedAt).Format("2006-01-02 15:04"), d.Confidence, string(reason))
+}
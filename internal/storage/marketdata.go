@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+)
+
+// 历史资金费率与持仓量按交易对分文件保存在存储目录下的子目录中。
+const (
+	fundingDirName      = "funding"
+	openInterestDirName = "open_interest"
+)
+
+// FundingRecord 为一次已结算的资金费率。Rate 为费率（0.0001 即 0.01%），为正时多头向空头支付。
+type FundingRecord struct {
+	Symbol string  `json:"symbol"`
+	Time   int64   `json:"time"`
+	Rate   float64 `json:"rate"`
+}
+
+// OpenInterestRecord 为一次持仓量采样，OpenInterest 为基础币数量，Notional 为计价币价值。
+type OpenInterestRecord struct {
+	Symbol       string  `json:"symbol"`
+	Time         int64   `json:"time"`
+	OpenInterest float64 `json:"openInterest"`
+	Notional     float64 `json:"notional"`
+}
+
+func marketDataPath(cfg config.StorageConfig, dir, symbol string) string {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	return filepath.Join(cfg.Path, dir, strings.ToUpper(symbol)+".jsonl")
+}
+
+// LastFundingTime 返回已保存的最新资金费率时间，没有记录时返回零值，用于增量下载。
+func LastFundingTime(cfg config.StorageConfig, symbol string) (time.Time, error) {
+	return lastRecordTime(marketDataPath(cfg, fundingDirName, symbol))
+}
+
+// LastOpenInterestTime 返回已保存的最新持仓量采样时间，没有记录时返回零值。
+func LastOpenInterestTime(cfg config.StorageConfig, symbol string) (time.Time, error) {
+	return lastRecordTime(marketDataPath(cfg, openInterestDirName, symbol))
+}
+
+// AppendFunding 追加资金费率，只写入晚于已保存最新记录的部分，返回实际写入条数。records 需按时间升序。
+func AppendFunding(cfg config.StorageConfig, symbol string, records []FundingRecord) (int, error) {
+	path := marketDataPath(cfg, fundingDirName, symbol)
+	last, err := lastRecordTime(path)
+	if err != nil {
+		return 0, err
+	}
+	var fresh []any
+	for _, record := range records {
+		if record.Time > last.UnixMilli() {
+			record.Symbol = strings.ToUpper(symbol)
+			fresh = append(fresh, record)
+		}
+	}
+	return len(fresh), appendRecords(path, fresh)
+}
+
+// AppendOpenInterest 追加持仓量采样，规则同 AppendFunding。
+func AppendOpenInterest(cfg config.StorageConfig, symbol string, records []OpenInterestRecord) (int, error) {
+	path := marketDataPath(cfg, openInterestDirName, symbol)
+	last, err := lastRecordTime(path)
+	if err != nil {
+		return 0, err
+	}
+	var fresh []any
+	for _, record := range records {
+		if record.Time > last.UnixMilli() {
+			record.Symbol = strings.ToUpper(symbol)
+			fresh = append(fresh, record)
+		}
+	}
+	return len(fresh), appendRecords(path, fresh)
+}
+
+// LoadFunding 读取交易对 since 之后的资金费率（按时间升序），文件不存在时返回空。
+func LoadFunding(cfg config.StorageConfig, symbol string, since time.Time) ([]FundingRecord, error) {
+	cutoff := since.UnixMilli()
+	var records []FundingRecord
+	err := scanRecords(marketDataPath(cfg, fundingDirName, symbol), func(line []byte) {
+		var record FundingRecord
+		if json.Unmarshal(line, &record) == nil && record.Time >= cutoff {
+			records = append(records, record)
+		}
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return records, err
+}
+
+// LoadOpenInterest 读取交易对 since 之后的持仓量采样（按时间升序），文件不存在时返回空。
+func LoadOpenInterest(cfg config.StorageConfig, symbol string, since time.Time) ([]OpenInterestRecord, error) {
+	cutoff := since.UnixMilli()
+	var records []OpenInterestRecord
+	err := scanRecords(marketDataPath(cfg, openInterestDirName, symbol), func(line []byte) {
+		var record OpenInterestRecord
+		if json.Unmarshal(line, &record) == nil && record.Time >= cutoff {
+			records = append(records, record)
+		}
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return records, err
+}
+
+// lastRecordTime 返回文件中最大的 time 字段，文件不存在时返回零值。
+func lastRecordTime(path string) (time.Time, error) {
+	var last int64
+	err := scanRecords(path, func(line []byte) {
+		var record struct {
+			Time int64 `json:"time"`
+		}
+		if json.Unmarshal(line, &record) == nil && record.Time > last {
+			last = record.Time
+		}
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil || last == 0 {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(last), nil
+}
+
+func appendRecords(path string, records []any) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
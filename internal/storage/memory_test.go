@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"autobot/internal/ai/memory"
+	"autobot/internal/config"
+)
+
+func TestMemoryFileRoundTrip(t *testing.T) {
+	cfg := config.StorageConfig{Path: t.TempDir()}
+	recorder := NewMemoryFile(cfg)
+	old := time.Now().Add(-time.Hour).UnixMilli()
+	for _, entry := range []memory.Entry{
+		{Symbol: "BTCUSDT", Situation: "old", CreatedAt: old},
+		{Symbol: "BTCUSDT", Situation: "new", Vector: []float32{1, 0}},
+	} {
+		if err := recorder.RecordMemory(context.Background(), entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := LoadMemories(cfg, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Situation != "new" || len(entries[0].Vector) != 2 {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
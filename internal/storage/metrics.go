@@ -0,0 +1,21 @@
+package storage
+
+import "autobot/internal/metrics"
+
+// 决策与成交落盘时同步更新的指标。
+var (
+	decisionsTotal = metrics.NewCounter("decisions_total", "AI decisions recorded.", "trader", "symbol", "provider", "action")
+	tradesTotal    = metrics.NewCounter("trades_total", "Trades recorded.", "trader", "symbol", "action", "side")
+	realizedPnLUSD = metrics.NewGauge("realized_pnl_usd", "Realized PnL summed over recorded trades since start.", "trader", "symbol")
+)
+
+func observeDecision(record DecisionRecord) {
+	decisionsTotal.Inc(record.Trader, record.Symbol, record.Provider, record.Action)
+}
+
+func observeTrade(record TradeRecord) {
+	tradesTotal.Inc(record.Trader, record.Symbol, record.Action, record.Side)
+	if record.PnL != 0 {
+		realizedPnLUSD.Add(record.PnL, record.Trader, record.Symbol)
+	}
+}
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"autobot/internal/config"
+)
+
+// tradersDirName 为 storage.perTrader 模式下各交易者子目录的父目录。
+const tradersDirName = "traders"
+
+// maxRecordLine 为单行记录的最大长度；决策记录包含完整的提示词与思维链，放宽默认的 64KB 限制。
+const maxRecordLine = 16 * 1024 * 1024
+
+// traderFiles 为某交易者独立的决策与成交文件。
+type traderFiles struct {
+	dec   *os.File
+	trade *os.File
+}
+
+// traderDir 返回交易者的子目录，名称中的路径分隔符等字符替换为下划线。
+func traderDir(root, trader string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(trader))
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+	return filepath.Join(root, tradersDirName, name)
+}
+
+// filesFor 返回交易者的文件，首次使用时创建子目录并打开；调用方需持有 s.mu。
+// 未开启 perTrader 或交易者为空时返回根目录的文件。
+func (s *fileStore) filesFor(trader string) (*traderFiles, error) {
+	if !s.cfg.PerTrader || trader == "" {
+		return &traderFiles{dec: s.decFile, trade: s.tradeFile}, nil
+	}
+	if files, ok := s.traderFiles[trader]; ok {
+		return files, nil
+	}
+	dir := traderDir(s.cfg.Path, trader)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create trader storage path: %w", err)
+	}
+	dec, err := os.OpenFile(filepath.Join(dir, decisionsFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open trader decisions file: %w", err)
+	}
+	trade, err := os.OpenFile(filepath.Join(dir, tradesFileName), os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		dec.Close()
+		return nil, fmt.Errorf("open trader trades file: %w", err)
+	}
+	files := &traderFiles{dec: dec, trade: trade}
+	s.traderFiles[trader] = files
+	return files, nil
+}
+
+// closeTraderFiles 关闭各交易者的文件，返回最后一个错误；调用方需持有 s.mu。
+func (s *fileStore) closeTraderFiles() error {
+	var err error
+	for _, files := range s.traderFiles {
+		if e := files.dec.Close(); e != nil {
+			err = e
+		}
+		if e := files.trade.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// RecentDecisionsFor 返回某交易者最近的决策，各交易者的缓存互不挤占。
+func (s *fileStore) RecentDecisionsFor(ctx context.Context, trader string, limit int) ([]DecisionRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf := s.traderDecisions[trader]
+	if limit <= 0 || limit > len(buf) {
+		limit = len(buf)
+	}
+	result := make([]DecisionRecord, limit)
+	copy(result, buf[len(buf)-limit:])
+	return result, nil
+}
+
+// RecentTradesFor 返回某交易者最近的成交。
+func (s *fileStore) RecentTradesFor(ctx context.Context, trader string, limit int) ([]TradeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buf := s.traderTrades[trader]
+	if limit <= 0 || limit > len(buf) {
+		limit = len(buf)
+	}
+	result := make([]TradeRecord, limit)
+	copy(result, buf[len(buf)-limit:])
+	return result, nil
+}
+
+func appendRecentDecision(buf []DecisionRecord, record DecisionRecord) []DecisionRecord {
+	buf = append(buf, record)
+	if len(buf) > recentLimit {
+		buf = buf[len(buf)-recentLimit:]
+	}
+	return buf
+}
+
+func appendRecentTrade(buf []TradeRecord, record TradeRecord) []TradeRecord {
+	buf = append(buf, record)
+	if len(buf) > recentLimit {
+		buf = buf[len(buf)-recentLimit:]
+	}
+	return buf
+}
+
+// loadRecent 从根目录及各交易者子目录的文件恢复最近记录：每个交易者各保留 recentLimit 条，
+// 全局缓存取合并后时间最近的 recentLimit 条。
+func (s *fileStore) loadRecent() {
+	for _, path := range recordPaths(s.cfg.Path, decisionsFileName) {
+		_ = scanRecords(path, func(line []byte) {
+			var rec DecisionRecord
+			if json.Unmarshal(line, &rec) == nil {
+				s.traderDecisions[rec.Trader] = appendRecentDecision(s.traderDecisions[rec.Trader], rec)
+			}
+		})
+	}
+	for _, path := range recordPaths(s.cfg.Path, tradesFileName) {
+		_ = scanRecords(path, func(line []byte) {
+			var rec TradeRecord
+			if json.Unmarshal(line, &rec) == nil {
+				s.traderTrades[rec.Trader] = appendRecentTrade(s.traderTrades[rec.Trader], rec)
+			}
+		})
+	}
+
+	var decisions []DecisionRecord
+	for _, buf := range s.traderDecisions {
+		decisions = append(decisions, buf...)
+	}
+	sort.SliceStable(decisions, func(i, j int) bool { return decisions[i].CreatedAt < decisions[j].CreatedAt })
+	if len(decisions) > recentLimit {
+		decisions = decisions[len(decisions)-recentLimit:]
+	}
+	s.decisionsBuf = decisions
+
+	var trades []TradeRecord
+	for _, buf := range s.traderTrades {
+		trades = append(trades, buf...)
+	}
+	sort.SliceStable(trades, func(i, j int) bool { return trades[i].CreatedAt < trades[j].CreatedAt })
+	if len(trades) > recentLimit {
+		trades = trades[len(trades)-recentLimit:]
+	}
+	s.tradesBuf = trades
+}
+
+// recordPaths 返回根目录的记录文件及所有交易者子目录下的同名文件，根目录文件在前。
+func recordPaths(root, name string) []string {
+	paths := []string{filepath.Join(root, name)}
+	nested, _ := filepath.Glob(filepath.Join(root, tradersDirName, "*", name))
+	sort.Strings(nested)
+	return append(paths, nested...)
+}
+
+// scanRecords 逐行读取 JSONL 文件，跳过空行。
+func scanRecords(path string, fn func(line []byte)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordLine)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); len(line) > 0 {
+			fn(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// loadDecisionFiles 读取 paths 中 since 之后、属于 trader（为空时不限）的决策，按时间排序。
+// 第一个文件（根目录）不存在时返回错误，交易者子目录的文件可以不存在。
+func loadDecisionFiles(paths []string, trader string, since time.Time) ([]DecisionRecord, error) {
+	cutoff := since.UnixMilli()
+	var records []DecisionRecord
+	for i, path := range paths {
+		err := scanRecords(path, func(line []byte) {
+			var rec DecisionRecord
+			if json.Unmarshal(line, &rec) != nil {
+				return
+			}
+			if rec.CreatedAt >= cutoff && (trader == "" || rec.Trader == trader) {
+				records = append(records, rec)
+			}
+		})
+		if err != nil && (i == 0 || !os.IsNotExist(err)) {
+			return nil, fmt.Errorf("open decisions file: %w", err)
+		}
+	}
+	if len(paths) > 1 {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].CreatedAt < records[j].CreatedAt })
+	}
+	return records, nil
+}
+
+// loadTradeFiles 同 loadDecisionFiles，读取成交记录。
+func loadTradeFiles(paths []string, trader string, since time.Time) ([]TradeRecord, error) {
+	cutoff := since.UnixMilli()
+	var records []TradeRecord
+	for i, path := range paths {
+		err := scanRecords(path, func(line []byte) {
+			var rec TradeRecord
+			if json.Unmarshal(line, &rec) != nil {
+				return
+			}
+			if rec.CreatedAt >= cutoff && (trader == "" || rec.Trader == trader) {
+				records = append(records, rec)
+			}
+		})
+		if err != nil && (i == 0 || !os.IsNotExist(err)) {
+			return nil, fmt.Errorf("open trades file: %w", err)
+		}
+	}
+	if len(paths) > 1 {
+		sort.SliceStable(records, func(i, j int) bool { return records[i].CreatedAt < records[j].CreatedAt })
+	}
+	return records, nil
+}
+
+// LoadTraderDecisions 读取某交易者 since 之后的决策：只读取根目录文件与该交易者子目录的文件。
+func LoadTraderDecisions(cfg config.StorageConfig, trader string, since time.Time) ([]DecisionRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	paths := []string{filepath.Join(cfg.Path, decisionsFileName), filepath.Join(traderDir(cfg.Path, trader), decisionsFileName)}
+	return loadDecisionFiles(paths, trader, since)
+}
+
+// LoadTraderTrades 读取某交易者 since 之后的成交，读取范围同 LoadTraderDecisions。
+func LoadTraderTrades(cfg config.StorageConfig, trader string, since time.Time) ([]TradeRecord, error) {
+	if cfg.Path == "" {
+		cfg.Path = "data"
+	}
+	paths := []string{filepath.Join(cfg.Path, tradesFileName), filepath.Join(traderDir(cfg.Path, trader), tradesFileName)}
+	return loadTradeFiles(paths, trader, since)
+}
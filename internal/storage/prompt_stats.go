@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// UnknownPromptVersion 为未记录提示词版本的决策（旧记录、导入的持仓等）归入的分组。
+const UnknownPromptVersion = "unknown"
+
+// PromptVersionStats 为某个提示词版本的决策与成交统计。
+type PromptVersionStats struct {
+	Version   string
+	Decisions int
+	Trades    int
+	Wins      int
+	WinRate   float64
+	TotalPnL  float64
+	AvgPnL    float64
+	Sharpe    float64
+}
+
+// ComparePromptVersions 按提示词版本汇总决策，并把每笔带盈亏的成交归因到同一交易者、同一交易对
+// 在成交之前最近一次开仓决策（成交 ID 与决策 ID 相同时直接对应），统计各版本的胜率与按单笔
+// 盈亏计算的夏普。结果按版本名排序。
+func ComparePromptVersions(decisions []DecisionRecord, trades []TradeRecord) []PromptVersionStats {
+	stats := make(map[string]*PromptVersionStats)
+	get := func(version string) *PromptVersionStats {
+		if version == "" {
+			version = UnknownPromptVersion
+		}
+		s := stats[version]
+		if s == nil {
+			s = &PromptVersionStats{Version: version}
+			stats[version] = s
+		}
+		return s
+	}
+
+	for _, d := range decisions {
+		get(d.PromptVersion).Decisions++
+	}
+
+	index := newOpenIndex(decisions)
+	returns := make(map[string][]float64)
+	for _, t := range trades {
+		if t.PnL == 0 {
+			continue
+		}
+		d, ok := index.opening(t)
+		if !ok {
+			continue
+		}
+		s := get(d.PromptVersion)
+		s.Trades++
+		s.TotalPnL += t.PnL
+		if t.PnL > 0 {
+			s.Wins++
+		}
+		returns[s.Version] = append(returns[s.Version], t.PnL)
+	}
+
+	out := make([]PromptVersionStats, 0, len(stats))
+	for version, s := range stats {
+		if s.Trades > 0 {
+			s.WinRate = float64(s.Wins) / float64(s.Trades)
+			s.AvgPnL = s.TotalPnL / float64(s.Trades)
+		}
+		s.Sharpe = tradeSharpe(returns[version])
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out
+}
+
+// openIndex 把成交归因到开仓决策：成交 ID 与决策 ID 相同时直接对应，否则取同一交易者、
+// 同一交易对在成交之前最近一次开仓决策。
+type openIndex struct {
+	byID  map[string]DecisionRecord
+	opens map[string][]DecisionRecord
+}
+
+func newOpenIndex(decisions []DecisionRecord) openIndex {
+	sorted := append([]DecisionRecord(nil), decisions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt < sorted[j].CreatedAt })
+	index := openIndex{byID: make(map[string]DecisionRecord, len(sorted)), opens: make(map[string][]DecisionRecord)}
+	for _, d := range sorted {
+		if d.ID != "" {
+			index.byID[d.ID] = d
+		}
+		if strings.HasPrefix(d.Action, "open_") {
+			key := d.Trader + "|" + d.Symbol
+			index.opens[key] = append(index.opens[key], d)
+		}
+	}
+	return index
+}
+
+// opening 返回成交对应的开仓决策。
+func (idx openIndex) opening(t TradeRecord) (DecisionRecord, bool) {
+	if d, ok := idx.byID[t.ID]; ok {
+		return d, true
+	}
+	return lastOpenBefore(idx.opens[t.Trader+"|"+t.Symbol], t.CreatedAt)
+}
+
+// lastOpenBefore 返回 at 之前（含）最近一次开仓决策，opens 按时间升序。
+func lastOpenBefore(opens []DecisionRecord, at int64) (DecisionRecord, bool) {
+	i := sort.Search(len(opens), func(i int) bool { return opens[i].CreatedAt > at })
+	if i == 0 {
+		return DecisionRecord{}, false
+	}
+	return opens[i-1], true
+}
+
+// tradeSharpe 按单笔盈亏计算夏普：均值 / 样本标准差 × √笔数，少于两笔时为 0。
+func tradeSharpe(pnls []float64) float64 {
+	if len(pnls) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, p := range pnls {
+		mean += p
+	}
+	mean /= float64(len(pnls))
+	variance := 0.0
+	for _, p := range pnls {
+		variance += (p - mean) * (p - mean)
+	}
+	std := math.Sqrt(variance / float64(len(pnls)-1))
+	if std == 0 {
+		return 0
+	}
+	return mean / std * math.Sqrt(float64(len(pnls)))
+}
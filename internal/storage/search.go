@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetRunes 为命中片段在关键词前后各保留的字符数。
+const snippetRunes = 40
+
+// DecisionMatch 为全文检索命中的一条决策：Score 越高越相关，Snippets 为各字段中首个命中处的上下文。
+type DecisionMatch struct {
+	Record   DecisionRecord
+	Score    int
+	Snippets []string
+}
+
+// searchField 为参与检索的字段及其权重。
+type searchField struct {
+	name   string
+	weight int
+	text   string
+}
+
+// SearchDecisions 在决策理由、风险提示、思维链、调整说明与错误信息中检索 query（不区分大小写）。
+// query 按空白拆成多个词，每个词都须出现在同一条决策中；得分为各词命中次数乘以字段权重（理由 3、
+// 风险提示 2、其余 1），整句连续出现的字段另加分。结果按得分从高到低、同分按时间从新到旧排列，
+// limit 大于 0 时只返回前 limit 条。记录量为本地 JSONL 文件的规模，逐条扫描即可，不建索引。
+func SearchDecisions(records []DecisionRecord, query string, limit int) []DecisionMatch {
+	phrase := strings.ToLower(strings.Join(strings.Fields(query), " "))
+	terms := strings.Fields(phrase)
+	if len(terms) == 0 {
+		return nil
+	}
+	var matches []DecisionMatch
+	for _, record := range records {
+		fields := []searchField{
+			{"理由", 3, record.Reason},
+			{"风险", 2, strings.Join(record.RiskNotes, "；")},
+			{"思维链", 1, record.CoTTrace},
+			{"调整", 1, strings.Join(record.AdjustNotes, "；")},
+			{"错误", 1, record.ErrorMessage},
+		}
+		lowered := make([]string, len(fields))
+		for i, field := range fields {
+			lowered[i] = strings.ToLower(field.text)
+		}
+		score := 0
+		for _, term := range terms {
+			hits := 0
+			for i, field := range fields {
+				n := strings.Count(lowered[i], term)
+				hits += n
+				score += n * field.weight
+			}
+			if hits == 0 {
+				score = 0
+				break
+			}
+		}
+		if score == 0 {
+			continue
+		}
+		match := DecisionMatch{Record: record, Score: score}
+		for i, field := range fields {
+			if len(terms) > 1 && strings.Contains(lowered[i], phrase) {
+				match.Score += 5 * field.weight
+			}
+			if snippet, ok := snippetAround(field.text, lowered[i], terms); ok {
+				match.Snippets = append(match.Snippets, field.name+": "+snippet)
+			}
+		}
+		matches = append(matches, match)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Record.CreatedAt > matches[j].Record.CreatedAt
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// snippetAround 返回 text 中最早命中的词前后各 snippetRunes 个字符，换行压成空格。
+// 小写化改变了字节长度时（少数 Unicode 字符）从小写文本截取。
+func snippetAround(text, lowered string, terms []string) (string, bool) {
+	at, size := -1, 0
+	for _, term := range terms {
+		if idx := strings.Index(lowered, term); idx >= 0 && (at < 0 || idx < at) {
+			at, size = idx, len(term)
+		}
+	}
+	if at < 0 {
+		return "", false
+	}
+	source := text
+	if len(lowered) != len(text) {
+		source = lowered
+	}
+	start, end := at, at+size
+	for n := 0; n < snippetRunes && start > 0; n++ {
+		_, width := utf8.DecodeLastRuneInString(source[:start])
+		start -= width
+	}
+	for n := 0; n < snippetRunes && end < len(source); n++ {
+		_, width := utf8.DecodeRuneInString(source[end:])
+		end += width
+	}
+	snippet := strings.Join(strings.Fields(source[start:end]), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(source) {
+		snippet += "…"
+	}
+	return snippet, true
+}
@@ -5,7 +5,12 @@ import (
 	"fmt"
 
 	"autobot/internal/ai"
+	"autobot/internal/ai/memory"
 	"autobot/internal/config"
+	"autobot/internal/cycle"
+	"autobot/internal/news"
+	"autobot/internal/pool"
+	"autobot/internal/version"
 )
 
 // Store 定义交易记录的持久化接口。
@@ -14,6 +19,17 @@ type Store interface {
 	RecordTrade(ctx context.Context, record TradeRecord) error
 	RecentDecisions(ctx context.Context, limit int) ([]DecisionRecord, error)
 	RecentTrades(ctx context.Context, limit int) ([]TradeRecord, error)
+	// RecentDecisionsFor/RecentTradesFor 返回某交易者最近的记录，各交易者的缓存互不挤占。
+	RecentDecisionsFor(ctx context.Context, trader string, limit int) ([]DecisionRecord, error)
+	RecentTradesFor(ctx context.Context, trader string, limit int) ([]TradeRecord, error)
+	RecordExchangeAudit(ctx context.Context, record ExchangeAuditRecord) error
+	RecordUsage(ctx context.Context, usage ai.Usage) error
+	RecordSeenArticle(ctx context.Context, record news.SeenRecord) error
+	RecordSentiment(ctx context.Context, record ai.SentimentRecord) error
+	RecordPoolSnapshot(ctx context.Context, snapshot pool.Snapshot) error
+	RecordMemory(ctx context.Context, entry memory.Entry) error
+	// RecordEvent 向哈希链事件日志追加一条事件（eventlog.Kind*），未开启 storage.eventLog 时忽略。
+	RecordEvent(ctx context.Context, kind, trader string, data any) error
 	Close() error
 }
 
@@ -40,6 +56,32 @@ type DecisionRecord struct {
 	ExecutionLog []string              // 执行日志
 	Success      bool                  // 是否成功
 	ErrorMessage string                // 错误信息
+
+	// AdjustRaw 为AI返回的原始调整参数，Adjust 为经异常值防护夹紧后的实际生效值
+	AdjustRaw    ai.AdjustmentPlan     // AI原始调整参数
+	AdjustNotes  []string              // 夹紧/拒绝说明
+
+	// OrderQuantity 为按交易所步长取整后的下单数量（基础币），与 Adjust 中取整后的 sizeMultiplier 对应；未下单时为 0
+	OrderQuantity float64
+
+	// Vetoes 为确定性护栏对模型决策的否决、改写与缩减说明，Action/Adjust 为护栏处理后的值
+	Vetoes []string
+
+	// Timings 为本周期各阶段（选币池、行情、新闻、提示词、AI、风控、执行）的耗时
+	Timings cycle.Timings
+
+	// Usage 为本次决策的 token 用量与折算费用
+	Usage ai.Usage
+
+	// PromptVersion 为生成决策的提示词模板版本（name@hash），用于按版本对比胜率与夏普
+	PromptVersion string
+
+	// StrategySignal 为同一周期纯规则策略的信号（long/short/exit/hold），Price 为决策时的最新价，用于AI与纯策略的反事实对比
+	StrategySignal string
+	Price          float64
+
+	// Build 为产生该决策的程序版本与提交，落盘时自动填入
+	Build version.Info
 }
 
 // AccountSnapshot 账户状态快照
@@ -75,6 +117,25 @@ type TradeRecord struct {
 	PnL       float64
 	Notes     string
 	CreatedAt int64
+	// Origin 为下单来源，空为AI/策略，OriginOperator 为操作者手动下单。
+	Origin string `json:",omitempty"`
+}
+
+// OriginOperator 标记操作者手动发起的成交。
+const OriginOperator = "operator"
+
+// ExchangeAuditRecord 记录一次发往交易所的签名请求及原始响应，密钥类字段已脱敏。
+type ExchangeAuditRecord struct {
+	Exchange       string            `json:"exchange"`
+	Method         string            `json:"method"`
+	URL            string            `json:"url"`
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+	RequestBody    string            `json:"requestBody,omitempty"`
+	Status         int               `json:"status"`
+	ResponseBody   string            `json:"responseBody,omitempty"`
+	Error          string            `json:"error,omitempty"`
+	DurationMs     int64             `json:"durationMs"`
+	CreatedAt      int64             `json:"createdAt"`
 }
 
 // New 根据配置创建持久化实现。
@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"fmt"
+
+	"autobot/internal/indicators"
+)
+
+// DefaultADXPeriod is the ADX period used when settings leave it unset.
+const DefaultADXPeriod = 14
+
+// TrendStrength holds the latest ADX and directional indicator values.
+type TrendStrength struct {
+	ADX     float64
+	PlusDI  float64
+	MinusDI float64
+}
+
+// LatestADX returns ADX, +DI and −DI at the last candle. It reports false
+// when there are fewer than 2*period candles.
+func LatestADX(candles []Candle, period int) (TrendStrength, bool) {
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i], lows[i], closes[i] = c.High, c.Low, c.Close
+	}
+	adx, plus, minus, err := indicators.ADX(highs, lows, closes, period)
+	if err != nil {
+		return TrendStrength{}, false
+	}
+	last := len(candles) - 1
+	return TrendStrength{ADX: adx[last], PlusDI: plus[last], MinusDI: minus[last]}, true
+}
+
+// TrendFiltered suppresses the base strategy's entry signals while ADX is
+// below MinADX, so crossover systems stay out of ranging markets. Exit and
+// hold signals are never filtered; with too few candles for ADX, entries are
+// suppressed as well.
+type TrendFiltered struct {
+	Base   Strategy
+	Period int
+	MinADX float64
+}
+
+func (t TrendFiltered) Name() string {
+	if t.Base == nil {
+		return "adx"
+	}
+	return t.Base.Name() + "+adx"
+}
+
+// Unwrap returns the filtered base strategy.
+func (t TrendFiltered) Unwrap() Strategy {
+	return t.Base
+}
+
+// Evaluate runs the base strategy and drops entries in weak trends.
+func (t TrendFiltered) Evaluate(candles []Candle) (Signal, error) {
+	if t.Base == nil {
+		return SignalHold, fmt.Errorf("adx filter requires a base strategy")
+	}
+	signal, err := t.Base.Evaluate(candles)
+	return t.filter(candles, signal, err)
+}
+
+// EvaluateTimeframes forwards frames to the base strategy and checks ADX on
+// the primary candles.
+func (t TrendFiltered) EvaluateTimeframes(primary string, frames Timeframes) (Signal, error) {
+	if t.Base == nil {
+		return SignalHold, fmt.Errorf("adx filter requires a base strategy")
+	}
+	signal, err := EvaluateTimeframes(t.Base, primary, frames)
+	return t.filter(frames[primary], signal, err)
+}
+
+func (t TrendFiltered) filter(candles []Candle, signal Signal, err error) (Signal, error) {
+	if err != nil || (signal != SignalLong && signal != SignalShort) {
+		return signal, err
+	}
+	period := t.Period
+	if period <= 0 {
+		period = DefaultADXPeriod
+	}
+	strength, ok := LatestADX(candles, period)
+	if !ok || strength.ADX < t.MinADX {
+		return SignalHold, nil
+	}
+	return signal, nil
+}
@@ -0,0 +1,51 @@
+package strategy
+
+import "autobot/internal/indicators"
+
+// DefaultATRPeriod is the ATR period used for volatility stops when the
+// trader does not set atrPeriod.
+const DefaultATRPeriod = 14
+
+// LatestATR returns the most recent Wilder ATR of the candles' high/low/close.
+// It reports false when there are fewer than period+1 candles.
+func LatestATR(candles []Candle, period int) (float64, bool) {
+	if period <= 0 {
+		period = DefaultATRPeriod
+	}
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+		closes[i] = c.Close
+	}
+	atr, err := indicators.ATR(highs, lows, closes, period)
+	if err != nil {
+		return 0, false
+	}
+	last := atr[len(atr)-1]
+	return last, last > 0
+}
+
+// ATRStopPercents converts stop-loss and take-profit ATR multiples into
+// distances from price as fractions (0.01 = 1%), so stops widen on volatile
+// symbols and tighten on quiet ones. A zero multiple, or too little history,
+// leaves the corresponding fallback fraction unchanged.
+func ATRStopPercents(candles []Candle, period int, stopMultiple, takeMultiple, stopPct, takePct float64) (float64, float64) {
+	if (stopMultiple <= 0 && takeMultiple <= 0) || len(candles) == 0 {
+		return stopPct, takePct
+	}
+	price := candles[len(candles)-1].Close
+	atr, ok := LatestATR(candles, period)
+	if !ok || price <= 0 {
+		return stopPct, takePct
+	}
+	if stopMultiple > 0 {
+		stopPct = stopMultiple * atr / price
+	}
+	if takeMultiple > 0 {
+		takePct = takeMultiple * atr / price
+	}
+	return stopPct, takePct
+}
@@ -0,0 +1,26 @@
+package strategy
+
+import "autobot/internal/indicators"
+
+// DefaultCCIPeriod is the CCI period used when settings leave it unset.
+const DefaultCCIPeriod = 20
+
+// LatestCCI returns the Commodity Channel Index at the last candle. Readings
+// above +100 or below -100 mark an unusually strong move away from the
+// average price. It reports false when there are fewer than period candles.
+func LatestCCI(candles []Candle, period int) (float64, bool) {
+	if period <= 0 {
+		period = DefaultCCIPeriod
+	}
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i], lows[i], closes[i] = c.High, c.Low, c.Close
+	}
+	cci, err := indicators.CCI(highs, lows, closes, period)
+	if err != nil {
+		return 0, false
+	}
+	return cci[len(cci)-1], true
+}
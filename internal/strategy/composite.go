@@ -8,6 +8,10 @@ import (
 )
 
 // CompositeStrategy combines EMA crossover with RSI and MACD confirmation.
+// With VolumeMultiple set, crossovers also need volume confirmation: the
+// crossover bar's volume must reach VolumeMultiple × the average of the
+// preceding VolumePeriod bars and OBV must have moved in the crossover's
+// direction over the same span, so a rally on shrinking volume is ignored.
 type CompositeStrategy struct {
 	FastEMAPeriod    int
 	SlowEMAPeriod    int
@@ -17,6 +21,8 @@ type CompositeStrategy struct {
 	MACDFastPeriod   int
 	MACDSlowPeriod   int
 	MACDSignalPeriod int
+	VolumePeriod     int
+	VolumeMultiple   float64
 }
 
 func (c CompositeStrategy) Name() string {
@@ -49,6 +55,9 @@ func (c CompositeStrategy) withDefaults() CompositeStrategy {
 	if cfg.MACDSignalPeriod == 0 {
 		cfg.MACDSignalPeriod = 9
 	}
+	if cfg.VolumePeriod == 0 {
+		cfg.VolumePeriod = 20
+	}
 	return cfg
 }
 
@@ -59,7 +68,7 @@ func (c CompositeStrategy) Evaluate(candles []Candle) (Signal, error) {
 	if len(candles) == 0 {
 		return SignalHold, fmt.Errorf("no candles provided")
 	}
-	if cfg.FastEMAPeriod <= 0 || cfg.SlowEMAPeriod <= 0 || cfg.MACDFastPeriod <= 0 || cfg.MACDSlowPeriod <= 0 || cfg.MACDSignalPeriod <= 0 || cfg.RSIPeriod <= 0 {
+	if cfg.FastEMAPeriod <= 0 || cfg.SlowEMAPeriod <= 0 || cfg.MACDFastPeriod <= 0 || cfg.MACDSlowPeriod <= 0 || cfg.MACDSignalPeriod <= 0 || cfg.RSIPeriod <= 0 || cfg.VolumePeriod <= 0 {
 		return SignalHold, fmt.Errorf("periods must be positive")
 	}
 	if cfg.FastEMAPeriod >= cfg.SlowEMAPeriod {
@@ -70,6 +79,9 @@ func (c CompositeStrategy) Evaluate(candles []Candle) (Signal, error) {
 	}
 
 	minLen := maxInt(cfg.SlowEMAPeriod+1, cfg.MACDSlowPeriod+cfg.MACDSignalPeriod, cfg.RSIPeriod+1)
+	if cfg.VolumeMultiple > 0 {
+		minLen = maxInt(minLen, cfg.VolumePeriod+1)
+	}
 	if len(candles) < minLen {
 		return SignalHold, fmt.Errorf("need at least %d candles", minLen)
 	}
@@ -137,6 +149,9 @@ func (c CompositeStrategy) Evaluate(candles []Candle) (Signal, error) {
 		if macdHist <= 0 || macdLineLast <= macdSignalLast {
 			return SignalHold, nil
 		}
+		if !cfg.volumeConfirms(candles, true) {
+			return SignalHold, nil
+		}
 		return SignalLong, nil
 	case SignalShort:
 		if rsi > cfg.RSILower {
@@ -145,12 +160,46 @@ func (c CompositeStrategy) Evaluate(candles []Candle) (Signal, error) {
 		if macdHist >= 0 || macdLineLast >= macdSignalLast {
 			return SignalHold, nil
 		}
+		if !cfg.volumeConfirms(candles, false) {
+			return SignalHold, nil
+		}
 		return SignalShort, nil
 	default:
 		return SignalHold, nil
 	}
 }
 
+// volumeConfirms reports whether the last bar's volume expanded and OBV
+// moved with the signal. It always passes when VolumeMultiple is unset.
+func (c CompositeStrategy) volumeConfirms(candles []Candle, long bool) bool {
+	if c.VolumeMultiple <= 0 {
+		return true
+	}
+	last := len(candles) - 1
+	start := last - c.VolumePeriod
+	closes := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	average := 0.0
+	for i, cndl := range candles {
+		closes[i], volumes[i] = cndl.Close, cndl.Volume
+		if i >= start && i < last {
+			average += cndl.Volume
+		}
+	}
+	average /= float64(c.VolumePeriod)
+	if average <= 0 || volumes[last] < average*c.VolumeMultiple {
+		return false
+	}
+	obv, err := indicators.OBV(closes, volumes)
+	if err != nil {
+		return false
+	}
+	if long {
+		return obv[last] > obv[start]
+	}
+	return obv[last] < obv[start]
+}
+
 func maxInt(values ...int) int {
 	max := values[0]
 	for _, v := range values[1:] {
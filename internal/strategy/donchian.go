@@ -0,0 +1,103 @@
+package strategy
+
+import (
+	"fmt"
+
+	"autobot/internal/indicators"
+)
+
+// DonchianBreakout is the classic turtle system: enter when the close breaks
+// the prior EntryPeriod-bar high/low, size off an ATR-based stop, and trail
+// the stop along the ExitPeriod-bar channel on the other side.
+type DonchianBreakout struct {
+	EntryPeriod int
+	ExitPeriod  int
+	ATRPeriod   int
+	ATRMultiple float64
+}
+
+func (d DonchianBreakout) Name() string {
+	return "donchian"
+}
+
+func (d DonchianBreakout) withDefaults() DonchianBreakout {
+	cfg := d
+	if cfg.EntryPeriod == 0 {
+		cfg.EntryPeriod = 20
+	}
+	if cfg.ExitPeriod == 0 {
+		cfg.ExitPeriod = 10
+	}
+	if cfg.ATRPeriod == 0 {
+		cfg.ATRPeriod = 20
+	}
+	if cfg.ATRMultiple == 0 {
+		cfg.ATRMultiple = 2
+	}
+	return cfg
+}
+
+// Evaluate signals long when the last close exceeds the highest high of the
+// preceding EntryPeriod bars and short when it falls below the lowest low.
+func (d DonchianBreakout) Evaluate(candles []Candle) (Signal, error) {
+	cfg := d.withDefaults()
+	if cfg.EntryPeriod <= 0 || cfg.ExitPeriod <= 0 || cfg.ATRPeriod <= 0 {
+		return SignalHold, fmt.Errorf("periods must be positive")
+	}
+	if len(candles) < cfg.EntryPeriod+1 {
+		return SignalHold, fmt.Errorf("need at least %d candles", cfg.EntryPeriod+1)
+	}
+
+	highs, lows, _ := candleSeries(candles)
+	last := len(candles) - 1
+	upper, lower, err := indicators.Donchian(highs[:last], lows[:last], cfg.EntryPeriod)
+	if err != nil {
+		return SignalHold, err
+	}
+	price := candles[last].Close
+	switch {
+	case price > upper[last-1]:
+		return SignalLong, nil
+	case price < lower[last-1]:
+		return SignalShort, nil
+	default:
+		return SignalHold, nil
+	}
+}
+
+// StopDistance returns ATRMultiple × ATR at the last candle.
+func (d DonchianBreakout) StopDistance(candles []Candle) (float64, bool) {
+	cfg := d.withDefaults()
+	highs, lows, closes := candleSeries(candles)
+	atr, err := indicators.ATR(highs, lows, closes, cfg.ATRPeriod)
+	if err != nil || atr[len(atr)-1] <= 0 {
+		return 0, false
+	}
+	return atr[len(atr)-1] * cfg.ATRMultiple, true
+}
+
+// TrailingStop returns the ExitPeriod-bar low for longs or high for shorts.
+func (d DonchianBreakout) TrailingStop(candles []Candle, long bool) (float64, bool) {
+	cfg := d.withDefaults()
+	highs, lows, _ := candleSeries(candles)
+	upper, lower, err := indicators.Donchian(highs, lows, cfg.ExitPeriod)
+	if err != nil {
+		return 0, false
+	}
+	if long {
+		return lower[len(lower)-1], true
+	}
+	return upper[len(upper)-1], true
+}
+
+func candleSeries(candles []Candle) (highs, lows, closes []float64) {
+	highs = make([]float64, len(candles))
+	lows = make([]float64, len(candles))
+	closes = make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+		closes[i] = c.Close
+	}
+	return highs, lows, closes
+}
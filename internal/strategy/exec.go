@@ -0,0 +1,180 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/subproc"
+)
+
+// DefaultExecWindow is how many of the most recent candles are sent to an
+// exec strategy when its config does not set Window.
+const DefaultExecWindow = 200
+
+// ExecStrategy delegates signal generation to an external process, so
+// research code written in Python or another language can run unchanged.
+// Each Evaluate writes one JSON line to the process's stdin:
+//
+//	{"strategy":"my_py","params":{...},"candles":[{"time":1700000000000,"open":1,"high":2,"low":0.5,"close":1.5,"volume":10}]}
+//
+// and reads one JSON line back:
+//
+//	{"signal":"long","stopDistance":120.5}
+//
+// signal is one of long, short, exit or hold; the optional stopDistance (in
+// price units) is reported through StopSizer. The process is started on
+// first use and restarted if it exits or times out.
+type ExecStrategy struct {
+	Label   string
+	Window  int
+	Params  map[string]any
+	process *subproc.Process
+
+	mu       sync.Mutex
+	lastTime time.Time
+	lastStop float64
+}
+
+// NewExecStrategy builds an exec strategy from its config entry.
+func NewExecStrategy(name string, cfg config.ExecStrategyConfig) *ExecStrategy {
+	return &ExecStrategy{
+		Label:  name,
+		Window: cfg.Window,
+		Params: cfg.Params,
+		process: &subproc.Process{
+			Command: cfg.Command,
+			Args:    cfg.Args,
+			Env:     cfg.Env,
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+			Logger:  loggerpkg.Get("strategy.exec." + name),
+		},
+	}
+}
+
+// execNames tracks names registered by RegisterExec so a later config may
+// redefine them without tripping the built-in clash check.
+var execNames sync.Map
+
+// RegisterExec registers every configured exec strategy under its name.
+// Names that clash with a built-in strategy are rejected.
+func RegisterExec(strategies map[string]config.ExecStrategyConfig) error {
+	builtin := map[string]bool{}
+	for _, name := range Names() {
+		if _, ok := execNames.Load(name); !ok {
+			builtin[name] = true
+		}
+	}
+	for name, cfg := range strategies {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if builtin[key] {
+			return fmt.Errorf("exec strategy %q clashes with a built-in strategy", name)
+		}
+		if strings.TrimSpace(cfg.Command) == "" {
+			return fmt.Errorf("exec strategy %q has no command", name)
+		}
+		name, cfg := key, cfg
+		execNames.Store(name, true)
+		Register(name, func(config.TradeSettings) Strategy {
+			return NewExecStrategy(name, cfg)
+		})
+	}
+	return nil
+}
+
+func (e *ExecStrategy) Name() string {
+	return e.Label
+}
+
+type execCandle struct {
+	Time   int64   `json:"time"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+type execRequest struct {
+	Strategy string         `json:"strategy"`
+	Params   map[string]any `json:"params,omitempty"`
+	Candles  []execCandle   `json:"candles"`
+}
+
+type execResponse struct {
+	Signal       string  `json:"signal"`
+	StopDistance float64 `json:"stopDistance"`
+	Error        string  `json:"error"`
+}
+
+// Evaluate sends the latest Window candles to the process and parses its signal.
+func (e *ExecStrategy) Evaluate(candles []Candle) (Signal, error) {
+	if len(candles) == 0 {
+		return SignalHold, fmt.Errorf("no candles")
+	}
+	window := e.Window
+	if window <= 0 {
+		window = DefaultExecWindow
+	}
+	if len(candles) > window {
+		candles = candles[len(candles)-window:]
+	}
+	req := execRequest{Strategy: e.Label, Params: e.Params, Candles: make([]execCandle, len(candles))}
+	for i, c := range candles {
+		req.Candles[i] = execCandle{Time: c.OpenTime.UnixMilli(), Open: c.Open, High: c.High, Low: c.Low, Close: c.Close, Volume: c.Volume}
+	}
+
+	line, err := e.process.Call(context.Background(), req)
+	if err != nil {
+		return SignalHold, fmt.Errorf("exec strategy %s: %w", e.Label, err)
+	}
+	var resp execResponse
+	if err := json.Unmarshal(line, &resp); err != nil {
+		e.process.Reset()
+		return SignalHold, fmt.Errorf("exec strategy %s: invalid response: %w", e.Label, err)
+	}
+	if resp.Error != "" {
+		return SignalHold, fmt.Errorf("exec strategy %s: %s", e.Label, resp.Error)
+	}
+
+	e.mu.Lock()
+	e.lastTime, e.lastStop = candles[len(candles)-1].OpenTime, resp.StopDistance
+	e.mu.Unlock()
+
+	switch strings.ToLower(strings.TrimSpace(resp.Signal)) {
+	case "long":
+		return SignalLong, nil
+	case "short":
+		return SignalShort, nil
+	case "exit":
+		return SignalExit, nil
+	case "hold", "":
+		return SignalHold, nil
+	default:
+		return SignalHold, fmt.Errorf("exec strategy %s: unknown signal %q", e.Label, resp.Signal)
+	}
+}
+
+// StopDistance reports the stopDistance returned by the Evaluate call for the
+// same last candle, if the process supplied one.
+func (e *ExecStrategy) StopDistance(candles []Candle) (float64, bool) {
+	if len(candles) == 0 {
+		return 0, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.lastStop <= 0 || !candles[len(candles)-1].OpenTime.Equal(e.lastTime) {
+		return 0, false
+	}
+	return e.lastStop, true
+}
+
+// Close stops the external process.
+func (e *ExecStrategy) Close() error {
+	return e.process.Close()
+}
@@ -0,0 +1,98 @@
+package strategy
+
+import (
+	"fmt"
+	"time"
+)
+
+// TakerFlow is the aggressive (taker) buy and sell volume over a trailing
+// window, in base-asset units.
+type TakerFlow struct {
+	Window     time.Duration
+	BuyVolume  float64
+	SellVolume float64
+	Trades     int
+}
+
+// Imbalance returns (buy - sell) / (buy + sell) in [-1, 1]; 0 when there was no volume.
+func (f TakerFlow) Imbalance() float64 {
+	total := f.BuyVolume + f.SellVolume
+	if total == 0 {
+		return 0
+	}
+	return (f.BuyVolume - f.SellVolume) / total
+}
+
+// FlowEvaluator is implemented by strategies that also consume taker flow.
+// Callers with trade data should prefer EvaluateFlow over Evaluate.
+type FlowEvaluator interface {
+	EvaluateFlow(candles []Candle, flows []TakerFlow) (Signal, error)
+}
+
+// DefaultFlowWindow is the taker flow window used by FlowConfirmed when built from settings.
+const DefaultFlowWindow = 5 * time.Minute
+
+// FlowConfirmed passes the base strategy's entry signals through only when
+// taker flow over Window leans the same way by at least MinImbalance. Without
+// flow data (plain Evaluate, or no matching window) signals pass unchanged.
+type FlowConfirmed struct {
+	Base         Strategy
+	Window       time.Duration
+	MinImbalance float64
+}
+
+func (f FlowConfirmed) Name() string {
+	if f.Base == nil {
+		return "flow"
+	}
+	return f.Base.Name() + "+flow"
+}
+
+// Unwrap returns the confirmed base strategy.
+func (f FlowConfirmed) Unwrap() Strategy {
+	return f.Base
+}
+
+// Evaluate runs the base strategy without a flow filter.
+func (f FlowConfirmed) Evaluate(candles []Candle) (Signal, error) {
+	return f.EvaluateFlow(candles, nil)
+}
+
+// EvaluateTimeframes forwards frames to the base strategy without a flow
+// filter, like Evaluate.
+func (f FlowConfirmed) EvaluateTimeframes(primary string, frames Timeframes) (Signal, error) {
+	if f.Base == nil {
+		return SignalHold, fmt.Errorf("flow confirmation requires a base strategy")
+	}
+	return EvaluateTimeframes(f.Base, primary, frames)
+}
+
+// EvaluateFlow runs the base strategy and filters entries against taker flow.
+func (f FlowConfirmed) EvaluateFlow(candles []Candle, flows []TakerFlow) (Signal, error) {
+	if f.Base == nil {
+		return SignalHold, fmt.Errorf("flow confirmation requires a base strategy")
+	}
+	var signal Signal
+	var err error
+	if inner, ok := f.Base.(FlowEvaluator); ok {
+		signal, err = inner.EvaluateFlow(candles, flows)
+	} else {
+		signal, err = f.Base.Evaluate(candles)
+	}
+	if err != nil || (signal != SignalLong && signal != SignalShort) {
+		return signal, err
+	}
+	for _, flow := range flows {
+		if flow.Window != f.Window || flow.Trades == 0 {
+			continue
+		}
+		imbalance := flow.Imbalance()
+		if signal == SignalShort {
+			imbalance = -imbalance
+		}
+		if imbalance < f.MinImbalance {
+			return SignalHold, nil
+		}
+	}
+	return signal, nil
+}
@@ -0,0 +1,211 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"autobot/internal/config"
+)
+
+// DefaultMinAgreement is the weight share a signal needs when a meta
+// strategy does not set minAgreement.
+const DefaultMinAgreement = 0.5
+
+// StrengthReporter is implemented by strategies that grade their latest
+// signal. SignalStrength returns a score in [-1, 1], positive for long and
+// negative for short, and false before the first evaluation.
+type StrengthReporter interface {
+	SignalStrength() (float64, bool)
+}
+
+// SignalStrength returns the strength reported by s or the strategy beneath
+// its decorators, so callers can pass it on to the AI with the signal.
+func SignalStrength(s Strategy) (float64, bool) {
+	if reporter, ok := Unwrap(s).(StrengthReporter); ok {
+		return reporter.SignalStrength()
+	}
+	return 0, false
+}
+
+// MetaChild is one weighted child of a MetaStrategy.
+type MetaChild struct {
+	Strategy Strategy
+	Weight   float64
+}
+
+// MetaVote records how one child voted in the latest evaluation.
+type MetaVote struct {
+	Name   string
+	Weight float64
+	Signal Signal
+	Err    error
+}
+
+// MetaStrategy runs several child strategies and combines their signals by
+// weight. The long, short or exit signal with the largest weight share wins
+// when that share of the total weight reaches MinAgreement; otherwise it
+// holds. Children that fail abstain, and the evaluation only fails when all
+// of them do. The strength is (long weight - short weight) / total weight.
+type MetaStrategy struct {
+	Label        string
+	Children     []MetaChild
+	MinAgreement float64
+
+	mu       sync.Mutex
+	strength float64
+	votes    []MetaVote
+	ready    bool
+}
+
+func (m *MetaStrategy) Name() string {
+	return m.Label
+}
+
+// Evaluate runs every child on candles and combines their signals.
+func (m *MetaStrategy) Evaluate(candles []Candle) (Signal, error) {
+	return m.combine(func(s Strategy) (Signal, error) { return s.Evaluate(candles) })
+}
+
+// EvaluateTimeframes passes frames to every child, so multi-timeframe
+// children see all intervals.
+func (m *MetaStrategy) EvaluateTimeframes(primary string, frames Timeframes) (Signal, error) {
+	return m.combine(func(s Strategy) (Signal, error) { return EvaluateTimeframes(s, primary, frames) })
+}
+
+// SignalStrength returns the composite strength of the latest evaluation.
+func (m *MetaStrategy) SignalStrength() (float64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.strength, m.ready
+}
+
+// Votes returns the child votes of the latest evaluation.
+func (m *MetaStrategy) Votes() []MetaVote {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MetaVote(nil), m.votes...)
+}
+
+func (m *MetaStrategy) combine(evaluate func(Strategy) (Signal, error)) (Signal, error) {
+	if len(m.Children) == 0 {
+		return SignalHold, fmt.Errorf("meta strategy %s has no children", m.Label)
+	}
+	minAgreement := m.MinAgreement
+	if minAgreement <= 0 {
+		minAgreement = DefaultMinAgreement
+	}
+
+	votes := make([]MetaVote, len(m.Children))
+	weights := map[Signal]float64{}
+	total := 0.0
+	var errs []error
+	for i, child := range m.Children {
+		signal, err := evaluate(child.Strategy)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", child.Strategy.Name(), err))
+			signal = SignalHold
+		}
+		votes[i] = MetaVote{Name: child.Strategy.Name(), Weight: child.Weight, Signal: signal, Err: err}
+		weights[signal] += child.Weight
+		total += child.Weight
+	}
+	if len(errs) == len(m.Children) {
+		return SignalHold, errors.Join(errs...)
+	}
+
+	result, strength := SignalHold, 0.0
+	if total > 0 {
+		strength = (weights[SignalLong] - weights[SignalShort]) / total
+		best, share, tied := SignalHold, 0.0, false
+		for _, signal := range []Signal{SignalLong, SignalShort, SignalExit} {
+			w := weights[signal] / total
+			switch {
+			case w > share:
+				best, share, tied = signal, w, false
+			case w == share && w > 0:
+				tied = true
+			}
+		}
+		if !tied && share >= minAgreement {
+			result = best
+		}
+	}
+
+	m.mu.Lock()
+	m.strength, m.votes, m.ready = strength, votes, true
+	m.mu.Unlock()
+	return result, nil
+}
+
+// metaNames tracks names registered by RegisterMeta, like execNames.
+var metaNames sync.Map
+
+// RegisterMeta registers every configured meta strategy under its name.
+// Children are built from the registry without decorators when a trader's
+// strategy is created, so call it after RegisterExec when children use exec
+// strategies. Names that clash with another strategy and children that are
+// unknown or themselves meta strategies are rejected. A meta strategy
+// accepts the params of all its children.
+func RegisterMeta(strategies map[string]config.MetaStrategyConfig) error {
+	taken := map[string]bool{}
+	for _, name := range Names() {
+		if _, ok := metaNames.Load(name); !ok {
+			taken[name] = true
+		}
+	}
+	for name, cfg := range strategies {
+		key := normalizeName(name)
+		if taken[key] {
+			return fmt.Errorf("meta strategy %q clashes with an existing strategy", name)
+		}
+		if len(cfg.Strategies) < 2 {
+			return fmt.Errorf("meta strategy %q needs at least two children", name)
+		}
+		var params []string
+		seen := map[string]bool{}
+		for _, child := range cfg.Strategies {
+			childKey := normalizeName(child.Name)
+			_, registered := metaNames.Load(childKey)
+			if _, ok := strategies[child.Name]; ok || registered || childKey == key {
+				return fmt.Errorf("meta strategy %q cannot contain meta strategy %q", name, child.Name)
+			}
+			if !taken[childKey] {
+				return fmt.Errorf("meta strategy %q: unknown child strategy %q", name, child.Name)
+			}
+			registryMu.RLock()
+			childParams := registry[childKey].params
+			registryMu.RUnlock()
+			for _, param := range childParams {
+				if !seen[param] {
+					seen[param] = true
+					params = append(params, param)
+				}
+			}
+		}
+		name, cfg := key, cfg
+		metaNames.Store(name, true)
+		Register(name, func(settings config.TradeSettings) Strategy {
+			return newMeta(name, cfg, settings)
+		}, params...)
+	}
+	return nil
+}
+
+func newMeta(name string, cfg config.MetaStrategyConfig, settings config.TradeSettings) *MetaStrategy {
+	meta := &MetaStrategy{Label: name, MinAgreement: cfg.MinAgreement}
+	for _, child := range cfg.Strategies {
+		registryMu.RLock()
+		entry, ok := registry[normalizeName(child.Name)]
+		registryMu.RUnlock()
+		if !ok {
+			continue
+		}
+		weight := child.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		meta.Children = append(meta.Children, MetaChild{Strategy: entry.factory(settings), Weight: weight})
+	}
+	return meta
+}
@@ -0,0 +1,75 @@
+package strategy
+
+import (
+	"fmt"
+
+	"autobot/internal/indicators"
+)
+
+// CandlePatterns returns candlestick patterns completed within the last window candles.
+func CandlePatterns(candles []Candle, window int) []indicators.Pattern {
+	bars := make([]indicators.OHLC, len(candles))
+	for i, c := range candles {
+		bars[i] = indicators.OHLC{Open: c.Open, High: c.High, Low: c.Low, Close: c.Close}
+	}
+	return indicators.ScanPatterns(bars, window)
+}
+
+// PatternConfirmed passes the base strategy's entry signals through only when a
+// candlestick pattern with the same bias completed within the last Bars candles.
+// Exit and hold signals are never filtered.
+type PatternConfirmed struct {
+	Base Strategy
+	Bars int
+}
+
+func (p PatternConfirmed) Name() string {
+	if p.Base == nil {
+		return "patterns"
+	}
+	return p.Base.Name() + "+patterns"
+}
+
+// Unwrap returns the confirmed base strategy.
+func (p PatternConfirmed) Unwrap() Strategy {
+	return p.Base
+}
+
+// Evaluate runs the base strategy and requires pattern confirmation for entries.
+func (p PatternConfirmed) Evaluate(candles []Candle) (Signal, error) {
+	if p.Base == nil {
+		return SignalHold, fmt.Errorf("pattern confirmation requires a base strategy")
+	}
+	signal, err := p.Base.Evaluate(candles)
+	return p.confirm(candles, signal, err)
+}
+
+// EvaluateTimeframes forwards frames to the base strategy and looks for
+// confirming patterns on the primary candles.
+func (p PatternConfirmed) EvaluateTimeframes(primary string, frames Timeframes) (Signal, error) {
+	if p.Base == nil {
+		return SignalHold, fmt.Errorf("pattern confirmation requires a base strategy")
+	}
+	signal, err := EvaluateTimeframes(p.Base, primary, frames)
+	return p.confirm(frames[primary], signal, err)
+}
+
+func (p PatternConfirmed) confirm(candles []Candle, signal Signal, err error) (Signal, error) {
+	if err != nil || (signal != SignalLong && signal != SignalShort) {
+		return signal, err
+	}
+	bars := p.Bars
+	if bars <= 0 {
+		bars = 3
+	}
+	want := 1
+	if signal == SignalShort {
+		want = -1
+	}
+	for _, pattern := range CandlePatterns(candles, bars) {
+		if pattern.Bias == want {
+			return signal, nil
+		}
+	}
+	return SignalHold, nil
+}
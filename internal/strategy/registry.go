@@ -0,0 +1,228 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"autobot/internal/config"
+)
+
+// StopSizer is implemented by strategies that size positions off their own
+// initial stop distance (in price units) instead of the fixed stop percent.
+type StopSizer interface {
+	StopDistance(candles []Candle) (float64, bool)
+}
+
+// TrailingStopper is implemented by strategies that manage exits with a
+// trailing stop. Callers only ever move the stop in the position's favour.
+type TrailingStopper interface {
+	TrailingStop(candles []Candle, long bool) (float64, bool)
+}
+
+// Factory builds a strategy from a trader's resolved settings.
+type Factory func(settings config.TradeSettings) Strategy
+
+// DefaultName is used when a trader does not name a strategy.
+const DefaultName = "ema_rsi_macd"
+
+// filterParams are the settings New reads for its decorators; every
+// strategy accepts them as params.
+var filterParams = []string{"trendEmaPeriod", "adxPeriod", "adxMinStrength", "patternConfirmationBars", "takerFlowMinImbalance"}
+
+type registration struct {
+	factory Factory
+	params  []string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registration{}
+)
+
+// Register adds a strategy factory under name, replacing any previous one.
+// params lists the settings (by JSON name) the factory reads, which a
+// trader may then override through strategyParams.
+func Register(name string, factory Factory, params ...string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = registration{factory: factory, params: params}
+}
+
+// Params lists the settings the named strategy accepts as params, its own
+// followed by the decorator filters, or nil for an unknown strategy.
+func Params(name string) []string {
+	registryMu.RLock()
+	entry, ok := registry[normalizeName(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return append(append([]string(nil), entry.params...), filterParams...)
+}
+
+// Names lists the registered strategy names in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New builds the named strategy (DefaultName when empty) and wraps it with a
+// higher timeframe trend filter when TrendEMAPeriod is set, an ADX trend
+// filter when ADXMinStrength is set, candlestick pattern
+// confirmation when PatternConfirmationBars is set and taker flow
+// confirmation when TakerFlowMinImbalance is set.
+func New(name string, settings config.TradeSettings) (Strategy, error) {
+	name = normalizeName(name)
+	registryMu.RLock()
+	entry, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	base := entry.factory(settings)
+	if settings.TrendEMAPeriod > 0 {
+		base = TimeframeAligned{Base: base, EMAPeriod: settings.TrendEMAPeriod}
+	}
+	if settings.ADXMinStrength > 0 {
+		base = TrendFiltered{Base: base, Period: settings.ADXPeriod, MinADX: settings.ADXMinStrength}
+	}
+	if settings.PatternConfirmationBars > 0 {
+		base = PatternConfirmed{Base: base, Bars: settings.PatternConfirmationBars}
+	}
+	if settings.TakerFlowMinImbalance > 0 {
+		base = FlowConfirmed{Base: base, Window: DefaultFlowWindow, MinImbalance: settings.TakerFlowMinImbalance}
+	}
+	return base, nil
+}
+
+// ForTrader builds the strategy a trader selects, with its strategyParams
+// applied over the resolved settings.
+func ForTrader(profile config.TraderProfileResolved) (Strategy, error) {
+	settings, err := ApplyParams(profile.Strategy, profile.Settings, profile.StrategyParams)
+	if err != nil {
+		return nil, err
+	}
+	return New(profile.Strategy, settings)
+}
+
+// Configure registers the exec and meta strategies of cfg and checks that
+// every trader names a known strategy and that ApplyParams accepts its
+// strategyParams, so a bad key fails at startup instead of on the trader's
+// first evaluation. Commands call it right after config.Load.
+func Configure(cfg config.ParsedConfig) error {
+	if err := RegisterExec(cfg.ExecStrategies); err != nil {
+		return err
+	}
+	if err := RegisterMeta(cfg.MetaStrategies); err != nil {
+		return err
+	}
+	for _, profile := range cfg.TraderProfiles {
+		registryMu.RLock()
+		_, ok := registry[normalizeName(profile.Strategy)]
+		registryMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("trader %s: unknown strategy %q (available: %s)", profile.Name, normalizeName(profile.Strategy), strings.Join(Names(), ", "))
+		}
+		if _, err := ApplyParams(profile.Strategy, profile.Settings, profile.StrategyParams); err != nil {
+			return fmt.Errorf("trader %s: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyParams returns settings with params written over the fields of the
+// same JSON name. Only names listed by Params are accepted, and integer
+// settings reject fractional values.
+func ApplyParams(name string, settings config.TradeSettings, params map[string]float64) (config.TradeSettings, error) {
+	if len(params) == 0 {
+		return settings, nil
+	}
+	accepted := Params(name)
+	if accepted == nil {
+		return settings, fmt.Errorf("unknown strategy %q (available: %s)", normalizeName(name), strings.Join(Names(), ", "))
+	}
+	allowed := make(map[string]bool, len(accepted))
+	for _, param := range accepted {
+		allowed[param] = true
+	}
+	fields := make(map[string]any, len(params))
+	for key, value := range params {
+		if !allowed[key] {
+			return settings, fmt.Errorf("strategy %s does not accept param %q (accepted: %s)", normalizeName(name), key, strings.Join(accepted, ", "))
+		}
+		fields[key] = value
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return settings, err
+	}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return settings, fmt.Errorf("strategy %s params: %w", normalizeName(name), err)
+	}
+	return settings, nil
+}
+
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DefaultName
+	}
+	return name
+}
+
+func init() {
+	Register(DefaultName, func(settings config.TradeSettings) Strategy {
+		return CompositeStrategy{
+			FastEMAPeriod:    settings.FastEMAPeriod,
+			SlowEMAPeriod:    settings.SlowEMAPeriod,
+			RSIPeriod:        settings.RSIPeriod,
+			RSIUpper:         settings.RSIUpper,
+			RSILower:         settings.RSILower,
+			MACDFastPeriod:   settings.MACDFastPeriod,
+			MACDSlowPeriod:   settings.MACDSlowPeriod,
+			MACDSignalPeriod: settings.MACDSignalPeriod,
+			VolumePeriod:     settings.VolumeConfirmPeriod,
+			VolumeMultiple:   settings.VolumeConfirmMultiple,
+		}
+	}, "fastEmaPeriod", "slowEmaPeriod", "rsiPeriod", "rsiUpper", "rsiLower",
+		"macdFastPeriod", "macdSlowPeriod", "macdSignalPeriod", "volumeConfirmPeriod", "volumeConfirmMultiple")
+	Register("ema_crossover", func(settings config.TradeSettings) Strategy {
+		return MovingAverageCrossover{FastPeriod: settings.FastEMAPeriod, SlowPeriod: settings.SlowEMAPeriod}
+	}, "fastEmaPeriod", "slowEmaPeriod")
+	Register("donchian", func(settings config.TradeSettings) Strategy {
+		return DonchianBreakout{
+			EntryPeriod: settings.DonchianPeriod,
+			ExitPeriod:  settings.DonchianExitPeriod,
+			ATRPeriod:   settings.ATRPeriod,
+			ATRMultiple: settings.ATRStopMultiple,
+		}
+	}, "donchianPeriod", "donchianExitPeriod", "atrPeriod", "atrStopMultiple")
+	Register("supertrend", func(settings config.TradeSettings) Strategy {
+		return SuperTrendStrategy{ATRPeriod: settings.SuperTrendPeriod, Multiplier: settings.SuperTrendMultiplier}
+	}, "superTrendPeriod", "superTrendMultiplier")
+}
+
+// Unwrap returns the innermost strategy beneath decorators such as
+// PatternConfirmed, so callers can detect optional interfaces.
+func Unwrap(s Strategy) Strategy {
+	for {
+		wrapper, ok := s.(interface{ Unwrap() Strategy })
+		if !ok {
+			return s
+		}
+		inner := wrapper.Unwrap()
+		if inner == nil {
+			return s
+		}
+		s = inner
+	}
+}
@@ -0,0 +1,184 @@
+package strategy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"autobot/internal/config"
+)
+
+// fixedStrategy always returns the same signal or error.
+type fixedStrategy struct {
+	name   string
+	signal Signal
+	err    error
+}
+
+func (f fixedStrategy) Name() string { return f.name }
+
+func (f fixedStrategy) Evaluate([]Candle) (Signal, error) { return f.signal, f.err }
+
+func TestNewDefaultsAndUnknown(t *testing.T) {
+	s, err := New("", config.TradeSettings{FastEMAPeriod: 5, SlowEMAPeriod: 10})
+	if err != nil {
+		t.Fatalf("default strategy: %v", err)
+	}
+	if _, ok := s.(CompositeStrategy); !ok {
+		t.Fatalf("default strategy is %T, want CompositeStrategy", s)
+	}
+	if _, err := New("no_such_strategy", config.TradeSettings{}); err == nil {
+		t.Fatal("unknown strategy accepted")
+	}
+	if _, err := New("  Donchian ", config.TradeSettings{}); err != nil {
+		t.Fatalf("names are not normalised: %v", err)
+	}
+}
+
+func TestNewWrapsDecorators(t *testing.T) {
+	s, err := New("ema_crossover", config.TradeSettings{ADXMinStrength: 20, ADXPeriod: 14, PatternConfirmationBars: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.(PatternConfirmed); !ok {
+		t.Fatalf("outer strategy is %T, want PatternConfirmed", s)
+	}
+	if _, ok := Unwrap(s).(MovingAverageCrossover); !ok {
+		t.Fatalf("Unwrap returned %T, want MovingAverageCrossover", Unwrap(s))
+	}
+}
+
+func TestApplyParams(t *testing.T) {
+	base := config.TradeSettings{DonchianPeriod: 20, ATRStopMultiple: 2}
+
+	got, err := ApplyParams("donchian", base, map[string]float64{"donchianPeriod": 55, "atrStopMultiple": 2.5, "adxMinStrength": 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DonchianPeriod != 55 || got.ATRStopMultiple != 2.5 || got.ADXMinStrength != 25 {
+		t.Fatalf("settings = %+v", got)
+	}
+	if base.DonchianPeriod != 20 {
+		t.Fatal("ApplyParams modified its input")
+	}
+
+	for name, params := range map[string]map[string]float64{
+		"foreign param":    {"fastEmaPeriod": 9},
+		"fractional int":   {"donchianPeriod": 20.5},
+		"unknown strategy": nil,
+	} {
+		strategy := "donchian"
+		if params == nil {
+			strategy, params = "no_such_strategy", map[string]float64{"x": 1}
+		}
+		if _, err := ApplyParams(strategy, base, params); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestForTraderAppliesParams(t *testing.T) {
+	profile := config.TraderProfileResolved{Settings: config.TradeSettings{FastEMAPeriod: 5, SlowEMAPeriod: 10}}
+	profile.Strategy = "ema_crossover"
+	profile.StrategyParams = map[string]float64{"slowEmaPeriod": 30}
+	s, err := ForTrader(profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.(MovingAverageCrossover); got.FastPeriod != 5 || got.SlowPeriod != 30 {
+		t.Fatalf("strategy = %+v", got)
+	}
+}
+
+func TestConfigureChecksEveryTrader(t *testing.T) {
+	good := config.TraderProfileResolved{}
+	good.Name, good.Strategy = "a", "ema_crossover"
+	good.StrategyParams = map[string]float64{"slowEmaPeriod": 30}
+	if err := Configure(config.ParsedConfig{TraderProfiles: []config.TraderProfileResolved{good}}); err != nil {
+		t.Fatal(err)
+	}
+
+	badParam := good
+	badParam.Name = "b"
+	badParam.StrategyParams = map[string]float64{"noSuchParam": 1}
+	err := Configure(config.ParsedConfig{TraderProfiles: []config.TraderProfileResolved{good, badParam}})
+	if err == nil || !strings.Contains(err.Error(), "trader b:") {
+		t.Fatalf("bad param err = %v", err)
+	}
+
+	unknown := good
+	unknown.Name, unknown.Strategy, unknown.StrategyParams = "c", "no_such_strategy", nil
+	if err := Configure(config.ParsedConfig{TraderProfiles: []config.TraderProfileResolved{unknown}}); err == nil || !strings.Contains(err.Error(), "trader c:") {
+		t.Fatalf("unknown strategy err = %v", err)
+	}
+}
+
+func TestRegisterAndParams(t *testing.T) {
+	Register("Test_Fixed", func(config.TradeSettings) Strategy { return fixedStrategy{name: "test_fixed"} }, "rsiPeriod")
+	params := Params("test_fixed")
+	if len(params) != 1+len(filterParams) || params[0] != "rsiPeriod" {
+		t.Fatalf("params = %v", params)
+	}
+	if Params("no_such_strategy") != nil {
+		t.Fatal("unknown strategy has params")
+	}
+}
+
+func TestMetaStrategyCombine(t *testing.T) {
+	long := fixedStrategy{name: "long", signal: SignalLong}
+	short := fixedStrategy{name: "short", signal: SignalShort}
+	broken := fixedStrategy{name: "broken", err: errors.New("no data")}
+
+	cases := []struct {
+		name     string
+		children []MetaChild
+		min      float64
+		want     Signal
+		strength float64
+	}{
+		{"majority long", []MetaChild{{long, 2}, {short, 1}}, 0, SignalLong, 1.0 / 3},
+		{"below agreement", []MetaChild{{long, 2}, {short, 1}}, 0.8, SignalHold, 1.0 / 3},
+		{"tie holds", []MetaChild{{long, 1}, {short, 1}}, 0, SignalHold, 0},
+		{"failed child abstains", []MetaChild{{long, 1}, {broken, 1}}, 0.5, SignalLong, 0.5},
+	}
+	for _, tc := range cases {
+		meta := &MetaStrategy{Label: "meta", Children: tc.children, MinAgreement: tc.min}
+		got, err := meta.Evaluate(nil)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		strength, ok := SignalStrength(meta)
+		if got != tc.want || !ok || strength-tc.strength > 1e-9 || tc.strength-strength > 1e-9 {
+			t.Errorf("%s: signal %s strength %v, want %s %v", tc.name, got, strength, tc.want, tc.strength)
+		}
+	}
+
+	meta := &MetaStrategy{Label: "meta", Children: []MetaChild{{broken, 1}, {broken, 1}}}
+	if _, err := meta.Evaluate(nil); err == nil {
+		t.Fatal("meta with only failing children succeeded")
+	}
+}
+
+func TestRegisterMetaRejectsBadChildren(t *testing.T) {
+	child := func(name string) config.MetaStrategyChild { return config.MetaStrategyChild{Name: name, Weight: 1} }
+	if err := RegisterMeta(map[string]config.MetaStrategyConfig{
+		"test_meta": {Strategies: []config.MetaStrategyChild{child("donchian"), child("supertrend")}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New("test_meta", config.TradeSettings{}); err != nil {
+		t.Fatalf("registered meta: %v", err)
+	}
+
+	for name, cfg := range map[string]config.MetaStrategyConfig{
+		"donchian":     {Strategies: []config.MetaStrategyChild{child("ema_crossover"), child("supertrend")}},
+		"test_single":  {Strategies: []config.MetaStrategyChild{child("donchian")}},
+		"test_unknown": {Strategies: []config.MetaStrategyChild{child("donchian"), child("no_such_strategy")}},
+		"test_nested":  {Strategies: []config.MetaStrategyChild{child("donchian"), child("test_meta")}},
+	} {
+		if err := RegisterMeta(map[string]config.MetaStrategyConfig{name: cfg}); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
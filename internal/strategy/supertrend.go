@@ -0,0 +1,90 @@
+package strategy
+
+import (
+	"fmt"
+
+	"autobot/internal/indicators"
+)
+
+// SuperTrendStrategy enters when the SuperTrend flips: long when the close
+// breaks above the upper band and short when it breaks below the lower band.
+// The SuperTrend line doubles as the initial stop and the trailing stop.
+type SuperTrendStrategy struct {
+	ATRPeriod  int
+	Multiplier float64
+}
+
+func (s SuperTrendStrategy) Name() string {
+	return "supertrend"
+}
+
+func (s SuperTrendStrategy) withDefaults() SuperTrendStrategy {
+	cfg := s
+	if cfg.ATRPeriod == 0 {
+		cfg.ATRPeriod = 10
+	}
+	if cfg.Multiplier == 0 {
+		cfg.Multiplier = 3
+	}
+	return cfg
+}
+
+func (s SuperTrendStrategy) series(candles []Candle) ([]float64, []bool, error) {
+	cfg := s.withDefaults()
+	if cfg.ATRPeriod <= 0 || cfg.Multiplier <= 0 {
+		return nil, nil, fmt.Errorf("atr period and multiplier must be positive")
+	}
+	if len(candles) < cfg.ATRPeriod+2 {
+		return nil, nil, fmt.Errorf("need at least %d candles", cfg.ATRPeriod+2)
+	}
+	highs, lows, closes := candleSeries(candles)
+	return indicators.SuperTrend(highs, lows, closes, cfg.ATRPeriod, cfg.Multiplier)
+}
+
+// Evaluate signals long on the bar the trend turns up and short on the bar
+// it turns down; otherwise it holds.
+func (s SuperTrendStrategy) Evaluate(candles []Candle) (Signal, error) {
+	_, up, err := s.series(candles)
+	if err != nil {
+		return SignalHold, err
+	}
+	last := len(candles) - 1
+	switch {
+	case up[last] && !up[last-1]:
+		return SignalLong, nil
+	case !up[last] && up[last-1]:
+		return SignalShort, nil
+	default:
+		return SignalHold, nil
+	}
+}
+
+// StopDistance returns the distance from the last close to the SuperTrend
+// line.
+func (s SuperTrendStrategy) StopDistance(candles []Candle) (float64, bool) {
+	line, up, err := s.series(candles)
+	if err != nil {
+		return 0, false
+	}
+	last := len(candles) - 1
+	distance := candles[last].Close - line[last]
+	if !up[last] {
+		distance = -distance
+	}
+	return distance, distance > 0
+}
+
+// TrailingStop returns the SuperTrend line while the trend still matches the
+// position's side. Once the trend has flipped it reports false and leaves
+// the exit to the opposite entry signal.
+func (s SuperTrendStrategy) TrailingStop(candles []Candle, long bool) (float64, bool) {
+	line, up, err := s.series(candles)
+	if err != nil {
+		return 0, false
+	}
+	last := len(candles) - 1
+	if up[last] != long || line[last] <= 0 {
+		return 0, false
+	}
+	return line[last], true
+}
@@ -0,0 +1,107 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+
+	"autobot/internal/indicators"
+)
+
+// Timeframes maps an interval such as "1h" to its candles, oldest first.
+type Timeframes map[string][]Candle
+
+// TimeframeEvaluator is implemented by strategies that look at more than one
+// interval. primary is the signal interval; frames holds its candles plus
+// those of every extra interval the trader fetched. Callers with several
+// timeframes should prefer EvaluateTimeframes over Evaluate.
+type TimeframeEvaluator interface {
+	EvaluateTimeframes(primary string, frames Timeframes) (Signal, error)
+}
+
+// EvaluateTimeframes runs s on frames, falling back to Evaluate on the
+// primary candles for single-timeframe strategies.
+func EvaluateTimeframes(s Strategy, primary string, frames Timeframes) (Signal, error) {
+	if multi, ok := s.(TimeframeEvaluator); ok {
+		return multi.EvaluateTimeframes(primary, frames)
+	}
+	return s.Evaluate(frames[primary])
+}
+
+// TimeframeAligned passes the base strategy's entry signals through only
+// when every higher timeframe trends the same way: for longs the last close
+// of each non-primary frame must sit above its EMA(EMAPeriod), for shorts
+// below. A frame too short for the EMA suppresses entries. Without extra
+// frames (plain Evaluate) signals pass unchanged; exits are never filtered.
+type TimeframeAligned struct {
+	Base      Strategy
+	EMAPeriod int
+}
+
+func (t TimeframeAligned) Name() string {
+	if t.Base == nil {
+		return "mtf"
+	}
+	return t.Base.Name() + "+mtf"
+}
+
+// Unwrap returns the aligned base strategy.
+func (t TimeframeAligned) Unwrap() Strategy {
+	return t.Base
+}
+
+// Evaluate runs the base strategy without a higher timeframe filter.
+func (t TimeframeAligned) Evaluate(candles []Candle) (Signal, error) {
+	if t.Base == nil {
+		return SignalHold, fmt.Errorf("timeframe alignment requires a base strategy")
+	}
+	return t.Base.Evaluate(candles)
+}
+
+// EvaluateTimeframes runs the base strategy on frames and drops entries
+// against the higher timeframe trend.
+func (t TimeframeAligned) EvaluateTimeframes(primary string, frames Timeframes) (Signal, error) {
+	if t.Base == nil {
+		return SignalHold, fmt.Errorf("timeframe alignment requires a base strategy")
+	}
+	signal, err := EvaluateTimeframes(t.Base, primary, frames)
+	if err != nil || (signal != SignalLong && signal != SignalShort) {
+		return signal, err
+	}
+	for interval, candles := range frames {
+		if interval == primary {
+			continue
+		}
+		trend, ok := emaTrend(candles, t.EMAPeriod)
+		if !ok || (signal == SignalLong && trend <= 0) || (signal == SignalShort && trend >= 0) {
+			return SignalHold, nil
+		}
+	}
+	return signal, nil
+}
+
+// emaTrend returns the sign of the last close relative to EMA(period):
+// 1 above, -1 below, 0 on it.
+func emaTrend(candles []Candle, period int) (int, bool) {
+	if period <= 0 || len(candles) < period {
+		return 0, false
+	}
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	ema, err := indicators.EMA(closes, period)
+	if err != nil {
+		return 0, false
+	}
+	last := len(closes) - 1
+	if math.IsNaN(ema[last]) {
+		return 0, false
+	}
+	switch {
+	case closes[last] > ema[last]:
+		return 1, true
+	case closes[last] < ema[last]:
+		return -1, true
+	}
+	return 0, true
+}
@@ -0,0 +1,35 @@
+package strategy
+
+import "autobot/internal/indicators"
+
+// Default volume profile resolution and value-area coverage.
+const (
+	ProfileBins             = 24
+	ProfileValueAreaPercent = 70
+)
+
+// VolumeProfile computes the volume profile of the given candles using the
+// default bin count and a 70% value area.
+func VolumeProfile(candles []Candle) (indicators.Profile, bool) {
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	volumes := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i] = c.High
+		lows[i] = c.Low
+		volumes[i] = c.Volume
+	}
+	return indicators.VolumeProfile(highs, lows, volumes, ProfileBins, ProfileValueAreaPercent)
+}
+
+// ProfileStop places a stop just outside the value area: below the value-area
+// low for longs and above the value-area high for shorts, offset by
+// bufferPercent. It reports false when the boundary is on the wrong side of price.
+func ProfileStop(profile indicators.Profile, long bool, price, bufferPercent float64) (float64, bool) {
+	if long {
+		stop := profile.ValueAreaLow * (1 - bufferPercent/100)
+		return stop, stop > 0 && stop < price
+	}
+	stop := profile.ValueAreaHigh * (1 + bufferPercent/100)
+	return stop, stop > price
+}
@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	"time"
+
+	"autobot/internal/indicators"
+)
+
+// DefaultVWAPPeriod is the rolling VWAP window used in market snapshots.
+const DefaultVWAPPeriod = 20
+
+// RollingVWAP returns the VWAP of the last `period` candles. It reports false
+// when there are too few candles or no volume in the window.
+func RollingVWAP(candles []Candle, period int) (float64, bool) {
+	highs, lows, closes, volumes := vwapSeries(candles)
+	vwap, err := indicators.VWAP(highs, lows, closes, volumes, period)
+	if err != nil || vwap[len(vwap)-1] <= 0 {
+		return 0, false
+	}
+	return vwap[len(vwap)-1], true
+}
+
+// SessionVWAP returns the VWAP anchored at the start of the last candle's UTC
+// day, the session most crypto venues reset daily statistics on. It reports
+// false when the candles do not reach back to that day's first bar, since a
+// partial session would understate the anchor.
+func SessionVWAP(candles []Candle) (float64, bool) {
+	if len(candles) == 0 {
+		return 0, false
+	}
+	anchors := make([]bool, len(candles))
+	first := candles[0].OpenTime.UTC()
+	anchors[0] = first.Equal(first.Truncate(24 * time.Hour))
+	for i := 1; i < len(candles); i++ {
+		anchors[i] = !sameUTCDay(candles[i-1], candles[i])
+	}
+	highs, lows, closes, volumes := vwapSeries(candles)
+	vwap, err := indicators.AnchoredVWAP(highs, lows, closes, volumes, anchors)
+	if err != nil || vwap[len(vwap)-1] <= 0 {
+		return 0, false
+	}
+	return vwap[len(vwap)-1], true
+}
+
+func sameUTCDay(a, b Candle) bool {
+	ay, am, ad := a.OpenTime.UTC().Date()
+	by, bm, bd := b.OpenTime.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func vwapSeries(candles []Candle) (highs, lows, closes, volumes []float64) {
+	highs = make([]float64, len(candles))
+	lows = make([]float64, len(candles))
+	closes = make([]float64, len(candles))
+	volumes = make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i], lows[i], closes[i], volumes[i] = c.High, c.Low, c.Close, c.Volume
+	}
+	return highs, lows, closes, volumes
+}
@@ -0,0 +1,152 @@
+// Package subproc 管理以 stdin/stdout 逐行交换 JSON 的常驻子进程，
+// 供 exec 提供商与 exec 策略等进程外扩展复用。
+package subproc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// Process 为一个按需启动的子进程。每次 Call 写入一行请求并读取一行响应，同一时刻只有
+// 一个请求在途；超时、取消、读写失败后进程被结束，下一次 Call 时自动重启。
+type Process struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+	Timeout time.Duration
+	Logger  *loggerpkg.ModuleLogger
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+// Call 发送 request 的 JSON 编码并返回子进程输出的下一行。
+func (p *Process) Call(ctx context.Context, request any) ([]byte, error) {
+	line, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cmd == nil {
+		if err := p.startLocked(); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.stdin.Write(append(line, '\n')); err != nil {
+		p.stopLocked()
+		return nil, fmt.Errorf("%s write: %w", p.Command, err)
+	}
+
+	type readResult struct {
+		line []byte
+		err  error
+	}
+	done := make(chan readResult, 1)
+	stdout := p.stdout
+	go func() {
+		line, err := stdout.ReadBytes('\n')
+		done <- readResult{line: line, err: err}
+	}()
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// 子进程可能仍在处理，只能重启以免下一次读到过期响应
+		p.stopLocked()
+		return nil, ctx.Err()
+	case <-timer.C:
+		p.stopLocked()
+		return nil, fmt.Errorf("%s timed out after %s", p.Command, timeout)
+	case res := <-done:
+		if res.err != nil {
+			p.stopLocked()
+			return nil, fmt.Errorf("%s read: %w", p.Command, res.err)
+		}
+		return res.line, nil
+	}
+}
+
+// Reset 结束子进程，例如响应与请求对不上时。下一次 Call 会重新启动。
+func (p *Process) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopLocked()
+}
+
+// Close 结束子进程。
+func (p *Process) Close() error {
+	p.Reset()
+	return nil
+}
+
+func (p *Process) logf(format string, args ...any) {
+	if p.Logger != nil {
+		p.Logger.Printf(format, args...)
+	}
+}
+
+func (p *Process) startLocked() error {
+	cmd := exec.Command(p.Command, p.Args...)
+	cmd.Env = os.Environ()
+	for key, value := range p.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s start: %w", p.Command, err)
+	}
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			p.logf("stderr %s", scanner.Text())
+		}
+	}()
+	p.cmd, p.stdin, p.stdout = cmd, stdin, bufio.NewReader(stdout)
+	p.logf("process.start command=%s pid=%d", p.Command, cmd.Process.Pid)
+	return nil
+}
+
+func (p *Process) stopLocked() {
+	if p.cmd == nil {
+		return
+	}
+	_ = p.stdin.Close()
+	_ = p.cmd.Process.Kill()
+	err := p.cmd.Wait()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		p.logf("process.wait err=%v", err)
+	}
+	p.logf("process.stop command=%s", p.Command)
+	p.cmd, p.stdin, p.stdout = nil, nil, nil
+}
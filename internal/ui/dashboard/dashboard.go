@@ -6,12 +6,20 @@ import (
 	"io"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"autobot/internal/ai"
+	"autobot/internal/counterfactual"
+	"autobot/internal/cycle"
+	"autobot/internal/execution"
+	"autobot/internal/market"
 	"autobot/internal/news"
+	"autobot/internal/storage"
+	"autobot/internal/version"
 )
 
 const (
@@ -22,8 +30,12 @@ const (
 	aiRows         = 12
 	aiHistoryLimit = 36
 	renderInterval = time.Second
+	maxNewsAlerts  = 3
 )
 
+// cycleHistoryLimit is how many recent cycles per trader feed the timing line.
+const cycleHistoryLimit = 20
+
 // Color defines supported ANSI color intents for dashboard cells.
 type Color int
 
@@ -47,70 +59,90 @@ type traderSection struct {
 }
 
 type orderSnapshot struct {
-	Side  string
-	Lines []Line
+	Side   string
+	Lines  []Line
+	Detail *OrderDetail
+}
+
+// OrderDetail is a structured order for the order panel. Prices and the
+// quantity are formatted at render time with the symbol's precision, like the
+// positions panel. Zero StopLoss, TakeProfit or Price are omitted.
+type OrderDetail struct {
+	Symbol     string
+	Side       string
+	Type       string
+	Quantity   float64
+	Price      float64
+	StopLoss   float64
+	TakeProfit float64
+	Status     string
+	Note       string
 }
 
 type PnLSnapshot struct {
-	Realized    float64
-	Unrealized  float64
-	Equity      float64
-	MarginUsage float64
-	Available   float64
-	RiskStatus  string
-	MaxDrawdown float64
+	Realized    float64 `json:"realized"`
+	Unrealized  float64 `json:"unrealized"`
+	Equity      float64 `json:"equity"`
+	MarginUsage float64 `json:"marginUsage"`
+	Available   float64 `json:"available"`
+	RiskStatus  string  `json:"riskStatus"`
+	MaxDrawdown float64 `json:"maxDrawdown"`
 }
 
 // ContextSnapshot 保存交易上下文概要信息。
 type ContextSnapshot struct {
-	Timestamp      time.Time
-	RuntimeMinutes int
-	CallCount      int
-	Equity         float64
-	Available      float64
-	Unrealized     float64
-	DailyRealized  float64
-	MarginUsage    float64
-	RiskStatus     string
-	Sharpe         float64
-	WinRate        float64
-	TotalTrades    int
-	ProfitFactor   float64
-	Positions      []ContextPosition
-	InitialEquity  float64
-	PnLPercent     float64
+	Timestamp      time.Time         `json:"timestamp"`
+	RuntimeMinutes int               `json:"runtimeMinutes"`
+	CallCount      int               `json:"callCount"`
+	Equity         float64           `json:"equity"`
+	Available      float64           `json:"available"`
+	Unrealized     float64           `json:"unrealized"`
+	DailyRealized  float64           `json:"dailyRealized"`
+	MarginUsage    float64           `json:"marginUsage"`
+	RiskStatus     string            `json:"riskStatus"`
+	Sharpe         float64           `json:"sharpe"`
+	WinRate        float64           `json:"winRate"`
+	TotalTrades    int               `json:"totalTrades"`
+	ProfitFactor   float64           `json:"profitFactor"`
+	Positions      []ContextPosition `json:"positions"`
+	InitialEquity  float64           `json:"initialEquity"`
+	PnLPercent     float64           `json:"pnlPercent"`
+	// RampStatus 为新部署爬坡进度，未启用时为空。
+	RampStatus string `json:"rampStatus,omitempty"`
 }
 
 // ContextPosition 表示单个持仓快照。
 type ContextPosition struct {
-	Symbol         string
-	Side           string
-	Quantity       float64
-	EntryPrice     float64
-	Leverage       float64
-	Unrealized     float64
-	HoldingMinutes int
-	MarkPrice      float64
-	UnrealizedPct  float64
-	MarginUsed     float64
-	Liquidation    float64
+	Symbol         string  `json:"symbol"`
+	Side           string  `json:"side"`
+	Quantity       float64 `json:"quantity"`
+	EntryPrice     float64 `json:"entryPrice"`
+	Leverage       float64 `json:"leverage"`
+	Unrealized     float64 `json:"unrealized"`
+	HoldingMinutes int     `json:"holdingMinutes"`
+	MarkPrice      float64 `json:"markPrice"`
+	UnrealizedPct  float64 `json:"unrealizedPct"`
+	MarginUsed     float64 `json:"marginUsed"`
+	Liquidation    float64 `json:"liquidation"`
 }
 
 type DecisionLogEntry struct {
-	Timestamp  time.Time
-	Symbol     string
-	Action     string
-	Confidence float64
-	Reason     string
-	Thought    string
-	RiskNotes  []string
-	Result     string
-	Error      string
+	Timestamp  time.Time `json:"timestamp"`
+	Symbol     string    `json:"symbol"`
+	Action     string    `json:"action"`
+	Confidence float64   `json:"confidence"`
+	// Price 为决策时的市场价格，0 表示未知。
+	Price     float64  `json:"price,omitempty"`
+	Reason    string   `json:"reason"`
+	Thought   string   `json:"thought,omitempty"`
+	RiskNotes []string `json:"riskNotes"`
+	Result    string   `json:"result"`
+	Error     string   `json:"error,omitempty"`
 }
 
 type EquityPoint struct {
-	Timestamp time.Time
-	Equity    float64
+	Timestamp time.Time `json:"timestamp"`
+	Equity    float64   `json:"equity"`
 }
 
 // Dashboard maintains aggregated runtime information for terminal rendering.
@@ -118,6 +150,7 @@ type Dashboard struct {
 	mu            sync.Mutex
 	writer        io.Writer
 	news          []Line
+	newsAlerts    []Line
 	newsSource    string
 	traders       map[string]*traderSection
 	orders        map[string]orderSnapshot
@@ -129,6 +162,39 @@ type Dashboard struct {
 	contexts      map[string]ContextSnapshot
 	decisionLogs  map[string][]DecisionLogEntry
 	equityHistory map[string][]EquityPoint
+	watchlist     []Line
+	aiSpend       []ai.Spend
+
+	// sentiment is the latest news sentiment line shown above the news feed.
+	sentiment *Line
+	// aiHealth is the latest provider probe result.
+	aiHealth []ai.HealthStatus
+	// aiStatus is the latest per-provider latency, error rate, spend and
+	// breaker summary; when set it replaces the probe-only health panel.
+	aiStatus []ai.ProviderStatus
+	// cycleTimings holds the most recent cycle phase timings per trader.
+	cycleTimings map[string][]cycle.Timings
+	// onboarding is the latest symbol onboarding state from the coin pool.
+	onboarding []market.OnboardingStatus
+	// counterfactual is the running AI-vs-strategy-only comparison.
+	counterfactual []counterfactual.Comparison
+	// intents lists pending, executing and recently finished order intents.
+	intents []execution.Intent
+	// intentQueue backs the /api/intents cancel endpoint when set.
+	intentQueue *execution.IntentQueue
+	// events receives operator actions taken through the dashboard.
+	events storage.EventRecorder
+	// manual is the operator order form, enabled while RunManualOrders runs.
+	manual manualForm
+
+	// articles and lastSentiment back the snapshot sent to new push clients.
+	articles      []news.Article
+	lastSentiment *news.SentimentSummary
+	// prices holds the per-symbol display precision.
+	prices pricePrecision
+	// subscribers receive JSON state deltas for the /ws push API.
+	subMu       sync.Mutex
+	subscribers map[chan []byte]struct{}
 }
 
 // New creates a dashboard using the provided writer for output.
@@ -145,6 +211,7 @@ func New(writer io.Writer) *Dashboard {
 		contexts:      make(map[string]ContextSnapshot),
 		decisionLogs:  make(map[string][]DecisionLogEntry),
 		equityHistory: make(map[string][]EquityPoint),
+		cycleTimings:  make(map[string][]cycle.Timings),
 	}
 }
 
@@ -192,6 +259,149 @@ func (d *Dashboard) UpdateNews(sourceHint string, articles []news.Article) {
 	} else if sourceHint != "" {
 		d.newsSource = fmt.Sprintf("news.%s", strings.ToLower(sourceHint))
 	}
+	d.articles = append([]news.Article(nil), articles...)
+	d.publish(EventNews, "", NewsUpdate{Source: d.newsSource, Articles: d.articles})
+	d.requestRender()
+}
+
+// UpdateSentiment shows the latest news sentiment above the news feed.
+// Summaries from the local lexicon fallback are marked as low confidence.
+func (d *Dashboard) UpdateSentiment(summary news.SentimentSummary) {
+	var line *Line
+	if summary.Sentiment != "" {
+		text := fmt.Sprintf("情绪: %s (%.2f)", summary.Sentiment, summary.Score)
+		if summary.LowConfidence {
+			text += " [本地词典·低置信]"
+		}
+		color := ColorNone
+		switch strings.ToLower(summary.Sentiment) {
+		case "bullish":
+			color = ColorPositive
+		case "bearish":
+			color = ColorNegative
+		}
+		line = &Line{Text: text, Color: color}
+	}
+	d.mu.Lock()
+	d.sentiment = line
+	d.lastSentiment = &summary
+	d.mu.Unlock()
+	d.publish(EventSentiment, "", summary)
+	d.requestRender()
+}
+
+// UpdateWatchlist replaces the watch-only symbols panel. An empty slice hides it.
+func (d *Dashboard) UpdateWatchlist(lines []Line) {
+	d.mu.Lock()
+	d.watchlist = append([]Line(nil), lines...)
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+// UpdateAISpend replaces today's per-provider AI spend. The panel nets the
+// total against the realized PnL of all traders; an empty slice hides it.
+func (d *Dashboard) UpdateAISpend(spend []ai.Spend) {
+	d.mu.Lock()
+	d.aiSpend = append([]ai.Spend(nil), spend...)
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+// UpdateAIHealth replaces the latest AI provider health probe results.
+func (d *Dashboard) UpdateAIHealth(statuses []ai.HealthStatus) {
+	d.mu.Lock()
+	d.aiHealth = append([]ai.HealthStatus(nil), statuses...)
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+// UpdateAIStatus replaces the per-provider operational summary, typically fed
+// by ai.WatchStatus. Push clients receive it as an aiStatus event.
+func (d *Dashboard) UpdateAIStatus(statuses []ai.ProviderStatus) {
+	d.mu.Lock()
+	d.aiStatus = append([]ai.ProviderStatus(nil), statuses...)
+	d.mu.Unlock()
+	d.publish(EventAIStatus, "", statuses)
+	d.requestRender()
+}
+
+// UpdateCycleTimings records the phase timings of a finished trading cycle.
+// The dashboard shows the per-phase average over the trader's last
+// cycleHistoryLimit cycles so a single slow cycle does not dominate.
+func (d *Dashboard) UpdateCycleTimings(trader string, timings cycle.Timings) {
+	d.mu.Lock()
+	history := append(d.cycleTimings[trader], timings)
+	if len(history) > cycleHistoryLimit {
+		history = history[len(history)-cycleHistoryLimit:]
+	}
+	d.cycleTimings[trader] = history
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+// UpdateOnboarding replaces the symbol onboarding state, typically
+// market.Onboarding.Statuses after each pool refresh. The panel lists symbols
+// still on probation with the checks they fail and is hidden when none are.
+func (d *Dashboard) UpdateOnboarding(statuses []market.OnboardingStatus) {
+	d.mu.Lock()
+	d.onboarding = append([]market.OnboardingStatus(nil), statuses...)
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+// UpdateCounterfactual replaces the AI-vs-strategy-only comparison, typically
+// counterfactual.Tracker.Comparisons after each decision. Push clients
+// receive it as a counterfactual event; an empty slice hides the panel.
+func (d *Dashboard) UpdateCounterfactual(comparisons []counterfactual.Comparison) {
+	d.mu.Lock()
+	d.counterfactual = append([]counterfactual.Comparison(nil), comparisons...)
+	d.mu.Unlock()
+	d.publish(EventCounterfactual, "", comparisons)
+	d.requestRender()
+}
+
+// UpdateIntents replaces the order intent list shown in the pending intents
+// panel. Push clients receive it as an intents event; an empty slice hides
+// the panel.
+func (d *Dashboard) UpdateIntents(intents []execution.Intent) {
+	d.mu.Lock()
+	d.intents = append([]execution.Intent(nil), intents...)
+	d.mu.Unlock()
+	d.publish(EventIntents, "", intents)
+	d.requestRender()
+}
+
+// SetIntentQueue shows the intents of q in the pending intents panel and
+// lets Serve expose its cancel endpoint at /api/intents.
+func (d *Dashboard) SetIntentQueue(q *execution.IntentQueue) {
+	d.mu.Lock()
+	d.intentQueue = q
+	d.mu.Unlock()
+	q.OnChange(d.UpdateIntents)
+	d.UpdateIntents(q.List())
+}
+
+// SetEventRecorder records operator actions taken through the dashboard,
+// such as runtime log level changes, as eventlog.KindManual events in rec.
+func (d *Dashboard) SetEventRecorder(rec storage.EventRecorder) {
+	d.mu.Lock()
+	d.events = rec
+	d.mu.Unlock()
+}
+
+// AppendNewsAlert pins a market alert (e.g. large liquidations) above the news feed.
+func (d *Dashboard) AppendNewsAlert(text string, color Color) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	alerts := append([]Line{{Text: text, Color: color}}, d.newsAlerts...)
+	if len(alerts) > maxNewsAlerts {
+		alerts = alerts[:maxNewsAlerts]
+	}
+	d.newsAlerts = alerts
 	d.requestRender()
 }
 
@@ -219,7 +429,19 @@ func (d *Dashboard) AppendTraderEvent(trader string, message string) {
 	d.requestRender()
 }
 
-// UpdateOrder replaces the current order snapshot for the trader.
+// UpdateOrderDetail replaces the current order snapshot for the trader with
+// a structured order, shown with the symbol's price and quantity precision.
+func (d *Dashboard) UpdateOrderDetail(trader string, order OrderDetail) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.orders[trader] = orderSnapshot{Side: strings.ToUpper(order.Side), Detail: &order}
+	d.requestRender()
+}
+
+// UpdateOrder replaces the current order snapshot for the trader with
+// preformatted lines, shown as is. Prefer UpdateOrderDetail so the panel
+// uses the per-symbol precision.
 func (d *Dashboard) UpdateOrder(trader string, side string, lines []Line) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -235,6 +457,8 @@ func (d *Dashboard) UpdatePnL(trader string, snapshot PnLSnapshot) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.pnls[trader] = snapshot
+	d.observePnL(trader, snapshot)
+	d.publish(EventPnL, trader, snapshot)
 	d.requestRender()
 }
 
@@ -263,6 +487,8 @@ func (d *Dashboard) UpdateContext(trader string, snapshot ContextSnapshot) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	d.contexts[trader] = snapshot
+	d.observeContext(trader, snapshot)
+	d.publish(EventPositions, trader, snapshot)
 	d.requestRender()
 }
 
@@ -275,6 +501,7 @@ func (d *Dashboard) AppendDecisionLog(trader string, entry DecisionLogEntry) {
 		logs = logs[:5]
 	}
 	d.decisionLogs[trader] = logs
+	d.publish(EventDecision, trader, entry)
 	d.requestRender()
 }
 
@@ -290,6 +517,7 @@ func (d *Dashboard) AppendEquityPoint(trader string, timestamp time.Time, equity
 		history = history[len(history)-120:]
 	}
 	d.equityHistory[trader] = history
+	d.publish(EventEquity, trader, EquityPoint{Timestamp: timestamp, Equity: equity})
 	d.requestRender()
 }
 
@@ -306,6 +534,15 @@ func (d *Dashboard) AppendAIPlanLine(trader string, line Line) {
 	d.requestRender()
 }
 
+// StreamAIPlan clears the trader's plan panel and returns a callback that
+// appends each streamed line, suitable for ai.WithStream.
+func (d *Dashboard) StreamAIPlan(trader string) func(line string) {
+	d.UpdateAIPlan(trader, nil)
+	return func(line string) {
+		d.AppendAIPlanLine(trader, Line{Text: line})
+	}
+}
+
 // Start begins the rendering loop controlled by the provided context.
 func (d *Dashboard) Start(ctx context.Context) {
 	ticker := time.NewTicker(renderInterval)
@@ -352,7 +589,7 @@ func (d *Dashboard) render() string {
 	if len(summaryLines) == 0 {
 		summaryLines = []Line{{Text: "等待账户数据..."}}
 	}
-	summaryTitle := fmt.Sprintf("账户概览 (%s)", d.primary)
+	summaryTitle := fmt.Sprintf("账户概览 (%s) | autobot %s", d.primary, version.Get())
 
 	equityLines := buildEquityLines(d.equityHistory[d.primary])
 	if len(equityLines) > 0 {
@@ -362,12 +599,12 @@ func (d *Dashboard) render() string {
 		summaryLines = append(summaryLines, Line{Text: "收益率趋势: 等待净值数据..."})
 	}
 
-	positionsLines := buildPositionLines(ctxSnapshot)
+	positionsLines := buildPositionLines(ctxSnapshot, d.prices.decimals)
 	if len(positionsLines) == 0 {
 		positionsLines = []Line{{Text: "暂无持仓"}}
 	}
 
-	decisionLines := buildDecisionLogLines(d.decisionLogs[d.primary])
+	decisionLines := buildDecisionLogLines(d.decisionLogs[d.primary], d.prices.decimals)
 	if len(decisionLines) == 0 {
 		decisionLines = []Line{{Text: "暂无决策"}}
 	}
@@ -386,6 +623,9 @@ func (d *Dashboard) render() string {
 	orderLines := []Line{}
 	if snapshot, ok := d.orders[d.primary]; ok {
 		orderLines = append(orderLines, snapshot.Lines...)
+		if snapshot.Detail != nil {
+			orderLines = append(orderLines, buildOrderLines(*snapshot.Detail, d.prices.decimals, d.prices.quantityDecimals)...)
+		}
 		if section, ok := d.traders[d.primary]; ok && section != nil {
 			base := fmt.Sprintf("下单详情 (%s)", section.Exchange)
 			if snapshot.Side != "" {
@@ -402,7 +642,12 @@ func (d *Dashboard) render() string {
 	pnlTitle := "收益统计"
 	pnlLines := buildPnLLines(pnlSnapshot)
 
-	newsLines := append([]Line(nil), d.news...)
+	var newsLines []Line
+	if d.sentiment != nil {
+		newsLines = append(newsLines, *d.sentiment)
+	}
+	newsLines = append(newsLines, d.newsAlerts...)
+	newsLines = append(newsLines, d.news...)
 	newsTitle := fmt.Sprintf("新闻快讯 (%s)", d.newsSource)
 	if d.newsSource == "" {
 		newsTitle = "新闻快讯"
@@ -432,6 +677,36 @@ func (d *Dashboard) render() string {
 	output += renderFullWidth(aiTitle, aiLines)
 	output += renderFullWidth("AI 学习分析", learningLines)
 	output += renderFullWidth(aiPlanTitle, planLines)
+	if len(d.watchlist) > 0 {
+		output += renderFullWidth("观察列表（仅分析，不交易）", d.watchlist)
+	}
+	if len(d.aiSpend) > 0 {
+		realized := 0.0
+		for _, pnl := range d.pnls {
+			realized += pnl.Realized
+		}
+		output += renderFullWidth("AI 费用（今日, UTC）", buildAISpendLines(d.aiSpend, realized))
+	}
+	if len(d.cycleTimings) > 0 {
+		output += renderFullWidth(fmt.Sprintf("周期耗时（近%d个周期平均）", cycleHistoryLimit), buildCycleTimingLines(d.cycleTimings))
+	}
+	if d.manual.enabled {
+		output += renderFullWidth("手动下单（操作者）", buildManualLines(d.manual))
+	}
+	if len(d.intents) > 0 {
+		output += renderFullWidth("待执行意图（pending 期间可撤销）", buildIntentLines(d.intents, d.prices.decimals, time.Now()))
+	}
+	if len(d.counterfactual) > 0 {
+		output += renderFullWidth("AI vs 纯策略（反事实模拟，单位仓位）", buildCounterfactualLines(d.counterfactual))
+	}
+	if lines := buildOnboardingLines(d.onboarding); len(lines) > 0 {
+		output += renderFullWidth("币种准入（试用期）", lines)
+	}
+	if len(d.aiStatus) > 0 {
+		output += renderFullWidth("AI 运行状态（错误率为近1小时）", buildAIStatusLines(d.aiStatus))
+	} else if len(d.aiHealth) > 0 {
+		output += renderFullWidth("AI 提供商状态", buildAIHealthLines(d.aiHealth))
+	}
 	return output
 }
 
@@ -575,6 +850,9 @@ func buildSummaryLines(ctx ContextSnapshot, pnl PnLSnapshot) []Line {
 		{Text: fmt.Sprintf("总收益: %+.2f%% | 夏普: %.2f | 胜率: %.2f%% | ProfitFactor: %s", totalPnLPct, sharpe, winRate, formatProfitFactor(profitFactor)), Color: colorByValue(totalPnLPct)},
 	}
 
+	if ctx.RampStatus != "" {
+		lines[1].Text += " | " + ctx.RampStatus
+	}
 	if margin > 75 {
 		lines[1].Color = ColorNegative
 	}
@@ -585,7 +863,9 @@ func buildSummaryLines(ctx ContextSnapshot, pnl PnLSnapshot) []Line {
 	return lines
 }
 
-func buildPositionLines(ctx ContextSnapshot) []Line {
+// buildPositionLines lists positions by absolute unrealized PnL; decimals
+// gives the price precision of a symbol.
+func buildPositionLines(ctx ContextSnapshot, decimals func(symbol string, price float64) int) []Line {
 	positions := make([]ContextPosition, len(ctx.Positions))
 	copy(positions, ctx.Positions)
 	if len(positions) == 0 {
@@ -598,20 +878,23 @@ func buildPositionLines(ctx ContextSnapshot) []Line {
 	lines := make([]Line, 0, len(positions))
 	for _, pos := range positions {
 		pnlText := fmt.Sprintf("%+.2f%% (%s)", pos.UnrealizedPct, formatSigned(pos.Unrealized))
+		price := func(v float64) string {
+			return strconv.FormatFloat(v, 'f', decimals(pos.Symbol, pos.EntryPrice), 64)
+		}
 		liqText := "--"
 		if pos.Liquidation > 0 {
-			liqText = fmt.Sprintf("%.2f", pos.Liquidation)
+			liqText = price(pos.Liquidation)
 		}
 		durText := ""
 		if pos.HoldingMinutes > 0 {
 			durText = fmt.Sprintf(" | 持仓%dm", pos.HoldingMinutes)
 		}
-		text := fmt.Sprintf("%s %-5s %.4f @ %.2f → %.2f | 盈亏 %s | 保证金 %.2f | 强平价 %s%s",
+		text := fmt.Sprintf("%s %-5s %.4f @ %s → %s | 盈亏 %s | 保证金 %.2f | 强平价 %s%s",
 			pos.Symbol,
 			pos.Side,
 			pos.Quantity,
-			pos.EntryPrice,
-			pos.MarkPrice,
+			price(pos.EntryPrice),
+			price(pos.MarkPrice),
 			pnlText,
 			pos.MarginUsed,
 			liqText,
@@ -630,7 +913,7 @@ func buildPositionLines(ctx ContextSnapshot) []Line {
 	return lines
 }
 
-func buildDecisionLogLines(logs []DecisionLogEntry) []Line {
+func buildDecisionLogLines(logs []DecisionLogEntry, decimals func(symbol string, price float64) int) []Line {
 	if len(logs) == 0 {
 		return nil
 	}
@@ -640,6 +923,9 @@ func buildDecisionLogLines(logs []DecisionLogEntry) []Line {
 			break
 		}
 		header := fmt.Sprintf("%s %s %s", log.Timestamp.Format("15:04:05"), log.Symbol, log.Action)
+		if log.Price > 0 {
+			header += " @ " + strconv.FormatFloat(log.Price, 'f', decimals(log.Symbol, log.Price), 64)
+		}
 		if log.Confidence > 0 {
 			header += fmt.Sprintf(" (信心%.1f)", log.Confidence)
 		}
@@ -917,6 +1203,229 @@ func buildPnLLines(snapshot PnLSnapshot) []Line {
 	return lines
 }
 
+func buildAISpendLines(spend []ai.Spend, realized float64) []Line {
+	lines := make([]Line, 0, len(spend)+1)
+	total := 0.0
+	for _, s := range spend {
+		total += s.CostUSD
+		lines = append(lines, Line{Text: fmt.Sprintf("%-10s 调用 %4d  输入 %9d tok  输出 %8d tok  $%.4f",
+			s.Provider, s.Calls, s.PromptTokens, s.CompletionTokens, s.CostUSD)})
+	}
+	net := realized - total
+	lines = append(lines, Line{
+		Text:  fmt.Sprintf("合计 $%.4f | 当日已实现盈亏 %s | 扣除AI费用后 %s", total, formatCurrency(realized), formatCurrency(net)),
+		Color: chooseSignColor(net),
+	})
+	return lines
+}
+
+func buildAIHealthLines(statuses []ai.HealthStatus) []Line {
+	lines := make([]Line, 0, len(statuses))
+	for _, s := range statuses {
+		if s.Healthy {
+			lines = append(lines, Line{
+				Text:  fmt.Sprintf("%-10s 正常  延迟 %5dms  检查于 %s", s.Provider, s.LatencyMs, s.CheckedAt.Format("15:04:05")),
+				Color: ColorPositive,
+			})
+			continue
+		}
+		lines = append(lines, Line{
+			Text:  fmt.Sprintf("%-10s 异常（连续%d次）  检查于 %s  %s", s.Provider, s.Failures, s.CheckedAt.Format("15:04:05"), s.Error),
+			Color: ColorNegative,
+		})
+	}
+	return lines
+}
+
+func buildAIStatusLines(statuses []ai.ProviderStatus) []Line {
+	lines := make([]Line, 0, len(statuses))
+	for _, s := range statuses {
+		latency := "   --"
+		if !s.LastCallAt.IsZero() {
+			latency = fmt.Sprintf("%5d", s.LastLatencyMs)
+		}
+		text := fmt.Sprintf("%-10s 延迟 %sms  错误率 %5.1f%% (%d次)  今日 %9d tok $%.4f  %s",
+			s.Provider, latency, s.ErrorRate*100, s.Calls, s.Tokens, s.CostUSD, breakerLabel(s.Breaker))
+		color := ColorNone
+		if s.Breaker != ai.BreakerClosed || s.ErrorRate >= 0.2 {
+			color = ColorNegative
+		}
+		if s.BreakerReason != "" {
+			text += "  " + s.BreakerReason
+		} else if s.LastError != "" {
+			text += "  最近错误: " + s.LastError
+		}
+		lines = append(lines, Line{Text: text, Color: color})
+	}
+	return lines
+}
+
+// buildCycleTimingLines renders one line per trader: the average cycle
+// duration followed by each phase's average and share, slowest first.
+func buildCycleTimingLines(history map[string][]cycle.Timings) []Line {
+	traders := make([]string, 0, len(history))
+	for name := range history {
+		traders = append(traders, name)
+	}
+	sort.Strings(traders)
+	lines := make([]Line, 0, len(traders))
+	for _, name := range traders {
+		avg := cycle.Average(history[name])
+		phases := append([]cycle.PhaseTiming(nil), avg.Phases...)
+		sort.SliceStable(phases, func(i, j int) bool { return phases[i].Ms > phases[j].Ms })
+		parts := make([]string, 0, len(phases))
+		for _, p := range phases {
+			share := 0.0
+			if avg.TotalMs > 0 {
+				share = float64(p.Ms) / float64(avg.TotalMs) * 100
+			}
+			parts = append(parts, fmt.Sprintf("%s %s %.0f%%", p.Phase, formatCycleMs(p.Ms), share))
+		}
+		text := fmt.Sprintf("%-12s %7s (%d)  %s", name, formatCycleMs(avg.TotalMs), len(history[name]), strings.Join(parts, "  "))
+		lines = append(lines, Line{Text: text, Color: ColorNone})
+	}
+	return lines
+}
+
+// buildCounterfactualLines renders one line per trader and symbol: each
+// path's total return, closed trades and win rate, its simulated position,
+// and the AI's edge over the strategy-only path.
+func buildCounterfactualLines(comparisons []counterfactual.Comparison) []Line {
+	lines := make([]Line, 0, len(comparisons))
+	for _, c := range comparisons {
+		text := fmt.Sprintf("%-12s %-10s AI %s%% (%d笔 胜率%.0f%% %s)  策略 %s%% (%d笔 胜率%.0f%% %s)  差 %s%%  分歧 %d/%d周期",
+			c.Trader, c.Symbol,
+			formatSigned(c.AI.TotalPercent()), c.AI.Trades, c.AI.WinRate()*100, counterfactualSide(c.AI.Side),
+			formatSigned(c.Strategy.TotalPercent()), c.Strategy.Trades, c.Strategy.WinRate()*100, counterfactualSide(c.Strategy.Side),
+			formatSigned(c.EdgePercent()), c.Disagreements, c.Cycles)
+		lines = append(lines, Line{Text: text, Color: chooseSignColor(c.EdgePercent())})
+	}
+	return lines
+}
+
+// buildIntentLines renders one line per order intent with its target price
+// at the symbol's precision. Pending intents show the seconds left before
+// they execute; finished ones show their note.
+func buildOrderLines(order OrderDetail, decimals func(symbol string, price float64) int, quantityDecimals func(symbol string) int) []Line {
+	price := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', decimals(order.Symbol, v), 64)
+	}
+	head := fmt.Sprintf("%s %s %s 数量 %s", order.Symbol, strings.ToUpper(order.Side), order.Type,
+		strconv.FormatFloat(order.Quantity, 'f', quantityDecimals(order.Symbol), 64))
+	if order.Price > 0 {
+		head += " @ " + price(order.Price)
+	}
+	lines := []Line{{Text: head}}
+	var protect []string
+	if order.StopLoss > 0 {
+		protect = append(protect, "止损 "+price(order.StopLoss))
+	}
+	if order.TakeProfit > 0 {
+		protect = append(protect, "止盈 "+price(order.TakeProfit))
+	}
+	if len(protect) > 0 {
+		lines = append(lines, Line{Text: strings.Join(protect, " | ")})
+	}
+	if order.Status != "" {
+		lines = append(lines, Line{Text: "状态 " + order.Status})
+	}
+	if order.Note != "" {
+		lines = append(lines, Line{Text: order.Note})
+	}
+	return lines
+}
+
+func buildIntentLines(intents []execution.Intent, decimals func(symbol string, price float64) int, now time.Time) []Line {
+	lines := make([]Line, 0, len(intents))
+	for _, intent := range intents {
+		status := intent.State
+		color := ColorNone
+		switch intent.State {
+		case execution.IntentPending:
+			left := intent.ExecuteAt.Sub(now).Seconds()
+			if left < 0 {
+				left = 0
+			}
+			status = fmt.Sprintf("pending %.0fs", math.Ceil(left))
+		case execution.IntentFilled:
+			color = ColorPositive
+		case execution.IntentCancelled, execution.IntentFailed:
+			color = ColorNegative
+		}
+		if intent.Note != "" && !intent.Active() {
+			status += " " + intent.Note
+		}
+		if intent.Origin == storage.OriginOperator {
+			status += " [手动]"
+		}
+		price := strconv.FormatFloat(intent.TargetPrice, 'f', decimals(intent.Symbol, intent.TargetPrice), 64)
+		text := fmt.Sprintf("#%-4s %-12s %-10s %-6s 数量 %-12g 目标价 %-14s %s",
+			intent.ID, intent.Trader, intent.Symbol, intent.Side, intent.Quantity, price, status)
+		lines = append(lines, Line{Text: text, Color: color})
+	}
+	return lines
+}
+
+func counterfactualSide(side string) string {
+	switch side {
+	case counterfactual.SideLong:
+		return "持多"
+	case counterfactual.SideShort:
+		return "持空"
+	}
+	return "空仓"
+}
+
+// buildOnboardingLines renders one line per probation symbol with its failed
+// checks, followed by the approved count. It returns nil when no symbol is on
+// probation.
+func buildOnboardingLines(statuses []market.OnboardingStatus) []Line {
+	var lines []Line
+	approved := 0
+	for _, status := range statuses {
+		if status.Approved {
+			approved++
+			continue
+		}
+		reasons := "等待检查"
+		if len(status.Failures) > 0 {
+			reasons = strings.Join(status.Failures, "；")
+		}
+		text := fmt.Sprintf("%-12s 首次 %s  检查 %s  %s", status.Symbol, status.FirstSeen.Local().Format("01-02 15:04"),
+			formatOnboardingTime(status.CheckedAt), reasons)
+		lines = append(lines, Line{Text: text, Color: ColorNegative})
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return append(lines, Line{Text: fmt.Sprintf("已准入 %d 个，试用期 %d 个", approved, len(lines)), Color: ColorNone})
+}
+
+func formatOnboardingTime(t time.Time) string {
+	if t.IsZero() {
+		return "--"
+	}
+	return t.Local().Format("15:04")
+}
+
+func formatCycleMs(ms int64) string {
+	if ms < 1000 {
+		return fmt.Sprintf("%dms", ms)
+	}
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
+
+func breakerLabel(state string) string {
+	switch state {
+	case ai.BreakerOpen:
+		return "断开"
+	case ai.BreakerDegraded:
+		return "降级"
+	default:
+		return "正常"
+	}
+}
+
 func chooseSignColor(value float64) Color {
 	if value > 0.0001 {
 		return ColorPositive
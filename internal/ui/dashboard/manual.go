@@ -0,0 +1,250 @@
+package dashboard
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"autobot/internal/execution"
+)
+
+// ManualOrderFunc places an operator order; a returned error is shown in the
+// form panel. execution.ManualDesk.Submit is the implementation: it routes the
+// order through the same risk gate and intent queue as AI trades and records
+// the fill with storage.OriginOperator.
+type ManualOrderFunc func(ctx context.Context, order execution.ManualOrder) error
+
+// Steps of the manual order form, in input order.
+const (
+	manualIdle = iota
+	manualTrader
+	manualSymbol
+	manualSide
+	manualQuantity
+	manualStop
+	manualTakeProfit
+	manualConfirm
+)
+
+var manualPrompts = map[int]string{
+	manualTrader:     "交易者",
+	manualSymbol:     "交易对",
+	manualSide:       "方向 (long/short)",
+	manualQuantity:   "数量",
+	manualStop:       "止损价 (回车不设)",
+	manualTakeProfit: "止盈价 (回车不设)",
+	manualConfirm:    "输入 yes 确认下单，其他任意输入取消",
+}
+
+// manualForm is the state of the keyboard-driven manual order form. It is
+// guarded by the dashboard mutex.
+type manualForm struct {
+	enabled bool
+	step    int
+	order   execution.ManualOrder
+	status  *Line
+}
+
+// RunManualOrders reads operator input line by line from in (usually
+// os.Stdin) until ctx is cancelled or in is closed. "m" opens the form, each
+// following line fills one field (an empty line keeps the default shown in
+// the panel, "q" cancels), and only a final "yes" passes the order to submit.
+// The form is shown in its own panel while this runs.
+func (d *Dashboard) RunManualOrders(ctx context.Context, in io.Reader, submit ManualOrderFunc) error {
+	lines := make(chan string)
+	errs := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		errs <- scanner.Err()
+	}()
+
+	d.mu.Lock()
+	d.manual = manualForm{enabled: true}
+	d.mu.Unlock()
+	d.requestRender()
+	defer func() {
+		d.mu.Lock()
+		d.manual = manualForm{}
+		d.mu.Unlock()
+		d.requestRender()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case line := <-lines:
+			order, ready := d.manualInput(line)
+			if !ready {
+				continue
+			}
+			d.setManualStatus(Line{Text: fmt.Sprintf("提交中: %s", describeManualOrder(order))})
+			if err := submit(ctx, order); err != nil {
+				d.setManualStatus(Line{Text: fmt.Sprintf("下单失败: %s: %v", describeManualOrder(order), err), Color: ColorNegative})
+				continue
+			}
+			d.setManualStatus(Line{Text: fmt.Sprintf("已下单: %s", describeManualOrder(order)), Color: ColorPositive})
+		}
+	}
+}
+
+// manualInput applies one line of operator input to the form and reports
+// whether a confirmed order is ready to submit.
+func (d *Dashboard) manualInput(line string) (execution.ManualOrder, bool) {
+	input := strings.TrimSpace(line)
+	d.mu.Lock()
+	defer d.requestRender()
+	defer d.mu.Unlock()
+	form := &d.manual
+
+	if form.step == manualIdle {
+		if strings.EqualFold(input, "m") {
+			form.step, form.order, form.status = manualTrader, execution.ManualOrder{Trader: d.primary}, nil
+		}
+		return execution.ManualOrder{}, false
+	}
+	if strings.EqualFold(input, "q") {
+		form.step, form.status = manualIdle, &Line{Text: "已取消手动下单"}
+		return execution.ManualOrder{}, false
+	}
+
+	var err error
+	switch form.step {
+	case manualTrader:
+		if input != "" {
+			form.order.Trader = input
+		}
+		section, ok := d.traders[form.order.Trader]
+		if !ok {
+			err = fmt.Errorf("未知交易者 %q", form.order.Trader)
+			break
+		}
+		form.order.Symbol = section.Symbol
+	case manualSymbol:
+		if input != "" {
+			form.order.Symbol = strings.ToUpper(input)
+		}
+		if form.order.Symbol == "" {
+			err = fmt.Errorf("交易对不能为空")
+		}
+	case manualSide:
+		switch strings.ToLower(input) {
+		case "long", "l", "多":
+			form.order.Side = "long"
+		case "short", "s", "空":
+			form.order.Side = "short"
+		default:
+			err = fmt.Errorf("方向需为 long 或 short")
+		}
+	case manualQuantity:
+		form.order.Quantity, err = strconv.ParseFloat(input, 64)
+		if err == nil && form.order.Quantity <= 0 {
+			err = fmt.Errorf("数量需大于0")
+		}
+	case manualStop:
+		form.order.StopLoss, err = parseManualPrice(input)
+	case manualTakeProfit:
+		form.order.TakeProfit, err = parseManualPrice(input)
+	case manualConfirm:
+		form.step = manualIdle
+		if input != "yes" {
+			form.status = &Line{Text: "未确认，已取消手动下单"}
+			return execution.ManualOrder{}, false
+		}
+		return form.order, true
+	}
+	if err != nil {
+		form.status = &Line{Text: err.Error(), Color: ColorNegative}
+		return execution.ManualOrder{}, false
+	}
+	form.step++
+	form.status = nil
+	return execution.ManualOrder{}, false
+}
+
+func (d *Dashboard) setManualStatus(status Line) {
+	d.mu.Lock()
+	d.manual.status = &status
+	d.mu.Unlock()
+	d.requestRender()
+}
+
+func parseManualPrice(input string) (float64, error) {
+	if input == "" {
+		return 0, nil
+	}
+	price, err := strconv.ParseFloat(input, 64)
+	if err != nil || price <= 0 {
+		return 0, fmt.Errorf("价格需为正数，回车表示不设")
+	}
+	return price, nil
+}
+
+func describeManualOrder(order execution.ManualOrder) string {
+	text := fmt.Sprintf("%s %s %s %g", order.Trader, order.Symbol, order.Side, order.Quantity)
+	if order.StopLoss > 0 {
+		text += fmt.Sprintf(" 止损 %g", order.StopLoss)
+	}
+	if order.TakeProfit > 0 {
+		text += fmt.Sprintf(" 止盈 %g", order.TakeProfit)
+	}
+	return text
+}
+
+// buildManualLines renders the form: the fields entered so far, the prompt
+// of the current field with its default, and the last status message.
+func buildManualLines(form manualForm) []Line {
+	var lines []Line
+	if form.step == manualIdle {
+		lines = append(lines, Line{Text: "输入 m 回车开始手动下单"})
+	} else {
+		order := form.order
+		fields := []struct {
+			step  int
+			value string
+		}{
+			{manualTrader, order.Trader},
+			{manualSymbol, order.Symbol},
+			{manualSide, order.Side},
+			{manualQuantity, formatManualValue(order.Quantity)},
+			{manualStop, formatManualValue(order.StopLoss)},
+			{manualTakeProfit, formatManualValue(order.TakeProfit)},
+		}
+		for _, field := range fields {
+			if field.step >= form.step {
+				break
+			}
+			lines = append(lines, Line{Text: fmt.Sprintf("%s: %s", manualPrompts[field.step], field.value)})
+		}
+		prompt := "> " + manualPrompts[form.step]
+		if form.step <= manualSymbol {
+			if value := fields[form.step-manualTrader].value; value != "" {
+				prompt += fmt.Sprintf(" [%s]", value)
+			}
+		}
+		lines = append(lines, Line{Text: prompt + "  (q 取消)", Color: ColorBuy})
+	}
+	if form.status != nil {
+		lines = append(lines, *form.status)
+	}
+	return lines
+}
+
+func formatManualValue(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
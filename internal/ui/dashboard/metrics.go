@@ -0,0 +1,54 @@
+package dashboard
+
+import "autobot/internal/metrics"
+
+// Account and position gauges mirrored from the dashboard state so Grafana
+// sees the same numbers as the terminal view.
+var (
+	equityUSD          = metrics.NewGauge("equity_usd", "Account equity.", "trader", "exchange")
+	availableUSD       = metrics.NewGauge("available_usd", "Available balance.", "trader", "exchange")
+	unrealizedPnLUSD   = metrics.NewGauge("unrealized_pnl_usd", "Unrealized PnL of open positions.", "trader", "exchange")
+	dailyRealizedUSD   = metrics.NewGauge("daily_realized_pnl_usd", "Realized PnL of the current day.", "trader", "exchange")
+	marginUsageRatio   = metrics.NewGauge("margin_usage_ratio", "Used margin divided by equity.", "trader", "exchange")
+	positionSize       = metrics.NewGauge("position_quantity", "Open position size in base units.", "trader", "exchange", "symbol", "side")
+	positionUnrealized = metrics.NewGauge("position_unrealized_pnl_usd", "Unrealized PnL per position.", "trader", "exchange", "symbol", "side")
+	positionMarginUSD  = metrics.NewGauge("position_margin_usd", "Margin allocated to the position.", "trader", "exchange", "symbol", "side")
+)
+
+// exchangeLocked returns the venue registered for trader. d.mu must be held.
+func (d *Dashboard) exchangeLocked(trader string) string {
+	if section, ok := d.traders[trader]; ok {
+		return section.Exchange
+	}
+	return ""
+}
+
+func (d *Dashboard) observeContext(trader string, snapshot ContextSnapshot) {
+	venue := d.exchangeLocked(trader)
+	equityUSD.Set(snapshot.Equity, trader, venue)
+	availableUSD.Set(snapshot.Available, trader, venue)
+	unrealizedPnLUSD.Set(snapshot.Unrealized, trader, venue)
+	dailyRealizedUSD.Set(snapshot.DailyRealized, trader, venue)
+	// MarginUsage is a percentage on the dashboard; metrics use ratios.
+	marginUsageRatio.Set(snapshot.MarginUsage/100, trader, venue)
+
+	// Closed positions must disappear rather than keep their last value.
+	positionSize.DeleteMatching("trader", trader)
+	positionUnrealized.DeleteMatching("trader", trader)
+	positionMarginUSD.DeleteMatching("trader", trader)
+	for _, pos := range snapshot.Positions {
+		positionSize.Set(pos.Quantity, trader, venue, pos.Symbol, pos.Side)
+		positionUnrealized.Set(pos.Unrealized, trader, venue, pos.Symbol, pos.Side)
+		positionMarginUSD.Set(pos.MarginUsed, trader, venue, pos.Symbol, pos.Side)
+	}
+}
+
+func (d *Dashboard) observePnL(trader string, snapshot PnLSnapshot) {
+	venue := d.exchangeLocked(trader)
+	if snapshot.Equity != 0 {
+		equityUSD.Set(snapshot.Equity, trader, venue)
+	}
+	if snapshot.MarginUsage != 0 {
+		marginUsageRatio.Set(snapshot.MarginUsage/100, trader, venue)
+	}
+}
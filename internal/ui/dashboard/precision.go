@@ -0,0 +1,143 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"autobot/internal/exchange"
+)
+
+// maxAutoDecimals bounds the precision picked for symbols without a known
+// tick size, enough for sub-cent memecoins.
+const maxAutoDecimals = 10
+
+// pricePrecision maps upper-cased symbols to display decimals. Configured
+// overrides win over decimals derived from the exchange tick size; steps
+// holds the quantity decimals derived from the lot step. It has its own lock
+// so FormatPrice works both inside render and from callers.
+type pricePrecision struct {
+	mu         sync.RWMutex
+	configured map[string]int
+	ticks      map[string]int
+	steps      map[string]int
+}
+
+// SetPriceDecimals pins the number of decimals prices of symbol are shown
+// with, typically from dashboard.priceDecimals in the config.
+func (d *Dashboard) SetPriceDecimals(symbol string, decimals int) {
+	p := &d.prices
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.configured == nil {
+		p.configured = make(map[string]int)
+	}
+	p.configured[strings.ToUpper(symbol)] = decimals
+	d.requestRender()
+}
+
+// SetTickSize derives the display decimals of symbol from its exchange tick
+// size, so BTC shows 0.1 steps and PEPE shows all significant digits.
+func (d *Dashboard) SetTickSize(symbol string, tick float64) {
+	if tick <= 0 {
+		return
+	}
+	p := &d.prices
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ticks == nil {
+		p.ticks = make(map[string]int)
+	}
+	p.ticks[strings.ToUpper(symbol)] = exchange.StepDecimals(tick)
+	d.requestRender()
+}
+
+// SetStepSize derives the quantity decimals of symbol from its lot step.
+func (d *Dashboard) SetStepSize(symbol string, step float64) {
+	if step <= 0 {
+		return
+	}
+	p := &d.prices
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.steps == nil {
+		p.steps = make(map[string]int)
+	}
+	p.steps[strings.ToUpper(symbol)] = exchange.StepDecimals(step)
+	d.requestRender()
+}
+
+// LoadTickSizes calls SetTickSize and SetStepSize for each symbol using the
+// venue's lot rules. The dashboard does not know the traders' exchanges, so
+// the program that builds it calls this once per exchange client with the
+// symbols it trades, before the first render. Symbols that fail keep
+// automatic precision; their errors are joined.
+func (d *Dashboard) LoadTickSizes(ctx context.Context, src exchange.LotRulesSource, symbols []string) error {
+	var errs []error
+	for _, symbol := range symbols {
+		rules, err := src.GetLotRules(ctx, symbol)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", symbol, err))
+			continue
+		}
+		d.SetTickSize(symbol, rules.TickSize)
+		d.SetStepSize(symbol, rules.StepSize)
+	}
+	return errors.Join(errs...)
+}
+
+// FormatPrice renders price with the precision of symbol.
+func (d *Dashboard) FormatPrice(symbol string, price float64) string {
+	return strconv.FormatFloat(price, 'f', d.prices.decimals(symbol, price), 64)
+}
+
+// FormatQuantity renders qty with the lot step decimals of symbol, or with
+// the shortest exact representation when the step is unknown.
+func (d *Dashboard) FormatQuantity(symbol string, qty float64) string {
+	return strconv.FormatFloat(qty, 'f', d.prices.quantityDecimals(symbol), 64)
+}
+
+// quantityDecimals returns the lot step decimals of symbol, or -1 (shortest
+// representation) when unknown.
+func (p *pricePrecision) quantityDecimals(symbol string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if decimals, ok := p.steps[strings.ToUpper(symbol)]; ok {
+		return decimals
+	}
+	return -1
+}
+
+func (p *pricePrecision) decimals(symbol string, price float64) int {
+	symbol = strings.ToUpper(symbol)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if decimals, ok := p.configured[symbol]; ok {
+		return decimals
+	}
+	if decimals, ok := p.ticks[symbol]; ok {
+		return decimals
+	}
+	return autoDecimals(price)
+}
+
+// autoDecimals picks a precision from the magnitude of price: two decimals
+// from 1000 up, four from 1, and four significant digits below 1.
+func autoDecimals(price float64) int {
+	abs := math.Abs(price)
+	switch {
+	case abs == 0 || abs >= 1000:
+		return 2
+	case abs >= 1:
+		return 4
+	}
+	decimals := int(math.Ceil(-math.Log10(abs))) + 3
+	if decimals > maxAutoDecimals {
+		return maxAutoDecimals
+	}
+	return decimals
+}
@@ -0,0 +1,46 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"autobot/internal/exchange"
+)
+
+type lotSource map[string]exchange.LotRules
+
+func (s lotSource) GetLotRules(ctx context.Context, symbol string) (exchange.LotRules, error) {
+	rules, ok := s[symbol]
+	if !ok {
+		return exchange.LotRules{}, errors.New("unknown symbol")
+	}
+	return rules, nil
+}
+
+func TestOrderLinesUseSymbolPrecision(t *testing.T) {
+	d := New(io.Discard)
+	src := lotSource{"BTCUSDT": {TickSize: 0.1, StepSize: 0.001}}
+	if err := d.LoadTickSizes(context.Background(), src, []string{"BTCUSDT", "ETHUSDT"}); err == nil {
+		t.Fatal("missing symbol did not report an error")
+	}
+	if got := d.FormatQuantity("BTCUSDT", 0.5); got != "0.500" {
+		t.Fatalf("quantity = %s", got)
+	}
+	if got := d.FormatQuantity("ETHUSDT", 0.25); got != "0.25" {
+		t.Fatalf("quantity without step = %s", got)
+	}
+
+	order := OrderDetail{Symbol: "BTCUSDT", Side: "long", Type: "MARKET", Quantity: 0.5, Price: 67123.46, StopLoss: 66000, Status: "FILLED"}
+	lines := buildOrderLines(order, d.prices.decimals, d.prices.quantityDecimals)
+	want := []string{"BTCUSDT LONG MARKET 数量 0.500 @ 67123.5", "止损 66000.0", "状态 FILLED"}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %+v", lines)
+	}
+	for i := range want {
+		if lines[i].Text != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i].Text, want[i])
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/counterfactual"
+	"autobot/internal/eventlog"
+	"autobot/internal/execution"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/metrics"
+	"autobot/internal/news"
+	"autobot/internal/version"
+	"autobot/internal/ws"
+)
+
+// Event types streamed by the /ws push API. A client first receives a
+// snapshot of the current state, then one event per dashboard update.
+const (
+	EventSnapshot       = "snapshot"
+	EventPositions      = "positions"
+	EventPnL            = "pnl"
+	EventDecision       = "decision"
+	EventEquity         = "equity"
+	EventNews           = "news"
+	EventSentiment      = "sentiment"
+	EventAIStatus       = "aiStatus"
+	EventCounterfactual = "counterfactual"
+	EventIntents        = "intents"
+)
+
+const (
+	subscriberBuffer = 64
+	pushPingInterval = 30 * time.Second
+	pushWriteTimeout = 10 * time.Second
+)
+
+// Event is a single JSON message on the push API. Trader is empty for
+// account-wide updates such as news.
+type Event struct {
+	Type   string    `json:"type"`
+	Trader string    `json:"trader,omitempty"`
+	Time   time.Time `json:"time"`
+	Data   any       `json:"data"`
+}
+
+// NewsUpdate is the payload of news events.
+type NewsUpdate struct {
+	Source   string         `json:"source"`
+	Articles []news.Article `json:"articles"`
+}
+
+// TraderState is the per-trader part of a snapshot event.
+type TraderState struct {
+	Symbol    string             `json:"symbol"`
+	Exchange  string             `json:"exchange"`
+	Context   *ContextSnapshot   `json:"context,omitempty"`
+	PnL       *PnLSnapshot       `json:"pnl,omitempty"`
+	Decisions []DecisionLogEntry `json:"decisions"`
+	Equity    []EquityPoint      `json:"equity"`
+}
+
+// Snapshot is the payload of the snapshot event sent on connect.
+type Snapshot struct {
+	Traders        map[string]TraderState      `json:"traders"`
+	News           NewsUpdate                  `json:"news"`
+	Sentiment      *news.SentimentSummary      `json:"sentiment,omitempty"`
+	AIStatus       []ai.ProviderStatus         `json:"aiStatus,omitempty"`
+	Counterfactual []counterfactual.Comparison `json:"counterfactual,omitempty"`
+	Intents        []execution.Intent          `json:"intents,omitempty"`
+}
+
+// Serve exposes the HTTP API on addr until ctx is cancelled: the /ws push
+// stream, the Prometheus /metrics endpoint, build metadata at /version and,
+// when token is set, /api/summary, the /admin/loglevel runtime log level API
+// and, with an intent queue attached, the /api/intents cancel endpoint. A
+// non-empty token is required on every endpoint.
+func (d *Dashboard) Serve(ctx context.Context, addr, token string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", requireToken(token, d.ServeWS))
+	mux.HandleFunc("/metrics", requireToken(token, metrics.Handler().ServeHTTP))
+	mux.HandleFunc("/version", requireToken(token, version.Handler().ServeHTTP))
+	if token != "" {
+		mux.HandleFunc("/api/summary", requireToken(token, d.ServeSummary))
+		mux.HandleFunc("/admin/loglevel", requireToken(token, d.recordLevelChanges(loggerpkg.Handler())))
+		d.mu.Lock()
+		queue := d.intentQueue
+		d.mu.Unlock()
+		if queue != nil {
+			mux.HandleFunc("/api/intents", requireToken(token, queue.Handler().ServeHTTP))
+		}
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		// hijacked websocket connections outlive Shutdown; tie their
+		// request contexts to ctx so they close as well
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// statusWriter remembers the status code written by the wrapped handler.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// recordLevelChanges wraps the log level API so every successful set or
+// reset is recorded through the event recorder, when one is attached.
+func (d *Dashboard) recordLevelChanges(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+		d.mu.Lock()
+		rec := d.events
+		d.mu.Unlock()
+		if rec == nil || sw.status >= http.StatusMultipleChoices {
+			return
+		}
+		var action eventlog.ManualAction
+		switch r.Method {
+		case http.MethodPost:
+			ttl := r.FormValue("ttl")
+			if ttl == "" {
+				ttl = "10m"
+			}
+			action = eventlog.ManualAction{Action: "loglevel.set", Note: fmt.Sprintf("module=%s level=%s ttl=%s", r.FormValue("module"), r.FormValue("level"), ttl)}
+		case http.MethodDelete:
+			action = eventlog.ManualAction{Action: "loglevel.reset", Note: "module=" + r.FormValue("module")}
+		default:
+			return
+		}
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			action.Operator = host
+		}
+		if err := rec.RecordEvent(r.Context(), eventlog.KindManual, "", action); err != nil {
+			loggerpkg.Get("ui.dashboard").Printf("loglevel.record_failed err=%v", err)
+		}
+	}
+}
+
+// ServeWS upgrades the request to a websocket and streams dashboard state:
+// a snapshot first, then deltas as they happen. The stream is read-only;
+// clients that fall behind are disconnected and should reconnect.
+func (d *Dashboard) ServeWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := ws.Accept(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// subscribe before taking the snapshot so no update falls in between
+	ch := d.subscribe()
+	defer d.unsubscribe(ch)
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	snapshot, err := json.Marshal(Event{Type: EventSnapshot, Time: time.Now(), Data: d.snapshot()})
+	if err != nil || d.writePush(conn, ws.OpText, snapshot) != nil {
+		return
+	}
+
+	ticker := time.NewTicker(pushPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-closed:
+			return
+		case msg, ok := <-ch:
+			if !ok || d.writePush(conn, ws.OpText, msg) != nil {
+				return
+			}
+		case <-ticker.C:
+			if d.writePush(conn, ws.OpPing, nil) != nil {
+				return
+			}
+		}
+	}
+}
+
+func (d *Dashboard) writePush(conn *ws.Conn, opcode int, data []byte) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(pushWriteTimeout)); err != nil {
+		return err
+	}
+	return conn.WriteMessage(opcode, data)
+}
+
+func (d *Dashboard) subscribe() chan []byte {
+	ch := make(chan []byte, subscriberBuffer)
+	d.subMu.Lock()
+	if d.subscribers == nil {
+		d.subscribers = make(map[chan []byte]struct{})
+	}
+	d.subscribers[ch] = struct{}{}
+	d.subMu.Unlock()
+	return ch
+}
+
+func (d *Dashboard) unsubscribe(ch chan []byte) {
+	d.subMu.Lock()
+	if _, ok := d.subscribers[ch]; ok {
+		delete(d.subscribers, ch)
+		close(ch)
+	}
+	d.subMu.Unlock()
+}
+
+// publish fans an event out to all push subscribers without blocking the
+// caller; a subscriber whose buffer is full is dropped.
+func (d *Dashboard) publish(eventType, trader string, data any) {
+	d.subMu.Lock()
+	defer d.subMu.Unlock()
+	if len(d.subscribers) == 0 {
+		return
+	}
+	msg, err := json.Marshal(Event{Type: eventType, Trader: trader, Time: time.Now(), Data: data})
+	if err != nil {
+		return
+	}
+	for ch := range d.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			delete(d.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (d *Dashboard) snapshot() Snapshot {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	snap := Snapshot{
+		Traders:        make(map[string]TraderState, len(d.traders)),
+		News:           NewsUpdate{Source: d.newsSource, Articles: append([]news.Article{}, d.articles...)},
+		Sentiment:      d.lastSentiment,
+		AIStatus:       append([]ai.ProviderStatus(nil), d.aiStatus...),
+		Counterfactual: append([]counterfactual.Comparison(nil), d.counterfactual...),
+		Intents:        append([]execution.Intent(nil), d.intents...),
+	}
+	names := make(map[string]struct{})
+	for name := range d.traders {
+		names[name] = struct{}{}
+	}
+	for name := range d.contexts {
+		names[name] = struct{}{}
+	}
+	for name := range d.pnls {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		state := TraderState{
+			Decisions: append([]DecisionLogEntry{}, d.decisionLogs[name]...),
+			Equity:    append([]EquityPoint{}, d.equityHistory[name]...),
+		}
+		if section, ok := d.traders[name]; ok {
+			state.Symbol, state.Exchange = section.Symbol, section.Exchange
+		}
+		if ctx, ok := d.contexts[name]; ok {
+			state.Context = &ctx
+		}
+		if pnl, ok := d.pnls[name]; ok {
+			state.PnL = &pnl
+		}
+		snap.Traders[name] = state
+	}
+	return snap
+}
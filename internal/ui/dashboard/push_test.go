@@ -0,0 +1,51 @@
+package dashboard
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"autobot/internal/eventlog"
+	loggerpkg "autobot/internal/logger"
+)
+
+type recordedEvent struct {
+	kind string
+	data any
+}
+
+type eventSink []recordedEvent
+
+func (s *eventSink) RecordEvent(ctx context.Context, kind, trader string, data any) error {
+	*s = append(*s, recordedEvent{kind, data})
+	return nil
+}
+
+func TestLevelChangesAreRecorded(t *testing.T) {
+	d := New(io.Discard)
+	sink := &eventSink{}
+	d.SetEventRecorder(sink)
+	handler := d.recordLevelChanges(loggerpkg.Handler())
+	defer loggerpkg.ResetLevel("test.module")
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/admin/loglevel?module=test.module&level=debug&ttl=1m", nil),
+		httptest.NewRequest(http.MethodPost, "/admin/loglevel?module=test.module&level=bogus", nil),
+		httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil),
+		httptest.NewRequest(http.MethodDelete, "/admin/loglevel?module=test.module", nil),
+	} {
+		handler(httptest.NewRecorder(), req)
+	}
+
+	if len(*sink) != 2 {
+		t.Fatalf("recorded %d events, want set and reset only: %+v", len(*sink), *sink)
+	}
+	for i, want := range []string{"loglevel.set", "loglevel.reset"} {
+		action, ok := (*sink)[i].data.(eventlog.ManualAction)
+		if (*sink)[i].kind != eventlog.KindManual || !ok || action.Action != want {
+			t.Errorf("event %d = %+v, want %s", i, (*sink)[i], want)
+		}
+	}
+}
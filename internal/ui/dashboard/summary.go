@@ -0,0 +1,174 @@
+package dashboard
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// summaryReasonLimit caps the decision reason so the payload stays small
+// enough for a phone widget.
+const summaryReasonLimit = 80
+
+// Summary is the compact account overview served at /api/summary.
+type Summary struct {
+	Time         time.Time         `json:"time"`
+	Equity       float64           `json:"equity"`
+	DailyPnL     float64           `json:"dailyPnl"`
+	Unrealized   float64           `json:"unrealized"`
+	RiskStatus   string            `json:"riskStatus"`
+	Positions    []SummaryPosition `json:"positions"`
+	LastDecision *SummaryDecision  `json:"lastDecision,omitempty"`
+	Traders      []SummaryTrader   `json:"traders"`
+}
+
+// SummaryTrader is the per-trader line of a summary.
+type SummaryTrader struct {
+	Name       string  `json:"name"`
+	Equity     float64 `json:"equity"`
+	DailyPnL   float64 `json:"dailyPnl"`
+	RiskStatus string  `json:"riskStatus,omitempty"`
+}
+
+// SummaryPosition is an open position in a summary.
+type SummaryPosition struct {
+	Trader        string  `json:"trader"`
+	Symbol        string  `json:"symbol"`
+	Side          string  `json:"side"`
+	Quantity      float64 `json:"quantity"`
+	EntryPrice    float64 `json:"entryPrice"`
+	MarkPrice     float64 `json:"markPrice"`
+	Unrealized    float64 `json:"unrealized"`
+	UnrealizedPct float64 `json:"unrealizedPct"`
+}
+
+// SummaryDecision is the most recent AI decision across all traders.
+type SummaryDecision struct {
+	Trader     string    `json:"trader"`
+	Time       time.Time `json:"time"`
+	Symbol     string    `json:"symbol"`
+	Action     string    `json:"action"`
+	Confidence float64   `json:"confidence"`
+	Reason     string    `json:"reason"`
+}
+
+// Summary returns the current account overview. Equity and PnL are summed
+// over traders; the risk status lists the distinct non-empty statuses.
+func (d *Dashboard) Summary() Summary {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	names := make(map[string]struct{})
+	for name := range d.contexts {
+		names[name] = struct{}{}
+	}
+	for name := range d.pnls {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	summary := Summary{Time: time.Now(), Positions: []SummaryPosition{}, Traders: []SummaryTrader{}}
+	var statuses []string
+	for _, name := range sorted {
+		ctx, pnl := d.contexts[name], d.pnls[name]
+		trader := SummaryTrader{
+			Name:       name,
+			Equity:     pickNonZero(ctx.Equity, pnl.Equity),
+			DailyPnL:   pickNonZero(ctx.DailyRealized, pnl.Realized),
+			RiskStatus: ctx.RiskStatus,
+		}
+		if trader.RiskStatus == "" {
+			trader.RiskStatus = pnl.RiskStatus
+		}
+		summary.Traders = append(summary.Traders, trader)
+		summary.Equity += trader.Equity
+		summary.DailyPnL += trader.DailyPnL
+		summary.Unrealized += pickNonZero(ctx.Unrealized, pnl.Unrealized)
+		if trader.RiskStatus != "" && !containsString(statuses, trader.RiskStatus) {
+			statuses = append(statuses, trader.RiskStatus)
+		}
+		for _, pos := range ctx.Positions {
+			summary.Positions = append(summary.Positions, SummaryPosition{
+				Trader:        name,
+				Symbol:        pos.Symbol,
+				Side:          pos.Side,
+				Quantity:      pos.Quantity,
+				EntryPrice:    pos.EntryPrice,
+				MarkPrice:     pos.MarkPrice,
+				Unrealized:    pos.Unrealized,
+				UnrealizedPct: pos.UnrealizedPct,
+			})
+		}
+	}
+	summary.RiskStatus = strings.Join(statuses, "; ")
+
+	for name, logs := range d.decisionLogs {
+		if len(logs) == 0 {
+			continue
+		}
+		latest := logs[0]
+		if summary.LastDecision != nil && !latest.Timestamp.After(summary.LastDecision.Time) {
+			continue
+		}
+		reason := []rune(strings.TrimSpace(latest.Reason))
+		if len(reason) > summaryReasonLimit {
+			reason = append(reason[:summaryReasonLimit], '…')
+		}
+		summary.LastDecision = &SummaryDecision{
+			Trader:     name,
+			Time:       latest.Timestamp,
+			Symbol:     latest.Symbol,
+			Action:     latest.Action,
+			Confidence: latest.Confidence,
+			Reason:     string(reason),
+		}
+	}
+	return summary
+}
+
+// ServeSummary writes Summary as JSON.
+func (d *Dashboard) ServeSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(d.Summary())
+}
+
+// requireToken rejects requests that carry neither "Authorization: Bearer
+// <token>" nor a matching token query parameter (for clients such as
+// phone shortcuts that cannot set headers). An empty token disables the check.
+func requireToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	if token == "" {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := r.URL.Query().Get("token")
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			got = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	loggerpkg "autobot/internal/logger"
+)
+
+// Release is the subset of a GitHub release used by the update check.
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	URL         string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Latest fetches the latest release from url, a GitHub
+// /repos/{owner}/{repo}/releases/latest endpoint or a compatible one.
+func Latest(ctx context.Context, client *http.Client, url string) (Release, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return Release{}, fmt.Errorf("get latest release: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Release{}, fmt.Errorf("latest release status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return Release{}, fmt.Errorf("decode latest release: %w", err)
+	}
+	if release.Tag == "" {
+		return Release{}, fmt.Errorf("latest release has no tag")
+	}
+	return release, nil
+}
+
+// Newer reports whether latest is a higher semantic version than current.
+// Versions that do not parse as vMAJOR.MINOR.PATCH (such as "dev") never
+// compare as older, so development builds do not nag.
+func Newer(current, latest string) bool {
+	cur, ok := parseSemver(current)
+	if !ok {
+		return false
+	}
+	next, ok := parseSemver(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if next[i] != cur[i] {
+			return next[i] > cur[i]
+		}
+	}
+	return false
+}
+
+// parseSemver parses "v1.2.3" or "1.2" ignoring any pre-release or build
+// suffix; missing components are zero.
+func parseSemver(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return out, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// WatchUpdates checks url at start and then every interval until ctx is
+// done, logging update.available when a newer release exists. It never
+// downloads or replaces the binary.
+func WatchUpdates(ctx context.Context, url string, interval time.Duration) {
+	logger := loggerpkg.Get("version")
+	current := Get().Version
+	notified := ""
+	for {
+		release, err := Latest(ctx, nil, url)
+		switch {
+		case err != nil:
+			logger.Printf("update.check_failed err=%v", err)
+		case Newer(current, release.Tag) && release.Tag != notified:
+			notified = release.Tag
+			logger.Printf("update.available current=%s latest=%s url=%s", current, release.Tag, release.URL)
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
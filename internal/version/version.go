@@ -0,0 +1,111 @@
+// Package version exposes the build metadata of the running binary.
+//
+// Version, Commit and BuildTime are injected at link time:
+//
+//	go build -ldflags "\
+//	  -X autobot/internal/version.Version=v1.4.0 \
+//	  -X autobot/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X autobot/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/...
+//
+// When they are not set, Commit and BuildTime fall back to the VCS stamp
+// that the go tool embeds in module builds.
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// Set via -ldflags -X.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info is the build metadata served at /version and stamped on decisions.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+	GoVersion string `json:"goVersion"`
+	// Modified reports uncommitted changes in the build tree (VCS stamp only).
+	Modified bool `json:"modified,omitempty"`
+}
+
+var (
+	once sync.Once
+	info Info
+)
+
+// Get returns the build metadata, resolved once.
+func Get() Info {
+	once.Do(func() {
+		info = Info{Version: Version, Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+		build, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = shortCommit(setting.Value)
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	})
+	return info
+}
+
+func shortCommit(rev string) string {
+	if len(rev) > 12 {
+		return rev[:12]
+	}
+	return rev
+}
+
+// String formats the metadata as "v1.4.0 (abc1234, 2025-01-15T08:00:00Z)".
+func (i Info) String() string {
+	s := i.Version
+	commit := i.Commit
+	if commit == "" {
+		commit = "unknown"
+	}
+	if i.Modified {
+		commit += "-dirty"
+	}
+	s += " (" + commit
+	if i.BuildTime != "" {
+		s += ", " + i.BuildTime
+	}
+	return s + ")"
+}
+
+// Banner is the one-line startup banner for the named binary.
+func Banner(name string) string {
+	i := Get()
+	return fmt.Sprintf("autobot %s %s %s/%s %s", name, i, runtime.GOOS, runtime.GOARCH, i.GoVersion)
+}
+
+// Handler serves Get as JSON.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_ = json.NewEncoder(w).Encode(Get())
+	})
+}
@@ -0,0 +1,172 @@
+// Package watch 分析观察列表中的交易对：只产出信号与AI点评，从不下单。
+package watch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autobot/internal/ai"
+	"autobot/internal/config"
+	loggerpkg "autobot/internal/logger"
+	"autobot/internal/market"
+	"autobot/internal/strategy"
+	"autobot/internal/ui/dashboard"
+)
+
+// Report 为单个观察交易对的最新分析结果。
+type Report struct {
+	Symbol     string
+	Interval   string
+	Price      float64
+	Change1h   float64
+	Change24h  float64
+	RSI14      float64
+	Signal     string
+	AIAction   string
+	Confidence float64
+	Commentary string
+	UpdatedAt  time.Time
+	Err        error
+}
+
+// String 返回适合单行展示的摘要。
+func (r Report) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%-10s 错误: %v", r.Symbol, r.Err)
+	}
+	text := fmt.Sprintf("%-10s %.4f | 1h %+.2f%% | 24h %+.2f%% | RSI14 %.1f | 信号 %s",
+		r.Symbol, r.Price, r.Change1h, r.Change24h, r.RSI14, r.Signal)
+	if r.AIAction != "" {
+		text += fmt.Sprintf(" | AI %s(%.2f) %s", r.AIAction, r.Confidence, r.Commentary)
+	}
+	return text
+}
+
+// Watcher 周期性分析观察列表。Provider 为空时只计算策略信号。
+type Watcher struct {
+	Source   market.Source
+	Strategy strategy.Strategy
+	Settings config.TradeSettings
+	Provider ai.Provider
+	Symbols  []string
+	Interval string
+	Every    time.Duration
+	// OnUpdate 在每轮分析完成后以全部结果回调，例如刷新看板。
+	OnUpdate func([]Report)
+	// OnThought 非空时，支持流式输出的提供商在生成点评期间逐行回调思维链。
+	OnThought func(symbol, line string)
+}
+
+// Run 立即分析一轮，此后每隔 Every 分析一次，直到 ctx 结束。
+func (w *Watcher) Run(ctx context.Context) error {
+	every := w.Every
+	if every <= 0 {
+		every = 5 * time.Minute
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		reports := w.Scan(ctx)
+		if w.OnUpdate != nil {
+			w.OnUpdate(reports)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan 依次分析每个交易对，单个交易对失败不影响其它交易对。
+func (w *Watcher) Scan(ctx context.Context) []Report {
+	logger := loggerpkg.Get("watch")
+	reports := make([]Report, 0, len(w.Symbols))
+	for _, symbol := range w.Symbols {
+		report := w.analyze(ctx, strings.ToUpper(strings.TrimSpace(symbol)))
+		if report.Err != nil {
+			logger.Printf("watch.error symbol=%s err=%v", report.Symbol, report.Err)
+		} else {
+			logger.Printf("watch.report symbol=%s price=%.6f signal=%s ai=%s confidence=%.2f",
+				report.Symbol, report.Price, report.Signal, report.AIAction, report.Confidence)
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+func (w *Watcher) analyze(ctx context.Context, symbol string) Report {
+	report := Report{Symbol: symbol, Interval: w.Interval, UpdatedAt: time.Now()}
+	lookback := w.Settings.LookbackCandles
+	if lookback <= 0 {
+		lookback = 120
+	}
+	snapshot, err := market.Collect(ctx, w.Source, symbol, w.Interval, lookback)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+	report.Price = snapshot.CurrentPrice
+	report.Change1h = snapshot.PriceChange1h
+	report.Change24h = snapshot.PriceChange24h
+	report.RSI14 = snapshot.RSI14
+
+	signal := strategy.SignalHold
+	if w.Strategy != nil {
+		candles, err := w.Source.GetKlines(ctx, symbol, w.Interval, lookback)
+		if err != nil {
+			report.Err = err
+			return report
+		}
+		if signal, err = w.Strategy.Evaluate(market.TransformCandles(candles, w.Settings)); err != nil {
+			signal = strategy.SignalHold
+		}
+	}
+	report.Signal = signal.String()
+
+	if w.Provider == nil {
+		return report
+	}
+	req := ai.DecisionRequest{
+		TraderName:     "watchlist",
+		Symbol:         symbol,
+		CurrentPrice:   snapshot.CurrentPrice,
+		StrategySignal: report.Signal,
+		Context: ai.DecisionContext{
+			CurrentTime: report.UpdatedAt.Format("2006-01-02 15:04:05"),
+			MarketData:  map[string]ai.MarketDataSnapshot{symbol: snapshot},
+		},
+	}
+	if w.OnThought != nil {
+		ctx = ai.WithStream(ctx, func(line string) { w.OnThought(symbol, line) })
+	}
+	decision, err := w.Provider.GenerateDecision(ctx, req)
+	if err != nil {
+		report.Err = fmt.Errorf("ai commentary: %w", err)
+		return report
+	}
+	report.AIAction = decision.Action
+	report.Confidence = decision.Confidence
+	report.Commentary = decision.Reason
+	return report
+}
+
+// Lines 将分析结果转换为看板行，按策略信号着色。
+func Lines(reports []Report) []dashboard.Line {
+	lines := make([]dashboard.Line, 0, len(reports))
+	for _, report := range reports {
+		line := dashboard.Line{Text: report.String()}
+		switch {
+		case report.Err != nil:
+			line.Color = dashboard.ColorNegative
+		case report.Signal == strategy.SignalLong.String():
+			line.Color = dashboard.ColorBuy
+		case report.Signal == strategy.SignalShort.String():
+			line.Color = dashboard.ColorSell
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,40 @@
+#!/usr/bin/env python3
+"""exec 策略协议的参考实现：快慢均线交叉。
+
+每行从 stdin 读取一个请求 {"strategy", "params", "candles": [{"time","open","high","low","close","volume"}]}，
+向 stdout 写出一行 {"signal": "long|short|exit|hold", "stopDistance": 价格单位的止损距离}。
+日志请写 stderr，stdout 只能输出响应行。
+"""
+import json
+import sys
+
+
+def sma(values, period):
+    return sum(values[-period:]) / period
+
+
+def evaluate(request):
+    params = request.get("params") or {}
+    fast = int(params.get("fast", 10))
+    slow = int(params.get("slow", 30))
+    closes = [c["close"] for c in request["candles"]]
+    if len(closes) < slow + 1:
+        return {"signal": "hold"}
+    prev_fast, prev_slow = sma(closes[:-1], fast), sma(closes[:-1], slow)
+    cur_fast, cur_slow = sma(closes, fast), sma(closes, slow)
+    ranges = [c["high"] - c["low"] for c in request["candles"][-14:]]
+    stop = 2 * sum(ranges) / len(ranges)
+    if prev_fast <= prev_slow and cur_fast > cur_slow:
+        return {"signal": "long", "stopDistance": stop}
+    if prev_fast >= prev_slow and cur_fast < cur_slow:
+        return {"signal": "short", "stopDistance": stop}
+    return {"signal": "hold"}
+
+
+for line in sys.stdin:
+    try:
+        response = evaluate(json.loads(line))
+    except Exception as exc:  # 错误回传给调用方，进程继续服务
+        response = {"signal": "hold", "error": str(exc)}
+    sys.stdout.write(json.dumps(response) + "\n")
+    sys.stdout.flush()